@@ -48,6 +48,13 @@ type CORSRule struct {
 	// +optional
 	ExposeHeaders []string `json:"exposeHeaders,omitempty"`
 
+	// Unique identifier for the rule. The value cannot be longer than 255
+	// characters. If every rule has an ID, rules are matched by their ID
+	// rather than their position when they are compared with the rules of
+	// the bucket.
+	// +optional
+	ID *string `json:"id,omitempty"`
+
 	// The time in seconds that your browser is to cache the preflight response
 	// for the specified resource.
 	// +optional
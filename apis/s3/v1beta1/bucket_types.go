@@ -64,6 +64,14 @@ type BucketParameters struct {
 	// +optional
 	ObjectLockEnabledForBucket *bool `json:"objectLockEnabledForBucket,omitempty"`
 
+	// ObjectLockConfiguration places an Object Lock configuration on the
+	// bucket. The rule specified in the Object Lock configuration will be
+	// applied by default to every new object placed in the bucket. Object
+	// Lock can only be configured on buckets that are created with
+	// objectLockEnabledForBucket set to true.
+	// +optional
+	ObjectLockConfiguration *ObjectLockConfiguration `json:"objectLockConfiguration,omitempty"`
+
 	// Specifies default encryption for a bucket using server-side encryption with
 	// Amazon S3-managed keys (SSE-S3) or customer master keys stored in AWS KMS
 	// (SSE-KMS). For information about the Amazon S3 default encryption feature,
@@ -117,6 +125,9 @@ type BucketParameters struct {
 	// For more information, see Billing and usage reporting for S3 buckets.
 	// (https://docs.aws.amazon.com/AmazonS3/latest/dev/BucketBilling.html) in the Amazon
 	// Simple Storage Service Developer Guide.
+	// Tag values may reference the name, namespace and UID of this resource
+	// with a template, e.g. "{{ .metadata.name }}", which is resolved before
+	// the tags are compared with and sent to AWS.
 	// +optional
 	BucketTagging *Tagging `json:"tagging,omitempty"`
 
@@ -139,9 +150,61 @@ type BucketParameters struct {
 	// +optional
 	NotificationConfiguration *NotificationConfiguration `json:"notificationConfiguration,omitempty"`
 
+	// OwnershipControls configures the ownership of objects uploaded to the
+	// bucket. The ownership controls are applied before the ACL of the bucket,
+	// and if the object ownership is BucketOwnerEnforced ACLs are disabled and
+	// neither the canned ACL nor the grants of this bucket are applied. The ACL
+	// of the bucket is reset to private before ACLs are disabled.
+	// +optional
+	OwnershipControls *OwnershipControls `json:"ownershipControls,omitempty"`
+
 	// PublicAccessBlockConfiguration that you want to apply to this Amazon
 	// S3 bucket.
 	PublicAccessBlockConfiguration *PublicAccessBlockConfiguration `json:"publicAccessBlockConfiguration,omitempty"`
+
+	// MetricsConfigurations specifies the configurations of the CloudWatch
+	// request metrics of this bucket, identified by their ID. Metrics
+	// configurations of the bucket that are not listed are deleted, unless
+	// the deletion of subresources is disabled. A bucket may have up to 1000
+	// metrics configurations.
+	// +optional
+	MetricsConfigurations []MetricsConfiguration `json:"metricsConfigurations,omitempty"`
+
+	// InventoryConfigurations specifies the inventory reports of this bucket,
+	// identified by their ID. Inventory configurations of the bucket that are
+	// not listed are deleted, unless the deletion of subresources is disabled.
+	// A bucket may have up to 1000 inventory configurations.
+	// +optional
+	InventoryConfigurations []InventoryConfiguration `json:"inventoryConfigurations,omitempty"`
+
+	// IntelligentTieringConfigurations specifies the S3 Intelligent-Tiering
+	// configurations of this bucket, identified by their ID. Intelligent
+	// tiering configurations of the bucket that are not listed are deleted,
+	// unless the deletion of subresources is disabled. A bucket may have up to
+	// 1000 intelligent tiering configurations.
+	// +optional
+	IntelligentTieringConfigurations []IntelligentTieringConfiguration `json:"intelligentTieringConfigurations,omitempty"`
+
+	// BaselineConfigMapRef references a ConfigMap holding baseline
+	// configuration that is merged into the configuration of this bucket
+	// before it is applied. The ConfigMap may hold a JSON encoded
+	// serverSideEncryptionConfiguration and tagging, in the same format as
+	// this spec. This spec takes precedence: the baseline encryption is only
+	// used if this spec specifies none, and baseline tags are only added if
+	// this spec does not set a tag with the same key. The encryption and tags
+	// of the bucket are not late initialized if a baseline is referenced.
+	// +optional
+	BaselineConfigMapRef *ConfigMapReference `json:"baselineConfigMapRef,omitempty"`
+}
+
+// A ConfigMapReference is a reference to a ConfigMap in an arbitrary
+// namespace.
+type ConfigMapReference struct {
+	// Name of the ConfigMap.
+	Name string `json:"name"`
+
+	// Namespace of the ConfigMap.
+	Namespace string `json:"namespace"`
 }
 
 // BucketSpec represents the desired state of the Bucket.
@@ -156,6 +219,60 @@ type BucketExternalStatus struct {
 	// about ARNs and how to use them, see S3 Resources (https://docs.aws.amazon.com/AmazonS3/latest/dev/s3-arn-format.html)
 	// in the Amazon Simple Storage Service guide.
 	ARN string `json:"arn"`
+
+	// APICalls is the number of S3 API calls made during the last reconcile
+	// of the Bucket. It is only reported if enabled on the provider.
+	// +optional
+	APICalls *int64 `json:"apiCalls,omitempty"`
+
+	// ReplicationRules is the observed replication state of each replication
+	// rule of the Bucket.
+	// +optional
+	ReplicationRules []ReplicationRuleObservation `json:"replicationRules,omitempty"`
+
+	// ServerSideEncryptionRules are the default encryption rules of the
+	// Bucket as observed in AWS.
+	// +optional
+	ServerSideEncryptionRules []ServerSideEncryptionRule `json:"serverSideEncryptionRules,omitempty"`
+
+	// KMSKeyRotationEnabled indicates whether automatic rotation is enabled
+	// for the customer managed KMS key used to encrypt the Bucket. It is only
+	// reported if enabled on the provider.
+	// +optional
+	KMSKeyRotationEnabled *bool `json:"kmsKeyRotationEnabled,omitempty"`
+
+	// LoggingConfiguration is the server access logging configuration of the
+	// Bucket as observed in AWS. It is not set if logging is disabled.
+	// +optional
+	LoggingConfiguration *LoggingConfiguration `json:"loggingConfiguration,omitempty"`
+}
+
+// ReplicationRuleObservation is the observed replication state of a
+// replication rule. It reflects the configuration reported by AWS; whether
+// replication actually meets the Replication Time Control SLA is only
+// reported through CloudWatch metrics.
+type ReplicationRuleObservation struct {
+	// ID of the rule.
+	// +optional
+	ID string `json:"id,omitempty"`
+
+	// Status of the rule, either Enabled or Disabled.
+	Status string `json:"status"`
+
+	// DestinationBucket is the ARN of the bucket objects are replicated to.
+	// +optional
+	DestinationBucket string `json:"destinationBucket,omitempty"`
+
+	// ReplicationTime is the status of S3 Replication Time Control for the
+	// destination, either Enabled or Disabled. It is empty if Replication
+	// Time Control is not configured.
+	// +optional
+	ReplicationTime string `json:"replicationTime,omitempty"`
+
+	// Metrics is the status of replication metrics for the destination,
+	// either Enabled or Disabled. It is empty if metrics are not configured.
+	// +optional
+	Metrics string `json:"metrics,omitempty"`
 }
 
 // BucketStatus represents the observed state of the Bucket.
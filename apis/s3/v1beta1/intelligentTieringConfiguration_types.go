@@ -0,0 +1,89 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+// IntelligentTieringConfiguration specifies the S3 Intelligent-Tiering
+// configuration of a bucket. For more information, see
+// PutBucketIntelligentTieringConfiguration (https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutBucketIntelligentTieringConfiguration.html).
+type IntelligentTieringConfiguration struct {
+	// The ID used to identify the S3 Intelligent-Tiering configuration,
+	// unique within the bucket.
+	// ID is a required field
+	ID string `json:"id"`
+
+	// Specifies the status of the configuration.
+	// Status is a required field
+	// +kubebuilder:validation:Enum=Enabled;Disabled
+	Status string `json:"status"`
+
+	// Specifies the S3 Intelligent-Tiering storage class tiers of the
+	// configuration.
+	// Tierings is a required field
+	Tierings []Tiering `json:"tierings"`
+
+	// Specifies a bucket filter. The configuration only includes objects that
+	// meet the filter's criteria. It includes all objects of the bucket if no
+	// filter is set.
+	// +optional
+	Filter *IntelligentTieringFilter `json:"filter,omitempty"`
+}
+
+// Tiering specifies after how many days of no access objects are moved to an
+// S3 Intelligent-Tiering access tier.
+type Tiering struct {
+	// S3 Intelligent-Tiering access tier.
+	// AccessTier is a required field
+	// +kubebuilder:validation:Enum=ARCHIVE_ACCESS;DEEP_ARCHIVE_ACCESS
+	AccessTier string `json:"accessTier"`
+
+	// The number of consecutive days of no access after which an object
+	// will be eligible to be transitioned to the corresponding tier. It must
+	// be between 90 and 730 for ARCHIVE_ACCESS and between 180 and 730 for
+	// DEEP_ARCHIVE_ACCESS.
+	// Days is a required field
+	Days int32 `json:"days"`
+}
+
+// IntelligentTieringFilter specifies the objects an S3 Intelligent-Tiering
+// configuration applies to. A filter must specify exactly one Prefix, Tag or
+// an And child element.
+type IntelligentTieringFilter struct {
+	// A conjunction (logical AND) of predicates, which is used in evaluating
+	// the filter. The operator must have at least two predicates, and an
+	// object must match all of the predicates in order for the filter to
+	// apply.
+	And *IntelligentTieringAndOperator `json:"and,omitempty"`
+
+	// An object key name prefix that identifies the subset of objects to which
+	// the configuration applies.
+	Prefix *string `json:"prefix,omitempty"`
+
+	// The tag used when evaluating the filter.
+	Tag *Tag `json:"tag,omitempty"`
+}
+
+// IntelligentTieringAndOperator is a conjunction (logical AND) of predicates,
+// which is used in evaluating an S3 Intelligent-Tiering filter.
+type IntelligentTieringAndOperator struct {
+	// An object key name prefix that identifies the subset of objects to which
+	// the configuration applies.
+	Prefix *string `json:"prefix,omitempty"`
+
+	// All of these tags must exist in the object's tag set in order for the
+	// configuration to apply.
+	Tags []Tag `json:"tags,omitempty"`
+}
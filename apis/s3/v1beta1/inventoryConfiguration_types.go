@@ -0,0 +1,140 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// InventoryConfiguration specifies the inventory configuration for a bucket.
+// For more information, see GET Bucket inventory (https://docs.aws.amazon.com/AmazonS3/latest/API/RESTBucketGETInventoryConfig.html)
+// in the Amazon Simple Storage Service API Reference.
+type InventoryConfiguration struct {
+	// The ID used to identify the inventory configuration, unique within the
+	// bucket.
+	// ID is a required field
+	ID string `json:"id"`
+
+	// Specifies whether the inventory is enabled or disabled. If set to True,
+	// an inventory list is generated. If set to False, no inventory list is
+	// generated.
+	// IsEnabled is a required field
+	IsEnabled bool `json:"isEnabled"`
+
+	// Contains information about where to publish the inventory results.
+	// Destination is a required field
+	Destination InventoryDestination `json:"destination"`
+
+	// Object versions to include in the inventory list. If set to All, the
+	// list includes all the object versions, which adds the version-related
+	// fields VersionId, IsLatest, and DeleteMarker to the list. If set to
+	// Current, the list does not contain these version-related fields.
+	// IncludedObjectVersions is a required field
+	// +kubebuilder:validation:Enum=All;Current
+	IncludedObjectVersions string `json:"includedObjectVersions"`
+
+	// Specifies the schedule for generating inventory results.
+	// Schedule is a required field
+	Schedule InventorySchedule `json:"schedule"`
+
+	// Specifies an inventory filter. The inventory only includes objects that
+	// meet the filter's criteria.
+	// +optional
+	Filter *InventoryFilter `json:"filter,omitempty"`
+
+	// Contains the optional fields that are included in the inventory results.
+	// +optional
+	OptionalFields []string `json:"optionalFields,omitempty"`
+}
+
+// InventoryDestination contains the bucket name, file format, bucket owner
+// (optional), and prefix (optional) where inventory results are published.
+type InventoryDestination struct {
+	// The Amazon Resource Name (ARN) of the bucket where inventory results
+	// will be published.
+	// At least one of bucket, bucketRef or bucketSelector is required.
+	// +optional
+	Bucket *string `json:"bucket,omitempty"`
+
+	// BucketRef references a Bucket to retrieve its ARN
+	// +optional
+	BucketRef *xpv1.Reference `json:"bucketRef,omitempty"`
+
+	// BucketSelector selects a reference to a Bucket to retrieve its ARN
+	// +optional
+	BucketSelector *xpv1.Selector `json:"bucketSelector,omitempty"`
+
+	// The account ID that owns the destination S3 bucket. If no account ID is
+	// provided, the owner is not validated before exporting data. Although
+	// this value is optional, we strongly recommend that you set it to help
+	// prevent problems if the destination bucket ownership changes.
+	// +optional
+	AccountID *string `json:"accountId,omitempty"`
+
+	// Specifies the output format of the inventory results.
+	// Format is a required field
+	// +kubebuilder:validation:Enum=CSV;ORC;Parquet
+	Format string `json:"format"`
+
+	// The prefix that is prepended to all inventory results.
+	// +optional
+	Prefix *string `json:"prefix,omitempty"`
+
+	// Contains the type of server-side encryption used to encrypt the
+	// inventory results.
+	// +optional
+	Encryption *InventoryEncryption `json:"encryption,omitempty"`
+}
+
+// InventoryEncryption contains the type of server-side encryption used to
+// encrypt the inventory results. At most one of SSEKMS and SSES3 may be set.
+type InventoryEncryption struct {
+	// Specifies the use of SSE-KMS to encrypt delivered inventory reports.
+	// +optional
+	SSEKMS *InventorySSEKMS `json:"sseKms,omitempty"`
+
+	// Specifies the use of SSE-S3 to encrypt delivered inventory reports.
+	// +optional
+	SSES3 *bool `json:"sseS3,omitempty"`
+}
+
+// InventorySSEKMS specifies the use of SSE-KMS to encrypt delivered inventory
+// reports.
+type InventorySSEKMS struct {
+	// Specifies the ID of the AWS Key Management Service (AWS KMS) symmetric
+	// customer managed customer master key (CMK) to use for encrypting
+	// inventory reports.
+	// KeyID is a required field
+	KeyID string `json:"keyId"`
+}
+
+// InventorySchedule specifies the schedule for generating inventory results.
+type InventorySchedule struct {
+	// Specifies how frequently inventory results are produced.
+	// Frequency is a required field
+	// +kubebuilder:validation:Enum=Daily;Weekly
+	Frequency string `json:"frequency"`
+}
+
+// InventoryFilter specifies an inventory filter. The inventory only includes
+// objects that meet the filter's criteria.
+type InventoryFilter struct {
+	// The prefix that an object must have to be included in the inventory
+	// results.
+	// Prefix is a required field
+	Prefix string `json:"prefix"`
+}
@@ -176,6 +176,8 @@ type NoncurrentVersionTransition struct {
 type Transition struct {
 	// Indicates when objects are transitioned to the specified storage class. The
 	// date value must be in ISO 8601 format. The time is always midnight UTC.
+	// Any other time of day is replaced with midnight UTC of the same day in
+	// UTC. Date and days cannot both be specified.
 	Date *metav1.Time `json:"date,omitempty"`
 
 	// Indicates the number of days after creation when objects are transitioned
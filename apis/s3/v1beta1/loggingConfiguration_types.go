@@ -22,6 +22,12 @@ import xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 // all log object keys for a bucket. For more information, see PUT Bucket logging
 // (https://docs.aws.amazon.com/AmazonS3/latest/API/RESTBucketPUTlogging.html)
 type LoggingConfiguration struct {
+	// Enabled turns server access logging of the bucket on or off. If it is
+	// false logging is disabled, but the target configuration is kept so it
+	// can be enabled again later. Defaults to true.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
 	// TargetBucket where logs will be stored, it can be the same bucket.
 	// At least one of targetBucket, targetBucketRef or targetBucketSelector is
 	// required.
@@ -36,6 +42,12 @@ type LoggingConfiguration struct {
 	// +optional
 	TargetBucketSelector *xpv1.Selector `json:"targetBucketSelector,omitempty"`
 
+	// TargetProviderConfigReference specifies the ProviderConfig used for
+	// calls against the target bucket, e.g. when it is owned by another
+	// account. The credentials of the Bucket are used if it is not set.
+	// +optional
+	TargetProviderConfigReference *xpv1.Reference `json:"targetProviderConfigRef,omitempty"`
+
 	// A prefix for all log object keys.
 	TargetPrefix string `json:"targetPrefix"`
 
@@ -0,0 +1,64 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+// MetricsConfiguration specifies a configuration for the CloudWatch request
+// metrics of a bucket. For more information, see PutBucketMetricsConfiguration
+// (https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutBucketMetricsConfiguration.html).
+type MetricsConfiguration struct {
+	// The ID used to identify the metrics configuration, unique within the
+	// bucket.
+	// ID is a required field
+	ID string `json:"id"`
+
+	// Specifies a metrics configuration filter. The metrics configuration will
+	// only include objects that meet the filter's criteria. The metrics
+	// configuration includes all objects of the bucket if no filter is set.
+	// +optional
+	Filter *MetricsFilter `json:"filter,omitempty"`
+}
+
+// MetricsFilter specifies a metrics configuration filter. A filter must specify
+// exactly one Prefix, Tag, AccessPointARN or an And child element.
+type MetricsFilter struct {
+	// A conjunction (logical AND) of predicates, which is used in evaluating
+	// a metrics filter. The operator must have at least two predicates, and an
+	// object must match all of the predicates in order for the filter to apply.
+	And *MetricsAndOperator `json:"and,omitempty"`
+
+	// The access point ARN used when evaluating a metrics filter.
+	AccessPointARN *string `json:"accessPointArn,omitempty"`
+
+	// The prefix used when evaluating a metrics filter.
+	Prefix *string `json:"prefix,omitempty"`
+
+	// The tag used when evaluating a metrics filter.
+	Tag *Tag `json:"tag,omitempty"`
+}
+
+// MetricsAndOperator is a conjunction (logical AND) of predicates, which is used
+// in evaluating a metrics filter.
+type MetricsAndOperator struct {
+	// The access point ARN used when evaluating an AND predicate.
+	AccessPointARN *string `json:"accessPointArn,omitempty"`
+
+	// The prefix used when evaluating an AND predicate.
+	Prefix *string `json:"prefix,omitempty"`
+
+	// The list of tags used when evaluating an AND predicate.
+	Tags []Tag `json:"tags,omitempty"`
+}
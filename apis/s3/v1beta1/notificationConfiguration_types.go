@@ -106,9 +106,19 @@ type LambdaFunctionConfiguration struct {
 
 	// The Amazon Resource Name (ARN) of the AWS Lambda function that Amazon S3
 	// invokes when the specified event type occurs.
-	//
-	// LambdaFunctionArn is a required field
-	LambdaFunctionArn string `json:"lambdaFunctionArn"`
+	// At least one of lambdaFunctionArn, lambdaFunctionRef or
+	// lambdaFunctionSelector is required.
+	// +optional
+	LambdaFunctionArn string `json:"lambdaFunctionArn,omitempty"`
+
+	// LambdaFunctionArnRef references a Lambda Function to retrieve its Arn
+	// +optional
+	LambdaFunctionArnRef *xpv1.Reference `json:"lambdaFunctionRef,omitempty"`
+
+	// LambdaFunctionArnSelector selects a reference to a Lambda Function to
+	// retrieve its Arn
+	// +optional
+	LambdaFunctionArnSelector *xpv1.Selector `json:"lambdaFunctionSelector,omitempty"`
 }
 
 // QueueConfiguration specifies the configuration for publishing messages to an Amazon Simple Queue
@@ -133,9 +143,17 @@ type QueueConfiguration struct {
 
 	// The Amazon Resource Name (ARN) of the Amazon SQS queue to which Amazon S3
 	// publishes a message when it detects events of the specified type.
-	//
-	// QueueArn is a required field
-	QueueArn string `json:"queueArn"`
+	// At least one of queueArn, queueRef or queueSelector is required.
+	// +optional
+	QueueArn string `json:"queueArn,omitempty"`
+
+	// QueueArnRef references an SQS Queue to retrieve its Arn
+	// +optional
+	QueueArnRef *xpv1.Reference `json:"queueRef,omitempty"`
+
+	// QueueArnSelector selects a reference to an SQS Queue to retrieve its Arn
+	// +optional
+	QueueArnSelector *xpv1.Selector `json:"queueSelector,omitempty"`
 }
 
 // TopicConfiguration specifies the configuration for publication of messages
@@ -0,0 +1,59 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+// ObjectLockConfiguration is the container element for Object Lock
+// configuration parameters.
+type ObjectLockConfiguration struct {
+	// Indicates whether this bucket has an Object Lock configuration enabled.
+	// Object Lock can only be enabled on buckets that are created with
+	// objectLockEnabledForBucket.
+	// +kubebuilder:validation:Enum=Enabled
+	ObjectLockEnabled string `json:"objectLockEnabled"`
+
+	// Specifies the Object Lock rule for the specified object. Enable this
+	// rule when you apply ObjectLockConfiguration to a bucket.
+	// +optional
+	Rule *ObjectLockRule `json:"rule,omitempty"`
+}
+
+// ObjectLockRule is the container element for an Object Lock rule.
+type ObjectLockRule struct {
+	// The default Object Lock retention mode and period that you want to
+	// apply to new objects placed in the specified bucket.
+	DefaultRetention DefaultRetention `json:"defaultRetention"`
+}
+
+// DefaultRetention is the container element for specifying the default Object
+// Lock retention settings for new objects placed in the specified bucket.
+// Either Days or Years must be set, but not both.
+type DefaultRetention struct {
+	// The default Object Lock retention mode you want to apply to new objects
+	// placed in the specified bucket.
+	// +kubebuilder:validation:Enum=GOVERNANCE;COMPLIANCE
+	Mode string `json:"mode"`
+
+	// The number of days that you want to specify for the default retention
+	// period.
+	// +optional
+	Days *int32 `json:"days,omitempty"`
+
+	// The number of years that you want to specify for the default retention
+	// period.
+	// +optional
+	Years *int32 `json:"years,omitempty"`
+}
@@ -26,7 +26,9 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
 	"github.com/crossplane/provider-aws/apis/identity/v1beta1"
+	kmsv1alpha1 "github.com/crossplane/provider-aws/apis/kms/v1alpha1"
 	"github.com/crossplane/provider-aws/apis/notification/v1alpha1"
+	sqsv1beta1 "github.com/crossplane/provider-aws/apis/sqs/v1beta1"
 )
 
 // SNSTopicARN returns a function that returns the ARN of the given SNS Topic.
@@ -40,6 +42,28 @@ func SNSTopicARN() reference.ExtractValueFn {
 	}
 }
 
+// KMSKeyARN returns a function that returns the ARN of the given KMS Key.
+func KMSKeyARN() reference.ExtractValueFn {
+	return func(mg resource.Managed) string {
+		r, ok := mg.(*kmsv1alpha1.Key)
+		if !ok {
+			return ""
+		}
+		return reference.FromPtrValue(r.Status.AtProvider.ARN)
+	}
+}
+
+// BucketARN returns a function that returns the ARN of the given Bucket.
+func BucketARN() reference.ExtractValueFn {
+	return func(mg resource.Managed) string {
+		r, ok := mg.(*Bucket)
+		if !ok {
+			return ""
+		}
+		return r.Status.AtProvider.ARN
+	}
+}
+
 // ResolveReferences of this Bucket
 func (mg *Bucket) ResolveReferences(ctx context.Context, c client.Reader) error { // nolint:gocyclo
 	r := reference.NewAPIResolver(c, mg)
@@ -63,6 +87,24 @@ func (mg *Bucket) ResolveReferences(ctx context.Context, c client.Reader) error
 		}
 	}
 
+	// Resolve spec.forProvider.notificationConfiguration.queueConfigurations[].queueArn
+	if mg.Spec.ForProvider.NotificationConfiguration != nil {
+		for i, v := range mg.Spec.ForProvider.NotificationConfiguration.QueueConfigurations {
+			rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+				CurrentValue: v.QueueArn,
+				Reference:    v.QueueArnRef,
+				Selector:     v.QueueArnSelector,
+				To:           reference.To{Managed: &sqsv1beta1.Queue{}, List: &sqsv1beta1.QueueList{}},
+				Extract:      sqsv1beta1.QueueARN(),
+			})
+			if err != nil {
+				return errors.Wrapf(err, "spec.forProvider.notificationConfiguration.queueConfigurations[%d].queueArn", i)
+			}
+			mg.Spec.ForProvider.NotificationConfiguration.QueueConfigurations[i].QueueArn = rsp.ResolvedValue
+			mg.Spec.ForProvider.NotificationConfiguration.QueueConfigurations[i].QueueArnRef = rsp.ResolvedReference
+		}
+	}
+
 	// Resolve spec.forProvider.loggingConfiguration.targetBucket
 	if mg.Spec.ForProvider.LoggingConfiguration != nil {
 		rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
@@ -113,5 +155,40 @@ func (mg *Bucket) ResolveReferences(ctx context.Context, c client.Reader) error
 		}
 	}
 
+	// Resolve spec.forProvider.serverSideEncryptionConfiguration.rules[*].applyServerSideEncryptionByDefault.kmsMasterKeyId
+	if mg.Spec.ForProvider.ServerSideEncryptionConfiguration != nil {
+		for i, v := range mg.Spec.ForProvider.ServerSideEncryptionConfiguration.Rules {
+			d := v.ApplyServerSideEncryptionByDefault
+			rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+				CurrentValue: reference.FromPtrValue(d.KMSMasterKeyID),
+				Reference:    d.KMSMasterKeyIDRef,
+				Selector:     d.KMSMasterKeyIDSelector,
+				To:           reference.To{Managed: &kmsv1alpha1.Key{}, List: &kmsv1alpha1.KeyList{}},
+				Extract:      KMSKeyARN(),
+			})
+			if err != nil {
+				return errors.Wrapf(err, "spec.forProvider.serverSideEncryptionConfiguration.rules[%d].applyServerSideEncryptionByDefault.kmsMasterKeyId", i)
+			}
+			mg.Spec.ForProvider.ServerSideEncryptionConfiguration.Rules[i].ApplyServerSideEncryptionByDefault.KMSMasterKeyID = reference.ToPtrValue(rsp.ResolvedValue)
+			mg.Spec.ForProvider.ServerSideEncryptionConfiguration.Rules[i].ApplyServerSideEncryptionByDefault.KMSMasterKeyIDRef = rsp.ResolvedReference
+		}
+	}
+
+	// Resolve spec.forProvider.inventoryConfigurations[*].destination.bucket
+	for i, v := range mg.Spec.ForProvider.InventoryConfigurations {
+		rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+			CurrentValue: reference.FromPtrValue(v.Destination.Bucket),
+			Reference:    v.Destination.BucketRef,
+			Selector:     v.Destination.BucketSelector,
+			To:           reference.To{Managed: &Bucket{}, List: &BucketList{}},
+			Extract:      BucketARN(),
+		})
+		if err != nil {
+			return errors.Wrapf(err, "spec.forProvider.inventoryConfigurations[%d].destination.bucket", i)
+		}
+		mg.Spec.ForProvider.InventoryConfigurations[i].Destination.Bucket = reference.ToPtrValue(rsp.ResolvedValue)
+		mg.Spec.ForProvider.InventoryConfigurations[i].Destination.BucketRef = rsp.ResolvedReference
+	}
+
 	return nil
 }
@@ -45,6 +45,24 @@ type ReplicationConfiguration struct {
 	//
 	// Rules is a required field
 	Rules []ReplicationRule `json:"rules"`
+
+	// SameRegionDestinations determines how destination buckets in the same
+	// region as this bucket are handled, which is often a misconfiguration
+	// of what was meant to be cross-region replication. Warn emits a warning
+	// event and applies the configuration, Reject refuses to apply it.
+	// Defaults to Warn.
+	// +kubebuilder:validation:Enum=Warn;Reject
+	// +optional
+	SameRegionDestinations *string `json:"sameRegionDestinations,omitempty"`
+
+	// VerifyRolePermissions enables a check, before the replication
+	// configuration is applied, that simulates the policies of the role to
+	// verify it may read the replication configuration of this bucket and
+	// replicate objects to each destination bucket. A misconfigured role
+	// otherwise only surfaces as objects that are not replicated. The
+	// provider needs permission to call iam:SimulatePrincipalPolicy.
+	// +optional
+	VerifyRolePermissions *bool `json:"verifyRolePermissions,omitempty"`
 }
 
 // ReplicationRule specifies which Amazon S3 objects to replicate and where to store the replicas.
@@ -162,6 +180,12 @@ type Destination struct {
 	// +optional
 	BucketSelector *xpv1.Selector `json:"bucketSelector,omitempty"`
 
+	// ProviderConfigReference specifies the ProviderConfig used for calls
+	// against the destination bucket, e.g. when it is owned by another
+	// account. The credentials of the Bucket are used if it is not set.
+	// +optional
+	ProviderConfigReference *xpv1.Reference `json:"providerConfigRef,omitempty"`
+
 	// A container that provides information about encryption. If SourceSelectionCriteria
 	// is specified, you must specify this element.
 	// +optional
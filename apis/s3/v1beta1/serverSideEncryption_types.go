@@ -16,11 +16,24 @@ limitations under the License.
 
 package v1beta1
 
+import (
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
 // ServerSideEncryptionConfiguration specifies the default server-side-encryption configuration.
 type ServerSideEncryptionConfiguration struct {
 	// Container for information about a particular server-side encryption configuration
 	// rule.
 	Rules []ServerSideEncryptionRule `json:"rules"`
+
+	// FallbackToAES256OnKMSError makes the provider encrypt the bucket with
+	// AES256 if the KMS key of a rule cannot be used, e.g. because it was
+	// disabled or deleted, instead of failing to apply the configuration. A
+	// warning event is emitted when the fallback is used. The fallback is
+	// reported by the EncryptionFallback condition and kept until the KMS
+	// keys or this setting change, which makes the provider try KMS again.
+	// +optional
+	FallbackToAES256OnKMSError *bool `json:"fallbackToAES256OnKMSError,omitempty"`
 }
 
 // ServerSideEncryptionRule Specifies the default server-side encryption configuration.
@@ -29,6 +42,12 @@ type ServerSideEncryptionRule struct {
 	// bucket. If a PUT Object request doesn't specify any server-side encryption,
 	// this default encryption will be applied.
 	ApplyServerSideEncryptionByDefault ServerSideEncryptionByDefault `json:"applyServerSideEncryptionByDefault"`
+
+	// BucketKeyEnabled makes S3 use an S3 Bucket Key for SSE-KMS on new
+	// objects in the bucket, which reduces the number of requests to KMS. It
+	// may only be enabled if the algorithm is aws:kms. Defaults to false.
+	// +optional
+	BucketKeyEnabled *bool `json:"bucketKeyEnabled,omitempty"`
 }
 
 // ServerSideEncryptionByDefault describes the default server-side encryption to
@@ -56,9 +75,26 @@ type ServerSideEncryptionByDefault struct {
 	// +optional
 	KMSMasterKeyID *string `json:"kmsMasterKeyId,omitempty"`
 
+	// KMSMasterKeyIDRef references a KMS Key to retrieve its ARN
+	// +optional
+	KMSMasterKeyIDRef *xpv1.Reference `json:"kmsMasterKeyIdRef,omitempty"`
+
+	// KMSMasterKeyIDSelector selects a reference to a KMS Key to retrieve its
+	// ARN
+	// +optional
+	KMSMasterKeyIDSelector *xpv1.Selector `json:"kmsMasterKeyIdSelector,omitempty"`
+
+	// KMSMasterKeyIDSecretRef references a key of a Secret that contains the
+	// KMS customer master key ID to use for the default encryption. It is
+	// only used if KMSMasterKeyID is not set.
+	// +optional
+	KMSMasterKeyIDSecretRef *xpv1.SecretKeySelector `json:"kmsMasterKeyIdSecretRef,omitempty"`
+
 	// NOTE(muvaf): aws:kms is not accepted by kubebuilder enum.
 
 	// Server-side encryption algorithm to use for the default encryption.
-	// Options are AES256 or aws:kms
-	SSEAlgorithm string `json:"sseAlgorithm"`
+	// Options are AES256 or aws:kms, or their aliases SSE-S3 and SSE-KMS.
+	// Defaults to aws:kms if a KMS key is set and is required otherwise.
+	// +optional
+	SSEAlgorithm string `json:"sseAlgorithm,omitempty"`
 }
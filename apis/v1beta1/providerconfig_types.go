@@ -31,6 +31,28 @@ type ProviderConfigSpec struct {
 	// of AWS calls made by the provider.
 	// +optional
 	Endpoint *EndpointConfig `json:"endpoint,omitempty"`
+
+	// S3 lets you configure defaults for the S3 resources that use this
+	// ProviderConfig.
+	// +optional
+	S3 *S3Config `json:"s3,omitempty"`
+}
+
+// S3Config holds defaults applied to S3 resources.
+type S3Config struct {
+	// Backend is the storage service that serves the S3 API for this
+	// ProviderConfig, e.g. through a custom endpoint. Subresources of a Bucket
+	// that an S3 compatible backend does not implement are skipped. Defaults
+	// to AWS.
+	// +kubebuilder:validation:Enum=AWS;MinIO;Ceph
+	// +optional
+	Backend string `json:"backend,omitempty"`
+
+	// DefaultKMSKeyID is the KMS key used by server side encryption rules of a
+	// Bucket that request aws:kms without specifying a kmsMasterKeyId. The
+	// Bucket spec itself is left untouched.
+	// +optional
+	DefaultKMSKeyID *string `json:"defaultKMSKeyId,omitempty"`
 }
 
 // ProviderCredentials required to authenticate.
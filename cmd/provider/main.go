@@ -30,6 +30,7 @@ import (
 
 	"github.com/crossplane/provider-aws/apis"
 	"github.com/crossplane/provider-aws/pkg/controller"
+	"github.com/crossplane/provider-aws/pkg/controller/s3"
 )
 
 func main() {
@@ -39,6 +40,13 @@ func main() {
 		syncInterval   = app.Flag("sync", "Sync interval controls how often all resources will be double checked for drift.").Short('s').Default("1h").Duration()
 		pollInterval   = app.Flag("poll", "Poll interval controls how often an individual resource should be checked for drift.").Default("1m").Duration()
 		leaderElection = app.Flag("leader-election", "Use leader election for the conroller manager.").Short('l').Default("false").OverrideDefaultFromEnvar("LEADER_ELECTION").Bool()
+		safeMode       = app.Flag("disable-bucket-subresource-deletion", "Never delete the configuration of S3 Bucket subresources, e.g. CORS or lifecycle rules, in AWS.").Default("false").Bool()
+		bucketAPICalls = app.Flag("report-bucket-api-calls", "Report the number of S3 API calls made during the last reconcile in the status of S3 Buckets.").Default("false").Bool()
+		bucketAudit    = app.Flag("audit-buckets", "Only report S3 Buckets whose configuration drifts from AWS, never create, update or delete them.").Default("false").Bool()
+		bucketTxn      = app.Flag("transactional-bucket-updates", "Revert the subresources of an S3 Bucket already changed during an update if changing another one fails.").Default("false").Bool()
+		bucketQuotas   = app.Flag("check-bucket-quotas", "Check the AWS quotas a subresource of an S3 Bucket counts against before applying it.").Default("false").Bool()
+		bucketKeyRot   = app.Flag("report-bucket-kms-key-rotation", "Report whether automatic rotation is enabled for the KMS key used to encrypt S3 Buckets in their status. Requires kms:GetKeyRotationStatus.").Default("false").Bool()
+		bucketLogKMS   = app.Flag("grant-bucket-log-delivery-kms-access", "Add a statement to the policy of the KMS key an S3 server access logging target bucket is encrypted with that allows log delivery, and remove it once logging is disabled. Requires kms:GetKeyPolicy and kms:PutKeyPolicy.").Default("false").Bool()
 	)
 	kingpin.MustParse(app.Parse(os.Args[1:]))
 
@@ -64,7 +72,29 @@ func main() {
 	kingpin.FatalIfError(err, "Cannot create controller manager")
 
 	kingpin.FatalIfError(apis.AddToScheme(mgr.GetScheme()), "Cannot add AWS APIs to scheme")
-	kingpin.FatalIfError(controller.Setup(mgr, log, ratelimiter.NewGlobal(ratelimiter.DefaultGlobalRPS), *pollInterval), "Cannot setup AWS controllers")
+	var bucketOpts []s3.BucketOption
+	if *safeMode {
+		bucketOpts = append(bucketOpts, s3.WithSubresourceDeletionDisabled())
+	}
+	if *bucketAPICalls {
+		bucketOpts = append(bucketOpts, s3.WithAPICallsInStatus())
+	}
+	if *bucketAudit {
+		bucketOpts = append(bucketOpts, s3.WithAuditOnly())
+	}
+	if *bucketKeyRot {
+		bucketOpts = append(bucketOpts, s3.WithKMSKeyRotationInStatus())
+	}
+	if *bucketLogKMS {
+		bucketOpts = append(bucketOpts, s3.WithLoggingTargetKMSKeyGrants())
+	}
+	if *bucketTxn {
+		bucketOpts = append(bucketOpts, s3.WithTransactionalUpdates())
+	}
+	if *bucketQuotas {
+		bucketOpts = append(bucketOpts, s3.WithQuotaPreflight())
+	}
+	kingpin.FatalIfError(controller.Setup(mgr, log, ratelimiter.NewGlobal(ratelimiter.DefaultGlobalRPS), *pollInterval, bucketOpts...), "Cannot setup AWS controllers")
 	kingpin.FatalIfError(mgr.Start(ctrl.SetupSignalHandler()), "Cannot start controller manager")
 
 }
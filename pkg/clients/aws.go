@@ -102,6 +102,22 @@ func UseProviderConfig(ctx context.Context, c client.Client, mg resource.Managed
 		return nil, errors.Wrap(err, "cannot track ProviderConfig usage")
 	}
 
+	return configForProviderConfig(ctx, c, pc, region)
+}
+
+// UseNamedProviderConfig produces a config for the ProviderConfig with the
+// given name. Unlike UseProviderConfig it does not track the usage of the
+// ProviderConfig, so it is meant for secondary calls of a managed resource,
+// e.g. against a resource owned by another account.
+func UseNamedProviderConfig(ctx context.Context, c client.Client, name, region string) (*aws.Config, error) {
+	pc := &v1beta1.ProviderConfig{}
+	if err := c.Get(ctx, types.NamespacedName{Name: name}, pc); err != nil {
+		return nil, errors.Wrap(err, "cannot get referenced Provider")
+	}
+	return configForProviderConfig(ctx, c, pc, region)
+}
+
+func configForProviderConfig(ctx context.Context, c client.Client, pc *v1beta1.ProviderConfig, region string) (*aws.Config, error) {
 	switch s := pc.Spec.Credentials.Source; s { //nolint:exhaustive
 	case xpv1.CredentialsSourceInjectedIdentity:
 		cfg, err := UsePodServiceAccount(ctx, []byte{}, DefaultSection, region)
@@ -649,6 +665,35 @@ func LateInitializeBoolPtr(in *bool, from *bool) *bool {
 	return from
 }
 
+// A LateInitializer late initializes a single field. See LateInitializeFields.
+type LateInitializer func()
+
+// LateInitializeStringPtrField returns a LateInitializer that sets the field
+// in points to as LateInitializeStringPtr would.
+func LateInitializeStringPtrField(in **string, from *string) LateInitializer {
+	return func() { *in = LateInitializeStringPtr(*in, from) }
+}
+
+// LateInitializeStringField returns a LateInitializer that sets the field in
+// points to as LateInitializeString would.
+func LateInitializeStringField(in *string, from *string) LateInitializer {
+	return func() { *in = LateInitializeString(*in, from) }
+}
+
+// LateInitializeBoolPtrField returns a LateInitializer that sets the field in
+// points to as LateInitializeBoolPtr would.
+func LateInitializeBoolPtrField(in **bool, from *bool) LateInitializer {
+	return func() { *in = LateInitializeBoolPtr(*in, from) }
+}
+
+// LateInitializeFields runs the given LateInitializers, so that several
+// fields can be late initialized in a single call.
+func LateInitializeFields(fields ...LateInitializer) {
+	for _, f := range fields {
+		f()
+	}
+}
+
 // CompactAndEscapeJSON removes space characters and URL-encodes the JSON string.
 func CompactAndEscapeJSON(s string) (string, error) {
 	buffer := new(bytes.Buffer)
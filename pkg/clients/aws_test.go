@@ -696,3 +696,55 @@ func TestUseProviderConfigResolveEndpoint(t *testing.T) {
 		})
 	}
 }
+
+func TestLateInitializeFields(t *testing.T) {
+	type fields struct {
+		strPtr  *string
+		str     string
+		boolPtr *bool
+	}
+
+	cases := map[string]struct {
+		in   fields
+		from fields
+	}{
+		"AllUnset": {
+			from: fields{strPtr: aws.String("from"), str: "from", boolPtr: aws.Bool(true)},
+		},
+		"AllSet": {
+			in:   fields{strPtr: aws.String("in"), str: "in", boolPtr: aws.Bool(false)},
+			from: fields{strPtr: aws.String("from"), str: "from", boolPtr: aws.Bool(true)},
+		},
+		"NothingObserved": {
+			in: fields{strPtr: aws.String("in"), str: "in", boolPtr: aws.Bool(false)},
+		},
+		"Mixed": {
+			in:   fields{str: "in"},
+			from: fields{strPtr: aws.String("from"), boolPtr: aws.Bool(false)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var fromStr *string
+			if tc.from.str != "" {
+				fromStr = aws.String(tc.from.str)
+			}
+			want := fields{
+				strPtr:  LateInitializeStringPtr(tc.in.strPtr, tc.from.strPtr),
+				str:     LateInitializeString(tc.in.str, fromStr),
+				boolPtr: LateInitializeBoolPtr(tc.in.boolPtr, tc.from.boolPtr),
+			}
+
+			got := tc.in
+			LateInitializeFields(
+				LateInitializeStringPtrField(&got.strPtr, tc.from.strPtr),
+				LateInitializeStringField(&got.str, fromStr),
+				LateInitializeBoolPtrField(&got.boolPtr, tc.from.boolPtr),
+			)
+			if diff := cmp.Diff(want, got, cmp.AllowUnexported(fields{})); diff != "" {
+				t.Errorf("LateInitializeFields(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -17,15 +17,24 @@ limitations under the License.
 package s3
 
 import (
+	"bytes"
 	"context"
+	"encoding/xml"
 	"errors"
 	"fmt"
+	"io/ioutil"
+	"net/http"
+	"regexp"
 	"sort"
+	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
 	"github.com/crossplane/crossplane-runtime/pkg/meta"
 
 	"github.com/crossplane/provider-aws/apis/s3/v1beta1"
@@ -36,6 +45,9 @@ import (
 var (
 	// BucketNotFoundErrCode is the error code sent by AWS when a bucket does not exist
 	BucketNotFoundErrCode = "NotFound"
+	// NoSuchBucketErrCode is the error code sent by AWS when the bucket a
+	// subresource call is made for does not exist
+	NoSuchBucketErrCode = "NoSuchBucket"
 	// CORSNotFoundErrCode is the error code sent by AWS when the CORS configuration does not exist
 	CORSNotFoundErrCode = "NoSuchCORSConfiguration"
 	// PublicAccessBlockNotFoundErrCode is NotFound error for PublicAccessBlock
@@ -50,18 +62,40 @@ var (
 	TaggingNotFoundErrCode = "NoSuchTagSet"
 	// WebsiteNotFoundErrCode is the error code sent by AWS when the website config does not exist
 	WebsiteNotFoundErrCode = "NoSuchWebsiteConfiguration"
+	// OwnershipControlsNotFoundErrCode is the error code sent by AWS when the ownership controls do not exist
+	OwnershipControlsNotFoundErrCode = "OwnershipControlsNotFoundError"
+	// ObjectLockNotFoundErrCode is the error code sent by AWS when Object Lock is not enabled on the bucket
+	ObjectLockNotFoundErrCode = "ObjectLockConfigurationNotFoundError"
+	// KMSErrCodePrefix prefixes the error codes sent by AWS when a request fails
+	// because its KMS key cannot be used, e.g. KMS.DisabledException
+	KMSErrCodePrefix = "KMS."
+	// KMSAccessDeniedErrCode is the error code sent by AWS when KMS denies
+	// the use of a key to S3
+	KMSAccessDeniedErrCode = "KMS.AccessDeniedException"
+	// AccessDeniedErrCode is the error code sent by AWS when the request is not authorized
+	AccessDeniedErrCode = "AccessDenied"
+	// InvalidRequestErrCode is the error code sent by AWS when a request is
+	// rejected, e.g. a replication configuration of an unversioned bucket
+	InvalidRequestErrCode = "InvalidRequest"
 
 	// MethodNotAllowed is the error code sent by AWS when the request method for an object is not allowed
 	MethodNotAllowed = "MethodNotAllowed"
 	// UnsupportedArgument is the error code sent by AWS when the request fields contain an argument that is not supported
 	UnsupportedArgument = "UnsupportedArgument"
+	// NotImplementedErrCode is the error code sent by S3 compatible backends, e.g. MinIO or Ceph, when they do not implement an API
+	NotImplementedErrCode = "NotImplemented"
 )
 
+// ObjectOwnershipBucketOwnerEnforced is the object ownership that disables
+// ACLs of the bucket and its objects.
+const ObjectOwnershipBucketOwnerEnforced = "BucketOwnerEnforced"
+
 // BucketClient is the interface for Client for making S3 Bucket requests.
 type BucketClient interface {
 	HeadBucket(ctx context.Context, input *s3.HeadBucketInput, opts ...func(*s3.Options)) (*s3.HeadBucketOutput, error)
 	CreateBucket(ctx context.Context, input *s3.CreateBucketInput, opts ...func(*s3.Options)) (*s3.CreateBucketOutput, error)
 	DeleteBucket(ctx context.Context, input *s3.DeleteBucketInput, opts ...func(*s3.Options)) (*s3.DeleteBucketOutput, error)
+	GetBucketLocation(ctx context.Context, input *s3.GetBucketLocationInput, opts ...func(*s3.Options)) (*s3.GetBucketLocationOutput, error)
 
 	PutBucketEncryption(ctx context.Context, input *s3.PutBucketEncryptionInput, opts ...func(*s3.Options)) (*s3.PutBucketEncryptionOutput, error)
 	GetBucketEncryption(ctx context.Context, input *s3.GetBucketEncryptionInput, opts ...func(*s3.Options)) (*s3.GetBucketEncryptionOutput, error)
@@ -98,6 +132,19 @@ type BucketClient interface {
 	PutBucketAnalyticsConfiguration(ctx context.Context, input *s3.PutBucketAnalyticsConfigurationInput, opts ...func(*s3.Options)) (*s3.PutBucketAnalyticsConfigurationOutput, error)
 	GetBucketAnalyticsConfiguration(ctx context.Context, input *s3.GetBucketAnalyticsConfigurationInput, opts ...func(*s3.Options)) (*s3.GetBucketAnalyticsConfigurationOutput, error)
 
+	PutBucketMetricsConfiguration(ctx context.Context, input *s3.PutBucketMetricsConfigurationInput, opts ...func(*s3.Options)) (*s3.PutBucketMetricsConfigurationOutput, error)
+	GetBucketMetricsConfiguration(ctx context.Context, input *s3.GetBucketMetricsConfigurationInput, opts ...func(*s3.Options)) (*s3.GetBucketMetricsConfigurationOutput, error)
+	DeleteBucketMetricsConfiguration(ctx context.Context, input *s3.DeleteBucketMetricsConfigurationInput, opts ...func(*s3.Options)) (*s3.DeleteBucketMetricsConfigurationOutput, error)
+	ListBucketMetricsConfigurations(ctx context.Context, input *s3.ListBucketMetricsConfigurationsInput, opts ...func(*s3.Options)) (*s3.ListBucketMetricsConfigurationsOutput, error)
+	PutBucketInventoryConfiguration(ctx context.Context, input *s3.PutBucketInventoryConfigurationInput, opts ...func(*s3.Options)) (*s3.PutBucketInventoryConfigurationOutput, error)
+	GetBucketInventoryConfiguration(ctx context.Context, input *s3.GetBucketInventoryConfigurationInput, opts ...func(*s3.Options)) (*s3.GetBucketInventoryConfigurationOutput, error)
+	DeleteBucketInventoryConfiguration(ctx context.Context, input *s3.DeleteBucketInventoryConfigurationInput, opts ...func(*s3.Options)) (*s3.DeleteBucketInventoryConfigurationOutput, error)
+	ListBucketInventoryConfigurations(ctx context.Context, input *s3.ListBucketInventoryConfigurationsInput, opts ...func(*s3.Options)) (*s3.ListBucketInventoryConfigurationsOutput, error)
+	PutBucketIntelligentTieringConfiguration(ctx context.Context, input *s3.PutBucketIntelligentTieringConfigurationInput, opts ...func(*s3.Options)) (*s3.PutBucketIntelligentTieringConfigurationOutput, error)
+	GetBucketIntelligentTieringConfiguration(ctx context.Context, input *s3.GetBucketIntelligentTieringConfigurationInput, opts ...func(*s3.Options)) (*s3.GetBucketIntelligentTieringConfigurationOutput, error)
+	DeleteBucketIntelligentTieringConfiguration(ctx context.Context, input *s3.DeleteBucketIntelligentTieringConfigurationInput, opts ...func(*s3.Options)) (*s3.DeleteBucketIntelligentTieringConfigurationOutput, error)
+	ListBucketIntelligentTieringConfigurations(ctx context.Context, input *s3.ListBucketIntelligentTieringConfigurationsInput, opts ...func(*s3.Options)) (*s3.ListBucketIntelligentTieringConfigurationsOutput, error)
+
 	PutBucketLifecycleConfiguration(ctx context.Context, input *s3.PutBucketLifecycleConfigurationInput, opts ...func(*s3.Options)) (*s3.PutBucketLifecycleConfigurationOutput, error)
 	GetBucketLifecycleConfiguration(ctx context.Context, input *s3.GetBucketLifecycleConfigurationInput, opts ...func(*s3.Options)) (*s3.GetBucketLifecycleConfigurationOutput, error)
 	DeleteBucketLifecycle(ctx context.Context, input *s3.DeleteBucketLifecycleInput, opts ...func(*s3.Options)) (*s3.DeleteBucketLifecycleOutput, error)
@@ -110,6 +157,13 @@ type BucketClient interface {
 	GetPublicAccessBlock(ctx context.Context, input *s3.GetPublicAccessBlockInput, opts ...func(*s3.Options)) (*s3.GetPublicAccessBlockOutput, error)
 	PutPublicAccessBlock(ctx context.Context, input *s3.PutPublicAccessBlockInput, opts ...func(*s3.Options)) (*s3.PutPublicAccessBlockOutput, error)
 	DeletePublicAccessBlock(ctx context.Context, input *s3.DeletePublicAccessBlockInput, opts ...func(*s3.Options)) (*s3.DeletePublicAccessBlockOutput, error)
+
+	GetBucketOwnershipControls(ctx context.Context, input *s3.GetBucketOwnershipControlsInput, opts ...func(*s3.Options)) (*s3.GetBucketOwnershipControlsOutput, error)
+	PutBucketOwnershipControls(ctx context.Context, input *s3.PutBucketOwnershipControlsInput, opts ...func(*s3.Options)) (*s3.PutBucketOwnershipControlsOutput, error)
+	DeleteBucketOwnershipControls(ctx context.Context, input *s3.DeleteBucketOwnershipControlsInput, opts ...func(*s3.Options)) (*s3.DeleteBucketOwnershipControlsOutput, error)
+
+	GetObjectLockConfiguration(ctx context.Context, input *s3.GetObjectLockConfigurationInput, opts ...func(*s3.Options)) (*s3.GetObjectLockConfigurationOutput, error)
+	PutObjectLockConfiguration(ctx context.Context, input *s3.PutObjectLockConfigurationInput, opts ...func(*s3.Options)) (*s3.PutObjectLockConfigurationOutput, error)
 }
 
 // NewClient returns a new client using AWS credentials as JSON encoded data.
@@ -123,6 +177,13 @@ func IsNotFound(err error) bool {
 	return errors.As(err, &notFoundError)
 }
 
+// IsNoSuchBucket returns true if the error code indicates that the bucket does
+// not exist. Unlike IsNotFound, it matches the errors of subresource calls
+// rather than of HeadBucket.
+func IsNoSuchBucket(err error) bool {
+	return IsErrorCode(err, NoSuchBucketErrCode)
+}
+
 // IsAlreadyExists helper function to test for ErrCodeBucketAlreadyOwnedByYou error
 func IsAlreadyExists(err error) bool {
 	var alreadyOwnedByYou *s3types.BucketAlreadyOwnedByYou
@@ -141,12 +202,33 @@ func GenerateCreateBucketInput(name string, s v1beta1.BucketParameters) *s3.Crea
 		GrantWriteACP:              s.GrantWriteACP,
 		ObjectLockEnabledForBucket: aws.ToBool(s.ObjectLockEnabledForBucket),
 	}
+	if ACLsDisabled(s) {
+		cbi.ACL = ""
+		cbi.GrantFullControl = nil
+		cbi.GrantRead = nil
+		cbi.GrantReadACP = nil
+		cbi.GrantWrite = nil
+		cbi.GrantWriteACP = nil
+	}
 	if s.LocationConstraint != "us-east-1" {
 		cbi.CreateBucketConfiguration = &s3types.CreateBucketConfiguration{LocationConstraint: s3types.BucketLocationConstraint(s.LocationConstraint)}
 	}
 	return cbi
 }
 
+// BucketRegion returns the region of a bucket with the supplied location
+// constraint as returned by GetBucketLocation. Buckets in us-east-1 have no
+// location constraint and some buckets in eu-west-1 have the legacy EU one.
+func BucketRegion(constraint s3types.BucketLocationConstraint) string {
+	switch constraint {
+	case "":
+		return "us-east-1"
+	case s3types.BucketLocationConstraintEu:
+		return "eu-west-1"
+	}
+	return string(constraint)
+}
+
 // GenerateBucketObservation generates the ARN string for the external status
 func GenerateBucketObservation(name string) v1beta1.BucketExternalStatus {
 	return v1beta1.BucketExternalStatus{
@@ -154,58 +236,96 @@ func GenerateBucketObservation(name string) v1beta1.BucketExternalStatus {
 	}
 }
 
+// IsErrorCode returns true if err, or an error it wraps, is an AWS API error
+// with the supplied error code, e.g. the "NoSuch..." code S3 returns when an
+// optional configuration of a bucket is read before it was set.
+func IsErrorCode(err error, code string) bool {
+	var awsErr smithy.APIError
+	return errors.As(err, &awsErr) && awsErr.ErrorCode() == code
+}
+
 // CORSConfigurationNotFound is parses the aws Error and validates if the cors configuration does not exist
 func CORSConfigurationNotFound(err error) bool {
-	var awsErr smithy.APIError
-	return errors.As(err, &awsErr) && awsErr.ErrorCode() == CORSNotFoundErrCode
+	return IsErrorCode(err, CORSNotFoundErrCode)
 }
 
 // ReplicationConfigurationNotFound is parses the aws Error and validates if the replication configuration does not exist
 func ReplicationConfigurationNotFound(err error) bool {
-	var awsErr smithy.APIError
-	return errors.As(err, &awsErr) && awsErr.ErrorCode() == ReplicationNotFoundErrCode
+	return IsErrorCode(err, ReplicationNotFoundErrCode)
 }
 
 // PublicAccessBlockConfigurationNotFound is parses the aws Error and validates if the public access block does not exist
 func PublicAccessBlockConfigurationNotFound(err error) bool {
-	var awsErr smithy.APIError
-	return errors.As(err, &awsErr) && awsErr.ErrorCode() == PublicAccessBlockNotFoundErrCode
+	return IsErrorCode(err, PublicAccessBlockNotFoundErrCode)
+}
+
+// OwnershipControlsNotFound parses the aws Error and validates if the ownership controls do not exist
+func OwnershipControlsNotFound(err error) bool {
+	return IsErrorCode(err, OwnershipControlsNotFoundErrCode)
+}
+
+// ObjectLockConfigurationNotFound parses the aws Error and validates if Object Lock is not enabled on the bucket
+func ObjectLockConfigurationNotFound(err error) bool {
+	return IsErrorCode(err, ObjectLockNotFoundErrCode)
 }
 
 // LifecycleConfigurationNotFound is parses the aws Error and validates if the lifecycle configuration does not exist
 func LifecycleConfigurationNotFound(err error) bool {
-	var awsErr smithy.APIError
-	return errors.As(err, &awsErr) && awsErr.ErrorCode() == LifecycleNotFoundErrCode
+	return IsErrorCode(err, LifecycleNotFoundErrCode)
 }
 
 // SSEConfigurationNotFound is parses the aws Error and validates if the SSE configuration does not exist
 func SSEConfigurationNotFound(err error) bool {
+	return IsErrorCode(err, SSENotFoundErrCode)
+}
+
+// KMSKeyInaccessible parses the aws Error and validates if the request failed
+// because its KMS key is disabled, deleted or may not be used.
+func KMSKeyInaccessible(err error) bool {
+	var awsErr smithy.APIError
+	return errors.As(err, &awsErr) && strings.HasPrefix(awsErr.ErrorCode(), KMSErrCodePrefix)
+}
+
+// KMSAccessDenied parses the aws Error and validates if KMS denied the use of
+// a key when S3 tried to use it. A plain AccessDenied of S3 is not a KMS
+// error, since S3 also returns it when the caller may not change the bucket.
+func KMSAccessDenied(err error) bool {
+	return IsErrorCode(err, KMSAccessDeniedErrCode)
+}
+
+// ReplicationVersioningNotEnabled parses the aws Error and validates if a
+// replication configuration was rejected because versioning is not enabled on
+// the source or a destination bucket.
+func ReplicationVersioningNotEnabled(err error) bool {
 	var awsErr smithy.APIError
-	return errors.As(err, &awsErr) && awsErr.ErrorCode() == SSENotFoundErrCode
+	return errors.As(err, &awsErr) && awsErr.ErrorCode() == InvalidRequestErrCode &&
+		strings.Contains(strings.ToLower(awsErr.ErrorMessage()), "versioning must be 'enabled'")
 }
 
 // TaggingNotFound is parses the aws Error and validates if the tagging configuration does not exist
 func TaggingNotFound(err error) bool {
-	var awsErr smithy.APIError
-	return errors.As(err, &awsErr) && awsErr.ErrorCode() == TaggingNotFoundErrCode
+	return IsErrorCode(err, TaggingNotFoundErrCode)
 }
 
 // WebsiteConfigurationNotFound is parses the aws Error and validates if the website configuration does not exist
 func WebsiteConfigurationNotFound(err error) bool {
-	var awsErr smithy.APIError
-	return errors.As(err, &awsErr) && awsErr.ErrorCode() == WebsiteNotFoundErrCode
+	return IsErrorCode(err, WebsiteNotFoundErrCode)
 }
 
 // MethodNotSupported is parses the aws Error and validates if the method is allowed for a request
 func MethodNotSupported(err error) bool {
-	var awsErr smithy.APIError
-	return errors.As(err, &awsErr) && awsErr.ErrorCode() == MethodNotAllowed
+	return IsErrorCode(err, MethodNotAllowed)
 }
 
 // ArgumentNotSupported is parses the aws Error and validates if parameters are now allowed for a request
 func ArgumentNotSupported(err error) bool {
-	var awsErr smithy.APIError
-	return errors.As(err, &awsErr) && awsErr.ErrorCode() == UnsupportedArgument
+	return IsErrorCode(err, UnsupportedArgument)
+}
+
+// NotImplemented returns true if the error indicates that the backend serving
+// the S3 API does not implement the request.
+func NotImplemented(err error) bool {
+	return IsErrorCode(err, NotImplementedErrCode)
 }
 
 // UpdateBucketACL creates the ACLInput, sends the request to put an ACL based on the bucket
@@ -223,6 +343,122 @@ func UpdateBucketACL(ctx context.Context, client BucketClient, bucket *v1beta1.B
 	return err
 }
 
+// ACLsDisabled returns true if the ownership controls of the bucket disable
+// ACLs, in which case AWS rejects any ACL other than the private canned ACL.
+func ACLsDisabled(p v1beta1.BucketParameters) bool {
+	if p.OwnershipControls == nil {
+		return false
+	}
+	for _, r := range p.OwnershipControls.Rules {
+		if r.ObjectOwnership == ObjectOwnershipBucketOwnerEnforced {
+			return true
+		}
+	}
+	return false
+}
+
+// ACLDeprecations returns a message for every part of the ACL configuration of
+// the bucket that AWS recommends against. ACLs are discouraged in favour of
+// bucket policies, so anything but the private canned ACL and any grant is
+// reported.
+func ACLDeprecations(p v1beta1.BucketParameters) []string {
+	msgs := make([]string, 0)
+	if acl := aws.ToString(p.ACL); acl != "" && acl != string(s3types.BucketCannedACLPrivate) {
+		msgs = append(msgs, fmt.Sprintf("canned ACL %q is discouraged by AWS, use a bucket policy instead", acl))
+	}
+	grants := []struct {
+		name  string
+		value *string
+	}{
+		{name: "grantFullControl", value: p.GrantFullControl},
+		{name: "grantRead", value: p.GrantRead},
+		{name: "grantReadAcp", value: p.GrantReadACP},
+		{name: "grantWrite", value: p.GrantWrite},
+		{name: "grantWriteAcp", value: p.GrantWriteACP},
+	}
+	for _, g := range grants {
+		if g.value != nil {
+			msgs = append(msgs, fmt.Sprintf("ACL grant %s is discouraged by AWS, use a bucket policy instead", g.name))
+		}
+	}
+	return msgs
+}
+
+const errInvalidKMSKeyID = "invalid KMS key %q, must be a key ID, key ARN, alias name or alias ARN"
+
+var kmsKeyIDRegexp = regexp.MustCompile(`^(` +
+	// key ID, either a UUID or the ID of a multi-Region key
+	`[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}|mrk-[0-9a-fA-F]{32}|` +
+	// alias name
+	`alias/[a-zA-Z0-9/_-]+|` +
+	// key or alias ARN
+	`arn:aws[a-z-]*:kms:[a-z0-9-]+:[0-9]{12}:(key/([0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}|mrk-[0-9a-fA-F]{32})|alias/[a-zA-Z0-9/_-]+)` +
+	`)$`)
+
+// ValidateKMSKeyID returns an error if the supplied KMS key identifier is
+// neither a key ID, a key ARN, an alias name nor an alias ARN.
+func ValidateKMSKeyID(id string) error {
+	if !kmsKeyIDRegexp.MatchString(id) {
+		return fmt.Errorf(errInvalidKMSKeyID, id)
+	}
+	return nil
+}
+
+// KMSKeyAccount returns the ID of the account that owns the KMS key with the
+// supplied key or alias ARN. It returns an empty string for key IDs and alias
+// names, which always refer to a key of the calling account.
+func KMSKeyAccount(id string) string {
+	a, err := arn.Parse(id)
+	if err != nil || a.Service != "kms" {
+		return ""
+	}
+	return a.AccountID
+}
+
+// normalizeMultiRegionKeyARN returns the supplied ARN of a multi-Region KMS
+// key without its region, since the replicas of such a key share their key ID
+// and S3 may report the ARN of the replica in the region of the bucket. Other
+// identifiers are returned as is.
+func normalizeMultiRegionKeyARN(id string) string {
+	a, err := arn.Parse(id)
+	if err != nil || a.Service != "kms" || !strings.HasPrefix(a.Resource, "key/mrk-") {
+		return id
+	}
+	a.Region = ""
+	return a.String()
+}
+
+// KMSKeyIDEqual returns true if the supplied KMS key identifiers are equal.
+// ARNs of a multi-Region key that differ only in their region are equal.
+func KMSKeyIDEqual(a, b string) bool {
+	return normalizeMultiRegionKeyARN(a) == normalizeMultiRegionKeyARN(b)
+}
+
+// enumSynonyms maps normalized alternative names of AWS enum values to the
+// normalized value AWS uses.
+var enumSynonyms = map[string]string{
+	"sse-s3":  "aes256",
+	"sse-kms": "aws:kms",
+}
+
+// NormalizeEnum returns the form of an AWS enum value that is used to compare
+// it, i.e. without surrounding whitespace, lower case and with synonyms such
+// as SSE-KMS replaced by the value AWS uses.
+func NormalizeEnum(v string) string {
+	n := strings.ToLower(strings.TrimSpace(v))
+	if s, ok := enumSynonyms[n]; ok {
+		return s
+	}
+	return n
+}
+
+// EnumEqual returns true if the supplied AWS enum values are equal after
+// normalization, e.g. "aws:kms" and "AWS:KMS" or "FULL_CONTROL" and
+// "full_control".
+func EnumEqual(a, b string) bool {
+	return NormalizeEnum(a) == NormalizeEnum(b)
+}
+
 // CopyTags converts a list of local v1beta.Tags to S3 Tags
 func CopyTags(tags []v1beta1.Tag) []s3types.Tag {
 	out := make([]s3types.Tag, 0)
@@ -246,7 +482,64 @@ func SortS3TagSet(tags []s3types.Tag) []s3types.Tag {
 	outTags := make([]s3types.Tag, len(tags))
 	copy(outTags, tags)
 	sort.SliceStable(outTags, func(i, j int) bool {
-		return aws.ToString(outTags[i].Key) < aws.ToString(outTags[j].Key)
+		if ki, kj := aws.ToString(outTags[i].Key), aws.ToString(outTags[j].Key); ki != kj {
+			return ki < kj
+		}
+		return aws.ToString(outTags[i].Value) < aws.ToString(outTags[j].Value)
 	})
 	return outTags
 }
+
+// WithEmbeddedErrorCheck makes the client inspect the body of responses with
+// HTTP status 200 for an S3 error document. S3 and some S3 compatible backends
+// may report a failure this way, which the SDK does not treat as an error for
+// most operations.
+func WithEmbeddedErrorCheck(o *s3.Options) {
+	o.APIOptions = append(o.APIOptions, func(stack *middleware.Stack) error {
+		return stack.Deserialize.Add(embeddedErrorMiddleware{}, middleware.After)
+	})
+}
+
+type embeddedErrorMiddleware struct{}
+
+func (embeddedErrorMiddleware) ID() string {
+	return "EmbeddedErrorCheck"
+}
+
+func (embeddedErrorMiddleware) HandleDeserialize(ctx context.Context, in middleware.DeserializeInput, next middleware.DeserializeHandler) (middleware.DeserializeOutput, middleware.Metadata, error) {
+	out, md, err := next.HandleDeserialize(ctx, in)
+	if err != nil {
+		return out, md, err
+	}
+	resp, ok := out.RawResponse.(*smithyhttp.Response)
+	if !ok || resp.StatusCode != http.StatusOK || resp.Body == nil {
+		return out, md, nil
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return out, md, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	if apiErr := embeddedError(body); apiErr != nil {
+		return out, md, apiErr
+	}
+	return out, md, nil
+}
+
+// embeddedError returns the error described by the given response body if it
+// is an S3 error document.
+func embeddedError(body []byte) error {
+	doc := struct {
+		XMLName xml.Name
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	}{}
+	if err := xml.Unmarshal(bytes.TrimSpace(body), &doc); err != nil {
+		return nil
+	}
+	if doc.XMLName.Local != "Error" || doc.Code == "" {
+		return nil
+	}
+	return &smithy.GenericAPIError{Code: doc.Code, Message: doc.Message}
+}
@@ -0,0 +1,404 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package s3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/document"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+const errorBody = `<?xml version="1.0" encoding="UTF-8"?>
+<Error><Code>AccessDenied</Code><Message>Access Denied</Message><RequestId>1</RequestId></Error>`
+
+type httpClientFn func(*http.Request) (*http.Response, error)
+
+func (fn httpClientFn) Do(r *http.Request) (*http.Response, error) {
+	return fn(r)
+}
+
+func okResponse(body string) httpClientFn {
+	return func(r *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{},
+			Body:       ioutil.NopCloser(strings.NewReader(body)),
+			Request:    r,
+		}, nil
+	}
+}
+
+func TestWithEmbeddedErrorCheck(t *testing.T) {
+	type args struct {
+		body string
+		opts []func(*s3.Options)
+	}
+
+	cases := map[string]struct {
+		args
+		want error
+	}{
+		"EmbeddedError": {
+			args: args{
+				body: errorBody,
+				opts: []func(*s3.Options){WithEmbeddedErrorCheck},
+			},
+			want: &smithy.GenericAPIError{Code: "AccessDenied", Message: "Access Denied"},
+		},
+		"EmptyBody": {
+			args: args{
+				opts: []func(*s3.Options){WithEmbeddedErrorCheck},
+			},
+		},
+		"EmbeddedErrorNotChecked": {
+			args: args{
+				body: errorBody,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cl := s3.NewFromConfig(aws.Config{
+				Region:      "us-east-1",
+				Credentials: aws.AnonymousCredentials{},
+				HTTPClient:  okResponse(tc.args.body),
+			})
+			_, err := cl.PutBucketLogging(context.Background(), &s3.PutBucketLoggingInput{
+				Bucket:              aws.String("bucket"),
+				BucketLoggingStatus: &s3types.BucketLoggingStatus{},
+			}, tc.args.opts...)
+			var got error
+			var apiErr *smithy.GenericAPIError
+			if err != nil && !errors.As(err, &apiErr) {
+				t.Fatalf("r: unexpected error: %s", err)
+			}
+			if apiErr != nil {
+				got = apiErr
+			}
+			if diff := cmp.Diff(tc.want, got, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIsErrorCode(t *testing.T) {
+	type args struct {
+		err  error
+		code string
+	}
+
+	cases := map[string]struct {
+		args
+		want bool
+	}{
+		"MatchingCode": {
+			args: args{err: &smithy.GenericAPIError{Code: SSENotFoundErrCode}, code: SSENotFoundErrCode},
+			want: true,
+		},
+		"WrappedMatchingCode": {
+			args: args{err: fmt.Errorf("cannot get encryption: %w", &smithy.GenericAPIError{Code: CORSNotFoundErrCode}), code: CORSNotFoundErrCode},
+			want: true,
+		},
+		"OtherCode": {
+			args: args{err: &smithy.GenericAPIError{Code: AccessDeniedErrCode}, code: CORSNotFoundErrCode},
+			want: false,
+		},
+		"NotAnAPIError": {
+			args: args{err: errors.New(CORSNotFoundErrCode), code: CORSNotFoundErrCode},
+			want: false,
+		},
+		"NoError": {
+			args: args{code: CORSNotFoundErrCode},
+			want: false,
+		},
+		"NotImplemented": {
+			args: args{err: fmt.Errorf("cannot get accelerate configuration: %w", &smithy.GenericAPIError{Code: NotImplementedErrCode}), code: NotImplementedErrCode},
+			want: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, IsErrorCode(tc.args.err, tc.args.code)); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReplicationVersioningNotEnabled(t *testing.T) {
+	cases := map[string]struct {
+		err  error
+		want bool
+	}{
+		"VersioningNotEnabled": {
+			err:  fmt.Errorf("cannot put Bucket replication: %w", &smithy.GenericAPIError{Code: InvalidRequestErrCode, Message: "Versioning must be 'Enabled' on the bucket to apply a replication configuration"}),
+			want: true,
+		},
+		"OtherInvalidRequest": {
+			err:  &smithy.GenericAPIError{Code: InvalidRequestErrCode, Message: "Priority must not be the same for any two rules"},
+			want: false,
+		},
+		"OtherCode": {
+			err:  &smithy.GenericAPIError{Code: AccessDeniedErrCode, Message: "Versioning must be 'Enabled'"},
+			want: false,
+		},
+		"NoError": {},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, ReplicationVersioningNotEnabled(tc.err)); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateKMSKeyID(t *testing.T) {
+	cases := map[string]struct {
+		id    string
+		valid bool
+	}{
+		"KeyID": {
+			id:    "1234abcd-12ab-34cd-56ef-1234567890ab",
+			valid: true,
+		},
+		"MultiRegionKeyID": {
+			id:    "mrk-1234abcd12ab34cd56ef1234567890ab",
+			valid: true,
+		},
+		"KeyARN": {
+			id:    "arn:aws:kms:us-east-2:111122223333:key/1234abcd-12ab-34cd-56ef-1234567890ab",
+			valid: true,
+		},
+		"GovCloudKeyARN": {
+			id:    "arn:aws-us-gov:kms:us-gov-west-1:111122223333:key/1234abcd-12ab-34cd-56ef-1234567890ab",
+			valid: true,
+		},
+		"AliasName": {
+			id:    "alias/ExampleAlias",
+			valid: true,
+		},
+		"AliasARN": {
+			id:    "arn:aws:kms:us-east-2:111122223333:alias/ExampleAlias",
+			valid: true,
+		},
+		"Empty": {
+			id: "",
+		},
+		"Garbage": {
+			id: "not a key",
+		},
+		"TruncatedKeyID": {
+			id: "1234abcd-12ab-34cd-56ef",
+		},
+		"AliasWithoutName": {
+			id: "alias/",
+		},
+		"ARNOfOtherService": {
+			id: "arn:aws:s3:::bucket",
+		},
+		"KeyARNWithoutAccount": {
+			id: "arn:aws:kms:us-east-2::key/1234abcd-12ab-34cd-56ef-1234567890ab",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateKMSKeyID(tc.id)
+			if tc.valid && err != nil {
+				t.Errorf("r: unexpected error: %s", err)
+			}
+			if !tc.valid && err == nil {
+				t.Errorf("r: expected an error for %q", tc.id)
+			}
+		})
+	}
+}
+
+func TestKMSKeyAccount(t *testing.T) {
+	cases := map[string]struct {
+		id      string
+		account string
+	}{
+		"KeyARN": {
+			id:      "arn:aws:kms:us-east-2:111122223333:key/1234abcd-12ab-34cd-56ef-1234567890ab",
+			account: "111122223333",
+		},
+		"AliasARN": {
+			id:      "arn:aws:kms:us-east-2:111122223333:alias/ExampleAlias",
+			account: "111122223333",
+		},
+		"KeyID": {
+			id: "1234abcd-12ab-34cd-56ef-1234567890ab",
+		},
+		"AliasName": {
+			id: "alias/ExampleAlias",
+		},
+		"ARNOfOtherService": {
+			id: "arn:aws:iam::111122223333:role/example",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.account, KMSKeyAccount(tc.id)); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestKMSKeyIDEqual(t *testing.T) {
+	mrk := "mrk-1234abcd12ab34cd56ef1234567890ab"
+	cases := map[string]struct {
+		a     string
+		b     string
+		equal bool
+	}{
+		"SameKeyID": {
+			a:     "1234abcd-12ab-34cd-56ef-1234567890ab",
+			b:     "1234abcd-12ab-34cd-56ef-1234567890ab",
+			equal: true,
+		},
+		"MultiRegionKeyReplicaRegion": {
+			a:     "arn:aws:kms:us-east-1:111122223333:key/" + mrk,
+			b:     "arn:aws:kms:eu-west-1:111122223333:key/" + mrk,
+			equal: true,
+		},
+		"MultiRegionKeyOtherAccount": {
+			a: "arn:aws:kms:us-east-1:111122223333:key/" + mrk,
+			b: "arn:aws:kms:eu-west-1:444455556666:key/" + mrk,
+		},
+		"SingleRegionKeyOtherRegion": {
+			a: "arn:aws:kms:us-east-1:111122223333:key/1234abcd-12ab-34cd-56ef-1234567890ab",
+			b: "arn:aws:kms:eu-west-1:111122223333:key/1234abcd-12ab-34cd-56ef-1234567890ab",
+		},
+		"AliasOtherRegion": {
+			a: "arn:aws:kms:us-east-1:111122223333:alias/mrk-alias",
+			b: "arn:aws:kms:eu-west-1:111122223333:alias/mrk-alias",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.equal, KMSKeyIDEqual(tc.a, tc.b)); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSortS3TagSet(t *testing.T) {
+	tag := func(k, v string) s3types.Tag {
+		return s3types.Tag{Key: aws.String(k), Value: aws.String(v)}
+	}
+	cases := map[string]struct {
+		in   []s3types.Tag
+		want []s3types.Tag
+	}{
+		"Empty": {
+			in:   []s3types.Tag{},
+			want: []s3types.Tag{},
+		},
+		"ByKey": {
+			in:   []s3types.Tag{tag("c", "1"), tag("a", "2"), tag("b", "3")},
+			want: []s3types.Tag{tag("a", "2"), tag("b", "3"), tag("c", "1")},
+		},
+		"ByValueForEqualKeys": {
+			in:   []s3types.Tag{tag("a", "2"), tag("a", "1")},
+			want: []s3types.Tag{tag("a", "1"), tag("a", "2")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := SortS3TagSet(tc.in)
+			if diff := cmp.Diff(tc.want, got, cmpopts.IgnoreTypes(document.NoSerde{})); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEnumEqual(t *testing.T) {
+	cases := map[string]struct {
+		a    string
+		b    string
+		want bool
+	}{
+		"Identical": {
+			a:    string(s3types.ServerSideEncryptionAwsKms),
+			b:    "aws:kms",
+			want: true,
+		},
+		"Case": {
+			a:    string(s3types.BucketLogsPermissionFullControl),
+			b:    "full_control",
+			want: true,
+		},
+		"Whitespace": {
+			a:    string(s3types.TypeCanonicalUser),
+			b:    " CanonicalUser ",
+			want: true,
+		},
+		"SynonymSSES3": {
+			a:    string(s3types.ServerSideEncryptionAes256),
+			b:    "SSE-S3",
+			want: true,
+		},
+		"SynonymSSEKMS": {
+			a:    "sse-kms",
+			b:    string(s3types.ServerSideEncryptionAwsKms),
+			want: true,
+		},
+		"Different": {
+			a:    string(s3types.ServerSideEncryptionAes256),
+			b:    string(s3types.ServerSideEncryptionAwsKms),
+			want: false,
+		},
+		"DifferentType": {
+			a:    string(s3types.TypeGroup),
+			b:    string(s3types.TypeCanonicalUser),
+			want: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, EnumEqual(tc.a, tc.b)); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
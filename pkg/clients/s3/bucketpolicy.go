@@ -18,15 +18,17 @@ package s3
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
-	"github.com/aws/smithy-go"
 
 	"github.com/crossplane/provider-aws/apis/s3/v1alpha3"
+	awsclient "github.com/crossplane/provider-aws/pkg/clients"
 )
 
 // BucketPolicyClient is the external client used for S3BucketPolicy Custom Resource
@@ -34,8 +36,23 @@ type BucketPolicyClient interface {
 	GetBucketPolicy(ctx context.Context, input *s3.GetBucketPolicyInput, opts ...func(*s3.Options)) (*s3.GetBucketPolicyOutput, error)
 	PutBucketPolicy(ctx context.Context, input *s3.PutBucketPolicyInput, opts ...func(*s3.Options)) (*s3.PutBucketPolicyOutput, error)
 	DeleteBucketPolicy(ctx context.Context, input *s3.DeleteBucketPolicyInput, opts ...func(*s3.Options)) (*s3.DeleteBucketPolicyOutput, error)
+	GetBucketEncryption(ctx context.Context, input *s3.GetBucketEncryptionInput, opts ...func(*s3.Options)) (*s3.GetBucketEncryptionOutput, error)
+	GetBucketPolicyStatus(ctx context.Context, input *s3.GetBucketPolicyStatusInput, opts ...func(*s3.Options)) (*s3.GetBucketPolicyStatusOutput, error)
+	GetPublicAccessBlock(ctx context.Context, input *s3.GetPublicAccessBlockInput, opts ...func(*s3.Options)) (*s3.GetPublicAccessBlockOutput, error)
 }
 
+// PolicyNotFoundErrCode is the error code sent by AWS when the bucket policy
+// does not exist
+const PolicyNotFoundErrCode = "NoSuchBucketPolicy"
+
+// sseConditionKey is the condition key bucket policies use to require a
+// server side encryption algorithm on uploads.
+const sseConditionKey = "s3:x-amz-server-side-encryption"
+
+// sseContextConditionKey is the condition key bucket policies use to require
+// an SSE-KMS encryption context on uploads.
+const sseContextConditionKey = "s3:x-amz-server-side-encryption-context"
+
 // NewBucketPolicyClient returns a new client given an aws config
 func NewBucketPolicyClient(cfg aws.Config) BucketPolicyClient {
 	return s3.NewFromConfig(cfg)
@@ -43,8 +60,7 @@ func NewBucketPolicyClient(cfg aws.Config) BucketPolicyClient {
 
 // IsErrorPolicyNotFound returns true if the error code indicates that the item was not found
 func IsErrorPolicyNotFound(err error) bool {
-	var awsErr smithy.APIError
-	return errors.As(err, &awsErr) && awsErr.ErrorCode() == "NoSuchBucketPolicy"
+	return IsErrorCode(err, PolicyNotFoundErrCode)
 }
 
 // IsErrorBucketNotFound returns true if the error code indicates that the bucket was not found
@@ -53,6 +69,12 @@ func IsErrorBucketNotFound(err error) bool {
 	return errors.As(err, &nsb)
 }
 
+// PublicAccessFullyBlocked returns true if all settings of the supplied
+// public access block are enabled.
+func PublicAccessFullyBlocked(c *s3types.PublicAccessBlockConfiguration) bool {
+	return c != nil && c.BlockPublicAcls && c.IgnorePublicAcls && c.BlockPublicPolicy && c.RestrictPublicBuckets
+}
+
 // Serialize is the custom marshaller for the BucketPolicyParameters
 func Serialize(p *v1alpha3.BucketPolicyBody) (interface{}, error) {
 	m := make(map[string]interface{})
@@ -191,3 +213,148 @@ func tryFirst(slc []string) interface{} {
 	}
 	return slc
 }
+
+// conditionStatement is a statement of a bucket policy, reduced to the fields
+// that are inspected by the checks below.
+type conditionStatement struct {
+	Effect    string                                `json:"Effect"`
+	Condition map[string]map[string]json.RawMessage `json:"Condition"`
+}
+
+// denyStatements returns the Deny statements of the supplied policy.
+func denyStatements(policy string) ([]conditionStatement, error) {
+	doc := struct {
+		Statement json.RawMessage `json:"Statement"`
+	}{}
+	if err := json.Unmarshal([]byte(policy), &doc); err != nil {
+		return nil, err
+	}
+	var statements []conditionStatement
+	if err := json.Unmarshal(doc.Statement, &statements); err != nil {
+		// A policy may hold a single statement instead of a list.
+		var st conditionStatement
+		if err := json.Unmarshal(doc.Statement, &st); err != nil {
+			return nil, err
+		}
+		statements = []conditionStatement{st}
+	}
+	deny := make([]conditionStatement, 0, len(statements))
+	for _, st := range statements {
+		if strings.EqualFold(st.Effect, "Deny") {
+			deny = append(deny, st)
+		}
+	}
+	return deny, nil
+}
+
+// RequiredSSEAlgorithms returns the server side encryption algorithms the
+// policy requires on uploads, i.e. the values of Deny statements conditioned
+// on StringNotEquals s3:x-amz-server-side-encryption. It returns nil if the
+// policy does not require a specific algorithm.
+func RequiredSSEAlgorithms(policy string) ([]string, error) {
+	statements, err := denyStatements(policy)
+	if err != nil {
+		return nil, err
+	}
+	var algorithms []string
+	for _, st := range statements {
+		for op, conditions := range st.Condition {
+			if op != "StringNotEquals" && op != "StringNotEqualsIfExists" {
+				continue
+			}
+			for k, v := range conditions {
+				if !strings.EqualFold(k, sseConditionKey) {
+					continue
+				}
+				values, err := stringOrList(v)
+				if err != nil {
+					return nil, err
+				}
+				algorithms = append(algorithms, values...)
+			}
+		}
+	}
+	return algorithms, nil
+}
+
+// RequiresSSEContext returns true if the policy denies uploads depending on
+// the SSE-KMS encryption context of the request, i.e. if a Deny statement is
+// conditioned on s3:x-amz-server-side-encryption-context. Uploads that rely
+// on the default encryption of the bucket do not send an encryption context.
+func RequiresSSEContext(policy string) (bool, error) {
+	statements, err := denyStatements(policy)
+	if err != nil {
+		return false, err
+	}
+	for _, st := range statements {
+		for _, conditions := range st.Condition {
+			for k := range conditions {
+				if strings.EqualFold(k, sseContextConditionKey) {
+					return true, nil
+				}
+			}
+		}
+	}
+	return false, nil
+}
+
+// CanonicalPolicy returns the supplied policy document in a canonical form in
+// which object keys are sorted and every list of a single element is replaced
+// by that element. AWS returns e.g. a single statement, principal or action
+// without the list it may have been submitted in.
+func CanonicalPolicy(policy string) (string, error) {
+	var doc interface{}
+	if err := json.Unmarshal([]byte(policy), &doc); err != nil {
+		return "", err
+	}
+	b, err := json.Marshal(collapseSingletons(doc))
+	return string(b), err
+}
+
+// collapseSingletons replaces every list of a single element within the
+// supplied decoded JSON value by that element.
+func collapseSingletons(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, e := range t {
+			t[k] = collapseSingletons(e)
+		}
+	case []interface{}:
+		if len(t) == 1 {
+			return collapseSingletons(t[0])
+		}
+		for i, e := range t {
+			t[i] = collapseSingletons(e)
+		}
+	}
+	return v
+}
+
+// PolicyEqual returns true if the supplied policies are equal in their
+// canonical form, ignoring the order of lists. Policies that are not valid
+// JSON are never equal.
+func PolicyEqual(a, b string) bool {
+	ca, err := CanonicalPolicy(a)
+	if err != nil {
+		return false
+	}
+	cb, err := CanonicalPolicy(b)
+	if err != nil {
+		return false
+	}
+	return awsclient.IsPolicyUpToDate(&ca, &cb)
+}
+
+// stringOrList decodes a policy value given either as a single string or as a
+// list of strings.
+func stringOrList(raw json.RawMessage) ([]string, error) {
+	var single string
+	if err := json.Unmarshal(raw, &single); err == nil {
+		return []string{single}, nil
+	}
+	var list []string
+	if err := json.Unmarshal(raw, &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
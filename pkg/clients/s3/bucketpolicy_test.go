@@ -149,3 +149,144 @@ func TestSerializeBucketPolicyStatement(t *testing.T) {
 		})
 	}
 }
+
+func TestRequiredSSEAlgorithms(t *testing.T) {
+	type want struct {
+		algorithms []string
+		err        bool
+	}
+
+	cases := map[string]struct {
+		policy string
+		want   want
+	}{
+		"DenyUnencryptedUploads": {
+			policy: `{"Version":"2012-10-17","Statement":[{"Effect":"Deny","Principal":"*","Action":"s3:PutObject","Resource":"arn:aws:s3:::b/*","Condition":{"StringNotEquals":{"s3:x-amz-server-side-encryption":"AES256"}}}]}`,
+			want:   want{algorithms: []string{"AES256"}},
+		},
+		"ListOfAlgorithmsIfExists": {
+			policy: `{"Version":"2012-10-17","Statement":[{"Effect":"Deny","Principal":"*","Action":"s3:PutObject","Resource":"arn:aws:s3:::b/*","Condition":{"StringNotEqualsIfExists":{"s3:x-amz-server-side-encryption":["AES256","aws:kms"]}}}]}`,
+			want:   want{algorithms: []string{"AES256", "aws:kms"}},
+		},
+		"SingleStatement": {
+			policy: `{"Version":"2012-10-17","Statement":{"Effect":"Deny","Principal":"*","Action":"s3:PutObject","Resource":"arn:aws:s3:::b/*","Condition":{"StringNotEquals":{"s3:x-amz-server-side-encryption":"aws:kms"}}}}`,
+			want:   want{algorithms: []string{"aws:kms"}},
+		},
+		"AllowIsIgnored": {
+			policy: `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":"*","Action":"s3:PutObject","Resource":"arn:aws:s3:::b/*","Condition":{"StringEquals":{"s3:x-amz-server-side-encryption":"AES256"}}}]}`,
+		},
+		"NoCondition": {
+			policy: `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":"*","Action":"s3:ListBucket","Resource":"arn:aws:s3:::b"}]}`,
+		},
+		"InvalidJSON": {
+			policy: `{`,
+			want:   want{err: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := RequiredSSEAlgorithms(tc.policy)
+			if diff := cmp.Diff(tc.want.err, err != nil); diff != "" {
+				t.Errorf("RequiredSSEAlgorithms(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.algorithms, got); diff != "" {
+				t.Errorf("RequiredSSEAlgorithms(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRequiresSSEContext(t *testing.T) {
+	type want struct {
+		requires bool
+		err      bool
+	}
+
+	cases := map[string]struct {
+		policy string
+		want   want
+	}{
+		"DenyWithoutContext": {
+			policy: `{"Version":"2012-10-17","Statement":[{"Effect":"Deny","Principal":"*","Action":"s3:PutObject","Resource":"arn:aws:s3:::b/*","Condition":{"Null":{"s3:x-amz-server-side-encryption-context":"true"}}}]}`,
+			want:   want{requires: true},
+		},
+		"DenyOtherContext": {
+			policy: `{"Version":"2012-10-17","Statement":{"Effect":"Deny","Principal":"*","Action":"s3:PutObject","Resource":"arn:aws:s3:::b/*","Condition":{"StringNotEquals":{"s3:x-amz-server-side-encryption-context":"eyJ0ZWFtIjoiYSJ9"}}}}`,
+			want:   want{requires: true},
+		},
+		"AllowIsIgnored": {
+			policy: `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":"*","Action":"s3:PutObject","Resource":"arn:aws:s3:::b/*","Condition":{"StringEquals":{"s3:x-amz-server-side-encryption-context":"eyJ0ZWFtIjoiYSJ9"}}}]}`,
+		},
+		"AlgorithmOnly": {
+			policy: `{"Version":"2012-10-17","Statement":[{"Effect":"Deny","Principal":"*","Action":"s3:PutObject","Resource":"arn:aws:s3:::b/*","Condition":{"StringNotEquals":{"s3:x-amz-server-side-encryption":"aws:kms"}}}]}`,
+		},
+		"InvalidJSON": {
+			policy: `{`,
+			want:   want{err: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := RequiresSSEContext(tc.policy)
+			if diff := cmp.Diff(tc.want.err, err != nil); diff != "" {
+				t.Errorf("RequiresSSEContext(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.requires, got); diff != "" {
+				t.Errorf("RequiresSSEContext(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPolicyEqual(t *testing.T) {
+	cases := map[string]struct {
+		a, b string
+		want bool
+	}{
+		"Identical": {
+			a:    `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"s3:GetObject","Principal":"*","Resource":"arn:aws:s3:::b/*"}]}`,
+			b:    `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"s3:GetObject","Principal":"*","Resource":"arn:aws:s3:::b/*"}]}`,
+			want: true,
+		},
+		"KeysReordered": {
+			a:    `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"s3:GetObject","Principal":"*"}]}`,
+			b:    `{"Statement":[{"Principal":"*","Action":"s3:GetObject","Effect":"Allow"}],"Version":"2012-10-17"}`,
+			want: true,
+		},
+		"SingleElementListsCollapsed": {
+			a:    `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:GetObject"],"Principal":{"AWS":["arn:aws:iam::123456789012:root"]},"Resource":["arn:aws:s3:::b/*"]}]}`,
+			b:    `{"Version":"2012-10-17","Statement":{"Effect":"Allow","Action":"s3:GetObject","Principal":{"AWS":"arn:aws:iam::123456789012:root"},"Resource":"arn:aws:s3:::b/*"}}`,
+			want: true,
+		},
+		"ListsReordered": {
+			a:    `{"Statement":[{"Effect":"Allow","Action":["s3:GetObject","s3:PutObject"]}]}`,
+			b:    `{"Statement":[{"Effect":"Allow","Action":["s3:PutObject","s3:GetObject"]}]}`,
+			want: true,
+		},
+		"DifferentPrincipal": {
+			a:    `{"Statement":[{"Effect":"Allow","Principal":{"AWS":["arn:aws:iam::123456789012:root"]}}]}`,
+			b:    `{"Statement":[{"Effect":"Allow","Principal":{"AWS":"arn:aws:iam::210987654321:root"}}]}`,
+			want: false,
+		},
+		"AdditionalAction": {
+			a:    `{"Statement":[{"Effect":"Allow","Action":["s3:GetObject"]}]}`,
+			b:    `{"Statement":[{"Effect":"Allow","Action":["s3:GetObject","s3:PutObject"]}]}`,
+			want: false,
+		},
+		"InvalidJSON": {
+			a:    `{`,
+			b:    `{`,
+			want: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, PolicyEqual(tc.a, tc.b)); diff != "" {
+				t.Errorf("PolicyEqual(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
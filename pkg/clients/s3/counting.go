@@ -0,0 +1,383 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package s3
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+var _ BucketClient = &CountingBucketClient{}
+
+// CountingBucketClient is a BucketClient that counts the calls made through it.
+type CountingBucketClient struct {
+	client BucketClient
+	calls  int64
+}
+
+// NewCountingBucketClient returns a BucketClient that counts the calls it
+// passes on to the supplied client.
+func NewCountingBucketClient(client BucketClient) *CountingBucketClient {
+	return &CountingBucketClient{client: client}
+}
+
+// Calls returns the number of calls made through the client.
+func (c *CountingBucketClient) Calls() int64 {
+	return atomic.LoadInt64(&c.calls)
+}
+
+func (c *CountingBucketClient) count() {
+	atomic.AddInt64(&c.calls, 1)
+}
+
+// HeadBucket counts the call and calls HeadBucket of the underlying client.
+func (c *CountingBucketClient) HeadBucket(ctx context.Context, input *s3.HeadBucketInput, opts ...func(*s3.Options)) (*s3.HeadBucketOutput, error) {
+	c.count()
+	return c.client.HeadBucket(ctx, input, opts...)
+}
+
+// GetBucketLocation counts the call and calls GetBucketLocation of the underlying client.
+func (c *CountingBucketClient) GetBucketLocation(ctx context.Context, input *s3.GetBucketLocationInput, opts ...func(*s3.Options)) (*s3.GetBucketLocationOutput, error) {
+	c.count()
+	return c.client.GetBucketLocation(ctx, input, opts...)
+}
+
+// CreateBucket counts the call and calls CreateBucket of the underlying client.
+func (c *CountingBucketClient) CreateBucket(ctx context.Context, input *s3.CreateBucketInput, opts ...func(*s3.Options)) (*s3.CreateBucketOutput, error) {
+	c.count()
+	return c.client.CreateBucket(ctx, input, opts...)
+}
+
+// DeleteBucket counts the call and calls DeleteBucket of the underlying client.
+func (c *CountingBucketClient) DeleteBucket(ctx context.Context, input *s3.DeleteBucketInput, opts ...func(*s3.Options)) (*s3.DeleteBucketOutput, error) {
+	c.count()
+	return c.client.DeleteBucket(ctx, input, opts...)
+}
+
+// PutBucketEncryption counts the call and calls PutBucketEncryption of the underlying client.
+func (c *CountingBucketClient) PutBucketEncryption(ctx context.Context, input *s3.PutBucketEncryptionInput, opts ...func(*s3.Options)) (*s3.PutBucketEncryptionOutput, error) {
+	c.count()
+	return c.client.PutBucketEncryption(ctx, input, opts...)
+}
+
+// GetBucketEncryption counts the call and calls GetBucketEncryption of the underlying client.
+func (c *CountingBucketClient) GetBucketEncryption(ctx context.Context, input *s3.GetBucketEncryptionInput, opts ...func(*s3.Options)) (*s3.GetBucketEncryptionOutput, error) {
+	c.count()
+	return c.client.GetBucketEncryption(ctx, input, opts...)
+}
+
+// DeleteBucketEncryption counts the call and calls DeleteBucketEncryption of the underlying client.
+func (c *CountingBucketClient) DeleteBucketEncryption(ctx context.Context, input *s3.DeleteBucketEncryptionInput, opts ...func(*s3.Options)) (*s3.DeleteBucketEncryptionOutput, error) {
+	c.count()
+	return c.client.DeleteBucketEncryption(ctx, input, opts...)
+}
+
+// PutBucketVersioning counts the call and calls PutBucketVersioning of the underlying client.
+func (c *CountingBucketClient) PutBucketVersioning(ctx context.Context, input *s3.PutBucketVersioningInput, opts ...func(*s3.Options)) (*s3.PutBucketVersioningOutput, error) {
+	c.count()
+	return c.client.PutBucketVersioning(ctx, input, opts...)
+}
+
+// GetBucketVersioning counts the call and calls GetBucketVersioning of the underlying client.
+func (c *CountingBucketClient) GetBucketVersioning(ctx context.Context, input *s3.GetBucketVersioningInput, opts ...func(*s3.Options)) (*s3.GetBucketVersioningOutput, error) {
+	c.count()
+	return c.client.GetBucketVersioning(ctx, input, opts...)
+}
+
+// PutBucketAccelerateConfiguration counts the call and calls PutBucketAccelerateConfiguration of the underlying client.
+func (c *CountingBucketClient) PutBucketAccelerateConfiguration(ctx context.Context, input *s3.PutBucketAccelerateConfigurationInput, opts ...func(*s3.Options)) (*s3.PutBucketAccelerateConfigurationOutput, error) {
+	c.count()
+	return c.client.PutBucketAccelerateConfiguration(ctx, input, opts...)
+}
+
+// GetBucketAccelerateConfiguration counts the call and calls GetBucketAccelerateConfiguration of the underlying client.
+func (c *CountingBucketClient) GetBucketAccelerateConfiguration(ctx context.Context, input *s3.GetBucketAccelerateConfigurationInput, opts ...func(*s3.Options)) (*s3.GetBucketAccelerateConfigurationOutput, error) {
+	c.count()
+	return c.client.GetBucketAccelerateConfiguration(ctx, input, opts...)
+}
+
+// PutBucketCors counts the call and calls PutBucketCors of the underlying client.
+func (c *CountingBucketClient) PutBucketCors(ctx context.Context, input *s3.PutBucketCorsInput, opts ...func(*s3.Options)) (*s3.PutBucketCorsOutput, error) {
+	c.count()
+	return c.client.PutBucketCors(ctx, input, opts...)
+}
+
+// GetBucketCors counts the call and calls GetBucketCors of the underlying client.
+func (c *CountingBucketClient) GetBucketCors(ctx context.Context, input *s3.GetBucketCorsInput, opts ...func(*s3.Options)) (*s3.GetBucketCorsOutput, error) {
+	c.count()
+	return c.client.GetBucketCors(ctx, input, opts...)
+}
+
+// DeleteBucketCors counts the call and calls DeleteBucketCors of the underlying client.
+func (c *CountingBucketClient) DeleteBucketCors(ctx context.Context, input *s3.DeleteBucketCorsInput, opts ...func(*s3.Options)) (*s3.DeleteBucketCorsOutput, error) {
+	c.count()
+	return c.client.DeleteBucketCors(ctx, input, opts...)
+}
+
+// PutBucketWebsite counts the call and calls PutBucketWebsite of the underlying client.
+func (c *CountingBucketClient) PutBucketWebsite(ctx context.Context, input *s3.PutBucketWebsiteInput, opts ...func(*s3.Options)) (*s3.PutBucketWebsiteOutput, error) {
+	c.count()
+	return c.client.PutBucketWebsite(ctx, input, opts...)
+}
+
+// GetBucketWebsite counts the call and calls GetBucketWebsite of the underlying client.
+func (c *CountingBucketClient) GetBucketWebsite(ctx context.Context, input *s3.GetBucketWebsiteInput, opts ...func(*s3.Options)) (*s3.GetBucketWebsiteOutput, error) {
+	c.count()
+	return c.client.GetBucketWebsite(ctx, input, opts...)
+}
+
+// DeleteBucketWebsite counts the call and calls DeleteBucketWebsite of the underlying client.
+func (c *CountingBucketClient) DeleteBucketWebsite(ctx context.Context, input *s3.DeleteBucketWebsiteInput, opts ...func(*s3.Options)) (*s3.DeleteBucketWebsiteOutput, error) {
+	c.count()
+	return c.client.DeleteBucketWebsite(ctx, input, opts...)
+}
+
+// PutBucketLogging counts the call and calls PutBucketLogging of the underlying client.
+func (c *CountingBucketClient) PutBucketLogging(ctx context.Context, input *s3.PutBucketLoggingInput, opts ...func(*s3.Options)) (*s3.PutBucketLoggingOutput, error) {
+	c.count()
+	return c.client.PutBucketLogging(ctx, input, opts...)
+}
+
+// GetBucketLogging counts the call and calls GetBucketLogging of the underlying client.
+func (c *CountingBucketClient) GetBucketLogging(ctx context.Context, input *s3.GetBucketLoggingInput, opts ...func(*s3.Options)) (*s3.GetBucketLoggingOutput, error) {
+	c.count()
+	return c.client.GetBucketLogging(ctx, input, opts...)
+}
+
+// PutBucketReplication counts the call and calls PutBucketReplication of the underlying client.
+func (c *CountingBucketClient) PutBucketReplication(ctx context.Context, input *s3.PutBucketReplicationInput, opts ...func(*s3.Options)) (*s3.PutBucketReplicationOutput, error) {
+	c.count()
+	return c.client.PutBucketReplication(ctx, input, opts...)
+}
+
+// GetBucketReplication counts the call and calls GetBucketReplication of the underlying client.
+func (c *CountingBucketClient) GetBucketReplication(ctx context.Context, input *s3.GetBucketReplicationInput, opts ...func(*s3.Options)) (*s3.GetBucketReplicationOutput, error) {
+	c.count()
+	return c.client.GetBucketReplication(ctx, input, opts...)
+}
+
+// DeleteBucketReplication counts the call and calls DeleteBucketReplication of the underlying client.
+func (c *CountingBucketClient) DeleteBucketReplication(ctx context.Context, input *s3.DeleteBucketReplicationInput, opts ...func(*s3.Options)) (*s3.DeleteBucketReplicationOutput, error) {
+	c.count()
+	return c.client.DeleteBucketReplication(ctx, input, opts...)
+}
+
+// PutBucketRequestPayment counts the call and calls PutBucketRequestPayment of the underlying client.
+func (c *CountingBucketClient) PutBucketRequestPayment(ctx context.Context, input *s3.PutBucketRequestPaymentInput, opts ...func(*s3.Options)) (*s3.PutBucketRequestPaymentOutput, error) {
+	c.count()
+	return c.client.PutBucketRequestPayment(ctx, input, opts...)
+}
+
+// GetBucketRequestPayment counts the call and calls GetBucketRequestPayment of the underlying client.
+func (c *CountingBucketClient) GetBucketRequestPayment(ctx context.Context, input *s3.GetBucketRequestPaymentInput, opts ...func(*s3.Options)) (*s3.GetBucketRequestPaymentOutput, error) {
+	c.count()
+	return c.client.GetBucketRequestPayment(ctx, input, opts...)
+}
+
+// PutBucketTagging counts the call and calls PutBucketTagging of the underlying client.
+func (c *CountingBucketClient) PutBucketTagging(ctx context.Context, input *s3.PutBucketTaggingInput, opts ...func(*s3.Options)) (*s3.PutBucketTaggingOutput, error) {
+	c.count()
+	return c.client.PutBucketTagging(ctx, input, opts...)
+}
+
+// GetBucketTagging counts the call and calls GetBucketTagging of the underlying client.
+func (c *CountingBucketClient) GetBucketTagging(ctx context.Context, input *s3.GetBucketTaggingInput, opts ...func(*s3.Options)) (*s3.GetBucketTaggingOutput, error) {
+	c.count()
+	return c.client.GetBucketTagging(ctx, input, opts...)
+}
+
+// DeleteBucketTagging counts the call and calls DeleteBucketTagging of the underlying client.
+func (c *CountingBucketClient) DeleteBucketTagging(ctx context.Context, input *s3.DeleteBucketTaggingInput, opts ...func(*s3.Options)) (*s3.DeleteBucketTaggingOutput, error) {
+	c.count()
+	return c.client.DeleteBucketTagging(ctx, input, opts...)
+}
+
+// PutBucketAnalyticsConfiguration counts the call and calls PutBucketAnalyticsConfiguration of the underlying client.
+func (c *CountingBucketClient) PutBucketAnalyticsConfiguration(ctx context.Context, input *s3.PutBucketAnalyticsConfigurationInput, opts ...func(*s3.Options)) (*s3.PutBucketAnalyticsConfigurationOutput, error) {
+	c.count()
+	return c.client.PutBucketAnalyticsConfiguration(ctx, input, opts...)
+}
+
+// GetBucketAnalyticsConfiguration counts the call and calls GetBucketAnalyticsConfiguration of the underlying client.
+func (c *CountingBucketClient) GetBucketAnalyticsConfiguration(ctx context.Context, input *s3.GetBucketAnalyticsConfigurationInput, opts ...func(*s3.Options)) (*s3.GetBucketAnalyticsConfigurationOutput, error) {
+	c.count()
+	return c.client.GetBucketAnalyticsConfiguration(ctx, input, opts...)
+}
+
+// PutBucketMetricsConfiguration counts the call and calls PutBucketMetricsConfiguration of the underlying client.
+func (c *CountingBucketClient) PutBucketMetricsConfiguration(ctx context.Context, input *s3.PutBucketMetricsConfigurationInput, opts ...func(*s3.Options)) (*s3.PutBucketMetricsConfigurationOutput, error) {
+	c.count()
+	return c.client.PutBucketMetricsConfiguration(ctx, input, opts...)
+}
+
+// GetBucketMetricsConfiguration counts the call and calls GetBucketMetricsConfiguration of the underlying client.
+func (c *CountingBucketClient) GetBucketMetricsConfiguration(ctx context.Context, input *s3.GetBucketMetricsConfigurationInput, opts ...func(*s3.Options)) (*s3.GetBucketMetricsConfigurationOutput, error) {
+	c.count()
+	return c.client.GetBucketMetricsConfiguration(ctx, input, opts...)
+}
+
+// DeleteBucketMetricsConfiguration counts the call and calls DeleteBucketMetricsConfiguration of the underlying client.
+func (c *CountingBucketClient) DeleteBucketMetricsConfiguration(ctx context.Context, input *s3.DeleteBucketMetricsConfigurationInput, opts ...func(*s3.Options)) (*s3.DeleteBucketMetricsConfigurationOutput, error) {
+	c.count()
+	return c.client.DeleteBucketMetricsConfiguration(ctx, input, opts...)
+}
+
+// ListBucketMetricsConfigurations counts the call and calls ListBucketMetricsConfigurations of the underlying client.
+func (c *CountingBucketClient) ListBucketMetricsConfigurations(ctx context.Context, input *s3.ListBucketMetricsConfigurationsInput, opts ...func(*s3.Options)) (*s3.ListBucketMetricsConfigurationsOutput, error) {
+	c.count()
+	return c.client.ListBucketMetricsConfigurations(ctx, input, opts...)
+}
+
+// PutBucketInventoryConfiguration counts the call and calls PutBucketInventoryConfiguration of the underlying client.
+func (c *CountingBucketClient) PutBucketInventoryConfiguration(ctx context.Context, input *s3.PutBucketInventoryConfigurationInput, opts ...func(*s3.Options)) (*s3.PutBucketInventoryConfigurationOutput, error) {
+	c.count()
+	return c.client.PutBucketInventoryConfiguration(ctx, input, opts...)
+}
+
+// GetBucketInventoryConfiguration counts the call and calls GetBucketInventoryConfiguration of the underlying client.
+func (c *CountingBucketClient) GetBucketInventoryConfiguration(ctx context.Context, input *s3.GetBucketInventoryConfigurationInput, opts ...func(*s3.Options)) (*s3.GetBucketInventoryConfigurationOutput, error) {
+	c.count()
+	return c.client.GetBucketInventoryConfiguration(ctx, input, opts...)
+}
+
+// DeleteBucketInventoryConfiguration counts the call and calls DeleteBucketInventoryConfiguration of the underlying client.
+func (c *CountingBucketClient) DeleteBucketInventoryConfiguration(ctx context.Context, input *s3.DeleteBucketInventoryConfigurationInput, opts ...func(*s3.Options)) (*s3.DeleteBucketInventoryConfigurationOutput, error) {
+	c.count()
+	return c.client.DeleteBucketInventoryConfiguration(ctx, input, opts...)
+}
+
+// ListBucketInventoryConfigurations counts the call and calls ListBucketInventoryConfigurations of the underlying client.
+func (c *CountingBucketClient) ListBucketInventoryConfigurations(ctx context.Context, input *s3.ListBucketInventoryConfigurationsInput, opts ...func(*s3.Options)) (*s3.ListBucketInventoryConfigurationsOutput, error) {
+	c.count()
+	return c.client.ListBucketInventoryConfigurations(ctx, input, opts...)
+}
+
+// PutBucketIntelligentTieringConfiguration counts the call and calls PutBucketIntelligentTieringConfiguration of the underlying client.
+func (c *CountingBucketClient) PutBucketIntelligentTieringConfiguration(ctx context.Context, input *s3.PutBucketIntelligentTieringConfigurationInput, opts ...func(*s3.Options)) (*s3.PutBucketIntelligentTieringConfigurationOutput, error) {
+	c.count()
+	return c.client.PutBucketIntelligentTieringConfiguration(ctx, input, opts...)
+}
+
+// GetBucketIntelligentTieringConfiguration counts the call and calls GetBucketIntelligentTieringConfiguration of the underlying client.
+func (c *CountingBucketClient) GetBucketIntelligentTieringConfiguration(ctx context.Context, input *s3.GetBucketIntelligentTieringConfigurationInput, opts ...func(*s3.Options)) (*s3.GetBucketIntelligentTieringConfigurationOutput, error) {
+	c.count()
+	return c.client.GetBucketIntelligentTieringConfiguration(ctx, input, opts...)
+}
+
+// DeleteBucketIntelligentTieringConfiguration counts the call and calls DeleteBucketIntelligentTieringConfiguration of the underlying client.
+func (c *CountingBucketClient) DeleteBucketIntelligentTieringConfiguration(ctx context.Context, input *s3.DeleteBucketIntelligentTieringConfigurationInput, opts ...func(*s3.Options)) (*s3.DeleteBucketIntelligentTieringConfigurationOutput, error) {
+	c.count()
+	return c.client.DeleteBucketIntelligentTieringConfiguration(ctx, input, opts...)
+}
+
+// ListBucketIntelligentTieringConfigurations counts the call and calls ListBucketIntelligentTieringConfigurations of the underlying client.
+func (c *CountingBucketClient) ListBucketIntelligentTieringConfigurations(ctx context.Context, input *s3.ListBucketIntelligentTieringConfigurationsInput, opts ...func(*s3.Options)) (*s3.ListBucketIntelligentTieringConfigurationsOutput, error) {
+	c.count()
+	return c.client.ListBucketIntelligentTieringConfigurations(ctx, input, opts...)
+}
+
+// PutBucketLifecycleConfiguration counts the call and calls PutBucketLifecycleConfiguration of the underlying client.
+func (c *CountingBucketClient) PutBucketLifecycleConfiguration(ctx context.Context, input *s3.PutBucketLifecycleConfigurationInput, opts ...func(*s3.Options)) (*s3.PutBucketLifecycleConfigurationOutput, error) {
+	c.count()
+	return c.client.PutBucketLifecycleConfiguration(ctx, input, opts...)
+}
+
+// GetBucketLifecycleConfiguration counts the call and calls GetBucketLifecycleConfiguration of the underlying client.
+func (c *CountingBucketClient) GetBucketLifecycleConfiguration(ctx context.Context, input *s3.GetBucketLifecycleConfigurationInput, opts ...func(*s3.Options)) (*s3.GetBucketLifecycleConfigurationOutput, error) {
+	c.count()
+	return c.client.GetBucketLifecycleConfiguration(ctx, input, opts...)
+}
+
+// DeleteBucketLifecycle counts the call and calls DeleteBucketLifecycle of the underlying client.
+func (c *CountingBucketClient) DeleteBucketLifecycle(ctx context.Context, input *s3.DeleteBucketLifecycleInput, opts ...func(*s3.Options)) (*s3.DeleteBucketLifecycleOutput, error) {
+	c.count()
+	return c.client.DeleteBucketLifecycle(ctx, input, opts...)
+}
+
+// PutBucketNotificationConfiguration counts the call and calls PutBucketNotificationConfiguration of the underlying client.
+func (c *CountingBucketClient) PutBucketNotificationConfiguration(ctx context.Context, input *s3.PutBucketNotificationConfigurationInput, opts ...func(*s3.Options)) (*s3.PutBucketNotificationConfigurationOutput, error) {
+	c.count()
+	return c.client.PutBucketNotificationConfiguration(ctx, input, opts...)
+}
+
+// GetBucketNotificationConfiguration counts the call and calls GetBucketNotificationConfiguration of the underlying client.
+func (c *CountingBucketClient) GetBucketNotificationConfiguration(ctx context.Context, input *s3.GetBucketNotificationConfigurationInput, opts ...func(*s3.Options)) (*s3.GetBucketNotificationConfigurationOutput, error) {
+	c.count()
+	return c.client.GetBucketNotificationConfiguration(ctx, input, opts...)
+}
+
+// GetBucketAcl counts the call and calls GetBucketAcl of the underlying client.
+func (c *CountingBucketClient) GetBucketAcl(ctx context.Context, input *s3.GetBucketAclInput, opts ...func(*s3.Options)) (*s3.GetBucketAclOutput, error) { //nolint
+	c.count()
+	return c.client.GetBucketAcl(ctx, input, opts...)
+}
+
+// PutBucketAcl counts the call and calls PutBucketAcl of the underlying client.
+func (c *CountingBucketClient) PutBucketAcl(ctx context.Context, input *s3.PutBucketAclInput, opts ...func(*s3.Options)) (*s3.PutBucketAclOutput, error) { //nolint
+	c.count()
+	return c.client.PutBucketAcl(ctx, input, opts...)
+}
+
+// GetPublicAccessBlock counts the call and calls GetPublicAccessBlock of the underlying client.
+func (c *CountingBucketClient) GetPublicAccessBlock(ctx context.Context, input *s3.GetPublicAccessBlockInput, opts ...func(*s3.Options)) (*s3.GetPublicAccessBlockOutput, error) {
+	c.count()
+	return c.client.GetPublicAccessBlock(ctx, input, opts...)
+}
+
+// PutPublicAccessBlock counts the call and calls PutPublicAccessBlock of the underlying client.
+func (c *CountingBucketClient) PutPublicAccessBlock(ctx context.Context, input *s3.PutPublicAccessBlockInput, opts ...func(*s3.Options)) (*s3.PutPublicAccessBlockOutput, error) {
+	c.count()
+	return c.client.PutPublicAccessBlock(ctx, input, opts...)
+}
+
+// DeletePublicAccessBlock counts the call and calls DeletePublicAccessBlock of the underlying client.
+func (c *CountingBucketClient) DeletePublicAccessBlock(ctx context.Context, input *s3.DeletePublicAccessBlockInput, opts ...func(*s3.Options)) (*s3.DeletePublicAccessBlockOutput, error) {
+	c.count()
+	return c.client.DeletePublicAccessBlock(ctx, input, opts...)
+}
+
+// GetBucketOwnershipControls counts the call and calls GetBucketOwnershipControls of the underlying client.
+func (c *CountingBucketClient) GetBucketOwnershipControls(ctx context.Context, input *s3.GetBucketOwnershipControlsInput, opts ...func(*s3.Options)) (*s3.GetBucketOwnershipControlsOutput, error) {
+	c.count()
+	return c.client.GetBucketOwnershipControls(ctx, input, opts...)
+}
+
+// PutBucketOwnershipControls counts the call and calls PutBucketOwnershipControls of the underlying client.
+func (c *CountingBucketClient) PutBucketOwnershipControls(ctx context.Context, input *s3.PutBucketOwnershipControlsInput, opts ...func(*s3.Options)) (*s3.PutBucketOwnershipControlsOutput, error) {
+	c.count()
+	return c.client.PutBucketOwnershipControls(ctx, input, opts...)
+}
+
+// DeleteBucketOwnershipControls counts the call and calls DeleteBucketOwnershipControls of the underlying client.
+func (c *CountingBucketClient) DeleteBucketOwnershipControls(ctx context.Context, input *s3.DeleteBucketOwnershipControlsInput, opts ...func(*s3.Options)) (*s3.DeleteBucketOwnershipControlsOutput, error) {
+	c.count()
+	return c.client.DeleteBucketOwnershipControls(ctx, input, opts...)
+}
+
+// GetObjectLockConfiguration counts the call and calls GetObjectLockConfiguration of the underlying client.
+func (c *CountingBucketClient) GetObjectLockConfiguration(ctx context.Context, input *s3.GetObjectLockConfigurationInput, opts ...func(*s3.Options)) (*s3.GetObjectLockConfigurationOutput, error) {
+	c.count()
+	return c.client.GetObjectLockConfiguration(ctx, input, opts...)
+}
+
+// PutObjectLockConfiguration counts the call and calls PutObjectLockConfiguration of the underlying client.
+func (c *CountingBucketClient) PutObjectLockConfiguration(ctx context.Context, input *s3.PutObjectLockConfigurationInput, opts ...func(*s3.Options)) (*s3.PutObjectLockConfigurationOutput, error) {
+	c.count()
+	return c.client.PutObjectLockConfiguration(ctx, input, opts...)
+}
@@ -29,9 +29,12 @@ var _ clientset.BucketPolicyClient = (*MockBucketPolicyClient)(nil)
 
 // MockBucketPolicyClient is a type that implements all the methods for RolePolicyAttachmentClient interface
 type MockBucketPolicyClient struct {
-	MockGetBucketPolicy    func(ctx context.Context, input *s3.GetBucketPolicyInput, opts []func(*s3.Options)) (*s3.GetBucketPolicyOutput, error)
-	MockPutBucketPolicy    func(ctx context.Context, input *s3.PutBucketPolicyInput, opts []func(*s3.Options)) (*s3.PutBucketPolicyOutput, error)
-	MockDeleteBucketPolicy func(ctx context.Context, input *s3.DeleteBucketPolicyInput, opts []func(*s3.Options)) (*s3.DeleteBucketPolicyOutput, error)
+	MockGetBucketPolicy       func(ctx context.Context, input *s3.GetBucketPolicyInput, opts []func(*s3.Options)) (*s3.GetBucketPolicyOutput, error)
+	MockPutBucketPolicy       func(ctx context.Context, input *s3.PutBucketPolicyInput, opts []func(*s3.Options)) (*s3.PutBucketPolicyOutput, error)
+	MockDeleteBucketPolicy    func(ctx context.Context, input *s3.DeleteBucketPolicyInput, opts []func(*s3.Options)) (*s3.DeleteBucketPolicyOutput, error)
+	MockGetBucketEncryption   func(ctx context.Context, input *s3.GetBucketEncryptionInput, opts []func(*s3.Options)) (*s3.GetBucketEncryptionOutput, error)
+	MockGetBucketPolicyStatus func(ctx context.Context, input *s3.GetBucketPolicyStatusInput, opts []func(*s3.Options)) (*s3.GetBucketPolicyStatusOutput, error)
+	MockGetPublicAccessBlock  func(ctx context.Context, input *s3.GetPublicAccessBlockInput, opts []func(*s3.Options)) (*s3.GetPublicAccessBlockOutput, error)
 }
 
 // GetBucketPolicy mocks GetBucketPolicy method
@@ -48,3 +51,18 @@ func (m *MockBucketPolicyClient) PutBucketPolicy(ctx context.Context, input *s3.
 func (m *MockBucketPolicyClient) DeleteBucketPolicy(ctx context.Context, input *s3.DeleteBucketPolicyInput, opts ...func(*s3.Options)) (*s3.DeleteBucketPolicyOutput, error) {
 	return m.MockDeleteBucketPolicy(ctx, input, opts)
 }
+
+// GetBucketEncryption mocks GetBucketEncryption method
+func (m *MockBucketPolicyClient) GetBucketEncryption(ctx context.Context, input *s3.GetBucketEncryptionInput, opts ...func(*s3.Options)) (*s3.GetBucketEncryptionOutput, error) {
+	return m.MockGetBucketEncryption(ctx, input, opts)
+}
+
+// GetBucketPolicyStatus mocks GetBucketPolicyStatus method
+func (m *MockBucketPolicyClient) GetBucketPolicyStatus(ctx context.Context, input *s3.GetBucketPolicyStatusInput, opts ...func(*s3.Options)) (*s3.GetBucketPolicyStatusOutput, error) {
+	return m.MockGetBucketPolicyStatus(ctx, input, opts)
+}
+
+// GetPublicAccessBlock mocks GetPublicAccessBlock method
+func (m *MockBucketPolicyClient) GetPublicAccessBlock(ctx context.Context, input *s3.GetPublicAccessBlockInput, opts ...func(*s3.Options)) (*s3.GetPublicAccessBlockOutput, error) {
+	return m.MockGetPublicAccessBlock(ctx, input, opts)
+}
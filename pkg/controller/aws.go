@@ -122,8 +122,9 @@ import (
 )
 
 // Setup creates all AWS controllers with the supplied logger and adds them to
-// the supplied manager.
-func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration) error {
+// the supplied manager. The supplied BucketOptions are passed to the
+// controller that reconciles S3 Buckets.
+func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration, bo ...s3.BucketOption) error {
 	for _, setup := range []func(ctrl.Manager, logging.Logger, workqueue.RateLimiter, time.Duration) error{
 		cache.SetupReplicationGroup,
 		cachesubnetgroup.SetupCacheSubnetGroup,
@@ -139,7 +140,9 @@ func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll ti
 		elb.SetupELB,
 		elbattachment.SetupELBAttachment,
 		nodegroup.SetupNodeGroup,
-		s3.SetupBucket,
+		func(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration) error {
+			return s3.SetupBucket(mgr, l, rl, poll, bo...)
+		},
 		bucketpolicy.SetupBucketPolicy,
 		iamaccesskey.SetupIAMAccessKey,
 		iamuser.SetupIAMUser,
@@ -18,12 +18,20 @@ package s3
 
 import (
 	"context"
+	"fmt"
+	"reflect"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
 	awss3 "github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go/service/kms"
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
 	k8serrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -36,9 +44,12 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/reference"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
+	lambdav1alpha1 "github.com/crossplane/provider-aws/apis/lambda/v1alpha1"
 	"github.com/crossplane/provider-aws/apis/s3/v1beta1"
+	awsv1beta1 "github.com/crossplane/provider-aws/apis/v1beta1"
 	awsclient "github.com/crossplane/provider-aws/pkg/clients"
 	"github.com/crossplane/provider-aws/pkg/clients/s3"
 	"github.com/crossplane/provider-aws/pkg/controller/s3/bucket"
@@ -51,12 +62,104 @@ const (
 	errCreateOrUpdate   = "cannot create or update"
 	errDelete           = "cannot delete"
 	errKubeUpdateFailed = "cannot update S3 custom resource"
+	errGetPC            = "cannot get referenced ProviderConfig"
+	errHook             = "rejected by pre create or update hook"
+	errPrerequisites    = "cannot check prerequisites"
+	errAuditOnlyCreate  = "Bucket does not exist and is not created because the controller is in audit-only mode"
+	errAuditOnlyDelete  = "Bucket is not deleted because the controller is in audit-only mode"
+	errCleanup          = "cannot clean up after subresource %s"
+	errResolveRefs      = "cannot resolve references"
+	errCapturePrior     = "cannot capture the current configuration of the subresource"
+	errQuota            = "cannot check quotas"
+	errQuotaExceeded    = "%s would exceed its quota: %s"
+	errQuotaNearLimit   = "%s is near its quota: %s"
+
+	reasonDeprecatedConfiguration event.Reason = "DeprecatedConfiguration"
+	reasonReconcileSummary        event.Reason = "ReconcileSummary"
+	reasonDriftDetected           event.Reason = "DriftDetected"
+	reasonLoggingTarget           event.Reason = "LoggingTargetLooksLikeCloudTrail"
+	reasonRolledBack              event.Reason = "RolledBack"
+	reasonQuotaNearLimit          event.Reason = "QuotaNearLimit"
 )
 
+// maxConcurrentObserves is the number of subresources of a Bucket that are
+// observed at the same time. It keeps a reconcile clear of the S3 request rate
+// limits.
+const maxConcurrentObserves = 4
+
+// A BucketOption configures the controller that reconciles Buckets.
+type BucketOption func(*connector)
+
+// WithSubresourceDeletionDisabled prevents the controller from ever deleting
+// the configuration of a Bucket subresource in AWS. Subresources that would
+// have been deleted are logged and left untouched.
+func WithSubresourceDeletionDisabled() BucketOption {
+	return func(c *connector) {
+		c.disableDelete = true
+	}
+}
+
+// WithAuditOnly makes the controller only observe Buckets and report the
+// subresources that drift from their configuration in AWS as a condition and
+// an event. It never creates, updates or deletes a Bucket or its
+// subresources, including its ACL.
+func WithAuditOnly() BucketOption {
+	return func(c *connector) {
+		c.auditOnly = true
+	}
+}
+
+// WithAPICallsInStatus makes the controller report the number of S3 API calls
+// made during the last reconcile of a Bucket in its status.
+func WithAPICallsInStatus() BucketOption {
+	return func(c *connector) {
+		c.apiCallsInStatus = true
+	}
+}
+
+// WithKMSKeyRotationInStatus makes the controller report whether automatic
+// rotation is enabled for the customer managed KMS key used to encrypt a
+// Bucket in its status. It makes an additional KMS API call per reconcile.
+func WithKMSKeyRotationInStatus() BucketOption {
+	return func(c *connector) {
+		c.kmsKeyRotationInStatus = true
+	}
+}
+
+// WithLoggingTargetKMSKeyGrants makes the controller allow S3 to deliver the
+// server access logs of a Bucket to a target bucket that encrypts new objects
+// with a customer managed KMS key by default. A statement is added to the key
+// policy while logging is enabled and removed once it is disabled, logs are
+// delivered to another target or the Bucket is deleted. It makes additional
+// KMS API calls when the logging configuration changes.
+func WithLoggingTargetKMSKeyGrants() BucketOption {
+	return func(c *connector) {
+		c.loggingKMSKeyGrants = true
+	}
+}
+
+// WithPreCreateOrUpdateHook registers a hook that is called before the
+// subresource managed by clients of the same type as the supplied one is
+// created or updated, e.g. &bucket.SSEConfigurationClient{}.
+func WithPreCreateOrUpdateHook(subresource bucket.SubresourceClient, h bucket.PreCreateOrUpdateHook) BucketOption {
+	return func(c *connector) {
+		if c.hooks == nil {
+			c.hooks = map[reflect.Type][]bucket.PreCreateOrUpdateHook{}
+		}
+		t := reflect.TypeOf(subresource)
+		c.hooks[t] = append(c.hooks[t], h)
+	}
+}
+
 // SetupBucket adds a controller that reconciles Buckets.
-func SetupBucket(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration) error {
+func SetupBucket(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration, opts ...BucketOption) error {
 	name := managed.ControllerName(v1beta1.BucketGroupKind)
 	logger := l.WithValues("controller", name)
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+	c := &connector{kube: mgr.GetClient(), newClientFn: s3.NewClient, logger: logger, recorder: recorder}
+	for _, o := range opts {
+		o(c)
+	}
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		WithOptions(controller.Options{
@@ -65,17 +168,111 @@ func SetupBucket(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, p
 		For(&v1beta1.Bucket{}).
 		Complete(managed.NewReconciler(mgr,
 			resource.ManagedKind(v1beta1.BucketGroupVersionKind),
-			managed.WithExternalConnecter(&connector{kube: mgr.GetClient(), newClientFn: s3.NewClient, logger: logger}),
-			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
+			managed.WithExternalConnecter(c),
+			managed.WithReferenceResolver(&referenceResolver{client: mgr.GetClient()}),
 			managed.WithPollInterval(poll),
 			managed.WithLogger(logger),
-			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+			managed.WithRecorder(recorder)))
+}
+
+// A referenceResolver resolves the references of a Bucket. References to
+// Lambda Functions are resolved here rather than by the Bucket itself, because
+// the lambda API package imports the s3 one.
+type referenceResolver struct {
+	client client.Client
+}
+
+// ResolveReferences of the supplied Bucket and updates it if a reference was
+// resolved.
+func (r *referenceResolver) ResolveReferences(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1beta1.Bucket)
+	if !ok {
+		return errors.New(errUnexpectedObject)
+	}
+	existing := cr.DeepCopy()
+	if err := cr.ResolveReferences(ctx, r.client); err != nil {
+		return errors.Wrap(err, errResolveRefs)
+	}
+	if err := resolveLambdaFunctionReferences(ctx, r.client, cr); err != nil {
+		return errors.Wrap(err, errResolveRefs)
+	}
+	if cmp.Equal(existing, cr) {
+		return nil
+	}
+	return errors.Wrap(r.client.Update(ctx, cr), errKubeUpdateFailed)
+}
+
+// lambdaFunctionARN returns a function that returns the ARN of the given
+// Lambda Function.
+func lambdaFunctionARN() reference.ExtractValueFn {
+	return func(mg resource.Managed) string {
+		r, ok := mg.(*lambdav1alpha1.Function)
+		if !ok {
+			return ""
+		}
+		return awsclient.StringValue(r.Status.AtProvider.FunctionARN)
+	}
+}
+
+// resolveLambdaFunctionReferences resolves the Lambda Functions referenced by
+// the notification configuration of the supplied Bucket. Only ARNs that are
+// not set yet are resolved.
+func resolveLambdaFunctionReferences(ctx context.Context, c client.Reader, cr *v1beta1.Bucket) error {
+	if cr.Spec.ForProvider.NotificationConfiguration == nil {
+		return nil
+	}
+	r := reference.NewAPIResolver(c, cr)
+	for i, v := range cr.Spec.ForProvider.NotificationConfiguration.LambdaFunctionConfigurations {
+		rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+			CurrentValue: v.LambdaFunctionArn,
+			Reference:    v.LambdaFunctionArnRef,
+			Selector:     v.LambdaFunctionArnSelector,
+			To:           reference.To{Managed: &lambdav1alpha1.Function{}, List: &lambdav1alpha1.FunctionList{}},
+			Extract:      lambdaFunctionARN(),
+		})
+		if err != nil {
+			return errors.Wrapf(err, "spec.forProvider.notificationConfiguration.lambdaFunctionConfigurations[%d].lambdaFunctionArn", i)
+		}
+		cr.Spec.ForProvider.NotificationConfiguration.LambdaFunctionConfigurations[i].LambdaFunctionArn = rsp.ResolvedValue
+		cr.Spec.ForProvider.NotificationConfiguration.LambdaFunctionConfigurations[i].LambdaFunctionArnRef = rsp.ResolvedReference
+	}
+	return nil
+}
+
+// WithTransactionalUpdates makes the controller revert the subresources it
+// already changed during an update to their previously observed configuration
+// if changing a later subresource fails. The revert is best effort; it is
+// reported as an event and never hides the original error.
+func WithTransactionalUpdates() BucketOption {
+	return func(c *connector) {
+		c.transactional = true
+	}
+}
+
+// WithQuotaPreflight makes the controller check the AWS quotas a subresource
+// counts against before creating or updating it. Subresources that would
+// exceed a quota are not applied and a warning is emitted for quotas that are
+// nearly used up. Checking a quota may make additional API calls.
+func WithQuotaPreflight() BucketOption {
+	return func(c *connector) {
+		c.quotaPreflight = true
+	}
 }
 
 type connector struct {
-	kube        client.Client
-	newClientFn func(config aws.Config) s3.BucketClient
-	logger      logging.Logger
+	kube          client.Client
+	newClientFn   func(config aws.Config) s3.BucketClient
+	logger        logging.Logger
+	recorder      event.Recorder
+	disableDelete bool
+	auditOnly     bool
+	hooks         map[reflect.Type][]bucket.PreCreateOrUpdateHook
+
+	apiCallsInStatus       bool
+	kmsKeyRotationInStatus bool
+	loggingKMSKeyGrants    bool
+	transactional          bool
+	quotaPreflight         bool
 }
 
 func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
@@ -87,15 +284,129 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 	if err != nil {
 		return nil, err
 	}
-	s3client := c.newClientFn(*cfg)
-	return &external{s3client: s3client, subresourceClients: bucket.NewSubresourceClients(s3client), kube: c.kube, logger: c.logger}, nil
+	s3cfg, err := c.s3Config(ctx, mg)
+	if err != nil {
+		return nil, err
+	}
+	var keyRotation bucket.KeyRotationGetter
+	var keyPolicy bucket.KeyPolicyClient
+	if c.kmsKeyRotationInStatus || c.loggingKMSKeyGrants {
+		sess, err := awsclient.GetConfigV1(ctx, c.kube, mg, cr.Spec.ForProvider.LocationConstraint)
+		if err != nil {
+			return nil, err
+		}
+		kmsClient := kms.New(sess)
+		if c.kmsKeyRotationInStatus {
+			keyRotation = kmsClient
+		}
+		if c.loggingKMSKeyGrants {
+			keyPolicy = kmsClient
+		}
+	}
+	s3client := s3.NewCountingBucketClient(c.newClientFn(*cfg))
+	clients := bucket.NewSubresourceClients(s3client, bucket.SubresourceClientOptions{
+		Logger:                  c.logger,
+		DefaultKMSKeyID:         s3cfg.DefaultKMSKeyID,
+		ClientForProviderConfig: c.clientForProviderConfig(cr.Spec.ForProvider.LocationConstraint),
+		Kube:                    c.kube,
+		Recorder:                c.recorder,
+		RoleSimulator:           iam.NewFromConfig(*cfg),
+		KeyRotation:             keyRotation,
+		KeyPolicy:               keyPolicy,
+	})
+	supported, unsupported := bucket.FilterSupported(bucket.Backend(s3cfg.Backend), clients)
+	return &external{
+		s3client:           s3client,
+		subresourceClients: supported,
+		unsupported:        unsupported,
+		kube:               c.kube,
+		logger:             c.logger,
+		recorder:           c.recorder,
+		disableDelete:      c.disableDelete,
+		auditOnly:          c.auditOnly,
+		hooks:              c.hooks,
+		calls:              s3client,
+		apiCallsInStatus:   c.apiCallsInStatus,
+		transactional:      c.transactional,
+		quotaPreflight:     c.quotaPreflight,
+	}, nil
+}
+
+// s3Config returns the S3 configuration of the ProviderConfig the given
+// resource references. It is empty if there is none.
+func (c *connector) s3Config(ctx context.Context, mg resource.Managed) (*awsv1beta1.S3Config, error) {
+	if mg.GetProviderConfigReference() == nil {
+		return &awsv1beta1.S3Config{}, nil
+	}
+	pc := &awsv1beta1.ProviderConfig{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: mg.GetProviderConfigReference().Name}, pc); err != nil {
+		return nil, errors.Wrap(err, errGetPC)
+	}
+	if pc.Spec.S3 == nil {
+		return &awsv1beta1.S3Config{}, nil
+	}
+	return pc.Spec.S3, nil
+}
+
+// clientForProviderConfig returns a function that creates a client using the
+// credentials of a named ProviderConfig. Subresources use it for calls against
+// buckets owned by another account.
+func (c *connector) clientForProviderConfig(region string) bucket.ClientForProviderConfigFn {
+	return func(ctx context.Context, name string) (s3.BucketClient, error) {
+		cfg, err := awsclient.UseNamedProviderConfig(ctx, c.kube, name, region)
+		if err != nil {
+			return nil, err
+		}
+		return c.newClientFn(*cfg), nil
+	}
 }
 
 type external struct {
 	kube               client.Client
 	s3client           s3.BucketClient
 	logger             logging.Logger
+	recorder           event.Recorder
 	subresourceClients []bucket.SubresourceClient
+	disableDelete      bool
+	auditOnly          bool
+	hooks              map[reflect.Type][]bucket.PreCreateOrUpdateHook
+
+	// unsupported are the clients of subresources the backend serving the S3
+	// API is known not to implement. They are never called.
+	unsupported []bucket.SubresourceClient
+
+	// calls counts the S3 API calls made during the reconcile this external
+	// client was created for.
+	calls            *s3.CountingBucketClient
+	apiCallsInStatus bool
+
+	// transactional makes Update revert the subresources it changed if a
+	// later one cannot be changed.
+	transactional bool
+
+	// quotaPreflight makes Update check the quotas of a subresource before
+	// creating or updating it.
+	quotaPreflight bool
+}
+
+// A change records a subresource changed during an update and the
+// configuration it had in AWS before.
+type change struct {
+	client bucket.SubresourceClient
+	prior  *v1beta1.Bucket
+}
+
+// reportAPICalls logs the number of S3 API calls made so far during the
+// reconcile and reports it in the status of the Bucket if enabled.
+func (e *external) reportAPICalls(cr *v1beta1.Bucket) {
+	if e.calls == nil {
+		return
+	}
+	n := e.calls.Calls()
+	e.logger.Debug("S3 API calls made during reconcile", "bucket", meta.GetExternalName(cr), "calls", n)
+	if e.apiCallsInStatus {
+		cr.Status.AtProvider.APICalls = &n
+	}
 }
 
 func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) { // nolint: gocyclo
@@ -103,6 +414,7 @@ func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 	if !ok {
 		return managed.ExternalObservation{}, errors.New(errUnexpectedObject)
 	}
+	defer e.reportAPICalls(cr)
 
 	if _, err := e.s3client.HeadBucket(ctx, &awss3.HeadBucketInput{Bucket: aws.String(meta.GetExternalName(cr))}); err != nil {
 		return managed.ExternalObservation{}, awsclient.Wrap(resource.Ignore(s3.IsNotFound, err), errHead)
@@ -112,39 +424,37 @@ func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 
 	lateInit := false
 	current := cr.Spec.ForProvider.DeepCopy()
+	// Subresource clients may annotate the Bucket when late initializing it.
+	annotations := cr.DeepCopy().GetAnnotations()
 
 	for _, awsClient := range e.subresourceClients {
 		if awsClient.SubresourceExists(cr) {
 			// we need this check, because we do not want to late init resources the user has
 			// manually removed, our main late init should happen in the Create method
 			err := awsClient.LateInitialize(ctx, cr)
-			if err != nil {
+			if resource.Ignore(s3.NotImplemented, err) != nil {
 				return managed.ExternalObservation{}, err
 			}
 		}
 	}
 
-	if !cmp.Equal(current, &cr.Spec.ForProvider) {
+	if !cmp.Equal(current, &cr.Spec.ForProvider) || !cmp.Equal(annotations, cr.GetAnnotations()) {
 		lateInit = true
 	}
 
-	for _, awsClient := range e.subresourceClients {
-		obs, err := awsClient.Observe(ctx, cr)
-		if err != nil {
-			return managed.ExternalObservation{}, err
-		}
-		if obs != bucket.Updated {
-			return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false, ResourceLateInitialized: lateInit}, nil
-		}
-	}
-
-	// TODO: smarter updating for the bucket, we dont need to update the ACL every time
-	err := s3.UpdateBucketACL(ctx, e.s3client, cr)
+	obs, notImplemented, err := e.observeSubresources(ctx, cr)
 	if err != nil {
 		return managed.ExternalObservation{}, err
 	}
+	e.reportUnsupported(cr, append(e.unsupported, notImplemented...))
+	if obs != bucket.Updated {
+		return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false, ResourceLateInitialized: lateInit}, nil
+	}
 
 	cr.Status.SetConditions(xpv1.Available())
+	if e.auditOnly {
+		cr.Status.SetConditions(bucket.NoDrift())
+	}
 
 	return managed.ExternalObservation{
 		ResourceExists:          true,
@@ -157,29 +467,127 @@ func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 	}, nil
 }
 
+// observeSubresources observes all subresources of the bucket concurrently,
+// at most maxConcurrentObserves at a time. It returns the worst status
+// reported by any subresource, the clients of subresources the backend does
+// not implement and the errors of all failed subresources in the order of the
+// clients. Each subresource observes its own copy of the bucket, whose
+// changed conditions and observed fields are merged into the status of the
+// supplied bucket in the order of the clients once all are done.
+func (e *external) observeSubresources(ctx context.Context, cr *v1beta1.Bucket) (bucket.ResourceStatus, []bucket.SubresourceClient, error) {
+	statuses := make([]bucket.ResourceStatus, len(e.subresourceClients))
+	errs := make([]error, len(e.subresourceClients))
+	observed := make([]*v1beta1.Bucket, len(e.subresourceClients))
+	for i := range observed {
+		observed[i] = cr.DeepCopy()
+	}
+
+	g := errgroup.Group{}
+	g.SetLimit(maxConcurrentObserves)
+	for i, awsClient := range e.subresourceClients {
+		i, awsClient := i, awsClient
+		g.Go(func() error {
+			statuses[i], errs[i] = awsClient.Observe(ctx, observed[i])
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	original := cr.DeepCopy()
+	for _, o := range observed {
+		mergeObservedStatus(cr, original, o)
+	}
+
+	worst := bucket.Updated
+	var notImplemented []bucket.SubresourceClient
+	var failed []error
+	for i := range statuses {
+		switch {
+		case s3.NotImplemented(errs[i]):
+			notImplemented = append(notImplemented, e.subresourceClients[i])
+		case errs[i] != nil:
+			failed = append(failed, errs[i])
+		case statuses[i] > worst:
+			worst = statuses[i]
+		}
+	}
+	switch len(failed) {
+	case 0:
+		return worst, notImplemented, nil
+	case 1:
+		return worst, notImplemented, failed[0]
+	default:
+		return worst, notImplemented, k8serrors.NewAggregate(failed)
+	}
+}
+
+// mergeObservedStatus sets the conditions and the observed fields of the
+// status of cr that differ between the status of original and the status of
+// observed, i.e. those the Observe of a subresource client changed.
+func mergeObservedStatus(cr, original, observed *v1beta1.Bucket) {
+	for _, c := range observed.Status.Conditions {
+		if !c.Equal(original.Status.GetCondition(c.Type)) {
+			cr.Status.SetConditions(c)
+		}
+	}
+	before := reflect.ValueOf(original.Status.AtProvider)
+	after := reflect.ValueOf(observed.Status.AtProvider)
+	merged := reflect.ValueOf(&cr.Status.AtProvider).Elem()
+	for i := 0; i < after.NumField(); i++ {
+		if !reflect.DeepEqual(before.Field(i).Interface(), after.Field(i).Interface()) {
+			merged.Field(i).Set(after.Field(i))
+		}
+	}
+}
+
+// reportUnsupported reports the subresources of the supplied clients that are
+// configured on the Bucket although the backend does not implement them. The
+// condition is only cleared if it was reported before.
+func (e *external) reportUnsupported(cr *v1beta1.Bucket, clients []bucket.SubresourceClient) {
+	var names []string
+	for _, c := range clients {
+		if c.SubresourceExists(cr) {
+			names = append(names, subresourceName(c))
+		}
+	}
+	switch {
+	case len(names) != 0:
+		cr.Status.SetConditions(bucket.SubresourcesUnsupported(names))
+	case cr.Status.GetCondition(bucket.TypeUnsupportedSubresources).Status == corev1.ConditionTrue:
+		cr.Status.SetConditions(bucket.SubresourcesSupported())
+	}
+}
+
 func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
 	cr, ok := mg.(*v1beta1.Bucket)
 	if !ok {
 		return managed.ExternalCreation{}, errors.New(errUnexpectedObject)
 	}
+	defer e.reportAPICalls(cr)
 
+	if e.auditOnly {
+		return managed.ExternalCreation{}, errors.New(errAuditOnlyCreate)
+	}
 	_, err := e.s3client.CreateBucket(ctx, s3.GenerateCreateBucketInput(meta.GetExternalName(cr), cr.Spec.ForProvider))
 	if resource.Ignore(s3.IsAlreadyExists, err) != nil {
 		return managed.ExternalCreation{}, awsclient.Wrap(err, errCreate)
 	}
+	e.warnACLDeprecations(cr)
 	current := cr.Spec.ForProvider.DeepCopy()
+	// Subresource clients may annotate the Bucket when late initializing it.
+	annotations := cr.DeepCopy().GetAnnotations()
 
 	errs := make([]error, 0)
 	for _, awsClient := range e.subresourceClients {
 		err := awsClient.LateInitialize(ctx, cr)
-		if err != nil {
+		if resource.Ignore(s3.NotImplemented, err) != nil {
 			// aggregate errors since we dont want all late inits to fail if just the first one fails
 			// this can only really be run on creation, and we lose fidelty if we let this go into the
 			// reconcile loop/Observe func
 			errs = append(errs, err)
 		}
 	}
-	if !cmp.Equal(current, &cr.Spec.ForProvider) {
+	if !cmp.Equal(current, &cr.Spec.ForProvider) || !cmp.Equal(annotations, cr.GetAnnotations()) {
 		if err := e.kube.Update(ctx, cr); err != nil {
 			errs = append(errs, awsclient.Wrap(err, errKubeUpdateFailed))
 		}
@@ -195,28 +603,270 @@ func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 	if !ok {
 		return managed.ExternalUpdate{}, errors.New(errUnexpectedObject)
 	}
+	defer e.reportAPICalls(cr)
 
+	if e.auditOnly {
+		return managed.ExternalUpdate{}, e.reportDrift(ctx, cr)
+	}
+
+	var waiting []string
+	var changes []change
+	summary := reconcileSummary{}
 	for _, awsClient := range e.subresourceClients {
+		name := subresourceName(awsClient)
 		status, err := awsClient.Observe(ctx, cr)
+		if s3.NotImplemented(err) {
+			summary.skipped = append(summary.skipped, name)
+			continue
+		}
 		if err != nil {
 			cr.Status.SetConditions(xpv1.ReconcileError(err))
 			return managed.ExternalUpdate{}, err
 		}
-		switch status { //nolint:exhaustive
+		switch status {
+		case bucket.Updated:
+			summary.unchanged = append(summary.unchanged, name)
 		case bucket.NeedsDeletion:
-			err = awsClient.Delete(ctx, cr)
+			if e.disableDelete {
+				e.logger.Info("Skipping deletion of Bucket subresource because deletion is disabled", "bucket", meta.GetExternalName(cr), "subresource", fmt.Sprintf("%T", awsClient))
+				summary.skipped = append(summary.skipped, name)
+				continue
+			}
+			prior, err := e.capturePrior(ctx, awsClient, cr)
 			if err != nil {
-				return managed.ExternalUpdate{}, awsclient.Wrap(err, errDelete)
+				return managed.ExternalUpdate{}, e.rollback(ctx, cr, changes, awsclient.Wrap(err, errCapturePrior))
+			}
+			if err := awsClient.Delete(ctx, cr); err != nil {
+				return managed.ExternalUpdate{}, e.rollback(ctx, cr, changes, awsclient.Wrap(err, errDelete))
 			}
+			changes = append(changes, change{client: awsClient, prior: prior})
+			summary.deleted = append(summary.deleted, name)
 		case bucket.NeedsUpdate:
-			if err := awsClient.CreateOrUpdate(ctx, cr); err != nil {
-				return managed.ExternalUpdate{}, awsclient.Wrap(err, errCreateOrUpdate)
+			unmet, err := bucket.UnmetPrerequisites(ctx, awsClient, cr)
+			if err != nil {
+				return managed.ExternalUpdate{}, e.rollback(ctx, cr, changes, awsclient.Wrap(err, errPrerequisites))
+			}
+			if len(unmet) != 0 {
+				e.logger.Debug("Skipping Bucket subresource until its prerequisites are met", "bucket", meta.GetExternalName(cr), "subresource", fmt.Sprintf("%T", awsClient), "prerequisites", unmet)
+				waiting = append(waiting, unmet...)
+				summary.skipped = append(summary.skipped, name)
+				continue
+			}
+			if err := e.checkQuotas(ctx, awsClient, cr); err != nil {
+				return managed.ExternalUpdate{}, e.rollback(ctx, cr, changes, err)
+			}
+			target, err := e.runHooks(ctx, awsClient, cr)
+			if err != nil {
+				return managed.ExternalUpdate{}, e.rollback(ctx, cr, changes, awsclient.Wrap(err, errHook))
+			}
+			prior, err := e.capturePrior(ctx, awsClient, cr)
+			if err != nil {
+				return managed.ExternalUpdate{}, e.rollback(ctx, cr, changes, awsclient.Wrap(err, errCapturePrior))
+			}
+			if err := awsClient.CreateOrUpdate(ctx, target); err != nil {
+				return managed.ExternalUpdate{}, e.rollback(ctx, cr, changes, awsclient.Wrap(err, errCreateOrUpdate))
+			}
+			// Conditions set by the client on a copy made for the hooks are kept.
+			if target != cr {
+				cr.Status.SetConditions(target.Status.Conditions...)
+			}
+			switch awsClient.(type) {
+			case *bucket.ACLClient:
+				e.warnACLDeprecations(cr)
+			case *bucket.LoggingConfigurationClient:
+				e.warnLoggingTarget(cr)
 			}
+			changes = append(changes, change{client: awsClient, prior: prior})
+			summary.updated = append(summary.updated, name)
 		}
 	}
+	e.recorder.Event(cr, event.Normal(reasonReconcileSummary, summary.String()))
+	switch {
+	case len(waiting) != 0:
+		cr.Status.SetConditions(bucket.WaitingForPrerequisites(waiting))
+	case cr.Status.GetCondition(bucket.TypePrerequisites).Status == corev1.ConditionFalse:
+		// Only report that prerequisites are met once we waited for them.
+		cr.Status.SetConditions(bucket.PrerequisitesMet())
+	}
 	return managed.ExternalUpdate{}, nil
 }
 
+// warnACLDeprecations emits a warning for each ACL setting of the Bucket that
+// AWS discourages. It is only called when the ACL is applied, so the warnings
+// are not repeated on every poll. The ACL is not managed once ACLs are
+// disabled, so it is not reported.
+func (e *external) warnACLDeprecations(cr *v1beta1.Bucket) {
+	if s3.ACLsDisabled(cr.Spec.ForProvider) {
+		return
+	}
+	for _, msg := range s3.ACLDeprecations(cr.Spec.ForProvider) {
+		e.recorder.Event(cr, event.Warning(reasonDeprecatedConfiguration, errors.New(msg)))
+	}
+}
+
+// warnLoggingTarget emits a warning if the logging target of the Bucket looks
+// like a CloudTrail log destination. Like warnACLDeprecations it is only
+// called when the logging configuration is applied.
+func (e *external) warnLoggingTarget(cr *v1beta1.Bucket) {
+	if msg := bucket.LoggingTargetWarning(cr.Spec.ForProvider.LoggingConfiguration); msg != "" {
+		e.recorder.Event(cr, event.Warning(reasonLoggingTarget, errors.New(msg)))
+	}
+}
+
+// checkQuotas returns an error if creating or updating the subresource of the
+// supplied client would exceed one of its quotas and warns about quotas that
+// are nearly used up. Quotas are only checked if the preflight is enabled.
+func (e *external) checkQuotas(ctx context.Context, awsClient bucket.SubresourceClient, cr *v1beta1.Bucket) error {
+	if !e.quotaPreflight {
+		return nil
+	}
+	usages, err := bucket.QuotaUsages(ctx, awsClient, cr)
+	if err != nil {
+		return awsclient.Wrap(err, errQuota)
+	}
+	name := subresourceName(awsClient)
+	for _, u := range usages {
+		switch {
+		case u.Exceeded():
+			return errors.Errorf(errQuotaExceeded, name, u)
+		case u.NearLimit():
+			e.recorder.Event(cr, event.Warning(reasonQuotaNearLimit, errors.Errorf(errQuotaNearLimit, name, u)))
+		}
+	}
+	return nil
+}
+
+// capturePrior returns a Bucket whose configuration of the subresource managed
+// by the supplied client is the one currently observed in AWS. It returns nil
+// unless updates are transactional or if the client cannot capture it.
+func (e *external) capturePrior(ctx context.Context, awsClient bucket.SubresourceClient, cr *v1beta1.Bucket) (*v1beta1.Bucket, error) {
+	if !e.transactional {
+		return nil, nil
+	}
+	return awsClient.CapturePrior(ctx, cr)
+}
+
+// rollback reverts the supplied changes, latest first, if updates are
+// transactional. Subresources that did not exist before are deleted unless
+// deletion is disabled, and those whose prior configuration could not be
+// captured are not reverted. Reverting is best effort; the supplied error is
+// always returned.
+func (e *external) rollback(ctx context.Context, cr *v1beta1.Bucket, changes []change, err error) error {
+	if !e.transactional || len(changes) == 0 {
+		return err
+	}
+	var reverted []string
+	var errs []error
+	for i := len(changes) - 1; i >= 0; i-- {
+		c := changes[i]
+		name := subresourceName(c.client)
+		switch {
+		case c.prior == nil:
+			e.logger.Info("Not reverting Bucket subresource because its prior configuration is unknown", "bucket", meta.GetExternalName(cr), "subresource", fmt.Sprintf("%T", c.client))
+			continue
+		case c.client.SubresourceExists(c.prior):
+			if rerr := c.client.CreateOrUpdate(ctx, c.prior); rerr != nil {
+				errs = append(errs, errors.Wrap(rerr, name))
+				continue
+			}
+		case e.disableDelete:
+			e.logger.Info("Not reverting Bucket subresource because deletion is disabled", "bucket", meta.GetExternalName(cr), "subresource", fmt.Sprintf("%T", c.client))
+			continue
+		default:
+			if rerr := c.client.Delete(ctx, c.prior); rerr != nil {
+				errs = append(errs, errors.Wrap(rerr, name))
+				continue
+			}
+		}
+		reverted = append(reverted, name)
+	}
+	msg := fmt.Sprintf("Reverted [%s] after a failed update", strings.Join(reverted, ", "))
+	if len(errs) != 0 {
+		msg = fmt.Sprintf("%s, cannot revert: %s", msg, k8serrors.NewAggregate(errs))
+	}
+	e.recorder.Event(cr, event.Warning(reasonRolledBack, errors.New(msg)))
+	return err
+}
+
+// reportDrift observes all subresources of the supplied Bucket and reports
+// those that do not match their configuration in AWS, without changing them.
+func (e *external) reportDrift(ctx context.Context, cr *v1beta1.Bucket) error {
+	var drifted []string
+	for _, awsClient := range e.subresourceClients {
+		status, err := awsClient.Observe(ctx, cr)
+		if s3.NotImplemented(err) {
+			continue
+		}
+		if err != nil {
+			cr.Status.SetConditions(xpv1.ReconcileError(err))
+			return err
+		}
+		if status != bucket.Updated {
+			drifted = append(drifted, subresourceName(awsClient))
+		}
+	}
+	if len(drifted) == 0 {
+		cr.Status.SetConditions(bucket.NoDrift())
+		return nil
+	}
+	cr.Status.SetConditions(bucket.DriftDetected(drifted))
+	e.recorder.Event(cr, event.Warning(reasonDriftDetected, errors.Errorf("subresources drift from their configuration in AWS: %s", strings.Join(drifted, ", "))))
+	return nil
+}
+
+// A reconcileSummary records the names of the subresources of a Bucket by
+// what happened to them during an update.
+type reconcileSummary struct {
+	updated   []string
+	deleted   []string
+	unchanged []string
+	skipped   []string
+}
+
+// String returns a single line listing the subresources of each category,
+// e.g. "updated: Tagging; deleted: none; unchanged: Versioning, CORS".
+// Skipped subresources are only listed if there are any.
+func (s reconcileSummary) String() string {
+	list := func(names []string) string {
+		if len(names) == 0 {
+			return "none"
+		}
+		return strings.Join(names, ", ")
+	}
+	msg := fmt.Sprintf("updated: %s; deleted: %s; unchanged: %s", list(s.updated), list(s.deleted), list(s.unchanged))
+	if len(s.skipped) != 0 {
+		msg += "; skipped: " + list(s.skipped)
+	}
+	return msg
+}
+
+// subresourceName returns the name of the subresource managed by the given
+// client, e.g. "SSEConfiguration" for the SSEConfigurationClient.
+func subresourceName(c bucket.SubresourceClient) string {
+	t := reflect.TypeOf(c)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return strings.TrimSuffix(t.Name(), "Client")
+}
+
+// runHooks calls the hooks registered for the given subresource client on a
+// copy of the Bucket and returns the copy. The Bucket itself is returned if
+// no hooks are registered.
+func (e *external) runHooks(ctx context.Context, awsClient bucket.SubresourceClient, cr *v1beta1.Bucket) (*v1beta1.Bucket, error) {
+	hooks := e.hooks[reflect.TypeOf(awsClient)]
+	if len(hooks) == 0 {
+		return cr, nil
+	}
+	target := cr.DeepCopy()
+	for _, h := range hooks {
+		if err := h(ctx, target); err != nil {
+			return nil, err
+		}
+	}
+	return target, nil
+}
+
 func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
 	cr, ok := mg.(*v1beta1.Bucket)
 	if !ok {
@@ -224,6 +874,16 @@ func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
 	}
 
 	cr.Status.SetConditions(xpv1.Deleting())
+	if e.auditOnly {
+		return errors.New(errAuditOnlyDelete)
+	}
+	// Resources outside of the bucket are cleaned up first, since the bucket
+	// configuration that records what was changed is gone with the bucket.
+	for _, awsClient := range e.subresourceClients {
+		if err := bucket.Cleanup(ctx, awsClient, cr); err != nil {
+			return errors.Wrapf(err, errCleanup, subresourceName(awsClient))
+		}
+	}
 	_, err := e.s3client.DeleteBucket(ctx, &awss3.DeleteBucketInput{Bucket: aws.String(meta.GetExternalName(cr))})
 	return resource.Ignore(s3.IsNotFound, err)
 }
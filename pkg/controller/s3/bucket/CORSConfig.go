@@ -21,6 +21,7 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
 
 	"github.com/crossplane/provider-aws/apis/s3/v1beta1"
 	awsclient "github.com/crossplane/provider-aws/pkg/clients"
@@ -31,8 +32,19 @@ const (
 	corsGetFailed    = "cannot get Bucket CORS configuration"
 	corsPutFailed    = "cannot put Bucket cors"
 	corsDeleteFailed = "cannot delete Bucket CORS configuration"
+
+	corsInvalidMethod = "CORS rule %d: invalid allowed method %q, must be one of GET, PUT, HEAD, POST or DELETE"
 )
 
+// corsMethods are the HTTP methods a CORS rule may allow.
+var corsMethods = map[string]bool{
+	"GET":    true,
+	"PUT":    true,
+	"HEAD":   true,
+	"POST":   true,
+	"DELETE": true,
+}
+
 // CORSConfigurationClient is the client for API methods and reconciling the CORSConfiguration
 type CORSConfigurationClient struct {
 	client s3.BucketClient
@@ -65,11 +77,27 @@ func (in *CORSConfigurationClient) CreateOrUpdate(ctx context.Context, bucket *v
 	if bucket.Spec.ForProvider.CORSConfiguration == nil {
 		return nil
 	}
+	if err := validateCORSRules(bucket.Spec.ForProvider.CORSConfiguration.CORSRules); err != nil {
+		return err
+	}
 	input := GeneratePutBucketCorsInput(meta.GetExternalName(bucket), bucket.Spec.ForProvider.CORSConfiguration)
 	_, err := in.client.PutBucketCors(ctx, input)
 	return awsclient.Wrap(err, corsPutFailed)
 }
 
+// validateCORSRules returns an error if a rule allows a method that S3 does
+// not support for CORS.
+func validateCORSRules(rules []v1beta1.CORSRule) error {
+	for i, rule := range rules {
+		for _, m := range rule.AllowedMethods {
+			if !corsMethods[m] {
+				return errors.Errorf(corsInvalidMethod, i, m)
+			}
+		}
+	}
+	return nil
+}
+
 // Delete creates the request to delete the resource on AWS or set it to the default value.
 func (in *CORSConfigurationClient) Delete(ctx context.Context, bucket *v1beta1.Bucket) error {
 	_, err := in.client.DeleteBucketCors(ctx,
@@ -106,6 +134,11 @@ func (in *CORSConfigurationClient) LateInitialize(ctx context.Context, bucket *v
 	return nil
 }
 
+// CapturePrior returns a Bucket with the CORS rules currently in AWS.
+func (in *CORSConfigurationClient) CapturePrior(ctx context.Context, bucket *v1beta1.Bucket) (*v1beta1.Bucket, error) {
+	return lateInitializedPrior(ctx, in, bucket)
+}
+
 // SubresourceExists checks if the subresource this controller manages currently exists
 func (in *CORSConfigurationClient) SubresourceExists(bucket *v1beta1.Bucket) bool {
 	return bucket.Spec.ForProvider.CORSConfiguration != nil
@@ -123,14 +156,19 @@ func GeneratePutBucketCorsInput(name string, config *v1beta1.CORSConfiguration)
 			AllowedMethods: cors.AllowedMethods,
 			AllowedOrigins: cors.AllowedOrigins,
 			ExposeHeaders:  cors.ExposeHeaders,
+			ID:             cors.ID,
 			MaxAgeSeconds:  cors.MaxAgeSeconds,
 		})
 	}
 	return bci
 }
 
-// CompareCORS compares the external and internal representations for the list of CORSRules
-func CompareCORS(local []v1beta1.CORSRule, external []types.CORSRule) ResourceStatus { // nolint:gocyclo
+// CompareCORS compares the external and internal representations for the list
+// of CORSRules. The order of the rules is not considered a change, since it
+// does not affect how S3 evaluates them. If every local rule has an ID, rules
+// are matched by ID. Otherwise local rules with an ID are matched first and
+// the remaining rules are matched by their content.
+func CompareCORS(local []v1beta1.CORSRule, external []types.CORSRule) ResourceStatus {
 	switch {
 	case len(local) == 0 && len(external) != 0:
 		return NeedsDeletion
@@ -140,20 +178,78 @@ func CompareCORS(local []v1beta1.CORSRule, external []types.CORSRule) ResourceSt
 		return NeedsUpdate
 	}
 
-	for i := range local {
-		outputRule := external[i]
-		if !(cmp.Equal(local[i].AllowedHeaders, outputRule.AllowedHeaders) &&
-			cmp.Equal(local[i].AllowedMethods, outputRule.AllowedMethods) &&
-			cmp.Equal(local[i].AllowedOrigins, outputRule.AllowedOrigins) &&
-			cmp.Equal(local[i].ExposeHeaders, outputRule.ExposeHeaders) &&
-			local[i].MaxAgeSeconds == outputRule.MaxAgeSeconds) {
-			return NeedsUpdate
+	if byID, ok := corsRulesByID(external); ok && allCORSRulesHaveIDs(local) {
+		for i := range local {
+			outputRule, found := byID[*local[i].ID]
+			if !found || !corsRuleEqual(local[i], outputRule) {
+				return NeedsUpdate
+			}
+		}
+		return Updated
+	}
+
+	matched := make([]bool, len(external))
+	for _, withID := range []bool{true, false} {
+		for i := range local {
+			if (local[i].ID != nil) != withID {
+				continue
+			}
+			if !matchCORSRule(local[i], external, matched) {
+				return NeedsUpdate
+			}
 		}
 	}
 
 	return Updated
 }
 
+// matchCORSRule marks the first unmatched external rule that equals the local
+// rule as matched. It returns false if there is no such rule.
+func matchCORSRule(local v1beta1.CORSRule, external []types.CORSRule, matched []bool) bool {
+	for j := range external {
+		if !matched[j] && corsRuleEqual(local, external[j]) {
+			matched[j] = true
+			return true
+		}
+	}
+	return false
+}
+
+// corsRuleEqual returns true if the local rule matches the external rule. The
+// ID is only compared if the local rule specifies one.
+func corsRuleEqual(local v1beta1.CORSRule, external types.CORSRule) bool {
+	return cmp.Equal(local.AllowedHeaders, external.AllowedHeaders) &&
+		cmp.Equal(local.AllowedMethods, external.AllowedMethods) &&
+		cmp.Equal(local.AllowedOrigins, external.AllowedOrigins) &&
+		cmp.Equal(local.ExposeHeaders, external.ExposeHeaders) &&
+		local.MaxAgeSeconds == external.MaxAgeSeconds &&
+		(local.ID == nil || awsclient.StringValue(local.ID) == awsclient.StringValue(external.ID))
+}
+
+// allCORSRulesHaveIDs returns true if every rule has a non-empty ID.
+func allCORSRulesHaveIDs(rules []v1beta1.CORSRule) bool {
+	for _, r := range rules {
+		if awsclient.StringValue(r.ID) == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// corsRulesByID indexes the rules by their ID. It returns false if a rule has
+// no ID or if an ID is used more than once.
+func corsRulesByID(rules []types.CORSRule) (map[string]types.CORSRule, bool) {
+	byID := make(map[string]types.CORSRule, len(rules))
+	for _, r := range rules {
+		id := awsclient.StringValue(r.ID)
+		if _, dup := byID[id]; id == "" || dup {
+			return nil, false
+		}
+		byID[id] = r
+	}
+	return byID, true
+}
+
 // GenerateCORSRule creates the cors rule from a GetBucketCORS request from the S3 Client
 func GenerateCORSRule(config []types.CORSRule) []v1beta1.CORSRule {
 	output := make([]v1beta1.CORSRule, len(config))
@@ -163,6 +259,7 @@ func GenerateCORSRule(config []types.CORSRule) []v1beta1.CORSRule {
 			AllowedMethods: cors.AllowedMethods,
 			AllowedOrigins: cors.AllowedOrigins,
 			ExposeHeaders:  cors.ExposeHeaders,
+			ID:             cors.ID,
 			MaxAgeSeconds:  cors.MaxAgeSeconds,
 		}
 	}
@@ -61,6 +61,142 @@ func generateAWSCORS() *s3types.CORSConfiguration {
 	}
 }
 
+// generateCORSRules returns a GET and a PUT rule with the supplied IDs, in
+// that order. An empty ID leaves the ID of the rule unset.
+func generateCORSRules(getID, putID string) []v1beta1.CORSRule {
+	rules := []v1beta1.CORSRule{
+		{AllowedMethods: []string{"GET"}, AllowedOrigins: []string{"test.origin"}},
+		{AllowedMethods: []string{"PUT"}, AllowedOrigins: []string{"test.origin"}},
+	}
+	if getID != "" {
+		rules[0].ID = awsclient.String(getID)
+	}
+	if putID != "" {
+		rules[1].ID = awsclient.String(putID)
+	}
+	return rules
+}
+
+func generateAWSCORSRules(getID, putID string) []s3types.CORSRule {
+	return GeneratePutBucketCorsInput(bucketName, &v1beta1.CORSConfiguration{CORSRules: generateCORSRules(getID, putID)}).CORSConfiguration.CORSRules
+}
+
+func reversedAWSCORSRules(rules []s3types.CORSRule) []s3types.CORSRule {
+	return []s3types.CORSRule{rules[1], rules[0]}
+}
+
+func TestCompareCORS(t *testing.T) {
+	type args struct {
+		local    []v1beta1.CORSRule
+		external []s3types.CORSRule
+	}
+
+	cases := map[string]struct {
+		args
+		want ResourceStatus
+	}{
+		"NoIDsSameOrder": {
+			args: args{
+				local:    generateCORSRules("", ""),
+				external: generateAWSCORSRules("", ""),
+			},
+			want: Updated,
+		},
+		"NoIDsReordered": {
+			args: args{
+				local:    generateCORSRules("", ""),
+				external: reversedAWSCORSRules(generateAWSCORSRules("", "")),
+			},
+			want: Updated,
+		},
+		"NoIDsRuleChanged": {
+			args: args{
+				local:    generateCORSRules("", ""),
+				external: append(generateAWSCORSRules("", "")[:1], s3types.CORSRule{AllowedMethods: []string{"PUT"}, AllowedOrigins: []string{"other.origin"}}),
+			},
+			want: NeedsUpdate,
+		},
+		"NoIDsDuplicateRule": {
+			args: args{
+				local:    generateCORSRules("", ""),
+				external: []s3types.CORSRule{generateAWSCORSRules("", "")[0], generateAWSCORSRules("", "")[0]},
+			},
+			want: NeedsUpdate,
+		},
+		"RuleAdded": {
+			args: args{
+				local:    generateCORSRules("", ""),
+				external: generateAWSCORSRules("", "")[:1],
+			},
+			want: NeedsUpdate,
+		},
+		"RuleRemoved": {
+			args: args{
+				local:    generateCORSRules("", "")[:1],
+				external: generateAWSCORSRules("", ""),
+			},
+			want: NeedsUpdate,
+		},
+		"IDsSameOrder": {
+			args: args{
+				local:    generateCORSRules("get", "put"),
+				external: generateAWSCORSRules("get", "put"),
+			},
+			want: Updated,
+		},
+		"IDsReordered": {
+			args: args{
+				local:    generateCORSRules("get", "put"),
+				external: reversedAWSCORSRules(generateAWSCORSRules("get", "put")),
+			},
+			want: Updated,
+		},
+		"IDsChanged": {
+			args: args{
+				local:    generateCORSRules("get", "put"),
+				external: generateAWSCORSRules("get", "upload"),
+			},
+			want: NeedsUpdate,
+		},
+		"IDsSwapped": {
+			args: args{
+				local:    generateCORSRules("put", "get"),
+				external: generateAWSCORSRules("get", "put"),
+			},
+			want: NeedsUpdate,
+		},
+		"IDsMissingExternally": {
+			args: args{
+				local:    generateCORSRules("get", "put"),
+				external: generateAWSCORSRules("", ""),
+			},
+			want: NeedsUpdate,
+		},
+		"PartialIDsReordered": {
+			args: args{
+				local:    generateCORSRules("get", ""),
+				external: reversedAWSCORSRules(generateAWSCORSRules("get", "put")),
+			},
+			want: Updated,
+		},
+		"PartialIDsChanged": {
+			args: args{
+				local:    generateCORSRules("put", ""),
+				external: generateAWSCORSRules("get", "put"),
+			},
+			want: NeedsUpdate,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, CompareCORS(tc.args.local, tc.args.external)); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestCORSObserve(t *testing.T) {
 	type args struct {
 		cl *CORSConfigurationClient
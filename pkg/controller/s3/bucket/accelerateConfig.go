@@ -22,6 +22,7 @@ import (
 	awss3 "github.com/aws/aws-sdk-go-v2/service/s3"
 	awss3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/pkg/errors"
 
 	"github.com/crossplane/provider-aws/apis/s3/v1beta1"
 	awsclient "github.com/crossplane/provider-aws/pkg/clients"
@@ -29,10 +30,22 @@ import (
 )
 
 const (
-	accelGetFailed = "cannot get Bucket accelerate configuration"
-	accelPutFailed = "cannot put Bucket accelerate configuration"
+	accelGetFailed    = "cannot get Bucket accelerate configuration"
+	accelPutFailed    = "cannot put Bucket accelerate configuration"
+	accelDeleteFailed = "cannot suspend Bucket transfer acceleration"
+
+	accelUnsupportedRegion = "transfer acceleration is not supported in region %s"
 )
 
+// regionsWithoutAcceleration contains the regions in which S3 Transfer
+// Acceleration is not available.
+var regionsWithoutAcceleration = map[string]bool{
+	"cn-north-1":     true,
+	"cn-northwest-1": true,
+	"us-gov-east-1":  true,
+	"us-gov-west-1":  true,
+}
+
 // AccelerateConfigurationClient is the client for API methods and reconciling the AccelerateConfiguration
 type AccelerateConfigurationClient struct {
 	client s3.BucketClient
@@ -45,6 +58,9 @@ func NewAccelerateConfigurationClient(client s3.BucketClient) *AccelerateConfigu
 
 // Observe checks if the resource exists and if it matches the local configuration
 func (in *AccelerateConfigurationClient) Observe(ctx context.Context, bucket *v1beta1.Bucket) (ResourceStatus, error) {
+	if err := validateAccelerateRegion(&bucket.Spec.ForProvider); err != nil {
+		return NeedsUpdate, err
+	}
 	external, err := in.client.GetBucketAccelerateConfiguration(ctx, &awss3.GetBucketAccelerateConfigurationInput{Bucket: awsclient.String(meta.GetExternalName(bucket))})
 	if err != nil {
 		// Short stop method for requests in a region without Acceleration Support
@@ -53,8 +69,19 @@ func (in *AccelerateConfigurationClient) Observe(ctx context.Context, bucket *v1
 		}
 		return NeedsUpdate, awsclient.Wrap(err, accelGetFailed)
 	}
-	if bucket.Spec.ForProvider.AccelerateConfiguration != nil &&
-		bucket.Spec.ForProvider.AccelerateConfiguration.Status != string(external.Status) {
+	// A bucket on which acceleration was never configured reports no status,
+	// which is equivalent to a suspended acceleration.
+	status := external.Status
+	if len(status) == 0 {
+		status = awss3types.BucketAccelerateStatusSuspended
+	}
+	if bucket.Spec.ForProvider.AccelerateConfiguration == nil {
+		if status == awss3types.BucketAccelerateStatusEnabled {
+			return NeedsDeletion, nil
+		}
+		return Updated, nil
+	}
+	if bucket.Spec.ForProvider.AccelerateConfiguration.Status != string(status) {
 		return NeedsUpdate, nil
 	}
 	return Updated, nil
@@ -65,16 +92,36 @@ func (in *AccelerateConfigurationClient) CreateOrUpdate(ctx context.Context, buc
 	if bucket.Spec.ForProvider.AccelerateConfiguration == nil {
 		return nil
 	}
+	if err := validateAccelerateRegion(&bucket.Spec.ForProvider); err != nil {
+		return err
+	}
 	input := GenerateAccelerateConfigurationInput(meta.GetExternalName(bucket), bucket.Spec.ForProvider.AccelerateConfiguration)
 	_, err := in.client.PutBucketAccelerateConfiguration(ctx, input)
 	return awsclient.Wrap(err, accelPutFailed)
 }
 
-// Delete does not do anything since AccelerateConfiguration doesn't have Delete call.
-func (*AccelerateConfigurationClient) Delete(_ context.Context, _ *v1beta1.Bucket) error {
+// validateAccelerateRegion returns an error if acceleration is enabled for a
+// bucket in a region that does not support it.
+func validateAccelerateRegion(params *v1beta1.BucketParameters) error {
+	config := params.AccelerateConfiguration
+	if config == nil || config.Status != string(awss3types.BucketAccelerateStatusEnabled) {
+		return nil
+	}
+	if region := params.LocationConstraint; regionsWithoutAcceleration[region] {
+		return errors.Errorf(accelUnsupportedRegion, region)
+	}
 	return nil
 }
 
+// Delete suspends transfer acceleration on the bucket, since
+// AccelerateConfiguration doesn't have Delete call.
+func (in *AccelerateConfigurationClient) Delete(ctx context.Context, bucket *v1beta1.Bucket) error {
+	input := GenerateAccelerateConfigurationInput(meta.GetExternalName(bucket),
+		&v1beta1.AccelerateConfiguration{Status: string(awss3types.BucketAccelerateStatusSuspended)})
+	_, err := in.client.PutBucketAccelerateConfiguration(ctx, input)
+	return awsclient.Wrap(err, accelDeleteFailed)
+}
+
 // LateInitialize is responsible for initializing the resource based on the external value
 func (in *AccelerateConfigurationClient) LateInitialize(ctx context.Context, bucket *v1beta1.Bucket) error {
 	external, err := in.client.GetBucketAccelerateConfiguration(ctx, &awss3.GetBucketAccelerateConfigurationInput{Bucket: awsclient.String(meta.GetExternalName(bucket))})
@@ -101,6 +148,11 @@ func (in *AccelerateConfigurationClient) LateInitialize(ctx context.Context, buc
 	return nil
 }
 
+// CapturePrior returns a Bucket with the accelerate configuration currently in AWS.
+func (in *AccelerateConfigurationClient) CapturePrior(ctx context.Context, bucket *v1beta1.Bucket) (*v1beta1.Bucket, error) {
+	return lateInitializedPrior(ctx, in, bucket)
+}
+
 // SubresourceExists checks if the subresource this controller manages currently exists
 func (in *AccelerateConfigurationClient) SubresourceExists(bucket *v1beta1.Bucket) bool {
 	return bucket.Spec.ForProvider.AccelerateConfiguration != nil
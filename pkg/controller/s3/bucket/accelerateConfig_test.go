@@ -86,6 +86,16 @@ func TestAccelerateObserve(t *testing.T) {
 				err:    nil,
 			},
 		},
+		"UnsupportedRegion": {
+			args: args{
+				b:  s3Testing.Bucket(s3Testing.WithRegion("cn-north-1"), s3Testing.WithAccelerationConfig(&v1beta1.AccelerateConfiguration{Status: enabled})),
+				cl: NewAccelerateConfigurationClient(fake.MockBucketClient{}),
+			},
+			want: want{
+				status: NeedsUpdate,
+				err:    errors.Errorf(accelUnsupportedRegion, "cn-north-1"),
+			},
+		},
 		"NoUpdateNotExists": {
 			args: args{
 				b: s3Testing.Bucket(s3Testing.WithAccelerationConfig(nil)),
@@ -100,6 +110,34 @@ func TestAccelerateObserve(t *testing.T) {
 				err:    nil,
 			},
 		},
+		"NeedsDeletion": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithAccelerationConfig(nil)),
+				cl: NewAccelerateConfigurationClient(fake.MockBucketClient{
+					MockGetBucketAccelerateConfiguration: func(ctx context.Context, input *s3.GetBucketAccelerateConfigurationInput, opts []func(*s3.Options)) (*s3.GetBucketAccelerateConfigurationOutput, error) {
+						return &s3.GetBucketAccelerateConfigurationOutput{Status: s3types.BucketAccelerateStatusEnabled}, nil
+					},
+				}),
+			},
+			want: want{
+				status: NeedsDeletion,
+				err:    nil,
+			},
+		},
+		"NoUpdateSuspendedNeverSet": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithAccelerationConfig(&v1beta1.AccelerateConfiguration{Status: suspended})),
+				cl: NewAccelerateConfigurationClient(fake.MockBucketClient{
+					MockGetBucketAccelerateConfiguration: func(ctx context.Context, input *s3.GetBucketAccelerateConfigurationInput, opts []func(*s3.Options)) (*s3.GetBucketAccelerateConfigurationOutput, error) {
+						return &s3.GetBucketAccelerateConfigurationOutput{}, nil
+					},
+				}),
+			},
+			want: want{
+				status: Updated,
+				err:    nil,
+			},
+		},
 		"NoUpdateExists": {
 			args: args{
 				b: s3Testing.Bucket(s3Testing.WithAccelerationConfig(&v1beta1.AccelerateConfiguration{Status: suspended})),
@@ -156,6 +194,15 @@ func TestAccelerateCreateOrUpdate(t *testing.T) {
 				err: awsclient.Wrap(errBoom, accelPutFailed),
 			},
 		},
+		"UnsupportedRegion": {
+			args: args{
+				b:  s3Testing.Bucket(s3Testing.WithRegion("us-gov-west-1"), s3Testing.WithAccelerationConfig(&v1beta1.AccelerateConfiguration{Status: enabled})),
+				cl: NewAccelerateConfigurationClient(fake.MockBucketClient{}),
+			},
+			want: want{
+				err: errors.Errorf(accelUnsupportedRegion, "us-gov-west-1"),
+			},
+		},
 		"InvalidConfig": {
 			args: args{
 				b: s3Testing.Bucket(s3Testing.WithAccelerationConfig(nil)),
@@ -194,6 +241,61 @@ func TestAccelerateCreateOrUpdate(t *testing.T) {
 	}
 }
 
+func TestAccelerateDelete(t *testing.T) {
+	type args struct {
+		cl *AccelerateConfigurationClient
+		b  *v1beta1.Bucket
+	}
+
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"Error": {
+			args: args{
+				b: s3Testing.Bucket(),
+				cl: NewAccelerateConfigurationClient(fake.MockBucketClient{
+					MockPutBucketAccelerateConfiguration: func(ctx context.Context, input *s3.PutBucketAccelerateConfigurationInput, opts []func(*s3.Options)) (*s3.PutBucketAccelerateConfigurationOutput, error) {
+						return nil, errBoom
+					},
+				}),
+			},
+			want: want{
+				err: awsclient.Wrap(errBoom, accelDeleteFailed),
+			},
+		},
+		"SuccessfulSuspend": {
+			args: args{
+				b: s3Testing.Bucket(),
+				cl: NewAccelerateConfigurationClient(fake.MockBucketClient{
+					MockPutBucketAccelerateConfiguration: func(ctx context.Context, input *s3.PutBucketAccelerateConfigurationInput, opts []func(*s3.Options)) (*s3.PutBucketAccelerateConfigurationOutput, error) {
+						if input.AccelerateConfiguration.Status != s3types.BucketAccelerateStatusSuspended {
+							return nil, errBoom
+						}
+						return &s3.PutBucketAccelerateConfigurationOutput{}, nil
+					},
+				}),
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.args.cl.Delete(context.Background(), tc.args.b)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestAccelLateInit(t *testing.T) {
 	type args struct {
 		cl SubresourceClient
@@ -0,0 +1,299 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bucket
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	awss3 "github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/provider-aws/apis/s3/v1beta1"
+	awsclient "github.com/crossplane/provider-aws/pkg/clients"
+	"github.com/crossplane/provider-aws/pkg/clients/s3"
+)
+
+const (
+	aclGetFailed     = "cannot get Bucket ACL"
+	aclPutFailed     = "cannot put Bucket ACL"
+	aclResetFailed   = "cannot reset Bucket ACL to private"
+	aclInvalidGrant  = "invalid grant %q of %s, must be a comma separated list of id= or uri= grantees"
+	aclEmailGrantee  = "grant %q of %s must identify grantees by id= rather than emailAddress=, since AWS reports them by their canonical user ID"
+	aclUnknownCanned = "unknown canned ACL %q"
+)
+
+// URIs of the predefined groups canned ACLs grant access to.
+const (
+	groupAllUsers           = "http://acs.amazonaws.com/groups/global/AllUsers"
+	groupAuthenticatedUsers = "http://acs.amazonaws.com/groups/global/AuthenticatedUsers"
+	groupLogDelivery        = "http://acs.amazonaws.com/groups/s3/LogDelivery"
+)
+
+// ACLClient is the client for API methods and reconciling the ACL of a Bucket
+type ACLClient struct {
+	client s3.BucketClient
+}
+
+// NewACLClient creates the client for the Bucket ACL
+func NewACLClient(client s3.BucketClient) *ACLClient {
+	return &ACLClient{client: client}
+}
+
+// Observe checks if the resource exists and if it matches the local
+// configuration. AWS always returns the explicit grants of a bucket, so a
+// canned ACL is expanded into the grants it stands for before comparing. The
+// ACL is not managed if the ownership controls of the bucket disable ACLs.
+func (in *ACLClient) Observe(ctx context.Context, bucket *v1beta1.Bucket) (ResourceStatus, error) {
+	if !in.SubresourceExists(bucket) || s3.ACLsDisabled(bucket.Spec.ForProvider) {
+		return Updated, nil
+	}
+	external, err := in.client.GetBucketAcl(ctx, &awss3.GetBucketAclInput{Bucket: awsclient.String(meta.GetExternalName(bucket))})
+	if err != nil {
+		return NeedsUpdate, awsclient.Wrap(err, aclGetFailed)
+	}
+	var owner *types.Owner
+	var grants []types.Grant
+	if external != nil {
+		owner = external.Owner
+		grants = external.Grants
+	}
+	desired, err := GenerateGrants(bucket.Spec.ForProvider, owner)
+	if err != nil {
+		return NeedsUpdate, err
+	}
+	if equalGrants(desired, grants) {
+		return Updated, nil
+	}
+	return NeedsUpdate, nil
+}
+
+// CreateOrUpdate sends a request to have resource created on AWS
+func (in *ACLClient) CreateOrUpdate(ctx context.Context, bucket *v1beta1.Bucket) error {
+	if !in.SubresourceExists(bucket) || s3.ACLsDisabled(bucket.Spec.ForProvider) {
+		return nil
+	}
+	if _, err := GenerateGrants(bucket.Spec.ForProvider, nil); err != nil {
+		return err
+	}
+	return awsclient.Wrap(s3.UpdateBucketACL(ctx, in.client, bucket), aclPutFailed)
+}
+
+// Delete resets the ACL to private, which is the default, since a bucket
+// always has an ACL.
+func (in *ACLClient) Delete(ctx context.Context, bucket *v1beta1.Bucket) error {
+	if s3.ACLsDisabled(bucket.Spec.ForProvider) {
+		return nil
+	}
+	_, err := in.client.PutBucketAcl(ctx, &awss3.PutBucketAclInput{
+		Bucket: awsclient.String(meta.GetExternalName(bucket)),
+		ACL:    types.BucketCannedACLPrivate,
+	})
+	return awsclient.Wrap(err, aclResetFailed)
+}
+
+// LateInitialize does nothing, since the grants returned by AWS cannot be
+// turned back into the canned ACL they were created from.
+func (in *ACLClient) LateInitialize(ctx context.Context, bucket *v1beta1.Bucket) error {
+	return nil
+}
+
+// CapturePrior returns a Bucket that grants the grantees of the ACL currently
+// in AWS, which replace the whole ACL when they are put. It returns nil if
+// ACLs are disabled, since no ACL can be put then.
+func (in *ACLClient) CapturePrior(ctx context.Context, bucket *v1beta1.Bucket) (*v1beta1.Bucket, error) {
+	if s3.ACLsDisabled(bucket.Spec.ForProvider) {
+		return nil, nil
+	}
+	external, err := in.client.GetBucketAcl(ctx, &awss3.GetBucketAclInput{Bucket: awsclient.String(meta.GetExternalName(bucket))})
+	if err != nil {
+		return nil, awsclient.Wrap(err, aclGetFailed)
+	}
+	grantees := map[types.Permission][]string{}
+	if external != nil {
+		for _, g := range external.Grants {
+			if g.Grantee == nil {
+				continue
+			}
+			var grantee string
+			switch g.Grantee.Type {
+			case types.TypeCanonicalUser:
+				grantee = fmt.Sprintf("id=%q", awsclient.StringValue(g.Grantee.ID))
+			case types.TypeGroup:
+				grantee = fmt.Sprintf("uri=%q", awsclient.StringValue(g.Grantee.URI))
+			default:
+				continue
+			}
+			grantees[g.Permission] = append(grantees[g.Permission], grantee)
+		}
+	}
+	header := func(p types.Permission) *string {
+		if len(grantees[p]) == 0 {
+			return nil
+		}
+		return awsclient.String(strings.Join(grantees[p], ", "))
+	}
+	prior := newPriorBucket(bucket)
+	fp := &prior.Spec.ForProvider
+	fp.GrantFullControl = header(types.PermissionFullControl)
+	fp.GrantRead = header(types.PermissionRead)
+	fp.GrantReadACP = header(types.PermissionReadAcp)
+	fp.GrantWrite = header(types.PermissionWrite)
+	fp.GrantWriteACP = header(types.PermissionWriteAcp)
+	return prior, nil
+}
+
+// SubresourceExists checks if the subresource this controller manages currently exists
+func (in *ACLClient) SubresourceExists(bucket *v1beta1.Bucket) bool {
+	p := bucket.Spec.ForProvider
+	return p.ACL != nil || p.GrantFullControl != nil || p.GrantRead != nil || p.GrantReadACP != nil || p.GrantWrite != nil || p.GrantWriteACP != nil
+}
+
+// GenerateGrants returns the grants AWS reports for the canned ACL and the
+// grants of the supplied parameters. The bucket owner, as returned by AWS, is
+// granted full control by every canned ACL. Grantees identified by their email
+// address are rejected, since AWS reports them by their canonical user ID and
+// they would never match.
+func GenerateGrants(p v1beta1.BucketParameters, owner *types.Owner) ([]types.Grant, error) {
+	var grants []types.Grant
+	if p.ACL != nil {
+		canned, err := cannedACLGrants(types.BucketCannedACL(awsclient.StringValue(p.ACL)), owner)
+		if err != nil {
+			return nil, err
+		}
+		grants = append(grants, canned...)
+	}
+	headers := []struct {
+		name       string
+		value      *string
+		permission types.Permission
+	}{
+		{name: "grantFullControl", value: p.GrantFullControl, permission: types.PermissionFullControl},
+		{name: "grantRead", value: p.GrantRead, permission: types.PermissionRead},
+		{name: "grantReadAcp", value: p.GrantReadACP, permission: types.PermissionReadAcp},
+		{name: "grantWrite", value: p.GrantWrite, permission: types.PermissionWrite},
+		{name: "grantWriteAcp", value: p.GrantWriteACP, permission: types.PermissionWriteAcp},
+	}
+	for _, h := range headers {
+		if h.value == nil {
+			continue
+		}
+		grantees, err := parseGrantees(*h.value)
+		if err != nil {
+			return nil, errors.Errorf(aclInvalidGrant, *h.value, h.name)
+		}
+		for i := range grantees {
+			if grantees[i].Type == types.TypeAmazonCustomerByEmail {
+				return nil, errors.Errorf(aclEmailGrantee, *h.value, h.name)
+			}
+			grants = append(grants, types.Grant{Grantee: &grantees[i], Permission: h.permission})
+		}
+	}
+	return grants, nil
+}
+
+// cannedACLGrants expands a canned ACL into the grants it stands for, see
+// https://docs.aws.amazon.com/AmazonS3/latest/userguide/acl-overview.html#canned-acl
+func cannedACLGrants(acl types.BucketCannedACL, owner *types.Owner) ([]types.Grant, error) {
+	grants := []types.Grant{{Grantee: ownerGrantee(owner), Permission: types.PermissionFullControl}}
+	group := func(uri string, p types.Permission) types.Grant {
+		return types.Grant{Grantee: &types.Grantee{Type: types.TypeGroup, URI: awsclient.String(uri)}, Permission: p}
+	}
+	switch acl {
+	case types.BucketCannedACLPrivate:
+	case types.BucketCannedACLPublicRead:
+		grants = append(grants, group(groupAllUsers, types.PermissionRead))
+	case types.BucketCannedACLPublicReadWrite:
+		grants = append(grants, group(groupAllUsers, types.PermissionRead), group(groupAllUsers, types.PermissionWrite))
+	case types.BucketCannedACLAuthenticatedRead:
+		grants = append(grants, group(groupAuthenticatedUsers, types.PermissionRead))
+	case "log-delivery-write":
+		grants = append(grants, group(groupLogDelivery, types.PermissionWrite), group(groupLogDelivery, types.PermissionReadAcp))
+	default:
+		return nil, errors.Errorf(aclUnknownCanned, acl)
+	}
+	return grants, nil
+}
+
+func ownerGrantee(owner *types.Owner) *types.Grantee {
+	g := &types.Grantee{Type: types.TypeCanonicalUser}
+	if owner != nil {
+		g.ID = owner.ID
+	}
+	return g
+}
+
+// parseGrantees parses the value of a grant header, e.g.
+// id="1234", uri="http://acs.amazonaws.com/groups/global/AllUsers".
+func parseGrantees(header string) ([]types.Grantee, error) {
+	var grantees []types.Grantee
+	for _, part := range strings.Split(header, ",") {
+		kv := strings.SplitN(strings.TrimSpace(part), "=", 2)
+		if len(kv) != 2 {
+			return nil, errors.New("missing grantee type")
+		}
+		value := awsclient.String(strings.Trim(strings.TrimSpace(kv[1]), `"`))
+		switch strings.ToLower(strings.TrimSpace(kv[0])) {
+		case "id":
+			grantees = append(grantees, types.Grantee{Type: types.TypeCanonicalUser, ID: value})
+		case "uri":
+			grantees = append(grantees, types.Grantee{Type: types.TypeGroup, URI: value})
+		case "emailaddress":
+			grantees = append(grantees, types.Grantee{Type: types.TypeAmazonCustomerByEmail, EmailAddress: value})
+		default:
+			return nil, errors.Errorf("unknown grantee type %q", kv[0])
+		}
+	}
+	return grantees, nil
+}
+
+// equalGrants reports whether a and b contain the same grants in any order.
+// Display names are ignored.
+func equalGrants(a, b []types.Grant) bool {
+	ka, kb := grantKeys(a), grantKeys(b)
+	if len(ka) != len(kb) {
+		return false
+	}
+	for i := range ka {
+		if ka[i] != kb[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func grantKeys(grants []types.Grant) []string {
+	keys := make([]string, 0, len(grants))
+	seen := map[string]bool{}
+	for _, g := range grants {
+		k := string(g.Permission)
+		if g.Grantee != nil {
+			k = strings.Join([]string{string(g.Grantee.Type), awsclient.StringValue(g.Grantee.ID), awsclient.StringValue(g.Grantee.URI), awsclient.StringValue(g.Grantee.EmailAddress), k}, "|")
+		}
+		if seen[k] {
+			continue
+		}
+		seen[k] = true
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,319 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bucket
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/provider-aws/apis/s3/v1beta1"
+	awsclient "github.com/crossplane/provider-aws/pkg/clients"
+	clientss3 "github.com/crossplane/provider-aws/pkg/clients/s3"
+	"github.com/crossplane/provider-aws/pkg/clients/s3/fake"
+	s3Testing "github.com/crossplane/provider-aws/pkg/controller/s3/testing"
+)
+
+var _ SubresourceClient = &ACLClient{}
+
+func withACL(acl *string, grantRead *string) s3Testing.BucketModifier {
+	return func(b *v1beta1.Bucket) {
+		b.Spec.ForProvider.ACL = acl
+		b.Spec.ForProvider.GrantRead = grantRead
+	}
+}
+
+func getACL(grants ...types.Grant) func(ctx context.Context, input *s3.GetBucketAclInput, opts []func(*s3.Options)) (*s3.GetBucketAclOutput, error) {
+	return func(ctx context.Context, input *s3.GetBucketAclInput, opts []func(*s3.Options)) (*s3.GetBucketAclOutput, error) {
+		return &s3.GetBucketAclOutput{Owner: &types.Owner{ID: awsclient.String("owner"), DisplayName: awsclient.String("me")}, Grants: grants}, nil
+	}
+}
+
+func ownerGrant() types.Grant {
+	return types.Grant{
+		Grantee:    &types.Grantee{Type: types.TypeCanonicalUser, ID: awsclient.String("owner"), DisplayName: awsclient.String("me")},
+		Permission: types.PermissionFullControl,
+	}
+}
+
+func allUsersGrant(p types.Permission) types.Grant {
+	return types.Grant{Grantee: &types.Grantee{Type: types.TypeGroup, URI: awsclient.String(groupAllUsers)}, Permission: p}
+}
+
+func TestACLObserve(t *testing.T) {
+	type args struct {
+		cl *ACLClient
+		b  *v1beta1.Bucket
+	}
+
+	type want struct {
+		status ResourceStatus
+		err    error
+	}
+
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"Error": {
+			args: args{
+				b: s3Testing.Bucket(),
+				cl: NewACLClient(fake.MockBucketClient{
+					MockGetBucketAcl: func(ctx context.Context, input *s3.GetBucketAclInput, opts []func(*s3.Options)) (*s3.GetBucketAclOutput, error) {
+						return nil, errBoom
+					},
+				}),
+			},
+			want: want{
+				status: NeedsUpdate,
+				err:    awsclient.Wrap(errBoom, aclGetFailed),
+			},
+		},
+		"NotConfigured": {
+			args: args{
+				b:  s3Testing.Bucket(withACL(nil, nil)),
+				cl: NewACLClient(fake.MockBucketClient{}),
+			},
+			want: want{
+				status: Updated,
+			},
+		},
+		"ACLsDisabled": {
+			args: args{
+				b:  s3Testing.Bucket(withACL(awsclient.String("public-read"), nil), s3Testing.WithOwnershipControls(generateOwnershipControls(clientss3.ObjectOwnershipBucketOwnerEnforced))),
+				cl: NewACLClient(fake.MockBucketClient{}),
+			},
+			want: want{
+				status: Updated,
+			},
+		},
+		"CannedACLExpanded": {
+			args: args{
+				b:  s3Testing.Bucket(withACL(awsclient.String("public-read"), nil)),
+				cl: NewACLClient(fake.MockBucketClient{MockGetBucketAcl: getACL(allUsersGrant(types.PermissionRead), ownerGrant())}),
+			},
+			want: want{
+				status: Updated,
+			},
+		},
+		"CannedACLDiffers": {
+			args: args{
+				b:  s3Testing.Bucket(withACL(awsclient.String("private"), nil)),
+				cl: NewACLClient(fake.MockBucketClient{MockGetBucketAcl: getACL(ownerGrant(), allUsersGrant(types.PermissionRead))}),
+			},
+			want: want{
+				status: NeedsUpdate,
+			},
+		},
+		"ExplicitGrants": {
+			args: args{
+				b: s3Testing.Bucket(withACL(nil, awsclient.String(`id="1234", uri="`+groupAllUsers+`"`))),
+				cl: NewACLClient(fake.MockBucketClient{MockGetBucketAcl: getACL(
+					allUsersGrant(types.PermissionRead),
+					types.Grant{Grantee: &types.Grantee{Type: types.TypeCanonicalUser, ID: awsclient.String("1234")}, Permission: types.PermissionRead},
+				)}),
+			},
+			want: want{
+				status: Updated,
+			},
+		},
+		"InvalidGrant": {
+			args: args{
+				b:  s3Testing.Bucket(withACL(nil, awsclient.String("1234"))),
+				cl: NewACLClient(fake.MockBucketClient{MockGetBucketAcl: getACL(ownerGrant())}),
+			},
+			want: want{
+				status: NeedsUpdate,
+				err:    errors.Errorf(aclInvalidGrant, "1234", "grantRead"),
+			},
+		},
+		"EmailAddressGrantee": {
+			args: args{
+				b:  s3Testing.Bucket(withACL(nil, awsclient.String(`emailAddress="user@example.com"`))),
+				cl: NewACLClient(fake.MockBucketClient{MockGetBucketAcl: getACL(ownerGrant())}),
+			},
+			want: want{
+				status: NeedsUpdate,
+				err:    errors.Errorf(aclEmailGrantee, `emailAddress="user@example.com"`, "grantRead"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			status, err := tc.args.cl.Observe(context.Background(), tc.args.b)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.status, status); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestACLCreateOrUpdate(t *testing.T) {
+	type args struct {
+		cl *ACLClient
+		b  *v1beta1.Bucket
+	}
+
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"Error": {
+			args: args{
+				b: s3Testing.Bucket(),
+				cl: NewACLClient(fake.MockBucketClient{
+					MockPutBucketAcl: func(ctx context.Context, input *s3.PutBucketAclInput, opts []func(*s3.Options)) (*s3.PutBucketAclOutput, error) {
+						return nil, errBoom
+					},
+				}),
+			},
+			want: want{
+				err: awsclient.Wrap(errBoom, aclPutFailed),
+			},
+		},
+		"ACLsDisabled": {
+			args: args{
+				b:  s3Testing.Bucket(s3Testing.WithOwnershipControls(generateOwnershipControls(clientss3.ObjectOwnershipBucketOwnerEnforced))),
+				cl: NewACLClient(fake.MockBucketClient{}),
+			},
+			want: want{},
+		},
+		"EmailAddressGrantee": {
+			args: args{
+				b:  s3Testing.Bucket(withACL(nil, awsclient.String(`emailAddress="user@example.com"`))),
+				cl: NewACLClient(fake.MockBucketClient{}),
+			},
+			want: want{
+				err: errors.Errorf(aclEmailGrantee, `emailAddress="user@example.com"`, "grantRead"),
+			},
+		},
+		"Success": {
+			args: args{
+				b: s3Testing.Bucket(withACL(awsclient.String("public-read"), nil)),
+				cl: NewACLClient(fake.MockBucketClient{
+					MockPutBucketAcl: func(ctx context.Context, input *s3.PutBucketAclInput, opts []func(*s3.Options)) (*s3.PutBucketAclOutput, error) {
+						if input.ACL != types.BucketCannedACLPublicRead {
+							return nil, errBoom
+						}
+						return &s3.PutBucketAclOutput{}, nil
+					},
+				}),
+			},
+			want: want{},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.args.cl.CreateOrUpdate(context.Background(), tc.args.b)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestACLDelete(t *testing.T) {
+	var got types.BucketCannedACL
+	cl := NewACLClient(fake.MockBucketClient{
+		MockPutBucketAcl: func(ctx context.Context, input *s3.PutBucketAclInput, opts []func(*s3.Options)) (*s3.PutBucketAclOutput, error) {
+			got = input.ACL
+			return &s3.PutBucketAclOutput{}, nil
+		},
+	})
+	if err := cl.Delete(context.Background(), s3Testing.Bucket(withACL(nil, nil))); err != nil {
+		t.Fatalf("Delete(...): unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(types.BucketCannedACLPrivate, got); diff != "" {
+		t.Errorf("r: -want, +got:\n%s", diff)
+	}
+}
+
+func TestACLCapturePrior(t *testing.T) {
+	type want struct {
+		params *v1beta1.BucketParameters
+		err    error
+	}
+
+	cases := map[string]struct {
+		b  *v1beta1.Bucket
+		cl *ACLClient
+		want
+	}{
+		"ACLsDisabled": {
+			b: s3Testing.Bucket(func(b *v1beta1.Bucket) {
+				b.Spec.ForProvider.OwnershipControls = &v1beta1.OwnershipControls{Rules: []v1beta1.OwnershipControlsRule{{ObjectOwnership: clientss3.ObjectOwnershipBucketOwnerEnforced}}}
+			}),
+			cl: NewACLClient(fake.MockBucketClient{}),
+		},
+		"Error": {
+			b: s3Testing.Bucket(),
+			cl: NewACLClient(fake.MockBucketClient{
+				MockGetBucketAcl: func(ctx context.Context, input *s3.GetBucketAclInput, opts []func(*s3.Options)) (*s3.GetBucketAclOutput, error) {
+					return nil, errBoom
+				},
+			}),
+			want: want{
+				err: awsclient.Wrap(errBoom, aclGetFailed),
+			},
+		},
+		"Grants": {
+			b: s3Testing.Bucket(withACL(awsclient.String("private"), nil)),
+			cl: NewACLClient(fake.MockBucketClient{
+				MockGetBucketAcl: getACL(ownerGrant(), allUsersGrant(types.PermissionRead), allUsersGrant(types.PermissionWrite),
+					types.Grant{Grantee: &types.Grantee{Type: types.TypeCanonicalUser, ID: awsclient.String("1234")}, Permission: types.PermissionRead}),
+			}),
+			want: want{
+				params: &v1beta1.BucketParameters{
+					LocationConstraint: s3Testing.Bucket().Spec.ForProvider.LocationConstraint,
+					GrantFullControl:   awsclient.String(`id="owner"`),
+					GrantRead:          awsclient.String(`uri="` + groupAllUsers + `", id="1234"`),
+					GrantWrite:         awsclient.String(`uri="` + groupAllUsers + `"`),
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			prior, err := tc.cl.CapturePrior(context.Background(), tc.b)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			var got *v1beta1.BucketParameters
+			if prior != nil {
+				got = &prior.Spec.ForProvider
+			}
+			if diff := cmp.Diff(tc.want.params, got); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
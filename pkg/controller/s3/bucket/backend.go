@@ -0,0 +1,68 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bucket
+
+import (
+	"reflect"
+)
+
+// A Backend is a storage service that serves the S3 API.
+type Backend string
+
+// Backends a ProviderConfig may use.
+const (
+	BackendAWS   Backend = "AWS"
+	BackendMinIO Backend = "MinIO"
+	BackendCeph  Backend = "Ceph"
+)
+
+// unsupportedSubresources are the subresources S3 compatible backends are
+// known not to implement, by the type of their clients. Subresources missing
+// here are detected when the backend responds with NotImplemented.
+var unsupportedSubresources = map[Backend][]reflect.Type{
+	BackendMinIO: {
+		reflect.TypeOf(&AccelerateConfigurationClient{}),
+		reflect.TypeOf(&LoggingConfigurationClient{}),
+		reflect.TypeOf(&RequestPaymentConfigurationClient{}),
+		reflect.TypeOf(&WebsiteConfigurationClient{}),
+	},
+	BackendCeph: {
+		reflect.TypeOf(&AccelerateConfigurationClient{}),
+	},
+}
+
+// FilterSupported splits the supplied clients into those whose subresources
+// the backend implements and those it does not, keeping their order.
+func FilterSupported(backend Backend, clients []SubresourceClient) (supported, unsupported []SubresourceClient) {
+	for _, c := range clients {
+		if isUnsupported(backend, c) {
+			unsupported = append(unsupported, c)
+			continue
+		}
+		supported = append(supported, c)
+	}
+	return supported, unsupported
+}
+
+func isUnsupported(backend Backend, c SubresourceClient) bool {
+	for _, t := range unsupportedSubresources[backend] {
+		if reflect.TypeOf(c) == t {
+			return true
+		}
+	}
+	return false
+}
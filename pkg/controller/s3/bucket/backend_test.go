@@ -0,0 +1,79 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bucket
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane/provider-aws/pkg/clients/s3/fake"
+)
+
+func TestFilterSupported(t *testing.T) {
+	cl := fake.MockBucketClient{}
+	accelerate := NewAccelerateConfigurationClient(cl)
+	cors := NewCORSConfigurationClient(cl)
+	logging := NewLoggingConfigurationClient(cl, nil, nil)
+	website := NewWebsiteConfigurationClient(cl)
+	clients := []SubresourceClient{accelerate, cors, logging, website}
+
+	type want struct {
+		supported   []SubresourceClient
+		unsupported []SubresourceClient
+	}
+
+	cases := map[string]struct {
+		backend Backend
+		want
+	}{
+		"Default": {
+			want: want{supported: clients},
+		},
+		"AWS": {
+			backend: BackendAWS,
+			want:    want{supported: clients},
+		},
+		"MinIO": {
+			backend: BackendMinIO,
+			want: want{
+				supported:   []SubresourceClient{cors},
+				unsupported: []SubresourceClient{accelerate, logging, website},
+			},
+		},
+		"Ceph": {
+			backend: BackendCeph,
+			want: want{
+				supported:   []SubresourceClient{cors, logging, website},
+				unsupported: []SubresourceClient{accelerate},
+			},
+		},
+	}
+
+	same := cmp.Comparer(func(a, b SubresourceClient) bool { return a == b })
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			supported, unsupported := FilterSupported(tc.backend, clients)
+			if diff := cmp.Diff(tc.want.supported, supported, same); diff != "" {
+				t.Errorf("FilterSupported(...): supported: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.unsupported, unsupported, same); diff != "" {
+				t.Errorf("FilterSupported(...): unsupported: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bucket
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/provider-aws/apis/s3/v1beta1"
+)
+
+const (
+	// BaselineSSEConfigurationKey is the key of the baseline ConfigMap that
+	// holds the JSON encoded server side encryption configuration.
+	BaselineSSEConfigurationKey = "serverSideEncryptionConfiguration"
+	// BaselineTaggingKey is the key of the baseline ConfigMap that holds the
+	// JSON encoded tagging.
+	BaselineTaggingKey = "tagging"
+
+	baselineGetFailed    = "cannot get baseline ConfigMap"
+	baselineDecodeFailed = "cannot decode %s of baseline ConfigMap"
+)
+
+// baseline is the configuration read from the ConfigMap a Bucket references.
+type baseline struct {
+	sse     *v1beta1.ServerSideEncryptionConfiguration
+	tagging *v1beta1.Tagging
+}
+
+// getBaseline returns the baseline configuration referenced by the given
+// bucket, or an empty baseline if it references none.
+func getBaseline(ctx context.Context, kube client.Client, bucket *v1beta1.Bucket) (baseline, error) {
+	ref := bucket.Spec.ForProvider.BaselineConfigMapRef
+	if ref == nil {
+		return baseline{}, nil
+	}
+	cm := &corev1.ConfigMap{}
+	if err := kube.Get(ctx, k8stypes.NamespacedName{Name: ref.Name, Namespace: ref.Namespace}, cm); err != nil {
+		return baseline{}, errors.Wrap(err, baselineGetFailed)
+	}
+	b := baseline{}
+	if v, ok := cm.Data[BaselineSSEConfigurationKey]; ok {
+		b.sse = &v1beta1.ServerSideEncryptionConfiguration{}
+		if err := json.Unmarshal([]byte(v), b.sse); err != nil {
+			return baseline{}, errors.Wrapf(err, baselineDecodeFailed, BaselineSSEConfigurationKey)
+		}
+	}
+	if v, ok := cm.Data[BaselineTaggingKey]; ok {
+		b.tagging = &v1beta1.Tagging{}
+		if err := json.Unmarshal([]byte(v), b.tagging); err != nil {
+			return baseline{}, errors.Wrapf(err, baselineDecodeFailed, BaselineTaggingKey)
+		}
+	}
+	return b, nil
+}
+
+// mergeSSE returns the server side encryption configuration of the spec if
+// there is one and the baseline configuration otherwise.
+func mergeSSE(spec, base *v1beta1.ServerSideEncryptionConfiguration) *v1beta1.ServerSideEncryptionConfiguration {
+	if spec != nil {
+		return spec
+	}
+	return base
+}
+
+// mergeTagging returns the tags of the spec followed by the baseline tags
+// whose keys the spec does not set. It returns nil if neither has tagging.
+func mergeTagging(spec, base *v1beta1.Tagging) *v1beta1.Tagging {
+	if base == nil {
+		return spec
+	}
+	if spec == nil {
+		return base
+	}
+	merged := spec.DeepCopy()
+	keys := make(map[string]bool, len(spec.TagSet))
+	for _, t := range spec.TagSet {
+		keys[t.Key] = true
+	}
+	for _, t := range base.TagSet {
+		if !keys[t.Key] {
+			merged.TagSet = append(merged.TagSet, t)
+		}
+	}
+	return merged
+}
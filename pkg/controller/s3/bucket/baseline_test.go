@@ -0,0 +1,256 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bucket
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go/document"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/provider-aws/apis/s3/v1beta1"
+	awsclient "github.com/crossplane/provider-aws/pkg/clients"
+	"github.com/crossplane/provider-aws/pkg/clients/s3/fake"
+	s3Testing "github.com/crossplane/provider-aws/pkg/controller/s3/testing"
+)
+
+var (
+	baselineRef = &v1beta1.ConfigMapReference{Name: "baseline", Namespace: "platform"}
+	baselineSSE = &v1beta1.ServerSideEncryptionConfiguration{
+		Rules: []v1beta1.ServerSideEncryptionRule{{
+			ApplyServerSideEncryptionByDefault: v1beta1.ServerSideEncryptionByDefault{SSEAlgorithm: sseAlgo},
+		}},
+	}
+)
+
+func baselineConfigMap(data map[string]string) *test.MockClient {
+	return &test.MockClient{
+		MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+			if key.Name != baselineRef.Name || key.Namespace != baselineRef.Namespace {
+				return errBoom
+			}
+			obj.(*corev1.ConfigMap).Data = data
+			return nil
+		},
+	}
+}
+
+func TestGetBaseline(t *testing.T) {
+	type want struct {
+		baseline baseline
+		err      error
+	}
+
+	cases := map[string]struct {
+		kube client.Client
+		ref  *v1beta1.ConfigMapReference
+		want want
+	}{
+		"NoReference": {},
+		"GetError": {
+			kube: &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			ref:  baselineRef,
+			want: want{err: errors.Wrap(errBoom, baselineGetFailed)},
+		},
+		"DecodeError": {
+			kube: baselineConfigMap(map[string]string{BaselineTaggingKey: "{"}),
+			ref:  baselineRef,
+			want: want{err: errors.Wrapf(errors.New("unexpected end of JSON input"), baselineDecodeFailed, BaselineTaggingKey)},
+		},
+		"Decoded": {
+			kube: baselineConfigMap(map[string]string{
+				BaselineSSEConfigurationKey: `{"rules":[{"applyServerSideEncryptionByDefault":{"sseAlgorithm":"AES256"}}]}`,
+				BaselineTaggingKey:          `{"tagSet":[{"key":"test","value":"value"}]}`,
+			}),
+			ref: baselineRef,
+			want: want{baseline: baseline{
+				sse:     baselineSSE,
+				tagging: &v1beta1.Tagging{TagSet: []v1beta1.Tag{tag}},
+			}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := getBaseline(context.Background(), tc.kube, s3Testing.Bucket(s3Testing.WithBaselineConfigMapRef(tc.ref)))
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.baseline, got, cmp.AllowUnexported(baseline{})); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMergeTagging(t *testing.T) {
+	override := v1beta1.Tag{Key: tag.Key, Value: "override"}
+
+	cases := map[string]struct {
+		spec *v1beta1.Tagging
+		base *v1beta1.Tagging
+		want *v1beta1.Tagging
+	}{
+		"Neither": {},
+		"SpecOnly": {
+			spec: &v1beta1.Tagging{TagSet: []v1beta1.Tag{tag}},
+			want: &v1beta1.Tagging{TagSet: []v1beta1.Tag{tag}},
+		},
+		"BaselineOnly": {
+			base: &v1beta1.Tagging{TagSet: []v1beta1.Tag{tag}},
+			want: &v1beta1.Tagging{TagSet: []v1beta1.Tag{tag}},
+		},
+		"SpecTakesPrecedence": {
+			spec: &v1beta1.Tagging{TagSet: []v1beta1.Tag{override, tag1}},
+			base: &v1beta1.Tagging{TagSet: []v1beta1.Tag{tag, tag2}},
+			want: &v1beta1.Tagging{TagSet: []v1beta1.Tag{override, tag1, tag2}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, mergeTagging(tc.spec, tc.base)); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMergeSSE(t *testing.T) {
+	cases := map[string]struct {
+		spec *v1beta1.ServerSideEncryptionConfiguration
+		base *v1beta1.ServerSideEncryptionConfiguration
+		want *v1beta1.ServerSideEncryptionConfiguration
+	}{
+		"Neither": {},
+		"BaselineOnly": {
+			base: generateSSEConfig(),
+			want: generateSSEConfig(),
+		},
+		"SpecTakesPrecedence": {
+			spec: generateKMSSSEConfig(),
+			base: generateSSEConfig(),
+			want: generateKMSSSEConfig(),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, mergeSSE(tc.spec, tc.base)); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestBaselineCreateOrUpdate(t *testing.T) {
+	kube := baselineConfigMap(map[string]string{
+		BaselineSSEConfigurationKey: `{"rules":[{"applyServerSideEncryptionByDefault":{"sseAlgorithm":"AES256"}}]}`,
+		BaselineTaggingKey:          `{"tagSet":[{"key":"test","value":"value"},{"key":"abc","value":"abc"}]}`,
+	})
+
+	t.Run("Tagging", func(t *testing.T) {
+		var got []types.Tag
+		cl := NewTaggingConfigurationClient(fake.MockBucketClient{
+			MockPutBucketTagging: func(ctx context.Context, input *s3.PutBucketTaggingInput, opts []func(*s3.Options)) (*s3.PutBucketTaggingOutput, error) {
+				got = input.Tagging.TagSet
+				return &s3.PutBucketTaggingOutput{}, nil
+			},
+		}, kube)
+		b := s3Testing.Bucket(
+			s3Testing.WithBaselineConfigMapRef(baselineRef),
+			s3Testing.WithTaggingConfig(&v1beta1.Tagging{TagSet: []v1beta1.Tag{{Key: "test", Value: "override"}, tag1}}),
+		)
+		if err := cl.CreateOrUpdate(context.Background(), b); err != nil {
+			t.Fatalf("CreateOrUpdate(...): %s", err)
+		}
+		want := []types.Tag{
+			{Key: awsclient.String("test"), Value: awsclient.String("override")},
+			awsTag1,
+			awsTag2,
+		}
+		if diff := cmp.Diff(want, got, cmpopts.IgnoreTypes(document.NoSerde{})); diff != "" {
+			t.Errorf("r: -want, +got:\n%s", diff)
+		}
+	})
+
+	t.Run("SSE", func(t *testing.T) {
+		var got *types.ServerSideEncryptionConfiguration
+		cl := NewSSEConfigurationClient(fake.MockBucketClient{
+			MockGetBucketEncryption: sseNotFound,
+			MockPutBucketEncryption: func(ctx context.Context, input *s3.PutBucketEncryptionInput, opts []func(*s3.Options)) (*s3.PutBucketEncryptionOutput, error) {
+				got = input.ServerSideEncryptionConfiguration
+				return &s3.PutBucketEncryptionOutput{}, nil
+			},
+		}, nil, kube, nil, nil)
+		if err := cl.CreateOrUpdate(context.Background(), s3Testing.Bucket(s3Testing.WithBaselineConfigMapRef(baselineRef))); err != nil {
+			t.Fatalf("CreateOrUpdate(...): %s", err)
+		}
+		want := &types.ServerSideEncryptionConfiguration{
+			Rules: []types.ServerSideEncryptionRule{{
+				ApplyServerSideEncryptionByDefault: &types.ServerSideEncryptionByDefault{SSEAlgorithm: types.ServerSideEncryptionAes256},
+			}},
+		}
+		if diff := cmp.Diff(want, got, cmpopts.IgnoreTypes(document.NoSerde{})); diff != "" {
+			t.Errorf("r: -want, +got:\n%s", diff)
+		}
+	})
+}
+
+func TestBaselineObserve(t *testing.T) {
+	kube := baselineConfigMap(map[string]string{
+		BaselineTaggingKey: `{"tagSet":[{"key":"test","value":"value"},{"key":"xyz","value":"abc"},{"key":"abc","value":"abc"}]}`,
+	})
+
+	cases := map[string]struct {
+		external []types.Tag
+		want     ResourceStatus
+	}{
+		"NoUpdateBaselineApplied": {
+			external: awsTags,
+			want:     Updated,
+		},
+		"UpdateNeededBaselineMissing": {
+			want: NeedsUpdate,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cl := NewTaggingConfigurationClient(fake.MockBucketClient{
+				MockGetBucketTagging: func(ctx context.Context, input *s3.GetBucketTaggingInput, opts []func(*s3.Options)) (*s3.GetBucketTaggingOutput, error) {
+					return &s3.GetBucketTaggingOutput{TagSet: tc.external}, nil
+				},
+			}, kube)
+			got, err := cl.Observe(context.Background(), s3Testing.Bucket(s3Testing.WithBaselineConfigMapRef(baselineRef)))
+			if err != nil {
+				t.Fatalf("Observe(...): %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,298 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bucket
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awss3 "github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/provider-aws/apis/s3/v1beta1"
+	awsclient "github.com/crossplane/provider-aws/pkg/clients"
+	"github.com/crossplane/provider-aws/pkg/clients/s3"
+)
+
+const (
+	intelligentTieringListFailed     = "cannot list Bucket intelligent tiering configurations"
+	intelligentTieringPutFailed      = "cannot put Bucket intelligent tiering configuration %s"
+	intelligentTieringDeleteFailed   = "cannot delete Bucket intelligent tiering configuration %s"
+	intelligentTieringDuplicateID    = "duplicate intelligent tiering configuration ID %q"
+	intelligentTieringUnknownTier    = "intelligent tiering configuration %q: unknown access tier %q"
+	intelligentTieringDaysOutOfRange = "intelligent tiering configuration %q: %s days must be between %d and %d, got %d"
+
+	// maxIntelligentTieringDays is the number of days of no access after
+	// which AWS moves objects to any access tier at the latest.
+	maxIntelligentTieringDays = 730
+
+	// maxIntelligentTieringConfigurations is the number of intelligent
+	// tiering configurations AWS allows per bucket.
+	maxIntelligentTieringConfigurations = 1000
+)
+
+// minIntelligentTieringDays are the numbers of days of no access after which
+// AWS moves objects to an access tier at the earliest.
+var minIntelligentTieringDays = map[types.IntelligentTieringAccessTier]int32{
+	types.IntelligentTieringAccessTierArchiveAccess:     90,
+	types.IntelligentTieringAccessTierDeepArchiveAccess: 180,
+}
+
+// IntelligentTieringConfigurationClient is the client for API methods and reconciling the IntelligentTieringConfigurations
+type IntelligentTieringConfigurationClient struct {
+	client s3.BucketClient
+}
+
+// NewIntelligentTieringConfigurationClient creates the client for Intelligent Tiering Configurations
+func NewIntelligentTieringConfigurationClient(client s3.BucketClient) *IntelligentTieringConfigurationClient {
+	return &IntelligentTieringConfigurationClient{client: client}
+}
+
+// Observe checks if the resource exists and if it matches the local
+// configuration. Intelligent tiering configurations are keyed by their ID, so
+// they need an update if a configured ID is missing or any of its fields
+// differ, and a deletion if the bucket has an intelligent tiering
+// configuration that is not configured.
+func (in *IntelligentTieringConfigurationClient) Observe(ctx context.Context, bucket *v1beta1.Bucket) (ResourceStatus, error) {
+	return in.configurations().observe(ctx, bucket)
+}
+
+// CreateOrUpdate puts the intelligent tiering configurations that are missing
+// or differ. Intelligent tiering configurations that are not configured are
+// removed by Delete.
+func (in *IntelligentTieringConfigurationClient) CreateOrUpdate(ctx context.Context, bucket *v1beta1.Bucket) error {
+	config := bucket.Spec.ForProvider.IntelligentTieringConfigurations
+	if len(config) == 0 {
+		return nil
+	}
+	if err := validateIntelligentTieringConfigurations(config); err != nil {
+		return err
+	}
+	return in.configurations().createOrUpdate(ctx, bucket)
+}
+
+// Delete deletes the intelligent tiering configurations of the bucket that
+// are not configured, i.e. all of them if none is configured.
+func (in *IntelligentTieringConfigurationClient) Delete(ctx context.Context, bucket *v1beta1.Bucket) error {
+	return in.configurations().deleteUnconfigured(ctx, bucket)
+}
+
+// configurations returns the keyedConfigurations of the intelligent tiering
+// configurations of a bucket.
+func (in *IntelligentTieringConfigurationClient) configurations() keyedConfigurations {
+	return keyedConfigurations{
+		changes: func(ctx context.Context, bucket *v1beta1.Bucket) ([]int, []string, error) {
+			external, err := in.list(ctx, bucket)
+			if err != nil {
+				return nil, nil, err
+			}
+			put, remove := intelligentTieringChanges(bucket.Spec.ForProvider.IntelligentTieringConfigurations, external)
+			return put, remove, nil
+		},
+		put: func(ctx context.Context, bucket *v1beta1.Bucket, i int) error {
+			c := bucket.Spec.ForProvider.IntelligentTieringConfigurations[i]
+			_, err := in.client.PutBucketIntelligentTieringConfiguration(ctx, &awss3.PutBucketIntelligentTieringConfigurationInput{
+				Bucket:                          awsclient.String(meta.GetExternalName(bucket)),
+				Id:                              awsclient.String(c.ID),
+				IntelligentTieringConfiguration: GenerateIntelligentTieringConfiguration(c),
+			})
+			return errors.Wrapf(err, intelligentTieringPutFailed, c.ID)
+		},
+		remove: func(ctx context.Context, bucket *v1beta1.Bucket, id string) error {
+			_, err := in.client.DeleteBucketIntelligentTieringConfiguration(ctx, &awss3.DeleteBucketIntelligentTieringConfigurationInput{
+				Bucket: awsclient.String(meta.GetExternalName(bucket)),
+				Id:     awsclient.String(id),
+			})
+			return errors.Wrapf(err, intelligentTieringDeleteFailed, id)
+		},
+	}
+}
+
+// LateInitialize is responsible for initializing the resource based on the external value
+func (in *IntelligentTieringConfigurationClient) LateInitialize(ctx context.Context, bucket *v1beta1.Bucket) error {
+	external, err := in.list(ctx, bucket)
+	if err != nil {
+		return err
+	}
+	fp := &bucket.Spec.ForProvider
+	if len(external) == 0 || fp.IntelligentTieringConfigurations != nil {
+		return nil
+	}
+	fp.IntelligentTieringConfigurations = make([]v1beta1.IntelligentTieringConfiguration, len(external))
+	for i, c := range external {
+		fp.IntelligentTieringConfigurations[i] = GenerateLocalIntelligentTieringConfiguration(c)
+	}
+	return nil
+}
+
+// CapturePrior returns a Bucket with the intelligent tiering configurations currently in AWS.
+func (in *IntelligentTieringConfigurationClient) CapturePrior(ctx context.Context, bucket *v1beta1.Bucket) (*v1beta1.Bucket, error) {
+	return lateInitializedPrior(ctx, in, bucket)
+}
+
+// SubresourceExists checks if the subresource this controller manages currently exists
+func (in *IntelligentTieringConfigurationClient) SubresourceExists(bucket *v1beta1.Bucket) bool {
+	return len(bucket.Spec.ForProvider.IntelligentTieringConfigurations) != 0
+}
+
+// Quotas of the intelligent tiering configurations. Intelligent tiering
+// configurations that are not configured count until they are deleted.
+func (in *IntelligentTieringConfigurationClient) Quotas() []Quota {
+	return []Quota{{
+		Description: "intelligent tiering configurations per bucket",
+		Limit:       maxIntelligentTieringConfigurations,
+		Usage: func(ctx context.Context, bucket *v1beta1.Bucket) (int, error) {
+			return in.configurations().usage(ctx, bucket, len(bucket.Spec.ForProvider.IntelligentTieringConfigurations))
+		},
+	}}
+}
+
+// list returns all intelligent tiering configurations of the bucket,
+// following the continuation tokens of truncated responses.
+func (in *IntelligentTieringConfigurationClient) list(ctx context.Context, bucket *v1beta1.Bucket) ([]types.IntelligentTieringConfiguration, error) {
+	input := &awss3.ListBucketIntelligentTieringConfigurationsInput{Bucket: awsclient.String(meta.GetExternalName(bucket))}
+	var configs []types.IntelligentTieringConfiguration
+	for {
+		out, err := in.client.ListBucketIntelligentTieringConfigurations(ctx, input)
+		if err != nil {
+			return nil, awsclient.Wrap(err, intelligentTieringListFailed)
+		}
+		if out == nil {
+			return configs, nil
+		}
+		configs = append(configs, out.IntelligentTieringConfigurationList...)
+		if !out.IsTruncated || out.NextContinuationToken == nil {
+			return configs, nil
+		}
+		input = &awss3.ListBucketIntelligentTieringConfigurationsInput{Bucket: input.Bucket, ContinuationToken: out.NextContinuationToken}
+	}
+}
+
+// intelligentTieringChanges returns the indices of the desired intelligent
+// tiering configurations that are missing from or differ in the external
+// ones, and the sorted IDs of the external intelligent tiering configurations
+// that are not desired.
+func intelligentTieringChanges(desired []v1beta1.IntelligentTieringConfiguration, external []types.IntelligentTieringConfiguration) ([]int, []string) {
+	desiredIDs := make([]string, len(desired))
+	for i, c := range desired {
+		desiredIDs[i] = c.ID
+	}
+	current := make([]v1beta1.IntelligentTieringConfiguration, len(external))
+	externalIDs := make([]string, len(external))
+	for i, c := range external {
+		current[i] = GenerateLocalIntelligentTieringConfiguration(c)
+		externalIDs[i] = current[i].ID
+	}
+	return diffByID(desiredIDs, externalIDs, func(d, e int) bool {
+		a, b := desired[d], current[e]
+		a.Filter, b.Filter = normalizeIntelligentTieringFilter(a.Filter), normalizeIntelligentTieringFilter(b.Filter)
+		return cmp.Equal(a, b, cmpopts.EquateEmpty(),
+			cmpopts.SortSlices(func(a, b v1beta1.Tag) bool { return a.Key < b.Key }),
+			cmpopts.SortSlices(func(a, b v1beta1.Tiering) bool { return a.AccessTier < b.AccessTier }))
+	})
+}
+
+// normalizeIntelligentTieringFilter returns nil for a filter that selects
+// nothing, which GenerateIntelligentTieringConfiguration sends as no filter at
+// all.
+func normalizeIntelligentTieringFilter(f *v1beta1.IntelligentTieringFilter) *v1beta1.IntelligentTieringFilter {
+	if f == nil || cmp.Equal(*f, v1beta1.IntelligentTieringFilter{}) {
+		return nil
+	}
+	return f
+}
+
+// validateIntelligentTieringConfigurations returns an error if two
+// intelligent tiering configurations have the same ID, since AWS keys them by
+// it, or if the days of a tiering are out of the range AWS allows for its
+// access tier: 90 to 730 days for ARCHIVE_ACCESS and 180 to 730 days for
+// DEEP_ARCHIVE_ACCESS.
+func validateIntelligentTieringConfigurations(configs []v1beta1.IntelligentTieringConfiguration) error {
+	seen := make(map[string]bool, len(configs))
+	for _, c := range configs {
+		if seen[c.ID] {
+			return errors.Errorf(intelligentTieringDuplicateID, c.ID)
+		}
+		seen[c.ID] = true
+		for _, t := range c.Tierings {
+			min, ok := minIntelligentTieringDays[types.IntelligentTieringAccessTier(t.AccessTier)]
+			if !ok {
+				return errors.Errorf(intelligentTieringUnknownTier, c.ID, t.AccessTier)
+			}
+			if t.Days < min || t.Days > maxIntelligentTieringDays {
+				return errors.Errorf(intelligentTieringDaysOutOfRange, c.ID, t.AccessTier, min, maxIntelligentTieringDays, t.Days)
+			}
+		}
+	}
+	return nil
+}
+
+// GenerateIntelligentTieringConfiguration creates the types.IntelligentTieringConfiguration for the AWS SDK
+func GenerateIntelligentTieringConfiguration(config v1beta1.IntelligentTieringConfiguration) *types.IntelligentTieringConfiguration {
+	out := &types.IntelligentTieringConfiguration{
+		Id:     awsclient.String(config.ID),
+		Status: types.IntelligentTieringStatus(config.Status),
+	}
+	if config.Tierings != nil {
+		out.Tierings = make([]types.Tiering, len(config.Tierings))
+		for i, t := range config.Tierings {
+			out.Tierings[i] = types.Tiering{AccessTier: types.IntelligentTieringAccessTier(t.AccessTier), Days: t.Days}
+		}
+	}
+	f := normalizeIntelligentTieringFilter(config.Filter)
+	if f == nil {
+		return out
+	}
+	out.Filter = &types.IntelligentTieringFilter{Prefix: f.Prefix}
+	if f.Tag != nil {
+		out.Filter.Tag = &types.Tag{Key: awsclient.String(f.Tag.Key), Value: awsclient.String(f.Tag.Value)}
+	}
+	if f.And != nil {
+		out.Filter.And = &types.IntelligentTieringAndOperator{Prefix: f.And.Prefix, Tags: s3.CopyTags(f.And.Tags)}
+	}
+	return out
+}
+
+// GenerateLocalIntelligentTieringConfiguration creates the v1beta1.IntelligentTieringConfiguration from the AWS SDK intelligent tiering configuration
+func GenerateLocalIntelligentTieringConfiguration(config types.IntelligentTieringConfiguration) v1beta1.IntelligentTieringConfiguration {
+	out := v1beta1.IntelligentTieringConfiguration{
+		ID:     aws.ToString(config.Id),
+		Status: string(config.Status),
+	}
+	if config.Tierings != nil {
+		out.Tierings = make([]v1beta1.Tiering, len(config.Tierings))
+		for i, t := range config.Tierings {
+			out.Tierings[i] = v1beta1.Tiering{AccessTier: string(t.AccessTier), Days: t.Days}
+		}
+	}
+	f := config.Filter
+	if f == nil {
+		return out
+	}
+	out.Filter = &v1beta1.IntelligentTieringFilter{Prefix: f.Prefix}
+	if f.Tag != nil {
+		out.Filter.Tag = &v1beta1.Tag{Key: aws.ToString(f.Tag.Key), Value: aws.ToString(f.Tag.Value)}
+	}
+	if f.And != nil {
+		out.Filter.And = &v1beta1.IntelligentTieringAndOperator{Prefix: f.And.Prefix, Tags: s3.CopyAWSTags(f.And.Tags)}
+	}
+	return out
+}
@@ -0,0 +1,469 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bucket
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/provider-aws/apis/s3/v1beta1"
+	awsclient "github.com/crossplane/provider-aws/pkg/clients"
+	"github.com/crossplane/provider-aws/pkg/clients/s3/fake"
+	s3Testing "github.com/crossplane/provider-aws/pkg/controller/s3/testing"
+)
+
+var (
+	_ SubresourceClient = &IntelligentTieringConfigurationClient{}
+	_ QuotaClient       = &IntelligentTieringConfigurationClient{}
+)
+
+func withIntelligentTiering(configs ...v1beta1.IntelligentTieringConfiguration) s3Testing.BucketModifier {
+	return func(b *v1beta1.Bucket) {
+		b.Spec.ForProvider.IntelligentTieringConfigurations = configs
+	}
+}
+
+func intelligentTiering(id string, m ...func(*v1beta1.IntelligentTieringConfiguration)) v1beta1.IntelligentTieringConfiguration {
+	c := v1beta1.IntelligentTieringConfiguration{
+		ID:     id,
+		Status: "Enabled",
+		Tierings: []v1beta1.Tiering{
+			{AccessTier: "ARCHIVE_ACCESS", Days: 90},
+			{AccessTier: "DEEP_ARCHIVE_ACCESS", Days: 180},
+		},
+		Filter: &v1beta1.IntelligentTieringFilter{Prefix: aws.String(id + "/")},
+	}
+	for _, f := range m {
+		f(&c)
+	}
+	return c
+}
+
+func awsIntelligentTiering(id string, m ...func(*types.IntelligentTieringConfiguration)) types.IntelligentTieringConfiguration {
+	c := types.IntelligentTieringConfiguration{
+		Id:     aws.String(id),
+		Status: types.IntelligentTieringStatusEnabled,
+		Tierings: []types.Tiering{
+			{AccessTier: types.IntelligentTieringAccessTierArchiveAccess, Days: 90},
+			{AccessTier: types.IntelligentTieringAccessTierDeepArchiveAccess, Days: 180},
+		},
+		Filter: &types.IntelligentTieringFilter{Prefix: aws.String(id + "/")},
+	}
+	for _, f := range m {
+		f(&c)
+	}
+	return c
+}
+
+func listIntelligentTiering(configs ...types.IntelligentTieringConfiguration) func(ctx context.Context, input *s3.ListBucketIntelligentTieringConfigurationsInput, opts []func(*s3.Options)) (*s3.ListBucketIntelligentTieringConfigurationsOutput, error) {
+	return func(ctx context.Context, input *s3.ListBucketIntelligentTieringConfigurationsInput, opts []func(*s3.Options)) (*s3.ListBucketIntelligentTieringConfigurationsOutput, error) {
+		return &s3.ListBucketIntelligentTieringConfigurationsOutput{IntelligentTieringConfigurationList: configs}, nil
+	}
+}
+
+func TestIntelligentTieringObserve(t *testing.T) {
+	type args struct {
+		cl *IntelligentTieringConfigurationClient
+		b  *v1beta1.Bucket
+	}
+
+	type want struct {
+		status ResourceStatus
+		err    error
+	}
+
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"Error": {
+			args: args{
+				b: s3Testing.Bucket(withIntelligentTiering(intelligentTiering("archive"))),
+				cl: NewIntelligentTieringConfigurationClient(fake.MockBucketClient{
+					MockListBucketIntelligentTieringConfigurations: func(ctx context.Context, input *s3.ListBucketIntelligentTieringConfigurationsInput, opts []func(*s3.Options)) (*s3.ListBucketIntelligentTieringConfigurationsOutput, error) {
+						return nil, errBoom
+					},
+				}),
+			},
+			want: want{
+				status: NeedsUpdate,
+				err:    awsclient.Wrap(errBoom, intelligentTieringListFailed),
+			},
+		},
+		"NoneConfigured": {
+			args: args{
+				b:  s3Testing.Bucket(),
+				cl: NewIntelligentTieringConfigurationClient(fake.MockBucketClient{MockListBucketIntelligentTieringConfigurations: listIntelligentTiering()}),
+			},
+			want: want{
+				status: Updated,
+			},
+		},
+		"NeedsDeletion": {
+			args: args{
+				b:  s3Testing.Bucket(),
+				cl: NewIntelligentTieringConfigurationClient(fake.MockBucketClient{MockListBucketIntelligentTieringConfigurations: listIntelligentTiering(awsIntelligentTiering("archive"))}),
+			},
+			want: want{
+				status: NeedsDeletion,
+			},
+		},
+		"MissingID": {
+			args: args{
+				b:  s3Testing.Bucket(withIntelligentTiering(intelligentTiering("archive"), intelligentTiering("logs"))),
+				cl: NewIntelligentTieringConfigurationClient(fake.MockBucketClient{MockListBucketIntelligentTieringConfigurations: listIntelligentTiering(awsIntelligentTiering("archive"))}),
+			},
+			want: want{
+				status: NeedsUpdate,
+			},
+		},
+		"DaysDiffer": {
+			args: args{
+				b: s3Testing.Bucket(withIntelligentTiering(intelligentTiering("archive", func(c *v1beta1.IntelligentTieringConfiguration) {
+					c.Tierings[0].Days = 120
+				}))),
+				cl: NewIntelligentTieringConfigurationClient(fake.MockBucketClient{MockListBucketIntelligentTieringConfigurations: listIntelligentTiering(awsIntelligentTiering("archive"))}),
+			},
+			want: want{
+				status: NeedsUpdate,
+			},
+		},
+		"FilterDiffers": {
+			args: args{
+				b: s3Testing.Bucket(withIntelligentTiering(intelligentTiering("archive", func(c *v1beta1.IntelligentTieringConfiguration) {
+					c.Filter = &v1beta1.IntelligentTieringFilter{Tag: &v1beta1.Tag{Key: "tier", Value: "archive"}}
+				}))),
+				cl: NewIntelligentTieringConfigurationClient(fake.MockBucketClient{MockListBucketIntelligentTieringConfigurations: listIntelligentTiering(awsIntelligentTiering("archive"))}),
+			},
+			want: want{
+				status: NeedsUpdate,
+			},
+		},
+		"NotConfiguredID": {
+			args: args{
+				b:  s3Testing.Bucket(withIntelligentTiering(intelligentTiering("archive"))),
+				cl: NewIntelligentTieringConfigurationClient(fake.MockBucketClient{MockListBucketIntelligentTieringConfigurations: listIntelligentTiering(awsIntelligentTiering("archive"), awsIntelligentTiering("stale"))}),
+			},
+			want: want{
+				status: NeedsDeletion,
+			},
+		},
+		"UpToDate": {
+			args: args{
+				b: s3Testing.Bucket(withIntelligentTiering(
+					intelligentTiering("archive"),
+					intelligentTiering("all", func(c *v1beta1.IntelligentTieringConfiguration) {
+						c.Filter = &v1beta1.IntelligentTieringFilter{}
+					}),
+					intelligentTiering("tagged", func(c *v1beta1.IntelligentTieringConfiguration) {
+						c.Filter = &v1beta1.IntelligentTieringFilter{And: &v1beta1.IntelligentTieringAndOperator{
+							Tags: []v1beta1.Tag{{Key: "a", Value: "1"}, {Key: "b", Value: "2"}},
+						}}
+					}),
+				)),
+				cl: NewIntelligentTieringConfigurationClient(fake.MockBucketClient{MockListBucketIntelligentTieringConfigurations: listIntelligentTiering(
+					awsIntelligentTiering("archive", func(c *types.IntelligentTieringConfiguration) {
+						c.Tierings[0], c.Tierings[1] = c.Tierings[1], c.Tierings[0]
+					}),
+					awsIntelligentTiering("all", func(c *types.IntelligentTieringConfiguration) {
+						c.Filter = nil
+					}),
+					awsIntelligentTiering("tagged", func(c *types.IntelligentTieringConfiguration) {
+						c.Filter = &types.IntelligentTieringFilter{And: &types.IntelligentTieringAndOperator{
+							Tags: []types.Tag{{Key: aws.String("b"), Value: aws.String("2")}, {Key: aws.String("a"), Value: aws.String("1")}},
+						}}
+					}),
+				)}),
+			},
+			want: want{
+				status: Updated,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			status, err := tc.args.cl.Observe(context.Background(), tc.args.b)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.status, status); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIntelligentTieringCreateOrUpdate(t *testing.T) {
+	type args struct {
+		b        *v1beta1.Bucket
+		external []types.IntelligentTieringConfiguration
+		putErr   error
+	}
+
+	type want struct {
+		err error
+		put []string
+	}
+
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"PutError": {
+			args: args{
+				b:      s3Testing.Bucket(withIntelligentTiering(intelligentTiering("archive"))),
+				putErr: errBoom,
+			},
+			want: want{
+				err: errors.Wrapf(errBoom, intelligentTieringPutFailed, "archive"),
+				put: []string{"archive"},
+			},
+		},
+		"DuplicateID": {
+			args: args{
+				b: s3Testing.Bucket(withIntelligentTiering(intelligentTiering("archive"), intelligentTiering("archive"))),
+			},
+			want: want{
+				err: errors.Errorf(intelligentTieringDuplicateID, "archive"),
+			},
+		},
+		"AddAndUpdate": {
+			args: args{
+				b: s3Testing.Bucket(withIntelligentTiering(intelligentTiering("archive"), intelligentTiering("logs"), intelligentTiering("same"))),
+				external: []types.IntelligentTieringConfiguration{
+					awsIntelligentTiering("archive", func(c *types.IntelligentTieringConfiguration) { c.Status = types.IntelligentTieringStatusDisabled }),
+					awsIntelligentTiering("same"),
+					awsIntelligentTiering("stale"),
+				},
+			},
+			want: want{
+				// Intelligent tiering configurations that are not configured
+				// are left to Delete.
+				put: []string{"archive", "logs"},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var put []string
+			cl := NewIntelligentTieringConfigurationClient(fake.MockBucketClient{
+				MockListBucketIntelligentTieringConfigurations: listIntelligentTiering(tc.args.external...),
+				MockPutBucketIntelligentTieringConfiguration: func(ctx context.Context, input *s3.PutBucketIntelligentTieringConfigurationInput, opts []func(*s3.Options)) (*s3.PutBucketIntelligentTieringConfigurationOutput, error) {
+					put = append(put, aws.ToString(input.Id))
+					return &s3.PutBucketIntelligentTieringConfigurationOutput{}, tc.args.putErr
+				},
+			})
+			err := cl.CreateOrUpdate(context.Background(), tc.args.b)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.put, put); diff != "" {
+				t.Errorf("put: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIntelligentTieringDays(t *testing.T) {
+	tiering := func(tier string, days int32) *v1beta1.Bucket {
+		return s3Testing.Bucket(withIntelligentTiering(intelligentTiering("archive", func(c *v1beta1.IntelligentTieringConfiguration) {
+			c.Tierings = []v1beta1.Tiering{{AccessTier: tier, Days: days}}
+		})))
+	}
+
+	cases := map[string]struct {
+		b   *v1beta1.Bucket
+		err error
+	}{
+		"ArchiveBelowMinimum": {
+			b:   tiering("ARCHIVE_ACCESS", 89),
+			err: errors.Errorf(intelligentTieringDaysOutOfRange, "archive", "ARCHIVE_ACCESS", 90, 730, 89),
+		},
+		"ArchiveMinimum": {
+			b: tiering("ARCHIVE_ACCESS", 90),
+		},
+		"ArchiveMaximum": {
+			b: tiering("ARCHIVE_ACCESS", 730),
+		},
+		"ArchiveAboveMaximum": {
+			b:   tiering("ARCHIVE_ACCESS", 731),
+			err: errors.Errorf(intelligentTieringDaysOutOfRange, "archive", "ARCHIVE_ACCESS", 90, 730, 731),
+		},
+		"DeepArchiveBelowMinimum": {
+			b:   tiering("DEEP_ARCHIVE_ACCESS", 179),
+			err: errors.Errorf(intelligentTieringDaysOutOfRange, "archive", "DEEP_ARCHIVE_ACCESS", 180, 730, 179),
+		},
+		"DeepArchiveMinimum": {
+			b: tiering("DEEP_ARCHIVE_ACCESS", 180),
+		},
+		"DeepArchiveMaximum": {
+			b: tiering("DEEP_ARCHIVE_ACCESS", 730),
+		},
+		"DeepArchiveAboveMaximum": {
+			b:   tiering("DEEP_ARCHIVE_ACCESS", 731),
+			err: errors.Errorf(intelligentTieringDaysOutOfRange, "archive", "DEEP_ARCHIVE_ACCESS", 180, 730, 731),
+		},
+		"UnknownAccessTier": {
+			b:   tiering("GLACIER", 90),
+			err: errors.Errorf(intelligentTieringUnknownTier, "archive", "GLACIER"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			put := false
+			cl := NewIntelligentTieringConfigurationClient(fake.MockBucketClient{
+				MockListBucketIntelligentTieringConfigurations: listIntelligentTiering(),
+				MockPutBucketIntelligentTieringConfiguration: func(ctx context.Context, input *s3.PutBucketIntelligentTieringConfigurationInput, opts []func(*s3.Options)) (*s3.PutBucketIntelligentTieringConfigurationOutput, error) {
+					put = true
+					return &s3.PutBucketIntelligentTieringConfigurationOutput{}, nil
+				},
+			})
+			err := cl.CreateOrUpdate(context.Background(), tc.b)
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if put != (tc.err == nil) {
+				t.Errorf("put: want %t, got %t", tc.err == nil, put)
+			}
+		})
+	}
+}
+
+func TestIntelligentTieringDelete(t *testing.T) {
+	cases := map[string]struct {
+		b    *v1beta1.Bucket
+		want []string
+	}{
+		"NoneConfigured": {
+			b:    s3Testing.Bucket(),
+			want: []string{"archive", "logs"},
+		},
+		"NotConfigured": {
+			b:    s3Testing.Bucket(withIntelligentTiering(intelligentTiering("logs"))),
+			want: []string{"archive"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var deleted []string
+			cl := NewIntelligentTieringConfigurationClient(fake.MockBucketClient{
+				MockListBucketIntelligentTieringConfigurations: listIntelligentTiering(awsIntelligentTiering("logs"), awsIntelligentTiering("archive")),
+				MockDeleteBucketIntelligentTieringConfiguration: func(ctx context.Context, input *s3.DeleteBucketIntelligentTieringConfigurationInput, opts []func(*s3.Options)) (*s3.DeleteBucketIntelligentTieringConfigurationOutput, error) {
+					deleted = append(deleted, aws.ToString(input.Id))
+					return &s3.DeleteBucketIntelligentTieringConfigurationOutput{}, nil
+				},
+			})
+			if err := cl.Delete(context.Background(), tc.b); err != nil {
+				t.Fatalf("Delete(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, deleted); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIntelligentTieringLateInitialize(t *testing.T) {
+	type args struct {
+		b        *v1beta1.Bucket
+		external []types.IntelligentTieringConfiguration
+	}
+
+	cases := map[string]struct {
+		args
+		want *v1beta1.Bucket
+	}{
+		"NoneExternal": {
+			args: args{
+				b: s3Testing.Bucket(),
+			},
+			want: s3Testing.Bucket(),
+		},
+		"FromExternal": {
+			args: args{
+				b: s3Testing.Bucket(),
+				external: []types.IntelligentTieringConfiguration{
+					awsIntelligentTiering("archive"),
+					awsIntelligentTiering("tagged", func(c *types.IntelligentTieringConfiguration) {
+						c.Filter = &types.IntelligentTieringFilter{Tag: &types.Tag{Key: aws.String("tier"), Value: aws.String("archive")}}
+					}),
+				},
+			},
+			want: s3Testing.Bucket(withIntelligentTiering(
+				intelligentTiering("archive"),
+				intelligentTiering("tagged", func(c *v1beta1.IntelligentTieringConfiguration) {
+					c.Filter = &v1beta1.IntelligentTieringFilter{Tag: &v1beta1.Tag{Key: "tier", Value: "archive"}}
+				}),
+			)),
+		},
+		"NoOverwrite": {
+			args: args{
+				b:        s3Testing.Bucket(withIntelligentTiering(intelligentTiering("archive"))),
+				external: []types.IntelligentTieringConfiguration{awsIntelligentTiering("logs")},
+			},
+			want: s3Testing.Bucket(withIntelligentTiering(intelligentTiering("archive"))),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cl := NewIntelligentTieringConfigurationClient(fake.MockBucketClient{MockListBucketIntelligentTieringConfigurations: listIntelligentTiering(tc.args.external...)})
+			if err := cl.LateInitialize(context.Background(), tc.args.b); err != nil {
+				t.Fatalf("LateInitialize(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, tc.args.b); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIntelligentTieringCapturePrior(t *testing.T) {
+	cl := NewIntelligentTieringConfigurationClient(fake.MockBucketClient{MockListBucketIntelligentTieringConfigurations: listIntelligentTiering(awsIntelligentTiering("archive"))})
+	prior, err := cl.CapturePrior(context.Background(), s3Testing.Bucket(withIntelligentTiering(intelligentTiering("logs"))))
+	if err != nil {
+		t.Fatalf("CapturePrior(...): unexpected error: %s", err)
+	}
+	if diff := cmp.Diff([]v1beta1.IntelligentTieringConfiguration{intelligentTiering("archive")}, prior.Spec.ForProvider.IntelligentTieringConfigurations); diff != "" {
+		t.Errorf("r: -want, +got:\n%s", diff)
+	}
+}
+
+func TestIntelligentTieringQuotas(t *testing.T) {
+	// Intelligent tiering configurations that are not configured count until
+	// they are deleted.
+	configs := make([]v1beta1.IntelligentTieringConfiguration, maxIntelligentTieringConfigurations)
+	cl := NewIntelligentTieringConfigurationClient(fake.MockBucketClient{MockListBucketIntelligentTieringConfigurations: listIntelligentTiering(awsIntelligentTiering("stale"))})
+	usages, err := QuotaUsages(context.Background(), cl, s3Testing.Bucket(withIntelligentTiering(configs...)))
+	if err != nil {
+		t.Fatalf("QuotaUsages(...): unexpected error: %s", err)
+	}
+	if len(usages) != 1 || !usages[0].Exceeded() {
+		t.Errorf("QuotaUsages(...): want the per bucket quota exceeded, got %v", usages)
+	}
+}
@@ -0,0 +1,284 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bucket
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awss3 "github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go/document"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/provider-aws/apis/s3/v1beta1"
+	awsclient "github.com/crossplane/provider-aws/pkg/clients"
+	"github.com/crossplane/provider-aws/pkg/clients/s3"
+)
+
+const (
+	inventoryListFailed   = "cannot list Bucket inventory configurations"
+	inventoryPutFailed    = "cannot put Bucket inventory configuration %s"
+	inventoryDeleteFailed = "cannot delete Bucket inventory configuration %s"
+	inventoryDuplicateID  = "duplicate inventory configuration ID %q"
+
+	// maxInventoryConfigurations is the number of inventory configurations
+	// AWS allows per bucket.
+	maxInventoryConfigurations = 1000
+)
+
+// InventoryConfigurationClient is the client for API methods and reconciling the InventoryConfigurations
+type InventoryConfigurationClient struct {
+	client s3.BucketClient
+}
+
+// NewInventoryConfigurationClient creates the client for Inventory Configurations
+func NewInventoryConfigurationClient(client s3.BucketClient) *InventoryConfigurationClient {
+	return &InventoryConfigurationClient{client: client}
+}
+
+// Observe checks if the resource exists and if it matches the local
+// configuration. Inventory configurations are keyed by their ID, so they need
+// an update if a configured ID is missing or any of its fields differ, and a
+// deletion if the bucket has an inventory configuration that is not
+// configured.
+func (in *InventoryConfigurationClient) Observe(ctx context.Context, bucket *v1beta1.Bucket) (ResourceStatus, error) {
+	return in.configurations().observe(ctx, bucket)
+}
+
+// CreateOrUpdate puts the inventory configurations that are missing or differ.
+// Inventory configurations that are not configured are removed by Delete.
+func (in *InventoryConfigurationClient) CreateOrUpdate(ctx context.Context, bucket *v1beta1.Bucket) error {
+	config := bucket.Spec.ForProvider.InventoryConfigurations
+	if len(config) == 0 {
+		return nil
+	}
+	if err := validateInventoryConfigurations(config); err != nil {
+		return err
+	}
+	return in.configurations().createOrUpdate(ctx, bucket)
+}
+
+// Delete deletes the inventory configurations of the bucket that are not
+// configured, i.e. all of them if none is configured.
+func (in *InventoryConfigurationClient) Delete(ctx context.Context, bucket *v1beta1.Bucket) error {
+	return in.configurations().deleteUnconfigured(ctx, bucket)
+}
+
+// configurations returns the keyedConfigurations of the inventory
+// configurations of a bucket.
+func (in *InventoryConfigurationClient) configurations() keyedConfigurations {
+	return keyedConfigurations{
+		changes: func(ctx context.Context, bucket *v1beta1.Bucket) ([]int, []string, error) {
+			external, err := in.list(ctx, bucket)
+			if err != nil {
+				return nil, nil, err
+			}
+			put, remove := inventoryChanges(bucket.Spec.ForProvider.InventoryConfigurations, external)
+			return put, remove, nil
+		},
+		put: func(ctx context.Context, bucket *v1beta1.Bucket, i int) error {
+			c := bucket.Spec.ForProvider.InventoryConfigurations[i]
+			_, err := in.client.PutBucketInventoryConfiguration(ctx, &awss3.PutBucketInventoryConfigurationInput{
+				Bucket:                 awsclient.String(meta.GetExternalName(bucket)),
+				Id:                     awsclient.String(c.ID),
+				InventoryConfiguration: GenerateInventoryConfiguration(c),
+			})
+			return errors.Wrapf(err, inventoryPutFailed, c.ID)
+		},
+		remove: func(ctx context.Context, bucket *v1beta1.Bucket, id string) error {
+			_, err := in.client.DeleteBucketInventoryConfiguration(ctx, &awss3.DeleteBucketInventoryConfigurationInput{
+				Bucket: awsclient.String(meta.GetExternalName(bucket)),
+				Id:     awsclient.String(id),
+			})
+			return errors.Wrapf(err, inventoryDeleteFailed, id)
+		},
+	}
+}
+
+// LateInitialize is responsible for initializing the resource based on the external value
+func (in *InventoryConfigurationClient) LateInitialize(ctx context.Context, bucket *v1beta1.Bucket) error {
+	external, err := in.list(ctx, bucket)
+	if err != nil {
+		return err
+	}
+	fp := &bucket.Spec.ForProvider
+	if len(external) == 0 || fp.InventoryConfigurations != nil {
+		return nil
+	}
+	fp.InventoryConfigurations = make([]v1beta1.InventoryConfiguration, len(external))
+	for i, c := range external {
+		fp.InventoryConfigurations[i] = GenerateLocalInventoryConfiguration(c)
+	}
+	return nil
+}
+
+// CapturePrior returns a Bucket with the inventory configurations currently in AWS.
+func (in *InventoryConfigurationClient) CapturePrior(ctx context.Context, bucket *v1beta1.Bucket) (*v1beta1.Bucket, error) {
+	return lateInitializedPrior(ctx, in, bucket)
+}
+
+// SubresourceExists checks if the subresource this controller manages currently exists
+func (in *InventoryConfigurationClient) SubresourceExists(bucket *v1beta1.Bucket) bool {
+	return len(bucket.Spec.ForProvider.InventoryConfigurations) != 0
+}
+
+// Quotas of the inventory configurations. Inventory configurations that are
+// not configured still count until they are deleted, which may be disabled.
+func (in *InventoryConfigurationClient) Quotas() []Quota {
+	return []Quota{{
+		Description: "inventory configurations per bucket",
+		Limit:       maxInventoryConfigurations,
+		Usage: func(ctx context.Context, bucket *v1beta1.Bucket) (int, error) {
+			return in.configurations().usage(ctx, bucket, len(bucket.Spec.ForProvider.InventoryConfigurations))
+		},
+	}}
+}
+
+// list returns all inventory configurations of the bucket, following the
+// continuation tokens of truncated responses.
+func (in *InventoryConfigurationClient) list(ctx context.Context, bucket *v1beta1.Bucket) ([]types.InventoryConfiguration, error) {
+	input := &awss3.ListBucketInventoryConfigurationsInput{Bucket: awsclient.String(meta.GetExternalName(bucket))}
+	var configs []types.InventoryConfiguration
+	for {
+		out, err := in.client.ListBucketInventoryConfigurations(ctx, input)
+		if err != nil {
+			return nil, awsclient.Wrap(err, inventoryListFailed)
+		}
+		if out == nil {
+			return configs, nil
+		}
+		configs = append(configs, out.InventoryConfigurationList...)
+		if !out.IsTruncated || out.NextContinuationToken == nil {
+			return configs, nil
+		}
+		input = &awss3.ListBucketInventoryConfigurationsInput{Bucket: input.Bucket, ContinuationToken: out.NextContinuationToken}
+	}
+}
+
+// inventoryChanges returns the indices of the desired inventory
+// configurations that are missing from or differ in the external ones, and
+// the sorted IDs of the external inventory configurations that are not
+// desired. The configurations are compared as AWS reports them, so that the
+// destination bucket reference and selector are left out.
+func inventoryChanges(desired []v1beta1.InventoryConfiguration, external []types.InventoryConfiguration) ([]int, []string) {
+	desiredIDs := make([]string, len(desired))
+	for i, c := range desired {
+		desiredIDs[i] = c.ID
+	}
+	externalIDs := make([]string, len(external))
+	for i, c := range external {
+		externalIDs[i] = aws.ToString(c.Id)
+	}
+	return diffByID(desiredIDs, externalIDs, func(d, e int) bool {
+		return cmp.Equal(*GenerateInventoryConfiguration(desired[d]), external[e], cmpopts.EquateEmpty(), cmpopts.IgnoreTypes(document.NoSerde{}),
+			cmpopts.SortSlices(func(a, b types.InventoryOptionalField) bool { return a < b }))
+	})
+}
+
+// validateInventoryConfigurations returns an error if two inventory
+// configurations have the same ID, since AWS keys them by it.
+func validateInventoryConfigurations(configs []v1beta1.InventoryConfiguration) error {
+	seen := make(map[string]bool, len(configs))
+	for _, c := range configs {
+		if seen[c.ID] {
+			return errors.Errorf(inventoryDuplicateID, c.ID)
+		}
+		seen[c.ID] = true
+	}
+	return nil
+}
+
+// GenerateInventoryConfiguration creates the types.InventoryConfiguration for the AWS SDK
+func GenerateInventoryConfiguration(config v1beta1.InventoryConfiguration) *types.InventoryConfiguration {
+	d := config.Destination
+	out := &types.InventoryConfiguration{
+		Id:                     awsclient.String(config.ID),
+		IsEnabled:              config.IsEnabled,
+		IncludedObjectVersions: types.InventoryIncludedObjectVersions(config.IncludedObjectVersions),
+		Schedule:               &types.InventorySchedule{Frequency: types.InventoryFrequency(config.Schedule.Frequency)},
+		Destination: &types.InventoryDestination{S3BucketDestination: &types.InventoryS3BucketDestination{
+			AccountId: d.AccountID,
+			Bucket:    d.Bucket,
+			Format:    types.InventoryFormat(d.Format),
+			Prefix:    d.Prefix,
+		}},
+	}
+	if e := d.Encryption; e != nil {
+		enc := &types.InventoryEncryption{}
+		if e.SSEKMS != nil {
+			enc.SSEKMS = &types.SSEKMS{KeyId: awsclient.String(e.SSEKMS.KeyID)}
+		}
+		if aws.ToBool(e.SSES3) {
+			enc.SSES3 = &types.SSES3{}
+		}
+		out.Destination.S3BucketDestination.Encryption = enc
+	}
+	if config.Filter != nil {
+		out.Filter = &types.InventoryFilter{Prefix: awsclient.String(config.Filter.Prefix)}
+	}
+	if config.OptionalFields != nil {
+		out.OptionalFields = make([]types.InventoryOptionalField, len(config.OptionalFields))
+		for i, f := range config.OptionalFields {
+			out.OptionalFields[i] = types.InventoryOptionalField(f)
+		}
+	}
+	return out
+}
+
+// GenerateLocalInventoryConfiguration creates the v1beta1.InventoryConfiguration from the AWS SDK inventory configuration
+func GenerateLocalInventoryConfiguration(config types.InventoryConfiguration) v1beta1.InventoryConfiguration {
+	out := v1beta1.InventoryConfiguration{
+		ID:                     aws.ToString(config.Id),
+		IsEnabled:              config.IsEnabled,
+		IncludedObjectVersions: string(config.IncludedObjectVersions),
+	}
+	if config.Schedule != nil {
+		out.Schedule.Frequency = string(config.Schedule.Frequency)
+	}
+	if config.Destination != nil && config.Destination.S3BucketDestination != nil {
+		d := config.Destination.S3BucketDestination
+		out.Destination = v1beta1.InventoryDestination{
+			AccountID: d.AccountId,
+			Bucket:    d.Bucket,
+			Format:    string(d.Format),
+			Prefix:    d.Prefix,
+		}
+		if e := d.Encryption; e != nil {
+			out.Destination.Encryption = &v1beta1.InventoryEncryption{}
+			if e.SSEKMS != nil {
+				out.Destination.Encryption.SSEKMS = &v1beta1.InventorySSEKMS{KeyID: aws.ToString(e.SSEKMS.KeyId)}
+			}
+			if e.SSES3 != nil {
+				out.Destination.Encryption.SSES3 = aws.Bool(true)
+			}
+		}
+	}
+	if config.Filter != nil {
+		out.Filter = &v1beta1.InventoryFilter{Prefix: aws.ToString(config.Filter.Prefix)}
+	}
+	if config.OptionalFields != nil {
+		out.OptionalFields = make([]string, len(config.OptionalFields))
+		for i, f := range config.OptionalFields {
+			out.OptionalFields[i] = string(f)
+		}
+	}
+	return out
+}
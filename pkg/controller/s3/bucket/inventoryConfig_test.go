@@ -0,0 +1,425 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bucket
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/provider-aws/apis/s3/v1beta1"
+	awsclient "github.com/crossplane/provider-aws/pkg/clients"
+	"github.com/crossplane/provider-aws/pkg/clients/s3/fake"
+	s3Testing "github.com/crossplane/provider-aws/pkg/controller/s3/testing"
+)
+
+var (
+	_ SubresourceClient = &InventoryConfigurationClient{}
+	_ QuotaClient       = &InventoryConfigurationClient{}
+)
+
+const inventoryBucketARN = "arn:aws:s3:::inventory"
+
+func withInventory(configs ...v1beta1.InventoryConfiguration) s3Testing.BucketModifier {
+	return func(b *v1beta1.Bucket) {
+		b.Spec.ForProvider.InventoryConfigurations = configs
+	}
+}
+
+func inventory(id string, m ...func(*v1beta1.InventoryConfiguration)) v1beta1.InventoryConfiguration {
+	c := v1beta1.InventoryConfiguration{
+		ID:                     id,
+		IsEnabled:              true,
+		IncludedObjectVersions: "Current",
+		Schedule:               v1beta1.InventorySchedule{Frequency: "Daily"},
+		Destination: v1beta1.InventoryDestination{
+			Bucket: aws.String(inventoryBucketARN),
+			Format: "CSV",
+			Prefix: aws.String(id + "/"),
+		},
+		OptionalFields: []string{"Size", "ETag"},
+	}
+	for _, f := range m {
+		f(&c)
+	}
+	return c
+}
+
+func awsInventory(id string, m ...func(*types.InventoryConfiguration)) types.InventoryConfiguration {
+	c := types.InventoryConfiguration{
+		Id:                     aws.String(id),
+		IsEnabled:              true,
+		IncludedObjectVersions: types.InventoryIncludedObjectVersionsCurrent,
+		Schedule:               &types.InventorySchedule{Frequency: types.InventoryFrequencyDaily},
+		Destination: &types.InventoryDestination{S3BucketDestination: &types.InventoryS3BucketDestination{
+			Bucket: aws.String(inventoryBucketARN),
+			Format: types.InventoryFormatCsv,
+			Prefix: aws.String(id + "/"),
+		}},
+		OptionalFields: []types.InventoryOptionalField{types.InventoryOptionalFieldSize, types.InventoryOptionalFieldETag},
+	}
+	for _, f := range m {
+		f(&c)
+	}
+	return c
+}
+
+func listInventory(configs ...types.InventoryConfiguration) func(ctx context.Context, input *s3.ListBucketInventoryConfigurationsInput, opts []func(*s3.Options)) (*s3.ListBucketInventoryConfigurationsOutput, error) {
+	return func(ctx context.Context, input *s3.ListBucketInventoryConfigurationsInput, opts []func(*s3.Options)) (*s3.ListBucketInventoryConfigurationsOutput, error) {
+		return &s3.ListBucketInventoryConfigurationsOutput{InventoryConfigurationList: configs}, nil
+	}
+}
+
+func TestInventoryObserve(t *testing.T) {
+	type args struct {
+		cl *InventoryConfigurationClient
+		b  *v1beta1.Bucket
+	}
+
+	type want struct {
+		status ResourceStatus
+		err    error
+	}
+
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"Error": {
+			args: args{
+				b: s3Testing.Bucket(withInventory(inventory("daily"))),
+				cl: NewInventoryConfigurationClient(fake.MockBucketClient{
+					MockListBucketInventoryConfigurations: func(ctx context.Context, input *s3.ListBucketInventoryConfigurationsInput, opts []func(*s3.Options)) (*s3.ListBucketInventoryConfigurationsOutput, error) {
+						return nil, errBoom
+					},
+				}),
+			},
+			want: want{
+				status: NeedsUpdate,
+				err:    awsclient.Wrap(errBoom, inventoryListFailed),
+			},
+		},
+		"NoneConfigured": {
+			args: args{
+				b:  s3Testing.Bucket(),
+				cl: NewInventoryConfigurationClient(fake.MockBucketClient{MockListBucketInventoryConfigurations: listInventory()}),
+			},
+			want: want{
+				status: Updated,
+			},
+		},
+		"NeedsDeletion": {
+			args: args{
+				b:  s3Testing.Bucket(),
+				cl: NewInventoryConfigurationClient(fake.MockBucketClient{MockListBucketInventoryConfigurations: listInventory(awsInventory("daily"))}),
+			},
+			want: want{
+				status: NeedsDeletion,
+			},
+		},
+		"MissingID": {
+			args: args{
+				b:  s3Testing.Bucket(withInventory(inventory("daily"), inventory("weekly"))),
+				cl: NewInventoryConfigurationClient(fake.MockBucketClient{MockListBucketInventoryConfigurations: listInventory(awsInventory("daily"))}),
+			},
+			want: want{
+				status: NeedsUpdate,
+			},
+		},
+		"FormatDiffers": {
+			args: args{
+				b: s3Testing.Bucket(withInventory(inventory("daily"))),
+				cl: NewInventoryConfigurationClient(fake.MockBucketClient{MockListBucketInventoryConfigurations: listInventory(awsInventory("daily", func(c *types.InventoryConfiguration) {
+					c.Destination.S3BucketDestination.Format = types.InventoryFormatParquet
+				}))}),
+			},
+			want: want{
+				status: NeedsUpdate,
+			},
+		},
+		"ScheduleDiffers": {
+			args: args{
+				b: s3Testing.Bucket(withInventory(inventory("daily"))),
+				cl: NewInventoryConfigurationClient(fake.MockBucketClient{MockListBucketInventoryConfigurations: listInventory(awsInventory("daily", func(c *types.InventoryConfiguration) {
+					c.Schedule.Frequency = types.InventoryFrequencyWeekly
+				}))}),
+			},
+			want: want{
+				status: NeedsUpdate,
+			},
+		},
+		"OptionalFieldRemoved": {
+			args: args{
+				b: s3Testing.Bucket(withInventory(inventory("daily", func(c *v1beta1.InventoryConfiguration) {
+					c.OptionalFields = []string{"Size"}
+				}))),
+				cl: NewInventoryConfigurationClient(fake.MockBucketClient{MockListBucketInventoryConfigurations: listInventory(awsInventory("daily"))}),
+			},
+			want: want{
+				status: NeedsUpdate,
+			},
+		},
+		"NotConfiguredID": {
+			args: args{
+				b:  s3Testing.Bucket(withInventory(inventory("daily"))),
+				cl: NewInventoryConfigurationClient(fake.MockBucketClient{MockListBucketInventoryConfigurations: listInventory(awsInventory("daily"), awsInventory("weekly"))}),
+			},
+			want: want{
+				status: NeedsDeletion,
+			},
+		},
+		"UpdateBeforeDeletion": {
+			args: args{
+				b: s3Testing.Bucket(withInventory(inventory("daily"))),
+				cl: NewInventoryConfigurationClient(fake.MockBucketClient{MockListBucketInventoryConfigurations: listInventory(
+					awsInventory("daily", func(c *types.InventoryConfiguration) { c.IsEnabled = false }),
+					awsInventory("weekly"),
+				)}),
+			},
+			want: want{
+				status: NeedsUpdate,
+			},
+		},
+		"UpToDate": {
+			args: args{
+				b: s3Testing.Bucket(withInventory(
+					inventory("daily", func(c *v1beta1.InventoryConfiguration) {
+						c.Destination.BucketRef = &xpv1.Reference{Name: "inventory"}
+						c.Destination.Encryption = &v1beta1.InventoryEncryption{SSES3: aws.Bool(true)}
+						c.Filter = &v1beta1.InventoryFilter{Prefix: "logs/"}
+					}),
+					inventory("weekly"),
+				)),
+				cl: NewInventoryConfigurationClient(fake.MockBucketClient{MockListBucketInventoryConfigurations: listInventory(
+					awsInventory("weekly", func(c *types.InventoryConfiguration) {
+						c.OptionalFields = []types.InventoryOptionalField{types.InventoryOptionalFieldETag, types.InventoryOptionalFieldSize}
+					}),
+					awsInventory("daily", func(c *types.InventoryConfiguration) {
+						c.Destination.S3BucketDestination.Encryption = &types.InventoryEncryption{SSES3: &types.SSES3{}}
+						c.Filter = &types.InventoryFilter{Prefix: aws.String("logs/")}
+					}),
+				)}),
+			},
+			want: want{
+				status: Updated,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			status, err := tc.args.cl.Observe(context.Background(), tc.args.b)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.status, status); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestInventoryCreateOrUpdate(t *testing.T) {
+	type args struct {
+		b        *v1beta1.Bucket
+		external []types.InventoryConfiguration
+		putErr   error
+	}
+
+	type want struct {
+		err     error
+		put     []string
+		deleted []string
+	}
+
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"PutError": {
+			args: args{
+				b:      s3Testing.Bucket(withInventory(inventory("daily"))),
+				putErr: errBoom,
+			},
+			want: want{
+				err: errors.Wrapf(errBoom, inventoryPutFailed, "daily"),
+				put: []string{"daily"},
+			},
+		},
+		"DuplicateID": {
+			args: args{
+				b: s3Testing.Bucket(withInventory(inventory("daily"), inventory("daily"))),
+			},
+			want: want{
+				err: errors.Errorf(inventoryDuplicateID, "daily"),
+			},
+		},
+		"AddAndUpdate": {
+			args: args{
+				b: s3Testing.Bucket(withInventory(inventory("daily"), inventory("weekly"), inventory("same"))),
+				external: []types.InventoryConfiguration{
+					awsInventory("daily", func(c *types.InventoryConfiguration) { c.IsEnabled = false }),
+					awsInventory("same"),
+					awsInventory("stale"),
+				},
+			},
+			want: want{
+				// Inventory configurations that are not configured are
+				// left to Delete.
+				put: []string{"daily", "weekly"},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var put, deleted []string
+			cl := NewInventoryConfigurationClient(fake.MockBucketClient{
+				MockListBucketInventoryConfigurations: listInventory(tc.args.external...),
+				MockPutBucketInventoryConfiguration: func(ctx context.Context, input *s3.PutBucketInventoryConfigurationInput, opts []func(*s3.Options)) (*s3.PutBucketInventoryConfigurationOutput, error) {
+					put = append(put, aws.ToString(input.Id))
+					return &s3.PutBucketInventoryConfigurationOutput{}, tc.args.putErr
+				},
+				MockDeleteBucketInventoryConfiguration: func(ctx context.Context, input *s3.DeleteBucketInventoryConfigurationInput, opts []func(*s3.Options)) (*s3.DeleteBucketInventoryConfigurationOutput, error) {
+					deleted = append(deleted, aws.ToString(input.Id))
+					return &s3.DeleteBucketInventoryConfigurationOutput{}, nil
+				},
+			})
+			err := cl.CreateOrUpdate(context.Background(), tc.args.b)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.put, put); diff != "" {
+				t.Errorf("put: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.deleted, deleted); diff != "" {
+				t.Errorf("deleted: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestInventoryDelete(t *testing.T) {
+	cases := map[string]struct {
+		b    *v1beta1.Bucket
+		want []string
+	}{
+		"NoneConfigured": {
+			b:    s3Testing.Bucket(),
+			want: []string{"daily", "weekly"},
+		},
+		"NotConfigured": {
+			b:    s3Testing.Bucket(withInventory(inventory("weekly"))),
+			want: []string{"daily"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var deleted []string
+			cl := NewInventoryConfigurationClient(fake.MockBucketClient{
+				MockListBucketInventoryConfigurations: listInventory(awsInventory("weekly"), awsInventory("daily")),
+				MockDeleteBucketInventoryConfiguration: func(ctx context.Context, input *s3.DeleteBucketInventoryConfigurationInput, opts []func(*s3.Options)) (*s3.DeleteBucketInventoryConfigurationOutput, error) {
+					deleted = append(deleted, aws.ToString(input.Id))
+					return &s3.DeleteBucketInventoryConfigurationOutput{}, nil
+				},
+			})
+			if err := cl.Delete(context.Background(), tc.b); err != nil {
+				t.Fatalf("Delete(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, deleted); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestInventoryLateInitialize(t *testing.T) {
+	type args struct {
+		b        *v1beta1.Bucket
+		external []types.InventoryConfiguration
+	}
+
+	cases := map[string]struct {
+		args
+		want *v1beta1.Bucket
+	}{
+		"NoneExternal": {
+			args: args{
+				b: s3Testing.Bucket(),
+			},
+			want: s3Testing.Bucket(),
+		},
+		"FromExternal": {
+			args: args{
+				b: s3Testing.Bucket(),
+				external: []types.InventoryConfiguration{
+					awsInventory("daily"),
+					awsInventory("encrypted", func(c *types.InventoryConfiguration) {
+						c.Destination.S3BucketDestination.Encryption = &types.InventoryEncryption{SSEKMS: &types.SSEKMS{KeyId: aws.String("key")}}
+					}),
+				},
+			},
+			want: s3Testing.Bucket(withInventory(
+				inventory("daily"),
+				inventory("encrypted", func(c *v1beta1.InventoryConfiguration) {
+					c.Destination.Encryption = &v1beta1.InventoryEncryption{SSEKMS: &v1beta1.InventorySSEKMS{KeyID: "key"}}
+				}),
+			)),
+		},
+		"NoOverwrite": {
+			args: args{
+				b:        s3Testing.Bucket(withInventory(inventory("daily"))),
+				external: []types.InventoryConfiguration{awsInventory("weekly")},
+			},
+			want: s3Testing.Bucket(withInventory(inventory("daily"))),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cl := NewInventoryConfigurationClient(fake.MockBucketClient{MockListBucketInventoryConfigurations: listInventory(tc.args.external...)})
+			if err := cl.LateInitialize(context.Background(), tc.args.b); err != nil {
+				t.Fatalf("LateInitialize(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, tc.args.b); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestInventoryQuotas(t *testing.T) {
+	// Inventory configurations that are not configured count until they are
+	// deleted.
+	configs := make([]v1beta1.InventoryConfiguration, maxInventoryConfigurations)
+	cl := NewInventoryConfigurationClient(fake.MockBucketClient{MockListBucketInventoryConfigurations: listInventory(awsInventory("stale"))})
+	usages, err := QuotaUsages(context.Background(), cl, s3Testing.Bucket(withInventory(configs...)))
+	if err != nil {
+		t.Fatalf("QuotaUsages(...): unexpected error: %s", err)
+	}
+	if len(usages) != 1 || !usages[0].Exceeded() {
+		t.Errorf("QuotaUsages(...): want the per bucket quota exceeded, got %v", usages)
+	}
+}
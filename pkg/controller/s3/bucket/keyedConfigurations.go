@@ -0,0 +1,123 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bucket
+
+import (
+	"context"
+	"sort"
+
+	"github.com/crossplane/provider-aws/apis/s3/v1beta1"
+)
+
+// keyedConfigurations reconciles a subresource that consists of
+// configurations keyed by their ID, e.g. the metrics or inventory
+// configurations of a bucket. Configurations that are missing or differ are
+// put by createOrUpdate. Configurations that are not configured are reported
+// as NeedsDeletion and only removed by deleteUnconfigured, so that they are
+// kept if the deletion of subresources is disabled.
+type keyedConfigurations struct {
+	// changes lists the configurations of the bucket in AWS and returns the
+	// indices of the configured ones that are missing or differ, and the
+	// sorted IDs of those that are not configured.
+	changes func(ctx context.Context, bucket *v1beta1.Bucket) ([]int, []string, error)
+
+	// put puts the configured configuration with the supplied index.
+	put func(ctx context.Context, bucket *v1beta1.Bucket, i int) error
+
+	// remove deletes the configuration with the supplied ID.
+	remove func(ctx context.Context, bucket *v1beta1.Bucket, id string) error
+}
+
+// observe returns NeedsUpdate if a configuration needs to be put, otherwise
+// NeedsDeletion if a configuration is not configured.
+func (k keyedConfigurations) observe(ctx context.Context, bucket *v1beta1.Bucket) (ResourceStatus, error) {
+	put, remove, err := k.changes(ctx, bucket)
+	switch {
+	case err != nil:
+		return NeedsUpdate, err
+	case len(put) != 0:
+		return NeedsUpdate, nil
+	case len(remove) != 0:
+		return NeedsDeletion, nil
+	}
+	return Updated, nil
+}
+
+// createOrUpdate puts the configurations that are missing or differ.
+func (k keyedConfigurations) createOrUpdate(ctx context.Context, bucket *v1beta1.Bucket) error {
+	put, _, err := k.changes(ctx, bucket)
+	if err != nil {
+		return err
+	}
+	for _, i := range put {
+		if err := k.put(ctx, bucket, i); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteUnconfigured deletes the configurations that are not configured, i.e.
+// all of them if none is configured.
+func (k keyedConfigurations) deleteUnconfigured(ctx context.Context, bucket *v1beta1.Bucket) error {
+	_, remove, err := k.changes(ctx, bucket)
+	if err != nil {
+		return err
+	}
+	for _, id := range remove {
+		if err := k.remove(ctx, bucket, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// usage returns the number of configurations the bucket has once the
+// supplied number of configured ones are put. Configurations that are not
+// configured count until they are deleted.
+func (k keyedConfigurations) usage(ctx context.Context, bucket *v1beta1.Bucket, configured int) (int, error) {
+	_, remove, err := k.changes(ctx, bucket)
+	if err != nil {
+		return 0, err
+	}
+	return configured + len(remove), nil
+}
+
+// diffByID returns the indices of the desired IDs that are missing from the
+// external IDs or whose configurations are not equal, and the sorted external
+// IDs that are not desired. equal is called with the indices of a desired and
+// an external configuration that have the same ID.
+func diffByID(desired, external []string, equal func(d, e int) bool) ([]int, []string) {
+	current := make(map[string]int, len(external))
+	for i, id := range external {
+		current[id] = i
+	}
+	var put []int
+	for d, id := range desired {
+		e, ok := current[id]
+		if !ok || !equal(d, e) {
+			put = append(put, d)
+		}
+		delete(current, id)
+	}
+	remove := make([]string, 0, len(current))
+	for id := range current {
+		remove = append(remove, id)
+	}
+	sort.Strings(remove)
+	return put, remove
+}
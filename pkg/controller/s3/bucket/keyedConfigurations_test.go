@@ -0,0 +1,82 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bucket
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDiffByID(t *testing.T) {
+	type args struct {
+		desired  []string
+		external []string
+		equal    func(d, e int) bool
+	}
+
+	type want struct {
+		put    []int
+		remove []string
+	}
+
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"NoneExternal": {
+			args: args{
+				desired: []string{"a", "b"},
+			},
+			want: want{
+				put:    []int{0, 1},
+				remove: []string{},
+			},
+		},
+		"NoneDesired": {
+			args: args{
+				external: []string{"b", "a"},
+			},
+			want: want{
+				remove: []string{"a", "b"},
+			},
+		},
+		"Mixed": {
+			args: args{
+				desired:  []string{"same", "differs", "missing"},
+				external: []string{"stale", "differs", "same"},
+				equal:    func(d, e int) bool { return d == 0 && e == 2 },
+			},
+			want: want{
+				put:    []int{1, 2},
+				remove: []string{"stale"},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			put, remove := diffByID(tc.args.desired, tc.args.external, tc.args.equal)
+			if diff := cmp.Diff(tc.want.put, put); diff != "" {
+				t.Errorf("put: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.remove, remove); diff != "" {
+				t.Errorf("remove: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -18,16 +18,21 @@ package bucket
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"time"
 
 	"github.com/aws/smithy-go/document"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	awss3 "github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
 	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
 
 	"github.com/crossplane/provider-aws/apis/s3/v1beta1"
 	awsclient "github.com/crossplane/provider-aws/pkg/clients"
@@ -38,16 +43,22 @@ const (
 	lifecycleGetFailed    = "cannot get Bucket lifecycle configuration"
 	lifecyclePutFailed    = "cannot put Bucket lifecycle configuration"
 	lifecycleDeleteFailed = "cannot delete Bucket lifecycle configuration"
+
+	lifecycleInvalidExpiration = "expiredObjectDeleteMarker cannot be specified with days or date in the expiration of lifecycle rule %d"
+	lifecycleExpirationDays    = "days and date cannot both be specified in the expiration of lifecycle rule %d"
+	lifecycleAbortWithTags     = "abortIncompleteMultipartUpload cannot be specified with a tag filter in lifecycle rule %d"
+	lifecycleInvalidTransition = "days and date cannot both be specified in transition %d of lifecycle rule %d"
 )
 
 // LifecycleConfigurationClient is the client for API methods and reconciling the LifecycleConfiguration
 type LifecycleConfigurationClient struct {
 	client s3.BucketClient
+	logger logging.Logger
 }
 
 // NewLifecycleConfigurationClient creates the client for Accelerate Configuration
-func NewLifecycleConfigurationClient(client s3.BucketClient) *LifecycleConfigurationClient {
-	return &LifecycleConfigurationClient{client: client}
+func NewLifecycleConfigurationClient(client s3.BucketClient, logger logging.Logger) *LifecycleConfigurationClient {
+	return &LifecycleConfigurationClient{client: client, logger: logger}
 }
 
 // Observe checks if the resource exists and if it matches the local configuration
@@ -67,6 +78,8 @@ func (in *LifecycleConfigurationClient) Observe(ctx context.Context, bucket *v1b
 	if response != nil {
 		external = response.Rules
 	}
+	normalizeLifecycleFilters(external)
+	normalizeLifecycleDates(external)
 	sortFilterTags(external)
 	switch {
 	case len(external) != 0 && len(local) == 0:
@@ -74,12 +87,19 @@ func (in *LifecycleConfigurationClient) Observe(ctx context.Context, bucket *v1b
 	// NOTE(muvaf): We ignore ID because it might have been auto-assigned by AWS
 	// and we don't have late-init for this subresource. Besides, a change in ID
 	// is almost never expected.
-	case cmp.Equal(external, GenerateLifecycleRules(local),
+	case cmp.Equal(external, normalizeLifecycleDates(GenerateLifecycleRules(local)),
 		cmpopts.IgnoreFields(types.LifecycleRule{}, "ID"), cmpopts.IgnoreTypes(document.NoSerde{})):
 		return Updated, nil
-	default:
-		return NeedsUpdate, nil
 	}
+	// Rules that have an ID are matched by it, so that AWS returning them in
+	// another order is not considered a change.
+	diff := DiffLifecycleRules(external, local)
+	if allLifecycleRulesHaveIDs(local) && diff.empty() {
+		return Updated, nil
+	}
+	in.logger.Debug("Bucket lifecycle configuration is out of date", "bucket", meta.GetExternalName(bucket),
+		"added", diff.Added, "removed", diff.Removed, "modified", diff.Modified)
+	return NeedsUpdate, nil
 }
 
 // CreateOrUpdate sends a request to have resource created on AWS
@@ -87,7 +107,11 @@ func (in *LifecycleConfigurationClient) CreateOrUpdate(ctx context.Context, buck
 	if bucket.Spec.ForProvider.LifecycleConfiguration == nil {
 		return nil
 	}
+	if err := validateLifecycleRules(bucket.Spec.ForProvider.LifecycleConfiguration.Rules); err != nil {
+		return err
+	}
 	input := GenerateLifecycleConfiguration(meta.GetExternalName(bucket), bucket.Spec.ForProvider.LifecycleConfiguration)
+	normalizeLifecycleDates(input.LifecycleConfiguration.Rules)
 	_, err := in.client.PutBucketLifecycleConfiguration(ctx, input)
 	return awsclient.Wrap(err, lifecyclePutFailed)
 
@@ -128,6 +152,11 @@ func (in *LifecycleConfigurationClient) LateInitialize(ctx context.Context, buck
 	return nil
 }
 
+// CapturePrior returns a Bucket with the lifecycle rules currently in AWS.
+func (in *LifecycleConfigurationClient) CapturePrior(ctx context.Context, bucket *v1beta1.Bucket) (*v1beta1.Bucket, error) {
+	return lateInitializedPrior(ctx, in, bucket)
+}
+
 // SubresourceExists checks if the subresource this controller manages currently exists
 func (in *LifecycleConfigurationClient) SubresourceExists(bucket *v1beta1.Bucket) bool {
 	return bucket.Spec.ForProvider.LifecycleConfiguration != nil
@@ -216,6 +245,138 @@ func GenerateLifecycleRules(in []v1beta1.LifecycleRule) []types.LifecycleRule {
 	return result
 }
 
+// normalizeLifecycleDates replaces the expiration and transition dates of the
+// supplied rules with midnight UTC of the day they fall on in UTC, which is
+// the only time of day S3 accepts. The Kubernetes API server may return dates
+// in another location, so both the desired and the observed dates are
+// normalized before they are compared or sent.
+func normalizeLifecycleDates(rules []types.LifecycleRule) []types.LifecycleRule {
+	for i := range rules {
+		if e := rules[i].Expiration; e != nil && e.Date != nil {
+			e.Date = lifecycleDate(*e.Date)
+		}
+		for j := range rules[i].Transitions {
+			if d := rules[i].Transitions[j].Date; d != nil {
+				rules[i].Transitions[j].Date = lifecycleDate(*d)
+			}
+		}
+	}
+	return rules
+}
+
+func lifecycleDate(t time.Time) *time.Time {
+	t = t.UTC()
+	d := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	return &d
+}
+
+// LifecycleRulesDiff lists the IDs of the lifecycle rules that differ between
+// the desired and the observed lifecycle configuration. Rules without an ID
+// are identified by their position, e.g. "#0".
+type LifecycleRulesDiff struct {
+	// Added rules exist only in the desired configuration.
+	Added []string
+	// Removed rules exist only in the observed configuration.
+	Removed []string
+	// Modified rules exist in both configurations but are not equal.
+	Modified []string
+}
+
+// DiffLifecycleRules computes which rules PutBucketLifecycleConfiguration
+// would add, remove or modify when replacing the external rules with the
+// local ones.
+func DiffLifecycleRules(external []types.LifecycleRule, local []v1beta1.LifecycleRule) LifecycleRulesDiff {
+	observed := make(map[string]types.LifecycleRule, len(external))
+	for i, rule := range external {
+		observed[lifecycleRuleKey(rule.ID, i)] = rule
+	}
+	diff := LifecycleRulesDiff{}
+	for i, rule := range normalizeLifecycleDates(GenerateLifecycleRules(local)) {
+		key := lifecycleRuleKey(rule.ID, i)
+		current, ok := observed[key]
+		switch {
+		case !ok:
+			diff.Added = append(diff.Added, key)
+		case !cmp.Equal(current, rule, cmpopts.IgnoreFields(types.LifecycleRule{}, "ID"), cmpopts.IgnoreTypes(document.NoSerde{})):
+			diff.Modified = append(diff.Modified, key)
+		}
+		delete(observed, key)
+	}
+	for key := range observed {
+		diff.Removed = append(diff.Removed, key)
+	}
+	sort.Strings(diff.Removed)
+	return diff
+}
+
+// empty returns true if no rule is added, removed or modified.
+func (d LifecycleRulesDiff) empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Modified) == 0
+}
+
+// allLifecycleRulesHaveIDs returns true if every rule has a non-empty ID.
+func allLifecycleRulesHaveIDs(rules []v1beta1.LifecycleRule) bool {
+	for _, r := range rules {
+		if awsclient.StringValue(r.ID) == "" {
+			return false
+		}
+	}
+	return true
+}
+
+func lifecycleRuleKey(id *string, index int) string {
+	if awsclient.StringValue(id) != "" {
+		return awsclient.StringValue(id)
+	}
+	return fmt.Sprintf("#%d", index)
+}
+
+// validateLifecycleRules checks the rules for combinations that AWS rejects.
+func validateLifecycleRules(rules []v1beta1.LifecycleRule) error {
+	for i, rule := range rules {
+		if rule.AbortIncompleteMultipartUpload != nil && filtersByTags(rule.Filter) {
+			return errors.Errorf(lifecycleAbortWithTags, i)
+		}
+		for j, transition := range rule.Transitions {
+			if transition.Days != 0 && transition.Date != nil {
+				return errors.Errorf(lifecycleInvalidTransition, j, i)
+			}
+		}
+		if rule.Expiration == nil {
+			continue
+		}
+		if rule.Expiration.Days != 0 && rule.Expiration.Date != nil {
+			return errors.Errorf(lifecycleExpirationDays, i)
+		}
+		if rule.Expiration.ExpiredObjectDeleteMarker && (rule.Expiration.Days != 0 || rule.Expiration.Date != nil) {
+			return errors.Errorf(lifecycleInvalidExpiration, i)
+		}
+	}
+	return nil
+}
+
+// filtersByTags returns true if the filter selects objects by their tags.
+func filtersByTags(filter *v1beta1.LifecycleRuleFilter) bool {
+	if filter == nil {
+		return false
+	}
+	return filter.Tag != nil || (filter.And != nil && len(filter.And.Tags) != 0)
+}
+
+// normalizeLifecycleFilters converts the filters of the supplied rules into
+// the shape GenerateLifecycleRules produces. AWS returns the empty filter of a
+// rule that applies to the whole bucket as nil, and rules created with the
+// deprecated prefix of the earlier version of the API without a filter.
+func normalizeLifecycleFilters(rules []types.LifecycleRule) {
+	for i := range rules {
+		if rules[i].Filter != nil {
+			continue
+		}
+		rules[i].Filter = &types.LifecycleRuleFilterMemberPrefix{Value: awsclient.StringValue(rules[i].Prefix)}
+		rules[i].Prefix = nil
+	}
+}
+
 func sortFilterTags(rules []types.LifecycleRule) {
 	for i := range rules {
 		andOperator, ok := rules[i].Filter.(*types.LifecycleRuleFilterMemberAnd)
@@ -27,8 +27,10 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/aws/smithy-go"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/crossplane/provider-aws/apis/s3/v1beta1"
@@ -47,6 +49,11 @@ var (
 	prefix            = "test-"
 	id                = "test-id"
 	storage           = "ONEZONE_IA"
+	// cest is the location the API server may return dates in, e.g. the local
+	// time of the provider.
+	cest = time.FixedZone("CEST", 2*60*60)
+	// awsMidnight is the date S3 stores for date and awsDate.
+	awsMidnight = time.Date(2020, time.September, 25, 0, 0, 0, 0, location)
 )
 
 var _ SubresourceClient = &LifecycleConfigurationClient{}
@@ -55,9 +62,7 @@ func generateLifecycleConfig() *v1beta1.BucketLifecycleConfiguration {
 	return &v1beta1.BucketLifecycleConfiguration{
 		Rules: []v1beta1.LifecycleRule{
 			{
-				AbortIncompleteMultipartUpload: &v1beta1.AbortIncompleteMultipartUpload{DaysAfterInitiation: 1},
 				Expiration: &v1beta1.LifecycleExpiration{
-					Date:                      &date,
 					Days:                      days,
 					ExpiredObjectDeleteMarker: marker,
 				},
@@ -75,7 +80,6 @@ func generateLifecycleConfig() *v1beta1.BucketLifecycleConfiguration {
 				}},
 				Status: enabled,
 				Transitions: []v1beta1.Transition{{
-					Date:         &date,
 					Days:         days,
 					StorageClass: storage,
 				}},
@@ -88,9 +92,7 @@ func generateAWSLifecycle(sortTag bool) *s3types.BucketLifecycleConfiguration {
 	conf := &s3types.BucketLifecycleConfiguration{
 		Rules: []s3types.LifecycleRule{
 			{
-				AbortIncompleteMultipartUpload: &s3types.AbortIncompleteMultipartUpload{DaysAfterInitiation: 1},
 				Expiration: &s3types.LifecycleExpiration{
-					Date:                      &awsDate,
 					Days:                      days,
 					ExpiredObjectDeleteMarker: marker,
 				},
@@ -108,7 +110,6 @@ func generateAWSLifecycle(sortTag bool) *s3types.BucketLifecycleConfiguration {
 				}},
 				Status: s3types.ExpirationStatusEnabled,
 				Transitions: []s3types.Transition{{
-					Date:         &awsDate,
 					Days:         days,
 					StorageClass: s3types.TransitionStorageClassOnezoneIa,
 				}},
@@ -121,6 +122,97 @@ func generateAWSLifecycle(sortTag bool) *s3types.BucketLifecycleConfiguration {
 	return conf
 }
 
+func generateDateTransitionLifecycleConfig(d metav1.Time) *v1beta1.BucketLifecycleConfiguration {
+	config := generateLifecycleConfig()
+	config.Rules[0].Transitions = []v1beta1.Transition{{Date: &d, StorageClass: storage}}
+	return config
+}
+
+func generateAWSDateTransitionLifecycle(d time.Time) *s3types.BucketLifecycleConfiguration {
+	config := generateAWSLifecycle(true)
+	config.Rules[0].Transitions = []s3types.Transition{{Date: &d, StorageClass: s3types.TransitionStorageClassOnezoneIa}}
+	return config
+}
+
+// generateWholeBucketLifecycleConfig returns a lifecycle configuration with a
+// rule that applies to all objects, optionally with an empty filter.
+func generateWholeBucketLifecycleConfig(filter *v1beta1.LifecycleRuleFilter) *v1beta1.BucketLifecycleConfiguration {
+	return &v1beta1.BucketLifecycleConfiguration{Rules: []v1beta1.LifecycleRule{{
+		ID:         awsclient.String("expire-all"),
+		Status:     "Enabled",
+		Filter:     filter,
+		Expiration: &v1beta1.LifecycleExpiration{Days: 30},
+	}}}
+}
+
+func generateAWSWholeBucketLifecycle(filter s3types.LifecycleRuleFilter, prefix *string) []s3types.LifecycleRule {
+	return []s3types.LifecycleRule{{
+		ID:         awsclient.String("expire-all"),
+		Status:     s3types.ExpirationStatusEnabled,
+		Filter:     filter,
+		Prefix:     prefix,
+		Expiration: &s3types.LifecycleExpiration{Days: 30},
+	}}
+}
+
+// AWS does not allow aborting incomplete multipart uploads in rules that
+// filter by tags, so these rules filter by prefix only.
+func generateAbortMultipartLifecycleConfig(days int32) *v1beta1.BucketLifecycleConfiguration {
+	return &v1beta1.BucketLifecycleConfiguration{
+		Rules: []v1beta1.LifecycleRule{
+			{
+				AbortIncompleteMultipartUpload: &v1beta1.AbortIncompleteMultipartUpload{DaysAfterInitiation: days},
+				Filter:                         &v1beta1.LifecycleRuleFilter{Prefix: awsclient.String(prefix)},
+				ID:                             awsclient.String(id),
+				Status:                         enabled,
+			},
+		},
+	}
+}
+
+func generateAWSAbortMultipartLifecycle(days int32) *s3types.BucketLifecycleConfiguration {
+	return &s3types.BucketLifecycleConfiguration{
+		Rules: []s3types.LifecycleRule{
+			{
+				AbortIncompleteMultipartUpload: &s3types.AbortIncompleteMultipartUpload{DaysAfterInitiation: days},
+				Filter:                         &s3types.LifecycleRuleFilterMemberPrefix{Value: prefix},
+				ID:                             awsclient.String(id),
+				Status:                         s3types.ExpirationStatusEnabled,
+			},
+		},
+	}
+}
+
+func generateDeleteMarkerLifecycleConfig(days int32) *v1beta1.BucketLifecycleConfiguration {
+	return &v1beta1.BucketLifecycleConfiguration{
+		Rules: []v1beta1.LifecycleRule{
+			{
+				Expiration: &v1beta1.LifecycleExpiration{
+					Days:                      days,
+					ExpiredObjectDeleteMarker: true,
+				},
+				ID:     awsclient.String(id),
+				Status: enabled,
+			},
+		},
+	}
+}
+
+func generateAWSDeleteMarkerLifecycle(marker bool) *s3types.BucketLifecycleConfiguration {
+	return &s3types.BucketLifecycleConfiguration{
+		Rules: []s3types.LifecycleRule{
+			{
+				Expiration: &s3types.LifecycleExpiration{
+					ExpiredObjectDeleteMarker: marker,
+				},
+				Filter: &s3types.LifecycleRuleFilterMemberPrefix{},
+				ID:     awsclient.String(id),
+				Status: s3types.ExpirationStatusEnabled,
+			},
+		},
+	}
+}
+
 func TestGenerateLifecycleConfiguration(t *testing.T) {
 	type args struct {
 		b *v1beta1.Bucket
@@ -142,6 +234,22 @@ func TestGenerateLifecycleConfiguration(t *testing.T) {
 				input: generateAWSLifecycle(true).Rules,
 			},
 		},
+		"WholeBucketNoFilter": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithLifecycleConfig(generateWholeBucketLifecycleConfig(nil))),
+			},
+			want: want{
+				input: generateAWSWholeBucketLifecycle(&s3types.LifecycleRuleFilterMemberPrefix{}, nil),
+			},
+		},
+		"WholeBucketEmptyFilter": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithLifecycleConfig(generateWholeBucketLifecycleConfig(&v1beta1.LifecycleRuleFilter{}))),
+			},
+			want: want{
+				input: generateAWSWholeBucketLifecycle(&s3types.LifecycleRuleFilterMemberPrefix{}, nil),
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -154,6 +262,89 @@ func TestGenerateLifecycleConfiguration(t *testing.T) {
 	}
 }
 
+func TestDiffLifecycleRules(t *testing.T) {
+	type args struct {
+		external []s3types.LifecycleRule
+		local    []v1beta1.LifecycleRule
+	}
+
+	withID := func(rules []v1beta1.LifecycleRule, id string) []v1beta1.LifecycleRule {
+		out := make([]v1beta1.LifecycleRule, len(rules))
+		for i := range rules {
+			out[i] = *rules[i].DeepCopy()
+			out[i].ID = awsclient.String(id)
+		}
+		return out
+	}
+
+	cases := map[string]struct {
+		args
+		want LifecycleRulesDiff
+	}{
+		"NoChange": {
+			args: args{
+				external: generateAWSLifecycle(true).Rules,
+				local:    generateLifecycleConfig().Rules,
+			},
+			want: LifecycleRulesDiff{},
+		},
+		"Added": {
+			args: args{
+				external: generateAWSLifecycle(true).Rules,
+				local:    append(generateLifecycleConfig().Rules, withID(generateDeleteMarkerLifecycleConfig(0).Rules, "new")...),
+			},
+			want: LifecycleRulesDiff{Added: []string{"new"}},
+		},
+		"Removed": {
+			args: args{
+				external: generateAWSLifecycle(true).Rules,
+				local:    nil,
+			},
+			want: LifecycleRulesDiff{Removed: []string{id}},
+		},
+		"Modified": {
+			args: args{
+				external: generateAWSDeleteMarkerLifecycle(false).Rules,
+				local:    generateDeleteMarkerLifecycleConfig(0).Rules,
+			},
+			want: LifecycleRulesDiff{Modified: []string{id}},
+		},
+		"NoIDUsesPosition": {
+			args: args{
+				external: nil,
+				local:    []v1beta1.LifecycleRule{{Status: enabled}},
+			},
+			want: LifecycleRulesDiff{Added: []string{"#0"}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := DiffLifecycleRules(tc.args.external, tc.args.local)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+// generateTwoRuleLifecycleConfig returns two rules that abort incomplete
+// multipart uploads after 7 and 14 days, with the supplied IDs.
+func generateTwoRuleLifecycleConfig(first, second *string) *v1beta1.BucketLifecycleConfiguration {
+	c := generateAbortMultipartLifecycleConfig(7)
+	c.Rules = append(c.Rules, generateAbortMultipartLifecycleConfig(14).Rules[0])
+	c.Rules[0].ID, c.Rules[1].ID = first, second
+	return c
+}
+
+// generateAWSReversedTwoRuleLifecycle returns the rules of
+// generateTwoRuleLifecycleConfig in reverse order.
+func generateAWSReversedTwoRuleLifecycle(first, second *string, secondDays int32) []s3types.LifecycleRule {
+	rules := []s3types.LifecycleRule{generateAWSAbortMultipartLifecycle(secondDays).Rules[0], generateAWSAbortMultipartLifecycle(7).Rules[0]}
+	rules[0].ID, rules[1].ID = second, first
+	return rules
+}
+
 func TestLifecycleObserve(t *testing.T) {
 	type args struct {
 		cl *LifecycleConfigurationClient
@@ -176,7 +367,7 @@ func TestLifecycleObserve(t *testing.T) {
 					MockGetBucketLifecycleConfiguration: func(ctx context.Context, input *s3.GetBucketLifecycleConfigurationInput, opts []func(*s3.Options)) (*s3.GetBucketLifecycleConfigurationOutput, error) {
 						return nil, errBoom
 					},
-				}),
+				}, logging.NewNopLogger()),
 			},
 			want: want{
 				status: NeedsUpdate,
@@ -190,7 +381,7 @@ func TestLifecycleObserve(t *testing.T) {
 					MockGetBucketLifecycleConfiguration: func(ctx context.Context, input *s3.GetBucketLifecycleConfigurationInput, opts []func(*s3.Options)) (*s3.GetBucketLifecycleConfigurationOutput, error) {
 						return &s3.GetBucketLifecycleConfigurationOutput{Rules: nil}, nil
 					},
-				}),
+				}, logging.NewNopLogger()),
 			},
 			want: want{
 				status: NeedsUpdate,
@@ -204,7 +395,7 @@ func TestLifecycleObserve(t *testing.T) {
 					MockGetBucketLifecycleConfiguration: func(ctx context.Context, input *s3.GetBucketLifecycleConfigurationInput, opts []func(*s3.Options)) (*s3.GetBucketLifecycleConfigurationOutput, error) {
 						return &s3.GetBucketLifecycleConfigurationOutput{Rules: generateAWSLifecycle(false).Rules}, nil
 					},
-				}),
+				}, logging.NewNopLogger()),
 			},
 			want: want{
 				status: NeedsDeletion,
@@ -218,7 +409,7 @@ func TestLifecycleObserve(t *testing.T) {
 					MockGetBucketLifecycleConfiguration: func(ctx context.Context, input *s3.GetBucketLifecycleConfigurationInput, opts []func(*s3.Options)) (*s3.GetBucketLifecycleConfigurationOutput, error) {
 						return nil, &smithy.GenericAPIError{Code: clients3.LifecycleNotFoundErrCode}
 					},
-				}),
+				}, logging.NewNopLogger()),
 			},
 			want: want{
 				status: Updated,
@@ -232,7 +423,7 @@ func TestLifecycleObserve(t *testing.T) {
 					MockGetBucketLifecycleConfiguration: func(ctx context.Context, input *s3.GetBucketLifecycleConfigurationInput, opts []func(*s3.Options)) (*s3.GetBucketLifecycleConfigurationOutput, error) {
 						return &s3.GetBucketLifecycleConfigurationOutput{Rules: nil}, nil
 					},
-				}),
+				}, logging.NewNopLogger()),
 			},
 			want: want{
 				status: Updated,
@@ -246,13 +437,205 @@ func TestLifecycleObserve(t *testing.T) {
 					MockGetBucketLifecycleConfiguration: func(ctx context.Context, input *s3.GetBucketLifecycleConfigurationInput, opts []func(*s3.Options)) (*s3.GetBucketLifecycleConfigurationOutput, error) {
 						return &s3.GetBucketLifecycleConfigurationOutput{Rules: generateAWSLifecycle(false).Rules}, nil
 					},
-				}),
+				}, logging.NewNopLogger()),
+			},
+			want: want{
+				status: Updated,
+				err:    nil,
+			},
+		},
+		"NoUpdateExistsDeleteMarker": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithLifecycleConfig(generateDeleteMarkerLifecycleConfig(0))),
+				cl: NewLifecycleConfigurationClient(fake.MockBucketClient{
+					MockGetBucketLifecycleConfiguration: func(ctx context.Context, input *s3.GetBucketLifecycleConfigurationInput, opts []func(*s3.Options)) (*s3.GetBucketLifecycleConfigurationOutput, error) {
+						return &s3.GetBucketLifecycleConfigurationOutput{Rules: generateAWSDeleteMarkerLifecycle(true).Rules}, nil
+					},
+				}, logging.NewNopLogger()),
+			},
+			want: want{
+				status: Updated,
+				err:    nil,
+			},
+		},
+		"NoUpdateAbortMultipart": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithLifecycleConfig(generateAbortMultipartLifecycleConfig(7))),
+				cl: NewLifecycleConfigurationClient(fake.MockBucketClient{
+					MockGetBucketLifecycleConfiguration: func(ctx context.Context, input *s3.GetBucketLifecycleConfigurationInput, opts []func(*s3.Options)) (*s3.GetBucketLifecycleConfigurationOutput, error) {
+						return &s3.GetBucketLifecycleConfigurationOutput{Rules: generateAWSAbortMultipartLifecycle(7).Rules}, nil
+					},
+				}, logging.NewNopLogger()),
+			},
+			want: want{
+				status: Updated,
+				err:    nil,
+			},
+		},
+		"UpdateNeededAbortMultipartDays": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithLifecycleConfig(generateAbortMultipartLifecycleConfig(7))),
+				cl: NewLifecycleConfigurationClient(fake.MockBucketClient{
+					MockGetBucketLifecycleConfiguration: func(ctx context.Context, input *s3.GetBucketLifecycleConfigurationInput, opts []func(*s3.Options)) (*s3.GetBucketLifecycleConfigurationOutput, error) {
+						return &s3.GetBucketLifecycleConfigurationOutput{Rules: generateAWSAbortMultipartLifecycle(3).Rules}, nil
+					},
+				}, logging.NewNopLogger()),
+			},
+			want: want{
+				status: NeedsUpdate,
+				err:    nil,
+			},
+		},
+		"NoUpdateWholeBucketNilFilter": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithLifecycleConfig(generateWholeBucketLifecycleConfig(nil))),
+				cl: NewLifecycleConfigurationClient(fake.MockBucketClient{
+					MockGetBucketLifecycleConfiguration: func(ctx context.Context, input *s3.GetBucketLifecycleConfigurationInput, opts []func(*s3.Options)) (*s3.GetBucketLifecycleConfigurationOutput, error) {
+						return &s3.GetBucketLifecycleConfigurationOutput{Rules: generateAWSWholeBucketLifecycle(nil, nil)}, nil
+					},
+				}, logging.NewNopLogger()),
+			},
+			want: want{
+				status: Updated,
+			},
+		},
+		"NoUpdateWholeBucketEmptyFilter": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithLifecycleConfig(generateWholeBucketLifecycleConfig(&v1beta1.LifecycleRuleFilter{}))),
+				cl: NewLifecycleConfigurationClient(fake.MockBucketClient{
+					MockGetBucketLifecycleConfiguration: func(ctx context.Context, input *s3.GetBucketLifecycleConfigurationInput, opts []func(*s3.Options)) (*s3.GetBucketLifecycleConfigurationOutput, error) {
+						return &s3.GetBucketLifecycleConfigurationOutput{Rules: generateAWSWholeBucketLifecycle(&s3types.LifecycleRuleFilterMemberPrefix{}, nil)}, nil
+					},
+				}, logging.NewNopLogger()),
+			},
+			want: want{
+				status: Updated,
+			},
+		},
+		"NoUpdateWholeBucketLegacyPrefix": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithLifecycleConfig(generateWholeBucketLifecycleConfig(nil))),
+				cl: NewLifecycleConfigurationClient(fake.MockBucketClient{
+					MockGetBucketLifecycleConfiguration: func(ctx context.Context, input *s3.GetBucketLifecycleConfigurationInput, opts []func(*s3.Options)) (*s3.GetBucketLifecycleConfigurationOutput, error) {
+						return &s3.GetBucketLifecycleConfigurationOutput{Rules: generateAWSWholeBucketLifecycle(nil, awsclient.String(""))}, nil
+					},
+				}, logging.NewNopLogger()),
+			},
+			want: want{
+				status: Updated,
+			},
+		},
+		"UpdateNeededWholeBucketPrefixAdded": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithLifecycleConfig(generateWholeBucketLifecycleConfig(&v1beta1.LifecycleRuleFilter{Prefix: awsclient.String("logs/")}))),
+				cl: NewLifecycleConfigurationClient(fake.MockBucketClient{
+					MockGetBucketLifecycleConfiguration: func(ctx context.Context, input *s3.GetBucketLifecycleConfigurationInput, opts []func(*s3.Options)) (*s3.GetBucketLifecycleConfigurationOutput, error) {
+						return &s3.GetBucketLifecycleConfigurationOutput{Rules: generateAWSWholeBucketLifecycle(nil, nil)}, nil
+					},
+				}, logging.NewNopLogger()),
+			},
+			want: want{
+				status: NeedsUpdate,
+			},
+		},
+		"NoUpdateDateTransitionOtherLocation": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithLifecycleConfig(generateDateTransitionLifecycleConfig(metav1.NewTime(awsMidnight.In(cest))))),
+				cl: NewLifecycleConfigurationClient(fake.MockBucketClient{
+					MockGetBucketLifecycleConfiguration: func(ctx context.Context, input *s3.GetBucketLifecycleConfigurationInput, opts []func(*s3.Options)) (*s3.GetBucketLifecycleConfigurationOutput, error) {
+						return &s3.GetBucketLifecycleConfigurationOutput{Rules: generateAWSDateTransitionLifecycle(awsMidnight).Rules}, nil
+					},
+				}, logging.NewNopLogger()),
 			},
 			want: want{
 				status: Updated,
 				err:    nil,
 			},
 		},
+		"NoUpdateDateTransitionTimeOfDay": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithLifecycleConfig(generateDateTransitionLifecycleConfig(metav1.NewTime(awsMidnight.Add(13 * time.Hour))))),
+				cl: NewLifecycleConfigurationClient(fake.MockBucketClient{
+					MockGetBucketLifecycleConfiguration: func(ctx context.Context, input *s3.GetBucketLifecycleConfigurationInput, opts []func(*s3.Options)) (*s3.GetBucketLifecycleConfigurationOutput, error) {
+						return &s3.GetBucketLifecycleConfigurationOutput{Rules: generateAWSDateTransitionLifecycle(awsMidnight).Rules}, nil
+					},
+				}, logging.NewNopLogger()),
+			},
+			want: want{
+				status: Updated,
+				err:    nil,
+			},
+		},
+		"UpdateNeededDateTransition": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithLifecycleConfig(generateDateTransitionLifecycleConfig(metav1.NewTime(awsMidnight.AddDate(0, 0, 1))))),
+				cl: NewLifecycleConfigurationClient(fake.MockBucketClient{
+					MockGetBucketLifecycleConfiguration: func(ctx context.Context, input *s3.GetBucketLifecycleConfigurationInput, opts []func(*s3.Options)) (*s3.GetBucketLifecycleConfigurationOutput, error) {
+						return &s3.GetBucketLifecycleConfigurationOutput{Rules: generateAWSDateTransitionLifecycle(awsMidnight).Rules}, nil
+					},
+				}, logging.NewNopLogger()),
+			},
+			want: want{
+				status: NeedsUpdate,
+				err:    nil,
+			},
+		},
+		"NoUpdateReorderedByID": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithLifecycleConfig(generateTwoRuleLifecycleConfig(awsclient.String("first"), awsclient.String("second")))),
+				cl: NewLifecycleConfigurationClient(fake.MockBucketClient{
+					MockGetBucketLifecycleConfiguration: func(ctx context.Context, input *s3.GetBucketLifecycleConfigurationInput, opts []func(*s3.Options)) (*s3.GetBucketLifecycleConfigurationOutput, error) {
+						return &s3.GetBucketLifecycleConfigurationOutput{Rules: generateAWSReversedTwoRuleLifecycle(awsclient.String("first"), awsclient.String("second"), 14)}, nil
+					},
+				}, logging.NewNopLogger()),
+			},
+			want: want{
+				status: Updated,
+				err:    nil,
+			},
+		},
+		"UpdateNeededReorderedByIDModified": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithLifecycleConfig(generateTwoRuleLifecycleConfig(awsclient.String("first"), awsclient.String("second")))),
+				cl: NewLifecycleConfigurationClient(fake.MockBucketClient{
+					MockGetBucketLifecycleConfiguration: func(ctx context.Context, input *s3.GetBucketLifecycleConfigurationInput, opts []func(*s3.Options)) (*s3.GetBucketLifecycleConfigurationOutput, error) {
+						return &s3.GetBucketLifecycleConfigurationOutput{Rules: generateAWSReversedTwoRuleLifecycle(awsclient.String("first"), awsclient.String("second"), 30)}, nil
+					},
+				}, logging.NewNopLogger()),
+			},
+			want: want{
+				status: NeedsUpdate,
+				err:    nil,
+			},
+		},
+		"UpdateNeededReorderedWithoutIDs": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithLifecycleConfig(generateTwoRuleLifecycleConfig(nil, nil))),
+				cl: NewLifecycleConfigurationClient(fake.MockBucketClient{
+					MockGetBucketLifecycleConfiguration: func(ctx context.Context, input *s3.GetBucketLifecycleConfigurationInput, opts []func(*s3.Options)) (*s3.GetBucketLifecycleConfigurationOutput, error) {
+						return &s3.GetBucketLifecycleConfigurationOutput{Rules: generateAWSReversedTwoRuleLifecycle(awsclient.String("first"), awsclient.String("second"), 14)}, nil
+					},
+				}, logging.NewNopLogger()),
+			},
+			want: want{
+				status: NeedsUpdate,
+				err:    nil,
+			},
+		},
+		"UpdateNeededDeleteMarker": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithLifecycleConfig(generateDeleteMarkerLifecycleConfig(0))),
+				cl: NewLifecycleConfigurationClient(fake.MockBucketClient{
+					MockGetBucketLifecycleConfiguration: func(ctx context.Context, input *s3.GetBucketLifecycleConfigurationInput, opts []func(*s3.Options)) (*s3.GetBucketLifecycleConfigurationOutput, error) {
+						return &s3.GetBucketLifecycleConfigurationOutput{Rules: generateAWSDeleteMarkerLifecycle(false).Rules}, nil
+					},
+				}, logging.NewNopLogger()),
+			},
+			want: want{
+				status: NeedsUpdate,
+				err:    nil,
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -289,7 +672,7 @@ func TestLifecycleCreateOrUpdate(t *testing.T) {
 					MockPutBucketLifecycleConfiguration: func(ctx context.Context, input *s3.PutBucketLifecycleConfigurationInput, opts []func(*s3.Options)) (*s3.PutBucketLifecycleConfigurationOutput, error) {
 						return nil, errBoom
 					},
-				}),
+				}, logging.NewNopLogger()),
 			},
 			want: want{
 				err: awsclient.Wrap(errBoom, lifecyclePutFailed),
@@ -302,7 +685,135 @@ func TestLifecycleCreateOrUpdate(t *testing.T) {
 					MockPutBucketLifecycleConfiguration: func(ctx context.Context, input *s3.PutBucketLifecycleConfigurationInput, opts []func(*s3.Options)) (*s3.PutBucketLifecycleConfigurationOutput, error) {
 						return &s3.PutBucketLifecycleConfigurationOutput{}, nil
 					},
-				}),
+				}, logging.NewNopLogger()),
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"InvalidDeleteMarkerWithDays": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithLifecycleConfig(generateDeleteMarkerLifecycleConfig(days))),
+				cl: NewLifecycleConfigurationClient(fake.MockBucketClient{
+					MockPutBucketLifecycleConfiguration: func(ctx context.Context, input *s3.PutBucketLifecycleConfigurationInput, opts []func(*s3.Options)) (*s3.PutBucketLifecycleConfigurationOutput, error) {
+						return nil, errBoom
+					},
+				}, logging.NewNopLogger()),
+			},
+			want: want{
+				err: errors.Errorf(lifecycleInvalidExpiration, 0),
+			},
+		},
+		"SuccessfulCreateDeleteMarker": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithLifecycleConfig(generateDeleteMarkerLifecycleConfig(0))),
+				cl: NewLifecycleConfigurationClient(fake.MockBucketClient{
+					MockPutBucketLifecycleConfiguration: func(ctx context.Context, input *s3.PutBucketLifecycleConfigurationInput, opts []func(*s3.Options)) (*s3.PutBucketLifecycleConfigurationOutput, error) {
+						return &s3.PutBucketLifecycleConfigurationOutput{}, nil
+					},
+				}, logging.NewNopLogger()),
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"SuccessfulCreateAbortMultipart": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithLifecycleConfig(generateAbortMultipartLifecycleConfig(7))),
+				cl: NewLifecycleConfigurationClient(fake.MockBucketClient{
+					MockPutBucketLifecycleConfiguration: func(ctx context.Context, input *s3.PutBucketLifecycleConfigurationInput, opts []func(*s3.Options)) (*s3.PutBucketLifecycleConfigurationOutput, error) {
+						if diff := cmp.Diff(generateAWSAbortMultipartLifecycle(7), input.LifecycleConfiguration, cmpopts.IgnoreTypes(document.NoSerde{})); diff != "" {
+							t.Errorf("r: -want, +got:\n%s", diff)
+						}
+						return &s3.PutBucketLifecycleConfigurationOutput{}, nil
+					},
+				}, logging.NewNopLogger()),
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"InvalidAbortMultipartWithAndTags": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithLifecycleConfig(func() *v1beta1.BucketLifecycleConfiguration {
+					c := generateLifecycleConfig()
+					c.Rules[0].AbortIncompleteMultipartUpload = &v1beta1.AbortIncompleteMultipartUpload{DaysAfterInitiation: 7}
+					return c
+				}())),
+				cl: NewLifecycleConfigurationClient(fake.MockBucketClient{}, logging.NewNopLogger()),
+			},
+			want: want{
+				err: errors.Errorf(lifecycleAbortWithTags, 0),
+			},
+		},
+		"InvalidAbortMultipartWithTag": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithLifecycleConfig(func() *v1beta1.BucketLifecycleConfiguration {
+					c := generateAbortMultipartLifecycleConfig(7)
+					c.Rules[0].Filter = &v1beta1.LifecycleRuleFilter{Tag: &tag}
+					return c
+				}())),
+				cl: NewLifecycleConfigurationClient(fake.MockBucketClient{}, logging.NewNopLogger()),
+			},
+			want: want{
+				err: errors.Errorf(lifecycleAbortWithTags, 0),
+			},
+		},
+		"SuccessfulCreateDateTransition": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithLifecycleConfig(generateDateTransitionLifecycleConfig(metav1.NewTime(awsMidnight.Add(13 * time.Hour).In(cest))))),
+				cl: NewLifecycleConfigurationClient(fake.MockBucketClient{
+					MockPutBucketLifecycleConfiguration: func(ctx context.Context, input *s3.PutBucketLifecycleConfigurationInput, opts []func(*s3.Options)) (*s3.PutBucketLifecycleConfigurationOutput, error) {
+						got := input.LifecycleConfiguration.Rules[0].Transitions[0].Date
+						if got == nil || got.Location() != time.UTC || !got.Equal(awsMidnight) {
+							t.Errorf("transition date: want %s, got %v", awsMidnight, got)
+						}
+						return &s3.PutBucketLifecycleConfigurationOutput{}, nil
+					},
+				}, logging.NewNopLogger()),
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"InvalidTransitionDaysAndDate": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithLifecycleConfig(func() *v1beta1.BucketLifecycleConfiguration {
+					c := generateDateTransitionLifecycleConfig(date)
+					c.Rules[0].Transitions[0].Days = days
+					return c
+				}())),
+				cl: NewLifecycleConfigurationClient(fake.MockBucketClient{}, logging.NewNopLogger()),
+			},
+			want: want{
+				err: errors.Errorf(lifecycleInvalidTransition, 0, 0),
+			},
+		},
+		"InvalidExpirationDaysAndDate": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithLifecycleConfig(func() *v1beta1.BucketLifecycleConfiguration {
+					c := generateLifecycleConfig()
+					c.Rules[0].Expiration.Date = &date
+					return c
+				}())),
+				cl: NewLifecycleConfigurationClient(fake.MockBucketClient{}, logging.NewNopLogger()),
+			},
+			want: want{
+				err: errors.Errorf(lifecycleExpirationDays, 0),
+			},
+		},
+		"SuccessfulCreateZeroDaysTransition": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithLifecycleConfig(func() *v1beta1.BucketLifecycleConfiguration {
+					c := generateLifecycleConfig()
+					c.Rules[0].Transitions[0].Days = 0
+					return c
+				}())),
+				cl: NewLifecycleConfigurationClient(fake.MockBucketClient{
+					MockPutBucketLifecycleConfiguration: func(ctx context.Context, input *s3.PutBucketLifecycleConfigurationInput, opts []func(*s3.Options)) (*s3.PutBucketLifecycleConfigurationOutput, error) {
+						return &s3.PutBucketLifecycleConfigurationOutput{}, nil
+					},
+				}, logging.NewNopLogger()),
 			},
 			want: want{
 				err: nil,
@@ -315,7 +826,7 @@ func TestLifecycleCreateOrUpdate(t *testing.T) {
 					MockPutBucketLifecycleConfiguration: func(ctx context.Context, input *s3.PutBucketLifecycleConfigurationInput, opts []func(*s3.Options)) (*s3.PutBucketLifecycleConfigurationOutput, error) {
 						return &s3.PutBucketLifecycleConfigurationOutput{}, nil
 					},
-				}),
+				}, logging.NewNopLogger()),
 			},
 			want: want{
 				err: nil,
@@ -354,7 +865,7 @@ func TestLifecycleDelete(t *testing.T) {
 					MockDeleteBucketLifecycle: func(ctx context.Context, input *s3.DeleteBucketLifecycleInput, opts []func(*s3.Options)) (*s3.DeleteBucketLifecycleOutput, error) {
 						return nil, errBoom
 					},
-				}),
+				}, logging.NewNopLogger()),
 			},
 			want: want{
 				err: awsclient.Wrap(errBoom, lifecycleDeleteFailed),
@@ -367,7 +878,7 @@ func TestLifecycleDelete(t *testing.T) {
 					MockDeleteBucketLifecycle: func(ctx context.Context, input *s3.DeleteBucketLifecycleInput, opts []func(*s3.Options)) (*s3.DeleteBucketLifecycleOutput, error) {
 						return &s3.DeleteBucketLifecycleOutput{}, nil
 					},
-				}),
+				}, logging.NewNopLogger()),
 			},
 			want: want{
 				err: nil,
@@ -407,7 +918,7 @@ func TestLifecycleLateInit(t *testing.T) {
 					MockGetBucketLifecycleConfiguration: func(ctx context.Context, input *s3.GetBucketLifecycleConfigurationInput, opts []func(*s3.Options)) (*s3.GetBucketLifecycleConfigurationOutput, error) {
 						return &s3.GetBucketLifecycleConfigurationOutput{}, errBoom
 					},
-				}),
+				}, logging.NewNopLogger()),
 			},
 			want: want{
 				err: awsclient.Wrap(errBoom, lifecycleGetFailed),
@@ -421,7 +932,7 @@ func TestLifecycleLateInit(t *testing.T) {
 					MockGetBucketLifecycleConfiguration: func(ctx context.Context, input *s3.GetBucketLifecycleConfigurationInput, opts []func(*s3.Options)) (*s3.GetBucketLifecycleConfigurationOutput, error) {
 						return &s3.GetBucketLifecycleConfigurationOutput{}, &smithy.GenericAPIError{Code: clients3.LifecycleNotFoundErrCode}
 					},
-				}),
+				}, logging.NewNopLogger()),
 			},
 			want: want{
 				err: nil,
@@ -435,7 +946,7 @@ func TestLifecycleLateInit(t *testing.T) {
 					MockGetBucketLifecycleConfiguration: func(ctx context.Context, input *s3.GetBucketLifecycleConfigurationInput, opts []func(*s3.Options)) (*s3.GetBucketLifecycleConfigurationOutput, error) {
 						return &s3.GetBucketLifecycleConfigurationOutput{Rules: make([]s3types.LifecycleRule, 0)}, nil
 					},
-				}),
+				}, logging.NewNopLogger()),
 			},
 			want: want{
 				err: nil,
@@ -449,7 +960,7 @@ func TestLifecycleLateInit(t *testing.T) {
 					MockGetBucketLifecycleConfiguration: func(ctx context.Context, input *s3.GetBucketLifecycleConfigurationInput, opts []func(*s3.Options)) (*s3.GetBucketLifecycleConfigurationOutput, error) {
 						return &s3.GetBucketLifecycleConfigurationOutput{Rules: generateAWSLifecycle(false).Rules}, nil
 					},
-				}),
+				}, logging.NewNopLogger()),
 			},
 			want: want{
 				err: nil,
@@ -465,7 +976,7 @@ func TestLifecycleLateInit(t *testing.T) {
 							{},
 						}}, nil
 					},
-				}),
+				}, logging.NewNopLogger()),
 			},
 			want: want{
 				err: nil,
@@ -18,15 +18,25 @@ package bucket
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
 
 	"github.com/aws/smithy-go/document"
 
 	awss3 "github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	awsv1 "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/kms"
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
 
 	"github.com/crossplane/provider-aws/apis/s3/v1beta1"
 	awsclient "github.com/crossplane/provider-aws/pkg/clients"
@@ -36,46 +46,425 @@ import (
 const (
 	loggingGetFailed = "cannot get Bucket logging configuration"
 	loggingPutFailed = "cannot put Bucket logging configuration"
+
+	loggingTargetClientFailed    = "cannot get client for logging target bucket"
+	loggingTargetAWSManagedKey   = "target bucket %s uses SSE-KMS default encryption with the AWS managed key aws/s3, whose key policy cannot allow log delivery; use a customer managed key or SSE-S3 (AES256) on the target bucket instead"
+	loggingKeyPolicyGetFailed    = "cannot get policy of KMS key %s"
+	loggingKeyPolicyPutFailed    = "cannot put policy of KMS key %s"
+	loggingKeyPolicyParseFailed  = "cannot parse policy of KMS key %s"
+	loggingKeyPolicyDefaultName  = "default"
+	loggingDeliveryPrincipal     = "logging.s3.amazonaws.com"
+	loggingDeliveryStatementBase = "S3ServerAccessLogDelivery"
+
+	loggingTargetLooksLikeCloudTrail = "logging target %q looks like a CloudTrail log destination; loggingConfiguration only manages S3 server access logs, which record requests made to this bucket, whereas object-level API activity is recorded by CloudTrail data events configured on a trail"
+
+	loggingGranteeMissingField = "target grant %d: grantee of type %s requires %s to be set"
+	loggingGranteeUnknownType  = "target grant %d: unknown grantee type %q"
 )
 
+// A KeyPolicyClient reads and writes the key policy of a KMS key.
+type KeyPolicyClient interface {
+	GetKeyPolicyWithContext(ctx awsv1.Context, input *kms.GetKeyPolicyInput, opts ...request.Option) (*kms.GetKeyPolicyOutput, error)
+	PutKeyPolicyWithContext(ctx awsv1.Context, input *kms.PutKeyPolicyInput, opts ...request.Option) (*kms.PutKeyPolicyOutput, error)
+}
+
 // LoggingConfigurationClient is the client for API methods and reconciling the LoggingConfiguration
 type LoggingConfigurationClient struct {
-	client s3.BucketClient
+	client    s3.BucketClient
+	clientFn  ClientForProviderConfigFn
+	keyPolicy KeyPolicyClient
 }
 
-// NewLoggingConfigurationClient creates the client for Logging Configuration
-func NewLoggingConfigurationClient(client s3.BucketClient) *LoggingConfigurationClient {
-	return &LoggingConfigurationClient{client: client}
+// NewLoggingConfigurationClient creates the client for Logging Configuration.
+// The clientFn is used for calls against a target bucket that references its
+// own ProviderConfig. If keyPolicy is set, the key policy of the KMS key a
+// target bucket encrypts new objects with by default is changed to allow S3
+// to deliver server access logs to it, and changed back once logging is
+// disabled, the target changes or the bucket is deleted.
+func NewLoggingConfigurationClient(client s3.BucketClient, clientFn ClientForProviderConfigFn, keyPolicy KeyPolicyClient) *LoggingConfigurationClient {
+	return &LoggingConfigurationClient{client: client, clientFn: clientFn, keyPolicy: keyPolicy}
 }
 
 // Observe checks if the resource exists and if it matches the local configuration
 func (in *LoggingConfigurationClient) Observe(ctx context.Context, bucket *v1beta1.Bucket) (ResourceStatus, error) {
 	external, err := in.client.GetBucketLogging(ctx, &awss3.GetBucketLoggingInput{Bucket: awsclient.String(meta.GetExternalName(bucket))})
+	bucket.Status.AtProvider.LoggingConfiguration = nil
 	if err != nil {
 		return NeedsUpdate, awsclient.Wrap(err, loggingGetFailed)
 	}
-	if !cmp.Equal(GenerateAWSLogging(bucket.Spec.ForProvider.LoggingConfiguration), external.LoggingEnabled,
-		cmpopts.IgnoreTypes(&xpv1.Reference{}, &xpv1.Selector{}), cmpopts.IgnoreTypes(document.NoSerde{})) {
+	if external != nil {
+		bucket.Status.AtProvider.LoggingConfiguration = GenerateLocalLogging(external.LoggingEnabled)
+	}
+	config := GenerateAWSLogging(bucket.Spec.ForProvider.LoggingConfiguration)
+	disabled := loggingDisabled(bucket.Spec.ForProvider.LoggingConfiguration)
+	// An empty response means that logging is disabled on the bucket.
+	if external == nil || external.LoggingEnabled == nil {
+		if config == nil || disabled {
+			return Updated, nil
+		}
+		return NeedsUpdate, nil
+	}
+	if bucket.Spec.ForProvider.LoggingConfiguration == nil {
+		return NeedsDeletion, nil
+	}
+	if disabled {
+		return NeedsUpdate, nil
+	}
+	if !cmp.Equal(config, external.LoggingEnabled,
+		cmpopts.IgnoreTypes(&xpv1.Reference{}, &xpv1.Selector{}), cmpopts.IgnoreTypes(document.NoSerde{}),
+		cmp.Comparer(func(a, b types.Type) bool { return s3.EnumEqual(string(a), string(b)) }),
+		cmp.Comparer(func(a, b types.BucketLogsPermission) bool { return s3.EnumEqual(string(a), string(b)) }),
+		cmpopts.SortSlices(targetGrantLess)) {
 		return NeedsUpdate, nil
 	}
 	return Updated, nil
 }
 
+// DryRunObserve returns the target bucket and prefix if they differ between
+// the local configuration and the logging configuration of the bucket. Both
+// are empty if logging is disabled or not configured. Target grants are not
+// part of the diff.
+func (in *LoggingConfigurationClient) DryRunObserve(ctx context.Context, bucket *v1beta1.Bucket) (Diff, error) {
+	config := bucket.Spec.ForProvider.LoggingConfiguration
+	external, err := in.client.GetBucketLogging(ctx, &awss3.GetBucketLoggingInput{Bucket: awsclient.String(meta.GetExternalName(bucket))})
+	if err != nil {
+		return nil, awsclient.Wrap(err, loggingGetFailed)
+	}
+	var wantBucket, wantPrefix, gotBucket, gotPrefix string
+	if config != nil && !loggingDisabled(config) {
+		wantBucket, wantPrefix = awsclient.StringValue(config.TargetBucket), config.TargetPrefix
+	}
+	if external != nil && external.LoggingEnabled != nil {
+		gotBucket, gotPrefix = awsclient.StringValue(external.LoggingEnabled.TargetBucket), awsclient.StringValue(external.LoggingEnabled.TargetPrefix)
+	}
+	var diff Diff
+	diff = diff.add("loggingConfiguration.targetBucket", wantBucket, gotBucket, exactly)
+	diff = diff.add("loggingConfiguration.targetPrefix", wantPrefix, gotPrefix, exactly)
+	return diff, nil
+}
+
 // CreateOrUpdate sends a request to have resource created on AWS
 func (in *LoggingConfigurationClient) CreateOrUpdate(ctx context.Context, bucket *v1beta1.Bucket) error {
 	if bucket.Spec.ForProvider.LoggingConfiguration == nil {
 		return nil
 	}
-	input := GeneratePutBucketLoggingInput(meta.GetExternalName(bucket), bucket.Spec.ForProvider.LoggingConfiguration)
-	_, err := in.client.PutBucketLogging(ctx, input)
-	return awsclient.Wrap(err, loggingPutFailed)
+	if loggingDisabled(bucket.Spec.ForProvider.LoggingConfiguration) {
+		return in.disableLogging(ctx, bucket)
+	}
+	config := bucket.Spec.ForProvider.LoggingConfiguration
+	if err := validateTargetGrants(config.TargetGrants); err != nil {
+		return err
+	}
+	previous, err := in.currentTarget(ctx, bucket)
+	if err != nil {
+		return err
+	}
+	keyID, err := in.grantTargetKeyAccess(ctx, bucket)
+	if err != nil {
+		return err
+	}
+	input := GeneratePutBucketLoggingInput(meta.GetExternalName(bucket), config)
+	if _, err := in.client.PutBucketLogging(ctx, input, s3.WithEmbeddedErrorCheck); err != nil {
+		return awsclient.Wrap(err, loggingPutFailed)
+	}
+	// Access to the key of the previous target is no longer needed once logs
+	// are delivered to another target, unless both use the same key.
+	if previous == nil || awsclient.StringValue(previous) == awsclient.StringValue(config.TargetBucket) {
+		return nil
+	}
+	previousKeyID, err := in.targetKey(ctx, previous, config.TargetProviderConfigReference)
+	if err != nil || previousKeyID == "" || previousKeyID == keyID {
+		return err
+	}
+	return in.revokeKeyAccess(ctx, bucket, previousKeyID)
 }
 
-// Delete does nothing because there is no deletion call for logging config.
-func (*LoggingConfigurationClient) Delete(_ context.Context, _ *v1beta1.Bucket) error {
+// targetGrantLess orders target grants by grantee ID, grantee type and
+// permission, since AWS does not return them in the order they were put. The
+// URI and email address break ties between grantees without an ID.
+func targetGrantLess(a, b types.TargetGrant) bool {
+	ka, kb := targetGrantKey(a), targetGrantKey(b)
+	for i := range ka {
+		if ka[i] != kb[i] {
+			return ka[i] < kb[i]
+		}
+	}
+	return false
+}
+
+func targetGrantKey(g types.TargetGrant) [5]string {
+	if g.Grantee == nil {
+		return [5]string{"", "", s3.NormalizeEnum(string(g.Permission))}
+	}
+	return [5]string{
+		awsclient.StringValue(g.Grantee.ID),
+		s3.NormalizeEnum(string(g.Grantee.Type)),
+		s3.NormalizeEnum(string(g.Permission)),
+		awsclient.StringValue(g.Grantee.URI),
+		awsclient.StringValue(g.Grantee.EmailAddress),
+	}
+}
+
+// LoggingTargetWarning returns a warning if the logging target of the supplied
+// configuration looks like a CloudTrail log destination, i.e. its bucket or
+// prefix contains "cloudtrail". Server access logging is often confused with
+// CloudTrail data events, and access logs written next to CloudTrail logs are
+// easily mistaken for them. It returns an empty string if there is nothing to
+// warn about.
+func LoggingTargetWarning(config *v1beta1.LoggingConfiguration) string {
+	if config == nil || loggingDisabled(config) {
+		return ""
+	}
+	for _, v := range []string{awsclient.StringValue(config.TargetBucket), config.TargetPrefix} {
+		if strings.Contains(strings.ToLower(v), "cloudtrail") {
+			return fmt.Sprintf(loggingTargetLooksLikeCloudTrail, awsclient.StringValue(config.TargetBucket)+"/"+config.TargetPrefix)
+		}
+	}
+	return ""
+}
+
+// loggingDisabled returns true if the configuration explicitly disables
+// logging. Logging is enabled if Enabled is not set.
+func loggingDisabled(config *v1beta1.LoggingConfiguration) bool {
+	return config != nil && config.Enabled != nil && !*config.Enabled
+}
+
+// validateTargetGrants returns an error if a grantee does not set the
+// identifier its type requires: ID for CanonicalUser, EmailAddress for
+// AmazonCustomerByEmail and URI for Group. S3 otherwise rejects the request
+// with a MalformedXML error that does not point at the offending grant.
+func validateTargetGrants(grants []v1beta1.TargetGrant) error {
+	for i, grant := range grants {
+		var field string
+		var value *string
+		switch types.Type(grant.Grantee.Type) {
+		case types.TypeCanonicalUser:
+			field, value = "ID", grant.Grantee.ID
+		case types.TypeAmazonCustomerByEmail:
+			field, value = "emailAddress", grant.Grantee.EmailAddress
+		case types.TypeGroup:
+			field, value = "URI", grant.Grantee.URI
+		default:
+			return errors.Errorf(loggingGranteeUnknownType, i, grant.Grantee.Type)
+		}
+		if awsclient.StringValue(value) == "" {
+			return errors.Errorf(loggingGranteeMissingField, i, grant.Grantee.Type, field)
+		}
+	}
 	return nil
 }
 
+// Delete disables logging on the bucket, since there is no deletion call for
+// the logging configuration.
+func (in *LoggingConfigurationClient) Delete(ctx context.Context, bucket *v1beta1.Bucket) error {
+	return in.disableLogging(ctx, bucket)
+}
+
+// Cleanup revokes the access to the KMS key of the current logging target
+// granted by CreateOrUpdate, since the key policy outlives the bucket. There
+// is nothing to revoke if the bucket is already gone.
+func (in *LoggingConfigurationClient) Cleanup(ctx context.Context, bucket *v1beta1.Bucket) error {
+	target, err := in.currentTarget(ctx, bucket)
+	if err != nil {
+		return resource.Ignore(s3.IsNoSuchBucket, err)
+	}
+	return in.revokeTargetKeyAccess(ctx, bucket, target, targetProviderConfigReference(bucket))
+}
+
+// disableLogging puts an empty logging status, which disables logging on the
+// bucket. Access to the KMS key of the target logs were delivered to is
+// revoked afterwards if it was granted.
+func (in *LoggingConfigurationClient) disableLogging(ctx context.Context, bucket *v1beta1.Bucket) error {
+	target, err := in.currentTarget(ctx, bucket)
+	if err != nil {
+		return err
+	}
+	_, err = in.client.PutBucketLogging(ctx, &awss3.PutBucketLoggingInput{
+		Bucket:              awsclient.String(meta.GetExternalName(bucket)),
+		BucketLoggingStatus: &types.BucketLoggingStatus{},
+	}, s3.WithEmbeddedErrorCheck)
+	if err != nil {
+		return awsclient.Wrap(err, loggingPutFailed)
+	}
+	return in.revokeTargetKeyAccess(ctx, bucket, target, targetProviderConfigReference(bucket))
+}
+
+// currentTarget returns the bucket the server access logs of the supplied
+// bucket are currently delivered to, or nil if logging is disabled. It is only
+// read if a key policy client is set, since it is only needed to revoke
+// access to the KMS key of the target.
+func (in *LoggingConfigurationClient) currentTarget(ctx context.Context, bucket *v1beta1.Bucket) (*string, error) {
+	if in.keyPolicy == nil {
+		return nil, nil
+	}
+	external, err := in.client.GetBucketLogging(ctx, &awss3.GetBucketLoggingInput{Bucket: awsclient.String(meta.GetExternalName(bucket))})
+	if err != nil {
+		return nil, awsclient.Wrap(err, loggingGetFailed)
+	}
+	if external == nil || external.LoggingEnabled == nil {
+		return nil, nil
+	}
+	return external.LoggingEnabled.TargetBucket, nil
+}
+
+// targetProviderConfigReference returns the ProviderConfig reference of the
+// logging target of the supplied bucket, if any.
+func targetProviderConfigReference(bucket *v1beta1.Bucket) *xpv1.Reference {
+	if bucket.Spec.ForProvider.LoggingConfiguration == nil {
+		return nil
+	}
+	return bucket.Spec.ForProvider.LoggingConfiguration.TargetProviderConfigReference
+}
+
+// grantTargetKeyAccess adds a statement to the key policy of the KMS key the
+// target bucket encrypts new objects with by default that allows S3 to use it
+// for delivering the server access logs of the bucket. The statement is
+// identified by a Sid derived from the name of the bucket, which is how it is
+// found again to revoke access. Nothing is changed unless a key policy client
+// is set. The check is best effort: if the encryption of the target cannot be
+// read, e.g. because it is owned by another account without a
+// targetProviderConfigRef being set, the key policy is left as is. It returns
+// the ID of the key access was granted to, if any.
+func (in *LoggingConfigurationClient) grantTargetKeyAccess(ctx context.Context, bucket *v1beta1.Bucket) (string, error) {
+	if in.keyPolicy == nil {
+		return "", nil
+	}
+	config := bucket.Spec.ForProvider.LoggingConfiguration
+	keyID, err := in.targetKey(ctx, config.TargetBucket, config.TargetProviderConfigReference)
+	if err != nil || keyID == "" {
+		return "", err
+	}
+	name := meta.GetExternalName(bucket)
+	return keyID, in.updateKeyPolicy(ctx, keyID, func(statements []interface{}) ([]interface{}, bool) {
+		sid := logDeliveryStatementID(name)
+		for _, s := range statements {
+			if statementID(s) == sid {
+				return statements, false
+			}
+		}
+		return append(statements, logDeliveryStatement(sid, name)), true
+	})
+}
+
+// revokeTargetKeyAccess removes the statement added by grantTargetKeyAccess
+// from the key policy of the KMS key the supplied target bucket encrypts new
+// objects with by default, if any.
+func (in *LoggingConfigurationClient) revokeTargetKeyAccess(ctx context.Context, bucket *v1beta1.Bucket, target *string, ref *xpv1.Reference) error {
+	keyID, err := in.targetKey(ctx, target, ref)
+	if err != nil || keyID == "" {
+		return err
+	}
+	return in.revokeKeyAccess(ctx, bucket, keyID)
+}
+
+// revokeKeyAccess removes the statement added by grantTargetKeyAccess from
+// the key policy of the supplied KMS key.
+func (in *LoggingConfigurationClient) revokeKeyAccess(ctx context.Context, bucket *v1beta1.Bucket, keyID string) error {
+	sid := logDeliveryStatementID(meta.GetExternalName(bucket))
+	return in.updateKeyPolicy(ctx, keyID, func(statements []interface{}) ([]interface{}, bool) {
+		kept := make([]interface{}, 0, len(statements))
+		for _, s := range statements {
+			if statementID(s) != sid {
+				kept = append(kept, s)
+			}
+		}
+		return kept, len(kept) != len(statements)
+	})
+}
+
+// targetKey returns the ID of the customer managed KMS key the supplied target
+// bucket encrypts new objects with by default. It is empty if the target does
+// not use SSE-KMS or its encryption cannot be read.
+func (in *LoggingConfigurationClient) targetKey(ctx context.Context, target *string, ref *xpv1.Reference) (string, error) {
+	if target == nil {
+		return "", nil
+	}
+	cl, err := clientFor(ctx, in.client, in.clientFn, ref)
+	if err != nil {
+		return "", errors.Wrap(err, loggingTargetClientFailed)
+	}
+	external, err := cl.GetBucketEncryption(ctx, &awss3.GetBucketEncryptionInput{Bucket: target})
+	if err != nil || external == nil || external.ServerSideEncryptionConfiguration == nil {
+		return "", nil
+	}
+	for _, rule := range external.ServerSideEncryptionConfiguration.Rules {
+		d := rule.ApplyServerSideEncryptionByDefault
+		if d == nil || !s3.EnumEqual(string(d.SSEAlgorithm), string(types.ServerSideEncryptionAwsKms)) {
+			continue
+		}
+		if awsclient.StringValue(d.KMSMasterKeyID) == "" {
+			return "", errors.Errorf(loggingTargetAWSManagedKey, awsclient.StringValue(target))
+		}
+		return awsclient.StringValue(d.KMSMasterKeyID), nil
+	}
+	return "", nil
+}
+
+// updateKeyPolicy applies fn to the statements of the default policy of the
+// supplied KMS key and puts the policy if fn reports a change. All other
+// elements of the policy are kept as they are.
+func (in *LoggingConfigurationClient) updateKeyPolicy(ctx context.Context, keyID string, fn func([]interface{}) ([]interface{}, bool)) error {
+	out, err := in.keyPolicy.GetKeyPolicyWithContext(ctx, &kms.GetKeyPolicyInput{KeyId: awsv1.String(keyID), PolicyName: awsv1.String(loggingKeyPolicyDefaultName)})
+	if err != nil {
+		return awsclient.Wrap(err, fmt.Sprintf(loggingKeyPolicyGetFailed, keyID))
+	}
+	policy := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(awsv1.StringValue(out.Policy)), &policy); err != nil {
+		return errors.Wrapf(err, loggingKeyPolicyParseFailed, keyID)
+	}
+	var statements []interface{}
+	switch s := policy["Statement"].(type) {
+	case []interface{}:
+		statements = s
+	case map[string]interface{}:
+		statements = []interface{}{s}
+	}
+	statements, changed := fn(statements)
+	if !changed {
+		return nil
+	}
+	policy["Statement"] = statements
+	raw, err := json.Marshal(policy)
+	if err != nil {
+		return errors.Wrapf(err, loggingKeyPolicyParseFailed, keyID)
+	}
+	_, err = in.keyPolicy.PutKeyPolicyWithContext(ctx, &kms.PutKeyPolicyInput{KeyId: awsv1.String(keyID), PolicyName: awsv1.String(loggingKeyPolicyDefaultName), Policy: awsv1.String(string(raw))})
+	return awsclient.Wrap(err, fmt.Sprintf(loggingKeyPolicyPutFailed, keyID))
+}
+
+// logDeliveryStatementID returns the Sid of the key policy statement that
+// allows S3 to deliver the server access logs of the named bucket. Sids may
+// only contain letters and digits, so the name is hashed.
+func logDeliveryStatementID(bucket string) string {
+	sum := sha256.Sum256([]byte(bucket))
+	return loggingDeliveryStatementBase + hex.EncodeToString(sum[:8])
+}
+
+// logDeliveryStatement returns a key policy statement that allows S3 to use
+// the key for delivering the server access logs of the named bucket only.
+func logDeliveryStatement(sid, bucket string) map[string]interface{} {
+	return map[string]interface{}{
+		"Sid":       sid,
+		"Effect":    "Allow",
+		"Principal": map[string]interface{}{"Service": loggingDeliveryPrincipal},
+		"Action":    []interface{}{"kms:GenerateDataKey*", "kms:Decrypt"},
+		"Resource":  "*",
+		"Condition": map[string]interface{}{
+			"StringEquals": map[string]interface{}{"aws:SourceArn": "arn:aws:s3:::" + bucket},
+		},
+	}
+}
+
+// statementID returns the Sid of the supplied key policy statement.
+func statementID(statement interface{}) string {
+	m, ok := statement.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	sid, _ := m["Sid"].(string)
+	return sid
+}
+
 // LateInitialize is responsible for initializing the resource based on the external value
 func (in *LoggingConfigurationClient) LateInitialize(ctx context.Context, bucket *v1beta1.Bucket) error {
 	external, err := in.client.GetBucketLogging(ctx, &awss3.GetBucketLoggingInput{Bucket: awsclient.String(meta.GetExternalName(bucket))})
@@ -95,28 +484,23 @@ func (in *LoggingConfigurationClient) LateInitialize(ctx context.Context, bucket
 
 	config := bucket.Spec.ForProvider.LoggingConfiguration
 	// Late initialize the target Bucket and target prefix
-	config.TargetBucket = awsclient.LateInitializeStringPtr(config.TargetBucket, external.LoggingEnabled.TargetBucket)
-	config.TargetPrefix = awsclient.LateInitializeString(config.TargetPrefix, external.LoggingEnabled.TargetPrefix)
+	awsclient.LateInitializeFields(
+		awsclient.LateInitializeStringPtrField(&config.TargetBucket, external.LoggingEnabled.TargetBucket),
+		awsclient.LateInitializeStringField(&config.TargetPrefix, external.LoggingEnabled.TargetPrefix),
+	)
 	// If the there is an external target grant list, and the local one does not exist
 	// we create the target grant list
 	if len(external.LoggingEnabled.TargetGrants) != 0 && config.TargetGrants == nil {
-		config.TargetGrants = make([]v1beta1.TargetGrant, len(external.LoggingEnabled.TargetGrants))
-		for i, v := range external.LoggingEnabled.TargetGrants {
-			config.TargetGrants[i] = v1beta1.TargetGrant{
-				Grantee: v1beta1.TargetGrantee{
-					DisplayName:  v.Grantee.DisplayName,
-					EmailAddress: v.Grantee.EmailAddress,
-					ID:           v.Grantee.ID,
-					Type:         string(v.Grantee.Type),
-					URI:          v.Grantee.URI,
-				},
-				Permission: string(v.Permission),
-			}
-		}
+		config.TargetGrants = GenerateLocalLogging(external.LoggingEnabled).TargetGrants
 	}
 	return nil
 }
 
+// CapturePrior returns a Bucket with the logging configuration currently in AWS.
+func (in *LoggingConfigurationClient) CapturePrior(ctx context.Context, bucket *v1beta1.Bucket) (*v1beta1.Bucket, error) {
+	return lateInitializedPrior(ctx, in, bucket)
+}
+
 // SubresourceExists checks if the subresource this controller manages currently exists
 func (in *LoggingConfigurationClient) SubresourceExists(bucket *v1beta1.Bucket) bool {
 	return bucket.Spec.ForProvider.LoggingConfiguration != nil
@@ -147,6 +531,35 @@ func GeneratePutBucketLoggingInput(name string, config *v1beta1.LoggingConfigura
 	return bci
 }
 
+// GenerateLocalLogging creates the local logging configuration from the
+// logging configuration returned by the S3 Client. It returns nil if logging
+// is disabled.
+func GenerateLocalLogging(external *types.LoggingEnabled) *v1beta1.LoggingConfiguration {
+	if external == nil {
+		return nil
+	}
+	local := &v1beta1.LoggingConfiguration{
+		TargetBucket: external.TargetBucket,
+		TargetPrefix: awsclient.StringValue(external.TargetPrefix),
+	}
+	if len(external.TargetGrants) != 0 {
+		local.TargetGrants = make([]v1beta1.TargetGrant, len(external.TargetGrants))
+	}
+	for i, v := range external.TargetGrants {
+		local.TargetGrants[i] = v1beta1.TargetGrant{Permission: string(v.Permission)}
+		if v.Grantee != nil {
+			local.TargetGrants[i].Grantee = v1beta1.TargetGrantee{
+				DisplayName:  v.Grantee.DisplayName,
+				EmailAddress: v.Grantee.EmailAddress,
+				ID:           v.Grantee.ID,
+				Type:         string(v.Grantee.Type),
+				URI:          v.Grantee.URI,
+			}
+		}
+	}
+	return local
+}
+
 // GenerateAWSLogging creates an S3 logging enabled struct from the local logging configuration
 func GenerateAWSLogging(local *v1beta1.LoggingConfiguration) *types.LoggingEnabled {
 	if local == nil {
@@ -18,21 +18,34 @@ package bucket
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"testing"
 
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	awsv1 "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/document"
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
 
 	"github.com/crossplane/provider-aws/apis/s3/v1beta1"
 	awsclient "github.com/crossplane/provider-aws/pkg/clients"
+	clientss3 "github.com/crossplane/provider-aws/pkg/clients/s3"
 	"github.com/crossplane/provider-aws/pkg/clients/s3/fake"
 	s3Testing "github.com/crossplane/provider-aws/pkg/controller/s3/testing"
 )
 
 var (
 	_           SubresourceClient = &LoggingConfigurationClient{}
+	_           DryRunClient      = &LoggingConfigurationClient{}
+	_           CleanupClient     = &LoggingConfigurationClient{}
 	bucketName                    = "test.Bucket.name"
 	permission                    = "FULL_CONTROL"
 	displayName                   = "name"
@@ -75,6 +88,53 @@ func generateAWSLogging() *s3types.LoggingEnabled {
 	}
 }
 
+func generateAWSTargetSSE(algorithm s3types.ServerSideEncryption) *s3types.ServerSideEncryptionConfiguration {
+	return &s3types.ServerSideEncryptionConfiguration{
+		Rules: []s3types.ServerSideEncryptionRule{
+			{
+				ApplyServerSideEncryptionByDefault: &s3types.ServerSideEncryptionByDefault{
+					SSEAlgorithm: algorithm,
+				},
+			},
+		},
+	}
+}
+
+func generateAWSTargetSSEWithKey(keyID string) *s3types.ServerSideEncryptionConfiguration {
+	config := generateAWSTargetSSE(s3types.ServerSideEncryptionAwsKms)
+	config.Rules[0].ApplyServerSideEncryptionByDefault.KMSMasterKeyID = &keyID
+	return config
+}
+
+func generateLoggingConfigWithTargetProviderConfig(name string) *v1beta1.LoggingConfiguration {
+	config := generateLoggingConfig()
+	config.TargetProviderConfigReference = &xpv1.Reference{Name: name}
+	return config
+}
+
+func generateLoggingConfigWithGrantee(grantee v1beta1.TargetGrantee) *v1beta1.LoggingConfiguration {
+	config := generateLoggingConfig()
+	config.TargetGrants = []v1beta1.TargetGrant{{Grantee: grantee, Permission: permission}}
+	return config
+}
+
+// generateLoggingConfigWithTwoGrants returns a logging configuration that
+// grants FULL_CONTROL to a canonical user and READ to a group.
+func generateLoggingConfigWithTwoGrants() *v1beta1.LoggingConfiguration {
+	config := generateLoggingConfig()
+	config.TargetGrants = append(config.TargetGrants, v1beta1.TargetGrant{
+		Grantee:    v1beta1.TargetGrantee{Type: string(s3types.TypeGroup), URI: &groupURI},
+		Permission: string(s3types.BucketLogsPermissionRead),
+	})
+	return config
+}
+
+func generateLoggingConfigWithEnabled(enabled bool) *v1beta1.LoggingConfiguration {
+	config := generateLoggingConfig()
+	config.Enabled = awsclient.Bool(enabled, awsclient.FieldRequired)
+	return config
+}
+
 func TestLoggingObserve(t *testing.T) {
 	type args struct {
 		cl *LoggingConfigurationClient
@@ -97,13 +157,55 @@ func TestLoggingObserve(t *testing.T) {
 					MockGetBucketLogging: func(ctx context.Context, input *s3.GetBucketLoggingInput, opts []func(*s3.Options)) (*s3.GetBucketLoggingOutput, error) {
 						return nil, errBoom
 					},
-				}),
+				}, nil, nil),
 			},
 			want: want{
 				status: NeedsUpdate,
 				err:    awsclient.Wrap(errBoom, loggingGetFailed),
 			},
 		},
+		"UpdateNeededDisabled": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithLoggingConfig(generateLoggingConfigWithEnabled(false))),
+				cl: NewLoggingConfigurationClient(fake.MockBucketClient{
+					MockGetBucketLogging: func(ctx context.Context, input *s3.GetBucketLoggingInput, opts []func(*s3.Options)) (*s3.GetBucketLoggingOutput, error) {
+						return &s3.GetBucketLoggingOutput{LoggingEnabled: generateAWSLogging()}, nil
+					},
+				}, nil, nil),
+			},
+			want: want{
+				status: NeedsUpdate,
+				err:    nil,
+			},
+		},
+		"NoUpdateDisabled": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithLoggingConfig(generateLoggingConfigWithEnabled(false))),
+				cl: NewLoggingConfigurationClient(fake.MockBucketClient{
+					MockGetBucketLogging: func(ctx context.Context, input *s3.GetBucketLoggingInput, opts []func(*s3.Options)) (*s3.GetBucketLoggingOutput, error) {
+						return &s3.GetBucketLoggingOutput{LoggingEnabled: nil}, nil
+					},
+				}, nil, nil),
+			},
+			want: want{
+				status: Updated,
+				err:    nil,
+			},
+		},
+		"NoUpdateEnabled": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithLoggingConfig(generateLoggingConfigWithEnabled(true))),
+				cl: NewLoggingConfigurationClient(fake.MockBucketClient{
+					MockGetBucketLogging: func(ctx context.Context, input *s3.GetBucketLoggingInput, opts []func(*s3.Options)) (*s3.GetBucketLoggingOutput, error) {
+						return &s3.GetBucketLoggingOutput{LoggingEnabled: generateAWSLogging()}, nil
+					},
+				}, nil, nil),
+			},
+			want: want{
+				status: Updated,
+				err:    nil,
+			},
+		},
 		"UpdateNeeded": {
 			args: args{
 				b: s3Testing.Bucket(s3Testing.WithLoggingConfig(generateLoggingConfig())),
@@ -111,7 +213,7 @@ func TestLoggingObserve(t *testing.T) {
 					MockGetBucketLogging: func(ctx context.Context, input *s3.GetBucketLoggingInput, opts []func(*s3.Options)) (*s3.GetBucketLoggingOutput, error) {
 						return &s3.GetBucketLoggingOutput{LoggingEnabled: nil}, nil
 					},
-				}),
+				}, nil, nil),
 			},
 			want: want{
 				status: NeedsUpdate,
@@ -125,7 +227,35 @@ func TestLoggingObserve(t *testing.T) {
 					MockGetBucketLogging: func(ctx context.Context, input *s3.GetBucketLoggingInput, opts []func(*s3.Options)) (*s3.GetBucketLoggingOutput, error) {
 						return &s3.GetBucketLoggingOutput{LoggingEnabled: nil}, nil
 					},
-				}),
+				}, nil, nil),
+			},
+			want: want{
+				status: Updated,
+				err:    nil,
+			},
+		},
+		"UpdateNeededEmptyResponse": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithLoggingConfig(generateLoggingConfig())),
+				cl: NewLoggingConfigurationClient(fake.MockBucketClient{
+					MockGetBucketLogging: func(ctx context.Context, input *s3.GetBucketLoggingInput, opts []func(*s3.Options)) (*s3.GetBucketLoggingOutput, error) {
+						return nil, nil
+					},
+				}, nil, nil),
+			},
+			want: want{
+				status: NeedsUpdate,
+				err:    nil,
+			},
+		},
+		"NoUpdateEmptyResponse": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithLoggingConfig(nil)),
+				cl: NewLoggingConfigurationClient(fake.MockBucketClient{
+					MockGetBucketLogging: func(ctx context.Context, input *s3.GetBucketLoggingInput, opts []func(*s3.Options)) (*s3.GetBucketLoggingOutput, error) {
+						return nil, nil
+					},
+				}, nil, nil),
 			},
 			want: want{
 				status: Updated,
@@ -139,13 +269,97 @@ func TestLoggingObserve(t *testing.T) {
 					MockGetBucketLogging: func(ctx context.Context, input *s3.GetBucketLoggingInput, opts []func(*s3.Options)) (*s3.GetBucketLoggingOutput, error) {
 						return &s3.GetBucketLoggingOutput{LoggingEnabled: generateAWSLogging()}, nil
 					},
-				}),
+				}, nil, nil),
+			},
+			want: want{
+				status: Updated,
+				err:    nil,
+			},
+		},
+		"NeedsDeletion": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithLoggingConfig(nil)),
+				cl: NewLoggingConfigurationClient(fake.MockBucketClient{
+					MockGetBucketLogging: func(ctx context.Context, input *s3.GetBucketLoggingInput, opts []func(*s3.Options)) (*s3.GetBucketLoggingOutput, error) {
+						return &s3.GetBucketLoggingOutput{LoggingEnabled: generateAWSLogging()}, nil
+					},
+				}, nil, nil),
+			},
+			want: want{
+				status: NeedsDeletion,
+				err:    nil,
+			},
+		},
+		"NoUpdateEnumCase": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithLoggingConfig(func() *v1beta1.LoggingConfiguration {
+					c := generateLoggingConfig()
+					c.TargetGrants[0].Grantee.Type = "canonicaluser"
+					c.TargetGrants[0].Permission = "full_control"
+					return c
+				}())),
+				cl: NewLoggingConfigurationClient(fake.MockBucketClient{
+					MockGetBucketLogging: func(ctx context.Context, input *s3.GetBucketLoggingInput, opts []func(*s3.Options)) (*s3.GetBucketLoggingOutput, error) {
+						return &s3.GetBucketLoggingOutput{LoggingEnabled: generateAWSLogging()}, nil
+					},
+				}, nil, nil),
 			},
 			want: want{
 				status: Updated,
 				err:    nil,
 			},
 		},
+		"NoUpdateGrantsReordered": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithLoggingConfig(generateLoggingConfigWithTwoGrants())),
+				cl: NewLoggingConfigurationClient(fake.MockBucketClient{
+					MockGetBucketLogging: func(ctx context.Context, input *s3.GetBucketLoggingInput, opts []func(*s3.Options)) (*s3.GetBucketLoggingOutput, error) {
+						l := generateAWSLogging()
+						l.TargetGrants = []s3types.TargetGrant{
+							{Grantee: &s3types.Grantee{Type: s3types.TypeGroup, URI: &groupURI}, Permission: s3types.BucketLogsPermissionRead},
+							l.TargetGrants[0],
+						}
+						return &s3.GetBucketLoggingOutput{LoggingEnabled: l}, nil
+					},
+				}, nil, nil),
+			},
+			want: want{
+				status: Updated,
+				err:    nil,
+			},
+		},
+		"UpdateNeededGrantMissing": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithLoggingConfig(generateLoggingConfigWithTwoGrants())),
+				cl: NewLoggingConfigurationClient(fake.MockBucketClient{
+					MockGetBucketLogging: func(ctx context.Context, input *s3.GetBucketLoggingInput, opts []func(*s3.Options)) (*s3.GetBucketLoggingOutput, error) {
+						return &s3.GetBucketLoggingOutput{LoggingEnabled: generateAWSLogging()}, nil
+					},
+				}, nil, nil),
+			},
+			want: want{
+				status: NeedsUpdate,
+				err:    nil,
+			},
+		},
+		"UpdateNeededPermission": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithLoggingConfig(func() *v1beta1.LoggingConfiguration {
+					c := generateLoggingConfig()
+					c.TargetGrants[0].Permission = "READ"
+					return c
+				}())),
+				cl: NewLoggingConfigurationClient(fake.MockBucketClient{
+					MockGetBucketLogging: func(ctx context.Context, input *s3.GetBucketLoggingInput, opts []func(*s3.Options)) (*s3.GetBucketLoggingOutput, error) {
+						return &s3.GetBucketLoggingOutput{LoggingEnabled: generateAWSLogging()}, nil
+					},
+				}, nil, nil),
+			},
+			want: want{
+				status: NeedsUpdate,
+				err:    nil,
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -179,10 +393,42 @@ func TestLoggingCreateOrUpdate(t *testing.T) {
 			args: args{
 				b: s3Testing.Bucket(s3Testing.WithLoggingConfig(generateLoggingConfig())),
 				cl: NewLoggingConfigurationClient(fake.MockBucketClient{
+					MockGetBucketEncryption: func(ctx context.Context, input *s3.GetBucketEncryptionInput, opts []func(*s3.Options)) (*s3.GetBucketEncryptionOutput, error) {
+						return nil, &smithy.GenericAPIError{Code: clientss3.SSENotFoundErrCode}
+					},
 					MockPutBucketLogging: func(ctx context.Context, input *s3.PutBucketLoggingInput, opts []func(*s3.Options)) (*s3.PutBucketLoggingOutput, error) {
 						return nil, errBoom
 					},
-				}),
+				}, nil, nil),
+			},
+			want: want{
+				err: awsclient.Wrap(errBoom, loggingPutFailed),
+			},
+		},
+		"Disabled": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithLoggingConfig(generateLoggingConfigWithEnabled(false))),
+				cl: NewLoggingConfigurationClient(fake.MockBucketClient{
+					MockPutBucketLogging: func(ctx context.Context, input *s3.PutBucketLoggingInput, opts []func(*s3.Options)) (*s3.PutBucketLoggingOutput, error) {
+						if input.BucketLoggingStatus == nil || input.BucketLoggingStatus.LoggingEnabled != nil {
+							return nil, errors.New("logging is not disabled")
+						}
+						return &s3.PutBucketLoggingOutput{}, nil
+					},
+				}, nil, nil),
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"DisabledError": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithLoggingConfig(generateLoggingConfigWithEnabled(false))),
+				cl: NewLoggingConfigurationClient(fake.MockBucketClient{
+					MockPutBucketLogging: func(ctx context.Context, input *s3.PutBucketLoggingInput, opts []func(*s3.Options)) (*s3.PutBucketLoggingOutput, error) {
+						return nil, errBoom
+					},
+				}, nil, nil),
 			},
 			want: want{
 				err: awsclient.Wrap(errBoom, loggingPutFailed),
@@ -192,10 +438,13 @@ func TestLoggingCreateOrUpdate(t *testing.T) {
 			args: args{
 				b: s3Testing.Bucket(s3Testing.WithLoggingConfig(generateLoggingConfig())),
 				cl: NewLoggingConfigurationClient(fake.MockBucketClient{
+					MockGetBucketEncryption: func(ctx context.Context, input *s3.GetBucketEncryptionInput, opts []func(*s3.Options)) (*s3.GetBucketEncryptionOutput, error) {
+						return nil, &smithy.GenericAPIError{Code: clientss3.SSENotFoundErrCode}
+					},
 					MockPutBucketLogging: func(ctx context.Context, input *s3.PutBucketLoggingInput, opts []func(*s3.Options)) (*s3.PutBucketLoggingOutput, error) {
 						return &s3.PutBucketLoggingOutput{}, nil
 					},
-				}),
+				}, nil, nil),
 			},
 			want: want{
 				err: nil,
@@ -205,15 +454,154 @@ func TestLoggingCreateOrUpdate(t *testing.T) {
 			args: args{
 				b: s3Testing.Bucket(s3Testing.WithLoggingConfig(generateLoggingConfig())),
 				cl: NewLoggingConfigurationClient(fake.MockBucketClient{
+					MockGetBucketEncryption: func(ctx context.Context, input *s3.GetBucketEncryptionInput, opts []func(*s3.Options)) (*s3.GetBucketEncryptionOutput, error) {
+						return nil, &smithy.GenericAPIError{Code: clientss3.SSENotFoundErrCode}
+					},
+					MockPutBucketLogging: func(ctx context.Context, input *s3.PutBucketLoggingInput, opts []func(*s3.Options)) (*s3.PutBucketLoggingOutput, error) {
+						return &s3.PutBucketLoggingOutput{}, nil
+					},
+				}, nil, nil),
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"SuccessfulCreateAESTarget": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithLoggingConfig(generateLoggingConfig())),
+				cl: NewLoggingConfigurationClient(fake.MockBucketClient{
+					MockGetBucketEncryption: func(ctx context.Context, input *s3.GetBucketEncryptionInput, opts []func(*s3.Options)) (*s3.GetBucketEncryptionOutput, error) {
+						return &s3.GetBucketEncryptionOutput{ServerSideEncryptionConfiguration: generateAWSTargetSSE(s3types.ServerSideEncryptionAes256)}, nil
+					},
+					MockPutBucketLogging: func(ctx context.Context, input *s3.PutBucketLoggingInput, opts []func(*s3.Options)) (*s3.PutBucketLoggingOutput, error) {
+						return &s3.PutBucketLoggingOutput{}, nil
+					},
+				}, nil, nil),
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"CanonicalUserGrantee": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithLoggingConfig(generateLoggingConfigWithGrantee(v1beta1.TargetGrantee{
+					ID:   &id,
+					Type: string(s3types.TypeCanonicalUser),
+				}))),
+				cl: NewLoggingConfigurationClient(fake.MockBucketClient{
+					MockGetBucketEncryption: func(ctx context.Context, input *s3.GetBucketEncryptionInput, opts []func(*s3.Options)) (*s3.GetBucketEncryptionOutput, error) {
+						return nil, &smithy.GenericAPIError{Code: clientss3.SSENotFoundErrCode}
+					},
+					MockPutBucketLogging: func(ctx context.Context, input *s3.PutBucketLoggingInput, opts []func(*s3.Options)) (*s3.PutBucketLoggingOutput, error) {
+						return &s3.PutBucketLoggingOutput{}, nil
+					},
+				}, nil, nil),
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"CanonicalUserGranteeWithoutID": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithLoggingConfig(generateLoggingConfigWithGrantee(v1beta1.TargetGrantee{
+					URI:  &groupURI,
+					Type: string(s3types.TypeCanonicalUser),
+				}))),
+				cl: NewLoggingConfigurationClient(fake.MockBucketClient{}, nil, nil),
+			},
+			want: want{
+				err: errors.Errorf(loggingGranteeMissingField, 0, s3types.TypeCanonicalUser, "ID"),
+			},
+		},
+		"EmailGrantee": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithLoggingConfig(generateLoggingConfigWithGrantee(v1beta1.TargetGrantee{
+					EmailAddress: &email,
+					Type:         string(s3types.TypeAmazonCustomerByEmail),
+				}))),
+				cl: NewLoggingConfigurationClient(fake.MockBucketClient{
+					MockGetBucketEncryption: func(ctx context.Context, input *s3.GetBucketEncryptionInput, opts []func(*s3.Options)) (*s3.GetBucketEncryptionOutput, error) {
+						return nil, &smithy.GenericAPIError{Code: clientss3.SSENotFoundErrCode}
+					},
 					MockPutBucketLogging: func(ctx context.Context, input *s3.PutBucketLoggingInput, opts []func(*s3.Options)) (*s3.PutBucketLoggingOutput, error) {
 						return &s3.PutBucketLoggingOutput{}, nil
 					},
-				}),
+				}, nil, nil),
 			},
 			want: want{
 				err: nil,
 			},
 		},
+		"EmailGranteeWithoutEmailAddress": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithLoggingConfig(generateLoggingConfigWithGrantee(v1beta1.TargetGrantee{
+					ID:   &id,
+					Type: string(s3types.TypeAmazonCustomerByEmail),
+				}))),
+				cl: NewLoggingConfigurationClient(fake.MockBucketClient{}, nil, nil),
+			},
+			want: want{
+				err: errors.Errorf(loggingGranteeMissingField, 0, s3types.TypeAmazonCustomerByEmail, "emailAddress"),
+			},
+		},
+		"GroupGrantee": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithLoggingConfig(generateLoggingConfigWithGrantee(v1beta1.TargetGrantee{
+					URI:  &groupURI,
+					Type: string(s3types.TypeGroup),
+				}))),
+				cl: NewLoggingConfigurationClient(fake.MockBucketClient{
+					MockGetBucketEncryption: func(ctx context.Context, input *s3.GetBucketEncryptionInput, opts []func(*s3.Options)) (*s3.GetBucketEncryptionOutput, error) {
+						return nil, &smithy.GenericAPIError{Code: clientss3.SSENotFoundErrCode}
+					},
+					MockPutBucketLogging: func(ctx context.Context, input *s3.PutBucketLoggingInput, opts []func(*s3.Options)) (*s3.PutBucketLoggingOutput, error) {
+						return &s3.PutBucketLoggingOutput{}, nil
+					},
+				}, nil, nil),
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"GroupGranteeWithoutURI": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithLoggingConfig(generateLoggingConfigWithGrantee(v1beta1.TargetGrantee{
+					ID:   &id,
+					Type: string(s3types.TypeGroup),
+				}))),
+				cl: NewLoggingConfigurationClient(fake.MockBucketClient{}, nil, nil),
+			},
+			want: want{
+				err: errors.Errorf(loggingGranteeMissingField, 0, s3types.TypeGroup, "URI"),
+			},
+		},
+		"UnknownGranteeType": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithLoggingConfig(generateLoggingConfigWithGrantee(v1beta1.TargetGrantee{
+					ID:   &id,
+					Type: "Robot",
+				}))),
+				cl: NewLoggingConfigurationClient(fake.MockBucketClient{}, nil, nil),
+			},
+			want: want{
+				err: errors.Errorf(loggingGranteeUnknownType, 0, "Robot"),
+			},
+		},
+		"CrossAccountTargetClientError": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithLoggingConfig(generateLoggingConfigWithTargetProviderConfig("target"))),
+				cl: NewLoggingConfigurationClient(fake.MockBucketClient{
+					MockGetBucketLogging: func(ctx context.Context, input *s3.GetBucketLoggingInput, opts []func(*s3.Options)) (*s3.GetBucketLoggingOutput, error) {
+						return &s3.GetBucketLoggingOutput{}, nil
+					},
+				}, func(ctx context.Context, name string) (clientss3.BucketClient, error) {
+					return nil, errBoom
+				}, mockKeyPolicy{}),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, loggingTargetClientFailed),
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -226,6 +614,402 @@ func TestLoggingCreateOrUpdate(t *testing.T) {
 	}
 }
 
+func TestLoggingDelete(t *testing.T) {
+	type want struct {
+		err    error
+		status *s3types.BucketLoggingStatus
+	}
+
+	cases := map[string]struct {
+		err error
+		want
+	}{
+		"Error": {
+			err: errBoom,
+			want: want{
+				err:    awsclient.Wrap(errBoom, loggingPutFailed),
+				status: &s3types.BucketLoggingStatus{},
+			},
+		},
+		"SuccessfulDisable": {
+			want: want{
+				status: &s3types.BucketLoggingStatus{},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var status *s3types.BucketLoggingStatus
+			cl := NewLoggingConfigurationClient(fake.MockBucketClient{
+				MockPutBucketLogging: func(ctx context.Context, input *s3.PutBucketLoggingInput, opts []func(*s3.Options)) (*s3.PutBucketLoggingOutput, error) {
+					status = input.BucketLoggingStatus
+					return &s3.PutBucketLoggingOutput{}, tc.err
+				},
+			}, nil, nil)
+			err := cl.Delete(context.Background(), s3Testing.Bucket(s3Testing.WithLoggingConfig(nil)))
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.status, status, cmpopts.IgnoreTypes(document.NoSerde{})); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+type mockKeyPolicy struct {
+	MockGetKeyPolicy func(input *kms.GetKeyPolicyInput) (*kms.GetKeyPolicyOutput, error)
+	MockPutKeyPolicy func(input *kms.PutKeyPolicyInput) (*kms.PutKeyPolicyOutput, error)
+}
+
+func (m mockKeyPolicy) GetKeyPolicyWithContext(_ awsv1.Context, input *kms.GetKeyPolicyInput, _ ...request.Option) (*kms.GetKeyPolicyOutput, error) {
+	return m.MockGetKeyPolicy(input)
+}
+
+func (m mockKeyPolicy) PutKeyPolicyWithContext(_ awsv1.Context, input *kms.PutKeyPolicyInput, _ ...request.Option) (*kms.PutKeyPolicyOutput, error) {
+	return m.MockPutKeyPolicy(input)
+}
+
+// keyPolicy returns a key policy that consists of the supplied statements
+// after the statement that allows the account to manage the key.
+func keyPolicy(statements ...map[string]interface{}) string {
+	all := []interface{}{map[string]interface{}{
+		"Sid":       "EnableIAMUserPermissions",
+		"Effect":    "Allow",
+		"Principal": map[string]interface{}{"AWS": "arn:aws:iam::123456789012:root"},
+		"Action":    "kms:*",
+		"Resource":  "*",
+	}}
+	for _, s := range statements {
+		all = append(all, s)
+	}
+	raw, _ := json.Marshal(map[string]interface{}{"Version": "2012-10-17", "Statement": all})
+	return string(raw)
+}
+
+func TestLoggingTargetKeyAccess(t *testing.T) {
+	granted := logDeliveryStatement(logDeliveryStatementID(s3Testing.BucketName), s3Testing.BucketName)
+
+	type args struct {
+		b        *v1beta1.Bucket
+		delete   bool
+		target   *s3types.ServerSideEncryptionConfiguration
+		clientFn ClientForProviderConfigFn
+		policy   string
+		getErr   error
+	}
+
+	type want struct {
+		err    error
+		policy *string
+	}
+
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"GrantAccess": {
+			args: args{
+				b:      s3Testing.Bucket(s3Testing.WithLoggingConfig(generateLoggingConfig())),
+				target: generateAWSTargetSSEWithKey(keyID),
+				policy: keyPolicy(),
+			},
+			want: want{
+				policy: awsclient.String(keyPolicy(granted)),
+			},
+		},
+		"AlreadyGranted": {
+			args: args{
+				b:      s3Testing.Bucket(s3Testing.WithLoggingConfig(generateLoggingConfig())),
+				target: generateAWSTargetSSEWithKey(keyID),
+				policy: keyPolicy(granted),
+			},
+			want: want{},
+		},
+		"GrantAccessCrossAccountTarget": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithLoggingConfig(generateLoggingConfigWithTargetProviderConfig("target"))),
+				clientFn: func(ctx context.Context, name string) (clientss3.BucketClient, error) {
+					return fake.MockBucketClient{
+						MockGetBucketEncryption: func(ctx context.Context, input *s3.GetBucketEncryptionInput, opts []func(*s3.Options)) (*s3.GetBucketEncryptionOutput, error) {
+							return &s3.GetBucketEncryptionOutput{ServerSideEncryptionConfiguration: generateAWSTargetSSEWithKey(keyID)}, nil
+						},
+					}, nil
+				},
+				policy: keyPolicy(),
+			},
+			want: want{
+				policy: awsclient.String(keyPolicy(granted)),
+			},
+		},
+		"AESTarget": {
+			args: args{
+				b:      s3Testing.Bucket(s3Testing.WithLoggingConfig(generateLoggingConfig())),
+				target: generateAWSTargetSSE(s3types.ServerSideEncryptionAes256),
+			},
+			want: want{},
+		},
+		"AWSManagedKeyTarget": {
+			args: args{
+				b:      s3Testing.Bucket(s3Testing.WithLoggingConfig(generateLoggingConfig())),
+				target: generateAWSTargetSSE(s3types.ServerSideEncryptionAwsKms),
+			},
+			want: want{
+				err: errors.Errorf(loggingTargetAWSManagedKey, bucketName),
+			},
+		},
+		"GetKeyPolicyError": {
+			args: args{
+				b:      s3Testing.Bucket(s3Testing.WithLoggingConfig(generateLoggingConfig())),
+				target: generateAWSTargetSSEWithKey(keyID),
+				getErr: errBoom,
+			},
+			want: want{
+				err: awsclient.Wrap(errBoom, fmt.Sprintf(loggingKeyPolicyGetFailed, keyID)),
+			},
+		},
+		"RevokeAccess": {
+			args: args{
+				b:      s3Testing.Bucket(s3Testing.WithLoggingConfig(nil)),
+				delete: true,
+				target: generateAWSTargetSSEWithKey(keyID),
+				policy: keyPolicy(granted),
+			},
+			want: want{
+				policy: awsclient.String(keyPolicy()),
+			},
+		},
+		"RevokeAccessNotGranted": {
+			args: args{
+				b:      s3Testing.Bucket(s3Testing.WithLoggingConfig(nil)),
+				delete: true,
+				target: generateAWSTargetSSEWithKey(keyID),
+				policy: keyPolicy(),
+			},
+			want: want{},
+		},
+		"RevokeAccessWhenDisabled": {
+			args: args{
+				b:      s3Testing.Bucket(s3Testing.WithLoggingConfig(generateLoggingConfigWithEnabled(false))),
+				target: generateAWSTargetSSEWithKey(keyID),
+				policy: keyPolicy(granted),
+			},
+			want: want{
+				policy: awsclient.String(keyPolicy()),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var put *string
+			cl := NewLoggingConfigurationClient(fake.MockBucketClient{
+				MockGetBucketLogging: func(ctx context.Context, input *s3.GetBucketLoggingInput, opts []func(*s3.Options)) (*s3.GetBucketLoggingOutput, error) {
+					return &s3.GetBucketLoggingOutput{LoggingEnabled: generateAWSLogging()}, nil
+				},
+				MockPutBucketLogging: func(ctx context.Context, input *s3.PutBucketLoggingInput, opts []func(*s3.Options)) (*s3.PutBucketLoggingOutput, error) {
+					return &s3.PutBucketLoggingOutput{}, nil
+				},
+				MockGetBucketEncryption: func(ctx context.Context, input *s3.GetBucketEncryptionInput, opts []func(*s3.Options)) (*s3.GetBucketEncryptionOutput, error) {
+					return &s3.GetBucketEncryptionOutput{ServerSideEncryptionConfiguration: tc.args.target}, nil
+				},
+			}, tc.args.clientFn, mockKeyPolicy{
+				MockGetKeyPolicy: func(input *kms.GetKeyPolicyInput) (*kms.GetKeyPolicyOutput, error) {
+					if awsv1.StringValue(input.KeyId) != keyID {
+						return nil, errors.New("unexpected key")
+					}
+					return &kms.GetKeyPolicyOutput{Policy: awsv1.String(tc.args.policy)}, tc.args.getErr
+				},
+				MockPutKeyPolicy: func(input *kms.PutKeyPolicyInput) (*kms.PutKeyPolicyOutput, error) {
+					put = input.Policy
+					return &kms.PutKeyPolicyOutput{}, nil
+				},
+			})
+			var err error
+			if tc.args.delete {
+				err = cl.Delete(context.Background(), tc.args.b)
+			} else {
+				err = cl.CreateOrUpdate(context.Background(), tc.args.b)
+			}
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.policy, put); diff != "" {
+				t.Errorf("policy: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLoggingTargetChange(t *testing.T) {
+	granted := logDeliveryStatement(logDeliveryStatementID(s3Testing.BucketName), s3Testing.BucketName)
+	previousTarget := "previous.target"
+	previousKeyID := "previous-key"
+
+	type args struct {
+		previous *s3types.ServerSideEncryptionConfiguration
+		current  string
+	}
+
+	cases := map[string]struct {
+		args
+		want map[string]string
+	}{
+		"RevokePreviousKey": {
+			args: args{
+				previous: generateAWSTargetSSEWithKey(previousKeyID),
+				current:  previousTarget,
+			},
+			want: map[string]string{
+				keyID:         keyPolicy(granted),
+				previousKeyID: keyPolicy(),
+			},
+		},
+		"SameKey": {
+			args: args{
+				previous: generateAWSTargetSSEWithKey(keyID),
+				current:  previousTarget,
+			},
+			want: map[string]string{
+				keyID: keyPolicy(granted),
+			},
+		},
+		"PreviousTargetNotEncryptedWithKMS": {
+			args: args{
+				previous: generateAWSTargetSSE(s3types.ServerSideEncryptionAes256),
+				current:  previousTarget,
+			},
+			want: map[string]string{
+				keyID: keyPolicy(granted),
+			},
+		},
+		"TargetUnchanged": {
+			args: args{
+				current: bucketName,
+			},
+			want: map[string]string{
+				keyID: keyPolicy(granted),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			// The policy of the key of the new target does not grant access
+			// yet, the policy of the key of the previous target does.
+			policies := map[string]string{keyID: keyPolicy(), previousKeyID: keyPolicy(granted)}
+			put := map[string]string{}
+			cl := NewLoggingConfigurationClient(fake.MockBucketClient{
+				MockGetBucketLogging: func(ctx context.Context, input *s3.GetBucketLoggingInput, opts []func(*s3.Options)) (*s3.GetBucketLoggingOutput, error) {
+					return &s3.GetBucketLoggingOutput{LoggingEnabled: &s3types.LoggingEnabled{TargetBucket: awsclient.String(tc.args.current)}}, nil
+				},
+				MockPutBucketLogging: func(ctx context.Context, input *s3.PutBucketLoggingInput, opts []func(*s3.Options)) (*s3.PutBucketLoggingOutput, error) {
+					return &s3.PutBucketLoggingOutput{}, nil
+				},
+				MockGetBucketEncryption: func(ctx context.Context, input *s3.GetBucketEncryptionInput, opts []func(*s3.Options)) (*s3.GetBucketEncryptionOutput, error) {
+					if awsclient.StringValue(input.Bucket) == previousTarget {
+						return &s3.GetBucketEncryptionOutput{ServerSideEncryptionConfiguration: tc.args.previous}, nil
+					}
+					return &s3.GetBucketEncryptionOutput{ServerSideEncryptionConfiguration: generateAWSTargetSSEWithKey(keyID)}, nil
+				},
+			}, nil, mockKeyPolicy{
+				MockGetKeyPolicy: func(input *kms.GetKeyPolicyInput) (*kms.GetKeyPolicyOutput, error) {
+					return &kms.GetKeyPolicyOutput{Policy: awsv1.String(policies[awsv1.StringValue(input.KeyId)])}, nil
+				},
+				MockPutKeyPolicy: func(input *kms.PutKeyPolicyInput) (*kms.PutKeyPolicyOutput, error) {
+					put[awsv1.StringValue(input.KeyId)] = awsv1.StringValue(input.Policy)
+					policies[awsv1.StringValue(input.KeyId)] = awsv1.StringValue(input.Policy)
+					return &kms.PutKeyPolicyOutput{}, nil
+				},
+			})
+			if err := cl.CreateOrUpdate(context.Background(), s3Testing.Bucket(s3Testing.WithLoggingConfig(generateLoggingConfig()))); err != nil {
+				t.Fatalf("CreateOrUpdate(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, put); diff != "" {
+				t.Errorf("policies: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLoggingCleanup(t *testing.T) {
+	granted := logDeliveryStatement(logDeliveryStatementID(s3Testing.BucketName), s3Testing.BucketName)
+
+	type args struct {
+		logging *s3.GetBucketLoggingOutput
+		getErr  error
+	}
+
+	type want struct {
+		err    error
+		policy *string
+	}
+
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"RevokeAccess": {
+			args: args{
+				logging: &s3.GetBucketLoggingOutput{LoggingEnabled: generateAWSLogging()},
+			},
+			want: want{
+				policy: awsclient.String(keyPolicy()),
+			},
+		},
+		"LoggingDisabled": {
+			args: args{
+				logging: &s3.GetBucketLoggingOutput{},
+			},
+			want: want{},
+		},
+		"BucketGone": {
+			args: args{
+				getErr: &smithy.GenericAPIError{Code: clientss3.NoSuchBucketErrCode},
+			},
+			want: want{},
+		},
+		"GetError": {
+			args: args{
+				getErr: errBoom,
+			},
+			want: want{
+				err: awsclient.Wrap(errBoom, loggingGetFailed),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var put *string
+			cl := NewLoggingConfigurationClient(fake.MockBucketClient{
+				MockGetBucketLogging: func(ctx context.Context, input *s3.GetBucketLoggingInput, opts []func(*s3.Options)) (*s3.GetBucketLoggingOutput, error) {
+					return tc.args.logging, tc.args.getErr
+				},
+				MockGetBucketEncryption: func(ctx context.Context, input *s3.GetBucketEncryptionInput, opts []func(*s3.Options)) (*s3.GetBucketEncryptionOutput, error) {
+					return &s3.GetBucketEncryptionOutput{ServerSideEncryptionConfiguration: generateAWSTargetSSEWithKey(keyID)}, nil
+				},
+			}, nil, mockKeyPolicy{
+				MockGetKeyPolicy: func(input *kms.GetKeyPolicyInput) (*kms.GetKeyPolicyOutput, error) {
+					return &kms.GetKeyPolicyOutput{Policy: awsv1.String(keyPolicy(granted))}, nil
+				},
+				MockPutKeyPolicy: func(input *kms.PutKeyPolicyInput) (*kms.PutKeyPolicyOutput, error) {
+					put = input.Policy
+					return &kms.PutKeyPolicyOutput{}, nil
+				},
+			})
+			err := cl.Cleanup(context.Background(), s3Testing.Bucket(s3Testing.WithLoggingConfig(generateLoggingConfig())))
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.policy, put); diff != "" {
+				t.Errorf("policy: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestLoggingLateInit(t *testing.T) {
 	type args struct {
 		cl SubresourceClient
@@ -248,7 +1032,7 @@ func TestLoggingLateInit(t *testing.T) {
 					MockGetBucketLogging: func(ctx context.Context, input *s3.GetBucketLoggingInput, opts []func(*s3.Options)) (*s3.GetBucketLoggingOutput, error) {
 						return &s3.GetBucketLoggingOutput{}, errBoom
 					},
-				}),
+				}, nil, nil),
 			},
 			want: want{
 				err: awsclient.Wrap(errBoom, loggingGetFailed),
@@ -262,7 +1046,7 @@ func TestLoggingLateInit(t *testing.T) {
 					MockGetBucketLogging: func(ctx context.Context, input *s3.GetBucketLoggingInput, opts []func(*s3.Options)) (*s3.GetBucketLoggingOutput, error) {
 						return &s3.GetBucketLoggingOutput{}, nil
 					},
-				}),
+				}, nil, nil),
 			},
 			want: want{
 				err: nil,
@@ -276,7 +1060,7 @@ func TestLoggingLateInit(t *testing.T) {
 					MockGetBucketLogging: func(ctx context.Context, input *s3.GetBucketLoggingInput, opts []func(*s3.Options)) (*s3.GetBucketLoggingOutput, error) {
 						return &s3.GetBucketLoggingOutput{LoggingEnabled: generateAWSLogging()}, nil
 					},
-				}),
+				}, nil, nil),
 			},
 			want: want{
 				err: nil,
@@ -290,7 +1074,7 @@ func TestLoggingLateInit(t *testing.T) {
 					MockGetBucketLogging: func(ctx context.Context, input *s3.GetBucketLoggingInput, opts []func(*s3.Options)) (*s3.GetBucketLoggingOutput, error) {
 						return &s3.GetBucketLoggingOutput{LoggingEnabled: &s3types.LoggingEnabled{}}, nil
 					},
-				}),
+				}, nil, nil),
 			},
 			want: want{
 				err: nil,
@@ -311,3 +1095,171 @@ func TestLoggingLateInit(t *testing.T) {
 		})
 	}
 }
+
+func TestLoggingDryRunObserve(t *testing.T) {
+	type want struct {
+		diff Diff
+		err  error
+	}
+
+	cases := map[string]struct {
+		config   *v1beta1.LoggingConfiguration
+		external *s3types.LoggingEnabled
+		err      error
+		want
+	}{
+		"Error": {
+			config: generateLoggingConfig(),
+			err:    errBoom,
+			want: want{
+				err: awsclient.Wrap(errBoom, loggingGetFailed),
+			},
+		},
+		"NoChanges": {
+			config:   generateLoggingConfig(),
+			external: generateAWSLogging(),
+		},
+		"ChangedPrefix": {
+			config: generateLoggingConfig(),
+			external: func() *s3types.LoggingEnabled {
+				l := generateAWSLogging()
+				l.TargetPrefix = awsclient.String("other/")
+				return l
+			}(),
+			want: want{
+				diff: Diff{{Path: "loggingConfiguration.targetPrefix", Desired: prefix, Observed: "other/"}},
+			},
+		},
+		"LoggingNotEnabled": {
+			config: generateLoggingConfig(),
+			want: want{
+				diff: Diff{
+					{Path: "loggingConfiguration.targetBucket", Desired: bucketName},
+					{Path: "loggingConfiguration.targetPrefix", Desired: prefix},
+				},
+			},
+		},
+		"NotConfigured": {
+			external: generateAWSLogging(),
+			want: want{
+				diff: Diff{
+					{Path: "loggingConfiguration.targetBucket", Observed: bucketName},
+					{Path: "loggingConfiguration.targetPrefix", Observed: prefix},
+				},
+			},
+		},
+		"Disabled": {
+			config:   generateLoggingConfigWithEnabled(false),
+			external: generateAWSLogging(),
+			want: want{
+				diff: Diff{
+					{Path: "loggingConfiguration.targetBucket", Observed: bucketName},
+					{Path: "loggingConfiguration.targetPrefix", Observed: prefix},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cl := NewLoggingConfigurationClient(fake.MockBucketClient{
+				MockGetBucketLogging: func(ctx context.Context, input *s3.GetBucketLoggingInput, opts []func(*s3.Options)) (*s3.GetBucketLoggingOutput, error) {
+					if tc.err != nil {
+						return nil, tc.err
+					}
+					return &s3.GetBucketLoggingOutput{LoggingEnabled: tc.external}, nil
+				},
+			}, nil, nil)
+			diff, err := DryRunObserve(context.Background(), cl, s3Testing.Bucket(s3Testing.WithLoggingConfig(tc.config)))
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.diff, diff); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLoggingObserveStatus(t *testing.T) {
+	stale := s3Testing.Bucket(s3Testing.WithLoggingConfig(nil), s3Testing.WithObservedLoggingConfig(generateLoggingConfig()))
+
+	cases := map[string]struct {
+		cl   *LoggingConfigurationClient
+		b    *v1beta1.Bucket
+		want *v1beta1.LoggingConfiguration
+	}{
+		"Observed": {
+			b: s3Testing.Bucket(s3Testing.WithLoggingConfig(generateLoggingConfig())),
+			cl: NewLoggingConfigurationClient(fake.MockBucketClient{
+				MockGetBucketLogging: func(ctx context.Context, input *s3.GetBucketLoggingInput, opts []func(*s3.Options)) (*s3.GetBucketLoggingOutput, error) {
+					return &s3.GetBucketLoggingOutput{LoggingEnabled: generateAWSLogging()}, nil
+				},
+			}, nil, nil),
+			want: generateLoggingConfig(),
+		},
+		"DisabledClearsStaleStatus": {
+			b: stale,
+			cl: NewLoggingConfigurationClient(fake.MockBucketClient{
+				MockGetBucketLogging: func(ctx context.Context, input *s3.GetBucketLoggingInput, opts []func(*s3.Options)) (*s3.GetBucketLoggingOutput, error) {
+					return &s3.GetBucketLoggingOutput{}, nil
+				},
+			}, nil, nil),
+			want: nil,
+		},
+		"ErrorClearsStaleStatus": {
+			b: s3Testing.Bucket(s3Testing.WithObservedLoggingConfig(generateLoggingConfig())),
+			cl: NewLoggingConfigurationClient(fake.MockBucketClient{
+				MockGetBucketLogging: func(ctx context.Context, input *s3.GetBucketLoggingInput, opts []func(*s3.Options)) (*s3.GetBucketLoggingOutput, error) {
+					return nil, errBoom
+				},
+			}, nil, nil),
+			want: nil,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, _ = tc.cl.Observe(context.Background(), tc.b)
+			if diff := cmp.Diff(tc.want, tc.b.Status.AtProvider.LoggingConfiguration); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLoggingTargetWarning(t *testing.T) {
+	cases := map[string]struct {
+		config *v1beta1.LoggingConfiguration
+		want   string
+	}{
+		"NotConfigured": {
+			config: nil,
+			want:   "",
+		},
+		"AccessLogTarget": {
+			config: &v1beta1.LoggingConfiguration{TargetBucket: awsclient.String("access-logs"), TargetPrefix: "logs/"},
+			want:   "",
+		},
+		"CloudTrailBucket": {
+			config: &v1beta1.LoggingConfiguration{TargetBucket: awsclient.String("org-CloudTrail-logs"), TargetPrefix: "logs/"},
+			want:   fmt.Sprintf(loggingTargetLooksLikeCloudTrail, "org-CloudTrail-logs/logs/"),
+		},
+		"CloudTrailPrefix": {
+			config: &v1beta1.LoggingConfiguration{TargetBucket: awsclient.String("logs"), TargetPrefix: "AWSLogs/123456789012/CloudTrail/"},
+			want:   fmt.Sprintf(loggingTargetLooksLikeCloudTrail, "logs/AWSLogs/123456789012/CloudTrail/"),
+		},
+		"Disabled": {
+			config: &v1beta1.LoggingConfiguration{TargetBucket: awsclient.String("cloudtrail"), Enabled: awsclient.Bool(false, awsclient.FieldRequired)},
+			want:   "",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, LoggingTargetWarning(tc.config)); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
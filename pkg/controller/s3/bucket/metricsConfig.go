@@ -0,0 +1,256 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bucket
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awss3 "github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/provider-aws/apis/s3/v1beta1"
+	awsclient "github.com/crossplane/provider-aws/pkg/clients"
+	"github.com/crossplane/provider-aws/pkg/clients/s3"
+)
+
+const (
+	metricsListFailed   = "cannot list Bucket metrics configurations"
+	metricsPutFailed    = "cannot put Bucket metrics configuration %s"
+	metricsDeleteFailed = "cannot delete Bucket metrics configuration %s"
+	metricsDuplicateID  = "duplicate metrics configuration ID %q"
+
+	// maxMetricsConfigurations is the number of metrics configurations AWS
+	// allows per bucket.
+	maxMetricsConfigurations = 1000
+)
+
+// MetricsConfigurationClient is the client for API methods and reconciling the MetricsConfigurations
+type MetricsConfigurationClient struct {
+	client s3.BucketClient
+}
+
+// NewMetricsConfigurationClient creates the client for Metrics Configurations
+func NewMetricsConfigurationClient(client s3.BucketClient) *MetricsConfigurationClient {
+	return &MetricsConfigurationClient{client: client}
+}
+
+// Observe checks if the resource exists and if it matches the local
+// configuration. Metrics configurations are keyed by their ID, so they need an
+// update if a configured ID is missing or its filter differs, and a deletion
+// if the bucket has a metrics configuration that is not configured.
+func (in *MetricsConfigurationClient) Observe(ctx context.Context, bucket *v1beta1.Bucket) (ResourceStatus, error) {
+	return in.configurations().observe(ctx, bucket)
+}
+
+// CreateOrUpdate puts the metrics configurations that are missing or differ.
+// Metrics configurations that are not configured are removed by Delete.
+func (in *MetricsConfigurationClient) CreateOrUpdate(ctx context.Context, bucket *v1beta1.Bucket) error {
+	config := bucket.Spec.ForProvider.MetricsConfigurations
+	if len(config) == 0 {
+		return nil
+	}
+	if err := validateMetricsConfigurations(config); err != nil {
+		return err
+	}
+	return in.configurations().createOrUpdate(ctx, bucket)
+}
+
+// Delete deletes the metrics configurations of the bucket that are not
+// configured, i.e. all of them if none is configured.
+func (in *MetricsConfigurationClient) Delete(ctx context.Context, bucket *v1beta1.Bucket) error {
+	return in.configurations().deleteUnconfigured(ctx, bucket)
+}
+
+// configurations returns the keyedConfigurations of the metrics
+// configurations of a bucket.
+func (in *MetricsConfigurationClient) configurations() keyedConfigurations {
+	return keyedConfigurations{
+		changes: func(ctx context.Context, bucket *v1beta1.Bucket) ([]int, []string, error) {
+			external, err := in.list(ctx, bucket)
+			if err != nil {
+				return nil, nil, err
+			}
+			put, remove := metricsChanges(bucket.Spec.ForProvider.MetricsConfigurations, external)
+			return put, remove, nil
+		},
+		put: func(ctx context.Context, bucket *v1beta1.Bucket, i int) error {
+			c := bucket.Spec.ForProvider.MetricsConfigurations[i]
+			_, err := in.client.PutBucketMetricsConfiguration(ctx, &awss3.PutBucketMetricsConfigurationInput{
+				Bucket:               awsclient.String(meta.GetExternalName(bucket)),
+				Id:                   awsclient.String(c.ID),
+				MetricsConfiguration: GenerateMetricsConfiguration(c),
+			})
+			return errors.Wrapf(err, metricsPutFailed, c.ID)
+		},
+		remove: func(ctx context.Context, bucket *v1beta1.Bucket, id string) error {
+			_, err := in.client.DeleteBucketMetricsConfiguration(ctx, &awss3.DeleteBucketMetricsConfigurationInput{
+				Bucket: awsclient.String(meta.GetExternalName(bucket)),
+				Id:     awsclient.String(id),
+			})
+			return errors.Wrapf(err, metricsDeleteFailed, id)
+		},
+	}
+}
+
+// LateInitialize is responsible for initializing the resource based on the external value
+func (in *MetricsConfigurationClient) LateInitialize(ctx context.Context, bucket *v1beta1.Bucket) error {
+	external, err := in.list(ctx, bucket)
+	if err != nil {
+		return err
+	}
+	fp := &bucket.Spec.ForProvider
+	if len(external) == 0 || fp.MetricsConfigurations != nil {
+		return nil
+	}
+	fp.MetricsConfigurations = make([]v1beta1.MetricsConfiguration, len(external))
+	for i, c := range external {
+		fp.MetricsConfigurations[i] = GenerateLocalMetricsConfiguration(c)
+	}
+	return nil
+}
+
+// CapturePrior returns a Bucket with the metrics configurations currently in AWS.
+func (in *MetricsConfigurationClient) CapturePrior(ctx context.Context, bucket *v1beta1.Bucket) (*v1beta1.Bucket, error) {
+	return lateInitializedPrior(ctx, in, bucket)
+}
+
+// SubresourceExists checks if the subresource this controller manages currently exists
+func (in *MetricsConfigurationClient) SubresourceExists(bucket *v1beta1.Bucket) bool {
+	return len(bucket.Spec.ForProvider.MetricsConfigurations) != 0
+}
+
+// Quotas of the metrics configurations. Metrics configurations that are not
+// configured count until they are deleted.
+func (in *MetricsConfigurationClient) Quotas() []Quota {
+	return []Quota{{
+		Description: "metrics configurations per bucket",
+		Limit:       maxMetricsConfigurations,
+		Usage: func(ctx context.Context, bucket *v1beta1.Bucket) (int, error) {
+			return in.configurations().usage(ctx, bucket, len(bucket.Spec.ForProvider.MetricsConfigurations))
+		},
+	}}
+}
+
+// list returns all metrics configurations of the bucket, following the
+// continuation tokens of truncated responses.
+func (in *MetricsConfigurationClient) list(ctx context.Context, bucket *v1beta1.Bucket) ([]types.MetricsConfiguration, error) {
+	input := &awss3.ListBucketMetricsConfigurationsInput{Bucket: awsclient.String(meta.GetExternalName(bucket))}
+	var configs []types.MetricsConfiguration
+	for {
+		out, err := in.client.ListBucketMetricsConfigurations(ctx, input)
+		if err != nil {
+			return nil, awsclient.Wrap(err, metricsListFailed)
+		}
+		if out == nil {
+			return configs, nil
+		}
+		configs = append(configs, out.MetricsConfigurationList...)
+		if !out.IsTruncated || out.NextContinuationToken == nil {
+			return configs, nil
+		}
+		input = &awss3.ListBucketMetricsConfigurationsInput{Bucket: input.Bucket, ContinuationToken: out.NextContinuationToken}
+	}
+}
+
+// metricsChanges returns the indices of the desired metrics configurations
+// that are missing from or differ in the external ones, and the sorted IDs of
+// the external metrics configurations that are not desired.
+func metricsChanges(desired []v1beta1.MetricsConfiguration, external []types.MetricsConfiguration) ([]int, []string) {
+	desiredIDs := make([]string, len(desired))
+	for i, c := range desired {
+		desiredIDs[i] = c.ID
+	}
+	current := make([]v1beta1.MetricsConfiguration, len(external))
+	externalIDs := make([]string, len(external))
+	for i, c := range external {
+		current[i] = GenerateLocalMetricsConfiguration(c)
+		externalIDs[i] = current[i].ID
+	}
+	return diffByID(desiredIDs, externalIDs, func(d, e int) bool {
+		return cmp.Equal(normalizeMetricsFilter(desired[d].Filter), normalizeMetricsFilter(current[e].Filter), cmpopts.EquateEmpty(), cmpopts.SortSlices(func(a, b v1beta1.Tag) bool { return a.Key < b.Key }))
+	})
+}
+
+// normalizeMetricsFilter returns nil for a filter that selects nothing, which
+// GenerateMetricsConfiguration sends as no filter at all, e.g. the one of the
+// default EntireBucket metrics configuration.
+func normalizeMetricsFilter(f *v1beta1.MetricsFilter) *v1beta1.MetricsFilter {
+	if f == nil || cmp.Equal(*f, v1beta1.MetricsFilter{}) {
+		return nil
+	}
+	return f
+}
+
+// validateMetricsConfigurations returns an error if two metrics configurations
+// have the same ID, since AWS keys them by it.
+func validateMetricsConfigurations(configs []v1beta1.MetricsConfiguration) error {
+	seen := make(map[string]bool, len(configs))
+	for _, c := range configs {
+		if seen[c.ID] {
+			return errors.Errorf(metricsDuplicateID, c.ID)
+		}
+		seen[c.ID] = true
+	}
+	return nil
+}
+
+// GenerateMetricsConfiguration creates the types.MetricsConfiguration for the AWS SDK
+func GenerateMetricsConfiguration(config v1beta1.MetricsConfiguration) *types.MetricsConfiguration {
+	out := &types.MetricsConfiguration{Id: awsclient.String(config.ID)}
+	f := config.Filter
+	switch {
+	case f == nil:
+	case f.And != nil:
+		out.Filter = &types.MetricsFilterMemberAnd{Value: types.MetricsAndOperator{
+			AccessPointArn: f.And.AccessPointARN,
+			Prefix:         f.And.Prefix,
+			Tags:           s3.CopyTags(f.And.Tags),
+		}}
+	case f.Tag != nil:
+		out.Filter = &types.MetricsFilterMemberTag{Value: types.Tag{Key: awsclient.String(f.Tag.Key), Value: awsclient.String(f.Tag.Value)}}
+	case f.Prefix != nil:
+		out.Filter = &types.MetricsFilterMemberPrefix{Value: *f.Prefix}
+	case f.AccessPointARN != nil:
+		out.Filter = &types.MetricsFilterMemberAccessPointArn{Value: *f.AccessPointARN}
+	}
+	return out
+}
+
+// GenerateLocalMetricsConfiguration creates the v1beta1.MetricsConfiguration from the AWS SDK metrics configuration
+func GenerateLocalMetricsConfiguration(config types.MetricsConfiguration) v1beta1.MetricsConfiguration {
+	out := v1beta1.MetricsConfiguration{ID: aws.ToString(config.Id)}
+	switch f := config.Filter.(type) {
+	case *types.MetricsFilterMemberAnd:
+		out.Filter = &v1beta1.MetricsFilter{And: &v1beta1.MetricsAndOperator{
+			AccessPointARN: f.Value.AccessPointArn,
+			Prefix:         f.Value.Prefix,
+			Tags:           s3.CopyAWSTags(f.Value.Tags),
+		}}
+	case *types.MetricsFilterMemberTag:
+		out.Filter = &v1beta1.MetricsFilter{Tag: &v1beta1.Tag{Key: aws.ToString(f.Value.Key), Value: aws.ToString(f.Value.Value)}}
+	case *types.MetricsFilterMemberPrefix:
+		out.Filter = &v1beta1.MetricsFilter{Prefix: aws.String(f.Value)}
+	case *types.MetricsFilterMemberAccessPointArn:
+		out.Filter = &v1beta1.MetricsFilter{AccessPointARN: aws.String(f.Value)}
+	}
+	return out
+}
@@ -0,0 +1,403 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bucket
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/provider-aws/apis/s3/v1beta1"
+	awsclient "github.com/crossplane/provider-aws/pkg/clients"
+	"github.com/crossplane/provider-aws/pkg/clients/s3/fake"
+	s3Testing "github.com/crossplane/provider-aws/pkg/controller/s3/testing"
+)
+
+var (
+	_ SubresourceClient = &MetricsConfigurationClient{}
+	_ QuotaClient       = &MetricsConfigurationClient{}
+)
+
+func withMetrics(configs ...v1beta1.MetricsConfiguration) s3Testing.BucketModifier {
+	return func(b *v1beta1.Bucket) {
+		b.Spec.ForProvider.MetricsConfigurations = configs
+	}
+}
+
+func prefixMetrics(id, prefix string) v1beta1.MetricsConfiguration {
+	return v1beta1.MetricsConfiguration{ID: id, Filter: &v1beta1.MetricsFilter{Prefix: aws.String(prefix)}}
+}
+
+func awsPrefixMetrics(id, prefix string) types.MetricsConfiguration {
+	return types.MetricsConfiguration{Id: aws.String(id), Filter: &types.MetricsFilterMemberPrefix{Value: prefix}}
+}
+
+func listMetrics(configs ...types.MetricsConfiguration) func(ctx context.Context, input *s3.ListBucketMetricsConfigurationsInput, opts []func(*s3.Options)) (*s3.ListBucketMetricsConfigurationsOutput, error) {
+	return func(ctx context.Context, input *s3.ListBucketMetricsConfigurationsInput, opts []func(*s3.Options)) (*s3.ListBucketMetricsConfigurationsOutput, error) {
+		return &s3.ListBucketMetricsConfigurationsOutput{MetricsConfigurationList: configs}, nil
+	}
+}
+
+func TestMetricsObserve(t *testing.T) {
+	type args struct {
+		cl *MetricsConfigurationClient
+		b  *v1beta1.Bucket
+	}
+
+	type want struct {
+		status ResourceStatus
+		err    error
+	}
+
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"Error": {
+			args: args{
+				b: s3Testing.Bucket(withMetrics(prefixMetrics("logs", "logs/"))),
+				cl: NewMetricsConfigurationClient(fake.MockBucketClient{
+					MockListBucketMetricsConfigurations: func(ctx context.Context, input *s3.ListBucketMetricsConfigurationsInput, opts []func(*s3.Options)) (*s3.ListBucketMetricsConfigurationsOutput, error) {
+						return nil, errBoom
+					},
+				}),
+			},
+			want: want{
+				status: NeedsUpdate,
+				err:    awsclient.Wrap(errBoom, metricsListFailed),
+			},
+		},
+		"NoneConfigured": {
+			args: args{
+				b:  s3Testing.Bucket(),
+				cl: NewMetricsConfigurationClient(fake.MockBucketClient{MockListBucketMetricsConfigurations: listMetrics()}),
+			},
+			want: want{
+				status: Updated,
+			},
+		},
+		"NeedsDeletion": {
+			args: args{
+				b:  s3Testing.Bucket(),
+				cl: NewMetricsConfigurationClient(fake.MockBucketClient{MockListBucketMetricsConfigurations: listMetrics(awsPrefixMetrics("logs", "logs/"))}),
+			},
+			want: want{
+				status: NeedsDeletion,
+			},
+		},
+		"MissingID": {
+			args: args{
+				b:  s3Testing.Bucket(withMetrics(prefixMetrics("logs", "logs/"), prefixMetrics("images", "images/"))),
+				cl: NewMetricsConfigurationClient(fake.MockBucketClient{MockListBucketMetricsConfigurations: listMetrics(awsPrefixMetrics("logs", "logs/"))}),
+			},
+			want: want{
+				status: NeedsUpdate,
+			},
+		},
+		"FilterDiffers": {
+			args: args{
+				b:  s3Testing.Bucket(withMetrics(prefixMetrics("logs", "logs/"))),
+				cl: NewMetricsConfigurationClient(fake.MockBucketClient{MockListBucketMetricsConfigurations: listMetrics(awsPrefixMetrics("logs", "old-logs/"))}),
+			},
+			want: want{
+				status: NeedsUpdate,
+			},
+		},
+		"NotConfiguredID": {
+			args: args{
+				b:  s3Testing.Bucket(withMetrics(prefixMetrics("logs", "logs/"))),
+				cl: NewMetricsConfigurationClient(fake.MockBucketClient{MockListBucketMetricsConfigurations: listMetrics(awsPrefixMetrics("logs", "logs/"), awsPrefixMetrics("images", "images/"))}),
+			},
+			want: want{
+				status: NeedsDeletion,
+			},
+		},
+		"UpdateBeforeDeletion": {
+			args: args{
+				b:  s3Testing.Bucket(withMetrics(prefixMetrics("logs", "logs/"))),
+				cl: NewMetricsConfigurationClient(fake.MockBucketClient{MockListBucketMetricsConfigurations: listMetrics(awsPrefixMetrics("logs", "old-logs/"), awsPrefixMetrics("images", "images/"))}),
+			},
+			want: want{
+				status: NeedsUpdate,
+			},
+		},
+		"UpToDate": {
+			args: args{
+				b: s3Testing.Bucket(withMetrics(
+					v1beta1.MetricsConfiguration{ID: "all"},
+					v1beta1.MetricsConfiguration{ID: "tagged", Filter: &v1beta1.MetricsFilter{And: &v1beta1.MetricsAndOperator{
+						Prefix: aws.String("logs/"),
+						Tags:   []v1beta1.Tag{{Key: "a", Value: "1"}, {Key: "b", Value: "2"}},
+					}}},
+				)),
+				cl: NewMetricsConfigurationClient(fake.MockBucketClient{MockListBucketMetricsConfigurations: listMetrics(
+					types.MetricsConfiguration{Id: aws.String("tagged"), Filter: &types.MetricsFilterMemberAnd{Value: types.MetricsAndOperator{
+						Prefix: aws.String("logs/"),
+						Tags:   []types.Tag{{Key: aws.String("b"), Value: aws.String("2")}, {Key: aws.String("a"), Value: aws.String("1")}},
+					}}},
+					types.MetricsConfiguration{Id: aws.String("all")},
+				)}),
+			},
+			want: want{
+				status: Updated,
+			},
+		},
+		"EntireBucket": {
+			args: args{
+				b:  s3Testing.Bucket(withMetrics(v1beta1.MetricsConfiguration{ID: "EntireBucket"})),
+				cl: NewMetricsConfigurationClient(fake.MockBucketClient{MockListBucketMetricsConfigurations: listMetrics(types.MetricsConfiguration{Id: aws.String("EntireBucket")})}),
+			},
+			want: want{
+				status: Updated,
+			},
+		},
+		"EntireBucketEmptyFilter": {
+			args: args{
+				b:  s3Testing.Bucket(withMetrics(v1beta1.MetricsConfiguration{ID: "EntireBucket", Filter: &v1beta1.MetricsFilter{}})),
+				cl: NewMetricsConfigurationClient(fake.MockBucketClient{MockListBucketMetricsConfigurations: listMetrics(types.MetricsConfiguration{Id: aws.String("EntireBucket")})}),
+			},
+			want: want{
+				status: Updated,
+			},
+		},
+		"EntireBucketFilterAdded": {
+			args: args{
+				b:  s3Testing.Bucket(withMetrics(prefixMetrics("EntireBucket", "logs/"))),
+				cl: NewMetricsConfigurationClient(fake.MockBucketClient{MockListBucketMetricsConfigurations: listMetrics(types.MetricsConfiguration{Id: aws.String("EntireBucket")})}),
+			},
+			want: want{
+				status: NeedsUpdate,
+			},
+		},
+		"Paginated": {
+			args: args{
+				b: s3Testing.Bucket(withMetrics(prefixMetrics("logs", "logs/"), prefixMetrics("images", "images/"))),
+				cl: NewMetricsConfigurationClient(fake.MockBucketClient{
+					MockListBucketMetricsConfigurations: func(ctx context.Context, input *s3.ListBucketMetricsConfigurationsInput, opts []func(*s3.Options)) (*s3.ListBucketMetricsConfigurationsOutput, error) {
+						if input.ContinuationToken == nil {
+							return &s3.ListBucketMetricsConfigurationsOutput{
+								MetricsConfigurationList: []types.MetricsConfiguration{awsPrefixMetrics("logs", "logs/")},
+								IsTruncated:              true,
+								NextContinuationToken:    aws.String("next"),
+							}, nil
+						}
+						return &s3.ListBucketMetricsConfigurationsOutput{
+							MetricsConfigurationList: []types.MetricsConfiguration{awsPrefixMetrics("images", "images/")},
+						}, nil
+					},
+				}),
+			},
+			want: want{
+				status: Updated,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			status, err := tc.args.cl.Observe(context.Background(), tc.args.b)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.status, status); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMetricsCreateOrUpdate(t *testing.T) {
+	type args struct {
+		b        *v1beta1.Bucket
+		external []types.MetricsConfiguration
+		putErr   error
+	}
+
+	type want struct {
+		err     error
+		put     []string
+		deleted []string
+	}
+
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"PutError": {
+			args: args{
+				b:      s3Testing.Bucket(withMetrics(prefixMetrics("logs", "logs/"))),
+				putErr: errBoom,
+			},
+			want: want{
+				err: errors.Wrapf(errBoom, metricsPutFailed, "logs"),
+				put: []string{"logs"},
+			},
+		},
+		"DuplicateID": {
+			args: args{
+				b: s3Testing.Bucket(withMetrics(prefixMetrics("logs", "logs/"), prefixMetrics("logs", "other/"))),
+			},
+			want: want{
+				err: errors.Errorf(metricsDuplicateID, "logs"),
+			},
+		},
+		"AddAndUpdate": {
+			args: args{
+				b:        s3Testing.Bucket(withMetrics(prefixMetrics("logs", "logs/"), prefixMetrics("images", "images/"), prefixMetrics("same", "same/"))),
+				external: []types.MetricsConfiguration{awsPrefixMetrics("logs", "old-logs/"), awsPrefixMetrics("same", "same/"), awsPrefixMetrics("stale", "stale/")},
+			},
+			want: want{
+				// Metrics configurations that are not configured are left
+				// to Delete.
+				put: []string{"logs", "images"},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var put, deleted []string
+			cl := NewMetricsConfigurationClient(fake.MockBucketClient{
+				MockListBucketMetricsConfigurations: listMetrics(tc.args.external...),
+				MockPutBucketMetricsConfiguration: func(ctx context.Context, input *s3.PutBucketMetricsConfigurationInput, opts []func(*s3.Options)) (*s3.PutBucketMetricsConfigurationOutput, error) {
+					put = append(put, aws.ToString(input.Id))
+					return &s3.PutBucketMetricsConfigurationOutput{}, tc.args.putErr
+				},
+				MockDeleteBucketMetricsConfiguration: func(ctx context.Context, input *s3.DeleteBucketMetricsConfigurationInput, opts []func(*s3.Options)) (*s3.DeleteBucketMetricsConfigurationOutput, error) {
+					deleted = append(deleted, aws.ToString(input.Id))
+					return &s3.DeleteBucketMetricsConfigurationOutput{}, nil
+				},
+			})
+			err := cl.CreateOrUpdate(context.Background(), tc.args.b)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.put, put); diff != "" {
+				t.Errorf("put: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.deleted, deleted); diff != "" {
+				t.Errorf("deleted: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMetricsDelete(t *testing.T) {
+	cases := map[string]struct {
+		b    *v1beta1.Bucket
+		want []string
+	}{
+		"NoneConfigured": {
+			b:    s3Testing.Bucket(),
+			want: []string{"images", "logs"},
+		},
+		"NotConfigured": {
+			b:    s3Testing.Bucket(withMetrics(prefixMetrics("logs", "logs/"))),
+			want: []string{"images"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var deleted []string
+			cl := NewMetricsConfigurationClient(fake.MockBucketClient{
+				MockListBucketMetricsConfigurations: listMetrics(awsPrefixMetrics("logs", "logs/"), awsPrefixMetrics("images", "images/")),
+				MockDeleteBucketMetricsConfiguration: func(ctx context.Context, input *s3.DeleteBucketMetricsConfigurationInput, opts []func(*s3.Options)) (*s3.DeleteBucketMetricsConfigurationOutput, error) {
+					deleted = append(deleted, aws.ToString(input.Id))
+					return &s3.DeleteBucketMetricsConfigurationOutput{}, nil
+				},
+			})
+			if err := cl.Delete(context.Background(), tc.b); err != nil {
+				t.Fatalf("Delete(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, deleted); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMetricsLateInitialize(t *testing.T) {
+	type args struct {
+		b        *v1beta1.Bucket
+		external []types.MetricsConfiguration
+	}
+
+	cases := map[string]struct {
+		args
+		want *v1beta1.Bucket
+	}{
+		"NoneExternal": {
+			args: args{
+				b: s3Testing.Bucket(),
+			},
+			want: s3Testing.Bucket(),
+		},
+		"FromExternal": {
+			args: args{
+				b: s3Testing.Bucket(),
+				external: []types.MetricsConfiguration{
+					awsPrefixMetrics("logs", "logs/"),
+					{Id: aws.String("tagged"), Filter: &types.MetricsFilterMemberTag{Value: types.Tag{Key: aws.String("a"), Value: aws.String("1")}}},
+				},
+			},
+			want: s3Testing.Bucket(withMetrics(
+				prefixMetrics("logs", "logs/"),
+				v1beta1.MetricsConfiguration{ID: "tagged", Filter: &v1beta1.MetricsFilter{Tag: &v1beta1.Tag{Key: "a", Value: "1"}}},
+			)),
+		},
+		"NoOverwrite": {
+			args: args{
+				b:        s3Testing.Bucket(withMetrics(prefixMetrics("logs", "logs/"))),
+				external: []types.MetricsConfiguration{awsPrefixMetrics("images", "images/")},
+			},
+			want: s3Testing.Bucket(withMetrics(prefixMetrics("logs", "logs/"))),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cl := NewMetricsConfigurationClient(fake.MockBucketClient{MockListBucketMetricsConfigurations: listMetrics(tc.args.external...)})
+			if err := cl.LateInitialize(context.Background(), tc.args.b); err != nil {
+				t.Fatalf("LateInitialize(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, tc.args.b); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMetricsQuotas(t *testing.T) {
+	// Metrics configurations that are not configured count until they are
+	// deleted.
+	configs := make([]v1beta1.MetricsConfiguration, maxMetricsConfigurations)
+	cl := NewMetricsConfigurationClient(fake.MockBucketClient{MockListBucketMetricsConfigurations: listMetrics(awsPrefixMetrics("stale", "stale/"))})
+	usages, err := QuotaUsages(context.Background(), cl, s3Testing.Bucket(withMetrics(configs...)))
+	if err != nil {
+		t.Fatalf("QuotaUsages(...): unexpected error: %s", err)
+	}
+	if len(usages) != 1 || !usages[0].Exceeded() {
+		t.Errorf("QuotaUsages(...): want the per bucket quota exceeded, got %v", usages)
+	}
+}
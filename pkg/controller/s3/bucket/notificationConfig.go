@@ -26,6 +26,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
 
 	"github.com/crossplane/provider-aws/apis/s3/v1beta1"
 	awsclient "github.com/crossplane/provider-aws/pkg/clients"
@@ -33,8 +34,11 @@ import (
 )
 
 const (
-	notificationGetFailed = "cannot get Bucket notification"
-	notificationPutFailed = "cannot put Bucket notification"
+	notificationGetFailed    = "cannot get Bucket notification"
+	notificationPutFailed    = "cannot put Bucket notification"
+	notificationDeleteFailed = "cannot delete Bucket notification"
+
+	notificationFilterDuplicateRule = "%s configuration %d: filter must not have more than one %s rule"
 )
 
 // NotificationConfigurationClient is the client for API methods and reconciling the LifecycleConfiguration
@@ -63,15 +67,46 @@ func (in *NotificationConfigurationClient) Observe(ctx context.Context, bucket *
 
 	generated := GenerateConfiguration(config)
 
-	if cmp.Equal(external.LambdaFunctionConfigurations, generated.LambdaFunctionConfigurations, cmpopts.IgnoreTypes(document.NoSerde{})) &&
-		cmp.Equal(external.QueueConfigurations, generated.QueueConfigurations, cmpopts.IgnoreTypes(document.NoSerde{})) &&
-		cmp.Equal(external.TopicConfigurations, generated.TopicConfigurations, cmpopts.IgnoreTypes(document.NoSerde{})) {
+	// The prefix and suffix rules of a filter may be in any order, and so may
+	// the events and the configurations themselves, which are matched by ID.
+	// A configuration without any targets matches a bucket without any
+	// notifications, which is what putting it results in.
+	opts := []cmp.Option{
+		cmpopts.IgnoreTypes(document.NoSerde{}),
+		cmpopts.EquateEmpty(),
+		cmpopts.SortSlices(func(a, b types.FilterRule) bool {
+			return s3.NormalizeEnum(string(a.Name)) < s3.NormalizeEnum(string(b.Name))
+		}),
+		cmp.Comparer(func(a, b types.FilterRuleName) bool { return s3.EnumEqual(string(a), string(b)) }),
+		cmpopts.SortSlices(func(a, b types.Event) bool { return a < b }),
+		cmpopts.SortSlices(func(a, b types.LambdaFunctionConfiguration) bool {
+			return notificationLess(a.Id, b.Id, a.LambdaFunctionArn, b.LambdaFunctionArn)
+		}),
+		cmpopts.SortSlices(func(a, b types.QueueConfiguration) bool {
+			return notificationLess(a.Id, b.Id, a.QueueArn, b.QueueArn)
+		}),
+		cmpopts.SortSlices(func(a, b types.TopicConfiguration) bool {
+			return notificationLess(a.Id, b.Id, a.TopicArn, b.TopicArn)
+		}),
+	}
+	if cmp.Equal(external.LambdaFunctionConfigurations, generated.LambdaFunctionConfigurations, opts...) &&
+		cmp.Equal(external.QueueConfigurations, generated.QueueConfigurations, opts...) &&
+		cmp.Equal(external.TopicConfigurations, generated.TopicConfigurations, opts...) {
 		return Updated, nil
 	}
 
 	return NeedsUpdate, nil
 }
 
+// notificationLess orders notification configurations by their ID, falling
+// back to the target ARN for configurations without one.
+func notificationLess(idA, idB, arnA, arnB *string) bool {
+	if awsclient.StringValue(idA) != awsclient.StringValue(idB) {
+		return awsclient.StringValue(idA) < awsclient.StringValue(idB)
+	}
+	return awsclient.StringValue(arnA) < awsclient.StringValue(arnB)
+}
+
 // GenerateLambdaConfiguration creates []awss3.LambdaFunctionConfiguration from the local NotificationConfiguration
 func GenerateLambdaConfiguration(config *v1beta1.NotificationConfiguration) []types.LambdaFunctionConfiguration {
 	// NOTE(muvaf): We skip prealloc because the behavior of AWS SDK differs when
@@ -188,16 +223,63 @@ func (in *NotificationConfigurationClient) CreateOrUpdate(ctx context.Context, b
 	if bucket.Spec.ForProvider.NotificationConfiguration == nil {
 		return nil
 	}
+	if err := validateNotificationFilters(bucket.Spec.ForProvider.NotificationConfiguration); err != nil {
+		return err
+	}
 	input := GenerateNotificationConfigurationInput(meta.GetExternalName(bucket), bucket.Spec.ForProvider.NotificationConfiguration)
 	_, err := in.client.PutBucketNotificationConfiguration(ctx, input)
 	return awsclient.Wrap(err, notificationPutFailed)
 }
 
-// Delete does nothing because there is no corresponding deletion call in awsclient.
-func (*NotificationConfigurationClient) Delete(_ context.Context, _ *v1beta1.Bucket) error {
+// validateNotificationFilters returns an error if a filter of the supplied
+// configuration has more than one prefix or more than one suffix rule, which
+// S3 rejects.
+func validateNotificationFilters(config *v1beta1.NotificationConfiguration) error {
+	for i, c := range config.LambdaFunctionConfigurations {
+		if err := validateFilter("lambda function", i, c.Filter); err != nil {
+			return err
+		}
+	}
+	for i, c := range config.QueueConfigurations {
+		if err := validateFilter("queue", i, c.Filter); err != nil {
+			return err
+		}
+	}
+	for i, c := range config.TopicConfigurations {
+		if err := validateFilter("topic", i, c.Filter); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateFilter(kind string, i int, filter *v1beta1.NotificationConfigurationFilter) error {
+	if filter == nil || filter.Key == nil {
+		return nil
+	}
+	seen := map[string]bool{}
+	for _, rule := range filter.Key.FilterRules {
+		name := s3.NormalizeEnum(rule.Name)
+		if seen[name] {
+			return errors.Errorf(notificationFilterDuplicateRule, kind, i, name)
+		}
+		seen[name] = true
+	}
 	return nil
 }
 
+// Delete clears all notification targets of the bucket. There is no
+// corresponding deletion call, so an empty configuration is put instead.
+func (in *NotificationConfigurationClient) Delete(ctx context.Context, bucket *v1beta1.Bucket) error {
+	_, err := in.client.PutBucketNotificationConfiguration(ctx,
+		&awss3.PutBucketNotificationConfigurationInput{
+			Bucket:                    awsclient.String(meta.GetExternalName(bucket)),
+			NotificationConfiguration: &types.NotificationConfiguration{},
+		},
+	)
+	return awsclient.Wrap(err, notificationDeleteFailed)
+}
+
 // LateInitialize is responsible for initializing the resource based on the external value
 func (in *NotificationConfigurationClient) LateInitialize(ctx context.Context, bucket *v1beta1.Bucket) error {
 	external, err := in.client.GetBucketNotificationConfiguration(ctx, &awss3.GetBucketNotificationConfigurationInput{Bucket: awsclient.String(meta.GetExternalName(bucket))})
@@ -232,6 +314,11 @@ func (in *NotificationConfigurationClient) LateInitialize(ctx context.Context, b
 	return nil
 }
 
+// CapturePrior returns a Bucket with the notification configuration currently in AWS.
+func (in *NotificationConfigurationClient) CapturePrior(ctx context.Context, bucket *v1beta1.Bucket) (*v1beta1.Bucket, error) {
+	return lateInitializedPrior(ctx, in, bucket)
+}
+
 // SubresourceExists checks if the subresource this controller manages currently exists
 func (in *NotificationConfigurationClient) SubresourceExists(bucket *v1beta1.Bucket) bool {
 	return bucket.Spec.ForProvider.NotificationConfiguration != nil
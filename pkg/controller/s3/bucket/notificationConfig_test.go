@@ -24,6 +24,7 @@ import (
 	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
 
 	"github.com/crossplane/provider-aws/apis/s3/v1beta1"
 	awsclient "github.com/crossplane/provider-aws/pkg/clients"
@@ -71,6 +72,22 @@ func generateAWSNotificationFilter() *s3types.NotificationConfigurationFilter {
 	}
 }
 
+func generatePrefixSuffixFilter(rules ...v1beta1.FilterRule) *v1beta1.NotificationConfigurationFilter {
+	return &v1beta1.NotificationConfigurationFilter{Key: &v1beta1.S3KeyFilter{FilterRules: rules}}
+}
+
+func generateNotificationConfigWithFilter(filter *v1beta1.NotificationConfigurationFilter) *v1beta1.NotificationConfiguration {
+	config := generateNotificationConfig()
+	config.QueueConfigurations[0].Filter = filter
+	return config
+}
+
+func generateAWSNotificationWithFilter(rules ...s3types.FilterRule) *s3types.NotificationConfiguration {
+	config := generateAWSNotification()
+	config.QueueConfigurations[0].Filter = &s3types.NotificationConfigurationFilter{Key: &s3types.S3KeyFilter{FilterRules: rules}}
+	return config
+}
+
 func generateNotificationConfig() *v1beta1.NotificationConfiguration {
 	return &v1beta1.NotificationConfiguration{
 		LambdaFunctionConfigurations: []v1beta1.LambdaFunctionConfiguration{{
@@ -191,6 +208,40 @@ func TestNotificationObserve(t *testing.T) {
 				err:    nil,
 			},
 		},
+		"UpdateNeededAllRemoved": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithNotificationConfig(&v1beta1.NotificationConfiguration{})),
+				cl: NewNotificationConfigurationClient(fake.MockBucketClient{
+					MockGetBucketNotificationConfiguration: func(ctx context.Context, input *s3.GetBucketNotificationConfigurationInput, opts []func(*s3.Options)) (*s3.GetBucketNotificationConfigurationOutput, error) {
+						return &s3.GetBucketNotificationConfigurationOutput{
+							QueueConfigurations: generateAWSNotification().QueueConfigurations,
+						}, nil
+					},
+				}),
+			},
+			want: want{
+				status: NeedsUpdate,
+				err:    nil,
+			},
+		},
+		"NoUpdateAllRemoved": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithNotificationConfig(&v1beta1.NotificationConfiguration{})),
+				cl: NewNotificationConfigurationClient(fake.MockBucketClient{
+					MockGetBucketNotificationConfiguration: func(ctx context.Context, input *s3.GetBucketNotificationConfigurationInput, opts []func(*s3.Options)) (*s3.GetBucketNotificationConfigurationOutput, error) {
+						return &s3.GetBucketNotificationConfigurationOutput{
+							LambdaFunctionConfigurations: []s3types.LambdaFunctionConfiguration{},
+							QueueConfigurations:          []s3types.QueueConfiguration{},
+							TopicConfigurations:          []s3types.TopicConfiguration{},
+						}, nil
+					},
+				}),
+			},
+			want: want{
+				status: Updated,
+				err:    nil,
+			},
+		},
 		"NoUpdateExists": {
 			args: args{
 				b: s3Testing.Bucket(s3Testing.WithNotificationConfig(generateNotificationConfig())),
@@ -209,6 +260,35 @@ func TestNotificationObserve(t *testing.T) {
 				err:    nil,
 			},
 		},
+		"NoUpdateDifferentOrder": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithNotificationConfig(func() *v1beta1.NotificationConfiguration {
+					config := generateNotificationConfig()
+					second := config.QueueConfigurations[0]
+					second.ID = awsclient.String("second")
+					second.Events = []string{"s3:ObjectRemoved:*", "s3:ObjectCreated:*"}
+					config.QueueConfigurations = append(config.QueueConfigurations, second)
+					return config
+				}())),
+				cl: NewNotificationConfigurationClient(fake.MockBucketClient{
+					MockGetBucketNotificationConfiguration: func(ctx context.Context, input *s3.GetBucketNotificationConfigurationInput, opts []func(*s3.Options)) (*s3.GetBucketNotificationConfigurationOutput, error) {
+						queues := generateAWSNotification().QueueConfigurations
+						second := queues[0]
+						second.Id = awsclient.String("second")
+						second.Events = []s3types.Event{"s3:ObjectCreated:*", "s3:ObjectRemoved:*"}
+						return &s3.GetBucketNotificationConfigurationOutput{
+							LambdaFunctionConfigurations: generateAWSNotification().LambdaFunctionConfigurations,
+							QueueConfigurations:          []s3types.QueueConfiguration{second, queues[0]},
+							TopicConfigurations:          generateAWSNotification().TopicConfigurations,
+						}, nil
+					},
+				}),
+			},
+			want: want{
+				status: Updated,
+				err:    nil,
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -224,6 +304,62 @@ func TestNotificationObserve(t *testing.T) {
 	}
 }
 
+func TestNotificationDelete(t *testing.T) {
+	type args struct {
+		cl *NotificationConfigurationClient
+		b  *v1beta1.Bucket
+	}
+
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"Error": {
+			args: args{
+				b: s3Testing.Bucket(),
+				cl: NewNotificationConfigurationClient(fake.MockBucketClient{
+					MockPutBucketNotificationConfiguration: func(ctx context.Context, input *s3.PutBucketNotificationConfigurationInput, opts []func(*s3.Options)) (*s3.PutBucketNotificationConfigurationOutput, error) {
+						return nil, errBoom
+					},
+				}),
+			},
+			want: want{
+				err: awsclient.Wrap(errBoom, notificationDeleteFailed),
+			},
+		},
+		"SuccessfulClear": {
+			args: args{
+				b: s3Testing.Bucket(),
+				cl: NewNotificationConfigurationClient(fake.MockBucketClient{
+					MockPutBucketNotificationConfiguration: func(ctx context.Context, input *s3.PutBucketNotificationConfigurationInput, opts []func(*s3.Options)) (*s3.PutBucketNotificationConfigurationOutput, error) {
+						c := input.NotificationConfiguration
+						if c == nil || len(c.LambdaFunctionConfigurations) != 0 || len(c.QueueConfigurations) != 0 || len(c.TopicConfigurations) != 0 {
+							return nil, errBoom
+						}
+						return &s3.PutBucketNotificationConfigurationOutput{}, nil
+					},
+				}),
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.args.cl.Delete(context.Background(), tc.args.b)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestNotificationCreateOrUpdate(t *testing.T) {
 	type args struct {
 		cl *NotificationConfigurationClient
@@ -277,6 +413,23 @@ func TestNotificationCreateOrUpdate(t *testing.T) {
 				err: nil,
 			},
 		},
+		"SuccessfulRemoveAll": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithNotificationConfig(&v1beta1.NotificationConfiguration{})),
+				cl: NewNotificationConfigurationClient(fake.MockBucketClient{
+					MockPutBucketNotificationConfiguration: func(ctx context.Context, input *s3.PutBucketNotificationConfigurationInput, opts []func(*s3.Options)) (*s3.PutBucketNotificationConfigurationOutput, error) {
+						c := input.NotificationConfiguration
+						if len(c.LambdaFunctionConfigurations) != 0 || len(c.QueueConfigurations) != 0 || len(c.TopicConfigurations) != 0 {
+							return nil, errBoom
+						}
+						return &s3.PutBucketNotificationConfigurationOutput{}, nil
+					},
+				}),
+			},
+			want: want{
+				err: nil,
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -378,3 +531,115 @@ func TestNotifLateInit(t *testing.T) {
 		})
 	}
 }
+
+func TestNotificationPrefixSuffixFilter(t *testing.T) {
+	suffix := "suffix"
+	images := "images/"
+	jpg := ".jpg"
+	prefixRule := v1beta1.FilterRule{Name: filterRuleName, Value: &images}
+	suffixRule := v1beta1.FilterRule{Name: suffix, Value: &jpg}
+	awsPrefixRule := s3types.FilterRule{Name: s3types.FilterRuleNamePrefix, Value: &images}
+	awsSuffixRule := s3types.FilterRule{Name: s3types.FilterRuleNameSuffix, Value: &jpg}
+
+	type want struct {
+		status ResourceStatus
+		err    error
+	}
+
+	cases := map[string]struct {
+		config   *v1beta1.NotificationConfiguration
+		external *s3types.NotificationConfiguration
+		want
+	}{
+		"NoUpdateSameOrder": {
+			config:   generateNotificationConfigWithFilter(generatePrefixSuffixFilter(prefixRule, suffixRule)),
+			external: generateAWSNotificationWithFilter(awsPrefixRule, awsSuffixRule),
+			want:     want{status: Updated},
+		},
+		"NoUpdateOtherOrder": {
+			config:   generateNotificationConfigWithFilter(generatePrefixSuffixFilter(suffixRule, prefixRule)),
+			external: generateAWSNotificationWithFilter(awsPrefixRule, awsSuffixRule),
+			want:     want{status: Updated},
+		},
+		"UpdateNeededSuffixMissing": {
+			config:   generateNotificationConfigWithFilter(generatePrefixSuffixFilter(prefixRule, suffixRule)),
+			external: generateAWSNotificationWithFilter(awsPrefixRule),
+			want:     want{status: NeedsUpdate},
+		},
+		"UpdateNeededSuffixChanged": {
+			config: generateNotificationConfigWithFilter(generatePrefixSuffixFilter(prefixRule, suffixRule)),
+			external: generateAWSNotificationWithFilter(awsPrefixRule, s3types.FilterRule{
+				Name: s3types.FilterRuleNameSuffix, Value: awsclient.String(".png"),
+			}),
+			want: want{status: NeedsUpdate},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cl := NewNotificationConfigurationClient(fake.MockBucketClient{
+				MockGetBucketNotificationConfiguration: func(ctx context.Context, input *s3.GetBucketNotificationConfigurationInput, opts []func(*s3.Options)) (*s3.GetBucketNotificationConfigurationOutput, error) {
+					return &s3.GetBucketNotificationConfigurationOutput{
+						LambdaFunctionConfigurations: tc.external.LambdaFunctionConfigurations,
+						QueueConfigurations:          tc.external.QueueConfigurations,
+						TopicConfigurations:          tc.external.TopicConfigurations,
+					}, nil
+				},
+			})
+			status, err := cl.Observe(context.Background(), s3Testing.Bucket(s3Testing.WithNotificationConfig(tc.config)))
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.status, status); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNotificationCreateOrUpdateFilterRestriction(t *testing.T) {
+	suffix := "suffix"
+	images := "images/"
+	jpg := ".jpg"
+	png := ".png"
+
+	cases := map[string]struct {
+		config *v1beta1.NotificationConfiguration
+		err    error
+	}{
+		"PrefixAndSuffix": {
+			config: generateNotificationConfigWithFilter(generatePrefixSuffixFilter(
+				v1beta1.FilterRule{Name: filterRuleName, Value: &images},
+				v1beta1.FilterRule{Name: suffix, Value: &jpg},
+			)),
+		},
+		"TwoSuffixes": {
+			config: generateNotificationConfigWithFilter(generatePrefixSuffixFilter(
+				v1beta1.FilterRule{Name: suffix, Value: &jpg},
+				v1beta1.FilterRule{Name: suffix, Value: &png},
+			)),
+			err: errors.Errorf(notificationFilterDuplicateRule, "queue", 0, suffix),
+		},
+		"TwoPrefixes": {
+			config: generateNotificationConfigWithFilter(generatePrefixSuffixFilter(
+				v1beta1.FilterRule{Name: filterRuleName, Value: &images},
+				v1beta1.FilterRule{Name: filterRuleName, Value: &filterRuleValue},
+			)),
+			err: errors.Errorf(notificationFilterDuplicateRule, "queue", 0, filterRuleName),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cl := NewNotificationConfigurationClient(fake.MockBucketClient{
+				MockPutBucketNotificationConfiguration: func(ctx context.Context, input *s3.PutBucketNotificationConfigurationInput, opts []func(*s3.Options)) (*s3.PutBucketNotificationConfigurationOutput, error) {
+					return &s3.PutBucketNotificationConfigurationOutput{}, nil
+				},
+			})
+			err := cl.CreateOrUpdate(context.Background(), s3Testing.Bucket(s3Testing.WithNotificationConfig(tc.config)))
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
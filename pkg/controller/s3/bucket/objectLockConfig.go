@@ -0,0 +1,194 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bucket
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awss3 "github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go/document"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/provider-aws/apis/s3/v1beta1"
+	awsclient "github.com/crossplane/provider-aws/pkg/clients"
+	"github.com/crossplane/provider-aws/pkg/clients/s3"
+)
+
+const (
+	objectLockGetFailed    = "cannot get Bucket Object Lock configuration"
+	objectLockPutFailed    = "cannot put Bucket Object Lock configuration"
+	objectLockDeleteFailed = "cannot remove Bucket Object Lock default retention"
+
+	objectLockNotEnabled      = "Object Lock is not enabled on the bucket: it can only be configured on buckets that were created with objectLockEnabledForBucket"
+	objectLockRetentionPeriod = "default retention must specify either days or years, but not both"
+)
+
+// ObjectLockConfigurationClient is the client for API methods and reconciling the ObjectLockConfiguration
+type ObjectLockConfigurationClient struct {
+	client s3.BucketClient
+}
+
+// NewObjectLockConfigurationClient creates the client for Object Lock Configuration
+func NewObjectLockConfigurationClient(client s3.BucketClient) *ObjectLockConfigurationClient {
+	return &ObjectLockConfigurationClient{client: client}
+}
+
+// Observe checks if the resource exists and if it matches the local configuration
+func (in *ObjectLockConfigurationClient) Observe(ctx context.Context, bucket *v1beta1.Bucket) (ResourceStatus, error) {
+	config := bucket.Spec.ForProvider.ObjectLockConfiguration
+	if err := validateObjectLockConfiguration(config); err != nil {
+		return NeedsUpdate, err
+	}
+	external, err := in.client.GetObjectLockConfiguration(ctx, &awss3.GetObjectLockConfigurationInput{Bucket: awsclient.String(meta.GetExternalName(bucket))})
+	if err != nil {
+		if s3.ObjectLockConfigurationNotFound(err) {
+			// Object Lock cannot be enabled on an existing bucket, so there
+			// is no point in trying to put the configuration.
+			if config == nil {
+				return Updated, nil
+			}
+			return NeedsUpdate, errors.New(objectLockNotEnabled)
+		}
+		return NeedsUpdate, awsclient.Wrap(err, objectLockGetFailed)
+	}
+
+	var observed *types.ObjectLockConfiguration
+	if external != nil {
+		observed = external.ObjectLockConfiguration
+	}
+	if config == nil {
+		// Object Lock cannot be disabled once it was enabled, only the
+		// default retention can be removed.
+		if observed != nil && observed.Rule != nil && observed.Rule.DefaultRetention != nil {
+			return NeedsDeletion, nil
+		}
+		return Updated, nil
+	}
+	if !cmp.Equal(GenerateObjectLockConfiguration(config), observed, cmpopts.IgnoreTypes(document.NoSerde{})) {
+		return NeedsUpdate, nil
+	}
+	return Updated, nil
+}
+
+// CreateOrUpdate sends a request to have resource created on AWS
+func (in *ObjectLockConfigurationClient) CreateOrUpdate(ctx context.Context, bucket *v1beta1.Bucket) error {
+	config := bucket.Spec.ForProvider.ObjectLockConfiguration
+	if config == nil {
+		return nil
+	}
+	if err := validateObjectLockConfiguration(config); err != nil {
+		return err
+	}
+	_, err := in.client.PutObjectLockConfiguration(ctx, &awss3.PutObjectLockConfigurationInput{
+		Bucket:                  awsclient.String(meta.GetExternalName(bucket)),
+		ObjectLockConfiguration: GenerateObjectLockConfiguration(config),
+	})
+	return awsclient.Wrap(err, objectLockPutFailed)
+}
+
+// Delete removes the default retention of the bucket, since Object Lock
+// cannot be disabled once it was enabled.
+func (in *ObjectLockConfigurationClient) Delete(ctx context.Context, bucket *v1beta1.Bucket) error {
+	_, err := in.client.PutObjectLockConfiguration(ctx, &awss3.PutObjectLockConfigurationInput{
+		Bucket: awsclient.String(meta.GetExternalName(bucket)),
+		ObjectLockConfiguration: &types.ObjectLockConfiguration{
+			ObjectLockEnabled: types.ObjectLockEnabledEnabled,
+		},
+	})
+	return awsclient.Wrap(err, objectLockDeleteFailed)
+}
+
+// LateInitialize is responsible for initializing the resource based on the external value
+func (in *ObjectLockConfigurationClient) LateInitialize(ctx context.Context, bucket *v1beta1.Bucket) error {
+	external, err := in.client.GetObjectLockConfiguration(ctx, &awss3.GetObjectLockConfigurationInput{Bucket: awsclient.String(meta.GetExternalName(bucket))})
+	if err != nil {
+		return awsclient.Wrap(resource.Ignore(s3.ObjectLockConfigurationNotFound, err), objectLockGetFailed)
+	}
+
+	if external == nil || external.ObjectLockConfiguration == nil {
+		return nil
+	}
+
+	fp := &bucket.Spec.ForProvider
+	if fp.ObjectLockConfiguration == nil {
+		fp.ObjectLockConfiguration = GenerateLocalObjectLockConfiguration(external.ObjectLockConfiguration)
+	}
+	return nil
+}
+
+// CapturePrior returns a Bucket with the object lock configuration currently in AWS.
+func (in *ObjectLockConfigurationClient) CapturePrior(ctx context.Context, bucket *v1beta1.Bucket) (*v1beta1.Bucket, error) {
+	return lateInitializedPrior(ctx, in, bucket)
+}
+
+// SubresourceExists checks if the subresource this controller manages currently exists
+func (in *ObjectLockConfigurationClient) SubresourceExists(bucket *v1beta1.Bucket) bool {
+	return bucket.Spec.ForProvider.ObjectLockConfiguration != nil
+}
+
+// validateObjectLockConfiguration returns an error if the default retention
+// does not specify exactly one of days and years, which S3 requires.
+func validateObjectLockConfiguration(config *v1beta1.ObjectLockConfiguration) error {
+	if config == nil || config.Rule == nil {
+		return nil
+	}
+	r := config.Rule.DefaultRetention
+	if (r.Days == nil) == (r.Years == nil) {
+		return errors.New(objectLockRetentionPeriod)
+	}
+	return nil
+}
+
+// GenerateObjectLockConfiguration creates the types.ObjectLockConfiguration for the AWS SDK
+func GenerateObjectLockConfiguration(config *v1beta1.ObjectLockConfiguration) *types.ObjectLockConfiguration {
+	if config == nil {
+		return nil
+	}
+	out := &types.ObjectLockConfiguration{ObjectLockEnabled: types.ObjectLockEnabled(config.ObjectLockEnabled)}
+	if config.Rule != nil {
+		r := config.Rule.DefaultRetention
+		out.Rule = &types.ObjectLockRule{DefaultRetention: &types.DefaultRetention{
+			Mode:  types.ObjectLockRetentionMode(r.Mode),
+			Days:  aws.ToInt32(r.Days),
+			Years: aws.ToInt32(r.Years),
+		}}
+	}
+	return out
+}
+
+// GenerateLocalObjectLockConfiguration creates the v1beta1.ObjectLockConfiguration from the AWS SDK Object Lock configuration
+func GenerateLocalObjectLockConfiguration(config *types.ObjectLockConfiguration) *v1beta1.ObjectLockConfiguration {
+	if config == nil {
+		return nil
+	}
+	out := &v1beta1.ObjectLockConfiguration{ObjectLockEnabled: string(config.ObjectLockEnabled)}
+	if config.Rule != nil && config.Rule.DefaultRetention != nil {
+		r := config.Rule.DefaultRetention
+		out.Rule = &v1beta1.ObjectLockRule{DefaultRetention: v1beta1.DefaultRetention{
+			Mode:  string(r.Mode),
+			Days:  awsclient.Int32(int(r.Days)),
+			Years: awsclient.Int32(int(r.Years)),
+		}}
+	}
+	return out
+}
@@ -0,0 +1,417 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bucket
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/document"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/provider-aws/apis/s3/v1beta1"
+	awsclient "github.com/crossplane/provider-aws/pkg/clients"
+	clientss3 "github.com/crossplane/provider-aws/pkg/clients/s3"
+	"github.com/crossplane/provider-aws/pkg/clients/s3/fake"
+	s3Testing "github.com/crossplane/provider-aws/pkg/controller/s3/testing"
+)
+
+var _ SubresourceClient = &ObjectLockConfigurationClient{}
+
+func generateObjectLockConfig(mode types.ObjectLockRetentionMode, days int) *v1beta1.ObjectLockConfiguration {
+	return &v1beta1.ObjectLockConfiguration{
+		ObjectLockEnabled: string(types.ObjectLockEnabledEnabled),
+		Rule: &v1beta1.ObjectLockRule{DefaultRetention: v1beta1.DefaultRetention{
+			Mode: string(mode),
+			Days: awsclient.Int32(days),
+		}},
+	}
+}
+
+func generateAWSObjectLockConfig(mode types.ObjectLockRetentionMode, days int32) *types.ObjectLockConfiguration {
+	return &types.ObjectLockConfiguration{
+		ObjectLockEnabled: types.ObjectLockEnabledEnabled,
+		Rule: &types.ObjectLockRule{DefaultRetention: &types.DefaultRetention{
+			Mode: mode,
+			Days: days,
+		}},
+	}
+}
+
+func getObjectLockConfig(config *types.ObjectLockConfiguration) func(ctx context.Context, input *s3.GetObjectLockConfigurationInput, opts []func(*s3.Options)) (*s3.GetObjectLockConfigurationOutput, error) {
+	return func(ctx context.Context, input *s3.GetObjectLockConfigurationInput, opts []func(*s3.Options)) (*s3.GetObjectLockConfigurationOutput, error) {
+		return &s3.GetObjectLockConfigurationOutput{ObjectLockConfiguration: config}, nil
+	}
+}
+
+func TestObjectLockObserve(t *testing.T) {
+	type args struct {
+		cl *ObjectLockConfigurationClient
+		b  *v1beta1.Bucket
+	}
+
+	type want struct {
+		status ResourceStatus
+		err    error
+	}
+
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"Error": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithObjectLockConfig(generateObjectLockConfig(types.ObjectLockRetentionModeGovernance, 1))),
+				cl: NewObjectLockConfigurationClient(fake.MockBucketClient{
+					MockGetObjectLockConfiguration: func(ctx context.Context, input *s3.GetObjectLockConfigurationInput, opts []func(*s3.Options)) (*s3.GetObjectLockConfigurationOutput, error) {
+						return nil, errBoom
+					},
+				}),
+			},
+			want: want{
+				status: NeedsUpdate,
+				err:    awsclient.Wrap(errBoom, objectLockGetFailed),
+			},
+		},
+		"DaysAndYears": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithObjectLockConfig(&v1beta1.ObjectLockConfiguration{
+					ObjectLockEnabled: string(types.ObjectLockEnabledEnabled),
+					Rule: &v1beta1.ObjectLockRule{DefaultRetention: v1beta1.DefaultRetention{
+						Mode:  string(types.ObjectLockRetentionModeCompliance),
+						Days:  awsclient.Int32(1),
+						Years: awsclient.Int32(1),
+					}},
+				})),
+				cl: NewObjectLockConfigurationClient(fake.MockBucketClient{}),
+			},
+			want: want{
+				status: NeedsUpdate,
+				err:    errors.New(objectLockRetentionPeriod),
+			},
+		},
+		"ObjectLockNotEnabled": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithObjectLockConfig(generateObjectLockConfig(types.ObjectLockRetentionModeGovernance, 1))),
+				cl: NewObjectLockConfigurationClient(fake.MockBucketClient{
+					MockGetObjectLockConfiguration: func(ctx context.Context, input *s3.GetObjectLockConfigurationInput, opts []func(*s3.Options)) (*s3.GetObjectLockConfigurationOutput, error) {
+						return nil, &smithy.GenericAPIError{Code: clientss3.ObjectLockNotFoundErrCode}
+					},
+				}),
+			},
+			want: want{
+				status: NeedsUpdate,
+				err:    errors.New(objectLockNotEnabled),
+			},
+		},
+		"RetentionChanged": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithObjectLockConfig(generateObjectLockConfig(types.ObjectLockRetentionModeGovernance, 30))),
+				cl: NewObjectLockConfigurationClient(fake.MockBucketClient{
+					MockGetObjectLockConfiguration: getObjectLockConfig(generateAWSObjectLockConfig(types.ObjectLockRetentionModeGovernance, 7)),
+				}),
+			},
+			want: want{
+				status: NeedsUpdate,
+				err:    nil,
+			},
+		},
+		"NeedsDeletion": {
+			args: args{
+				b: s3Testing.Bucket(),
+				cl: NewObjectLockConfigurationClient(fake.MockBucketClient{
+					MockGetObjectLockConfiguration: getObjectLockConfig(generateAWSObjectLockConfig(types.ObjectLockRetentionModeGovernance, 7)),
+				}),
+			},
+			want: want{
+				status: NeedsDeletion,
+				err:    nil,
+			},
+		},
+		"NoUpdateNotExists": {
+			args: args{
+				b: s3Testing.Bucket(),
+				cl: NewObjectLockConfigurationClient(fake.MockBucketClient{
+					MockGetObjectLockConfiguration: func(ctx context.Context, input *s3.GetObjectLockConfigurationInput, opts []func(*s3.Options)) (*s3.GetObjectLockConfigurationOutput, error) {
+						return nil, &smithy.GenericAPIError{Code: clientss3.ObjectLockNotFoundErrCode}
+					},
+				}),
+			},
+			want: want{
+				status: Updated,
+				err:    nil,
+			},
+		},
+		"NoUpdateNoRetention": {
+			args: args{
+				b: s3Testing.Bucket(),
+				cl: NewObjectLockConfigurationClient(fake.MockBucketClient{
+					MockGetObjectLockConfiguration: getObjectLockConfig(&types.ObjectLockConfiguration{ObjectLockEnabled: types.ObjectLockEnabledEnabled}),
+				}),
+			},
+			want: want{
+				status: Updated,
+				err:    nil,
+			},
+		},
+		"NoUpdateExists": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithObjectLockConfig(generateObjectLockConfig(types.ObjectLockRetentionModeCompliance, 7))),
+				cl: NewObjectLockConfigurationClient(fake.MockBucketClient{
+					MockGetObjectLockConfiguration: getObjectLockConfig(generateAWSObjectLockConfig(types.ObjectLockRetentionModeCompliance, 7)),
+				}),
+			},
+			want: want{
+				status: Updated,
+				err:    nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			status, err := tc.args.cl.Observe(context.Background(), tc.args.b)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.status, status); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestObjectLockCreateOrUpdate(t *testing.T) {
+	type args struct {
+		cl *ObjectLockConfigurationClient
+		b  *v1beta1.Bucket
+	}
+
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"Error": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithObjectLockConfig(generateObjectLockConfig(types.ObjectLockRetentionModeGovernance, 1))),
+				cl: NewObjectLockConfigurationClient(fake.MockBucketClient{
+					MockPutObjectLockConfiguration: func(ctx context.Context, input *s3.PutObjectLockConfigurationInput, opts []func(*s3.Options)) (*s3.PutObjectLockConfigurationOutput, error) {
+						return nil, errBoom
+					},
+				}),
+			},
+			want: want{
+				err: awsclient.Wrap(errBoom, objectLockPutFailed),
+			},
+		},
+		"NoRetentionPeriod": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithObjectLockConfig(&v1beta1.ObjectLockConfiguration{
+					ObjectLockEnabled: string(types.ObjectLockEnabledEnabled),
+					Rule: &v1beta1.ObjectLockRule{DefaultRetention: v1beta1.DefaultRetention{
+						Mode: string(types.ObjectLockRetentionModeGovernance),
+					}},
+				})),
+				cl: NewObjectLockConfigurationClient(fake.MockBucketClient{}),
+			},
+			want: want{
+				err: errors.New(objectLockRetentionPeriod),
+			},
+		},
+		"InvalidConfig": {
+			args: args{
+				b:  s3Testing.Bucket(),
+				cl: NewObjectLockConfigurationClient(fake.MockBucketClient{}),
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"SuccessfulCreate": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithObjectLockConfig(generateObjectLockConfig(types.ObjectLockRetentionModeGovernance, 1))),
+				cl: NewObjectLockConfigurationClient(fake.MockBucketClient{
+					MockPutObjectLockConfiguration: func(ctx context.Context, input *s3.PutObjectLockConfigurationInput, opts []func(*s3.Options)) (*s3.PutObjectLockConfigurationOutput, error) {
+						if diff := cmp.Diff(generateAWSObjectLockConfig(types.ObjectLockRetentionModeGovernance, 1), input.ObjectLockConfiguration, cmpopts.IgnoreTypes(document.NoSerde{})); diff != "" {
+							return nil, errors.New(diff)
+						}
+						return &s3.PutObjectLockConfigurationOutput{}, nil
+					},
+				}),
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.args.cl.CreateOrUpdate(context.Background(), tc.args.b)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestObjectLockDelete(t *testing.T) {
+	type args struct {
+		cl *ObjectLockConfigurationClient
+		b  *v1beta1.Bucket
+	}
+
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"Error": {
+			args: args{
+				b: s3Testing.Bucket(),
+				cl: NewObjectLockConfigurationClient(fake.MockBucketClient{
+					MockPutObjectLockConfiguration: func(ctx context.Context, input *s3.PutObjectLockConfigurationInput, opts []func(*s3.Options)) (*s3.PutObjectLockConfigurationOutput, error) {
+						return nil, errBoom
+					},
+				}),
+			},
+			want: want{
+				err: awsclient.Wrap(errBoom, objectLockDeleteFailed),
+			},
+		},
+		"SuccessfulRemoveRetention": {
+			args: args{
+				b: s3Testing.Bucket(),
+				cl: NewObjectLockConfigurationClient(fake.MockBucketClient{
+					MockPutObjectLockConfiguration: func(ctx context.Context, input *s3.PutObjectLockConfigurationInput, opts []func(*s3.Options)) (*s3.PutObjectLockConfigurationOutput, error) {
+						if input.ObjectLockConfiguration.Rule != nil {
+							return nil, errBoom
+						}
+						return &s3.PutObjectLockConfigurationOutput{}, nil
+					},
+				}),
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.args.cl.Delete(context.Background(), tc.args.b)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestObjectLockLateInit(t *testing.T) {
+	type args struct {
+		cl SubresourceClient
+		b  *v1beta1.Bucket
+	}
+
+	type want struct {
+		err error
+		cr  *v1beta1.Bucket
+	}
+
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"Error": {
+			args: args{
+				b: s3Testing.Bucket(),
+				cl: NewObjectLockConfigurationClient(fake.MockBucketClient{
+					MockGetObjectLockConfiguration: func(ctx context.Context, input *s3.GetObjectLockConfigurationInput, opts []func(*s3.Options)) (*s3.GetObjectLockConfigurationOutput, error) {
+						return nil, errBoom
+					},
+				}),
+			},
+			want: want{
+				err: awsclient.Wrap(errBoom, objectLockGetFailed),
+				cr:  s3Testing.Bucket(),
+			},
+		},
+		"NoLateInitNotEnabled": {
+			args: args{
+				b: s3Testing.Bucket(),
+				cl: NewObjectLockConfigurationClient(fake.MockBucketClient{
+					MockGetObjectLockConfiguration: func(ctx context.Context, input *s3.GetObjectLockConfigurationInput, opts []func(*s3.Options)) (*s3.GetObjectLockConfigurationOutput, error) {
+						return nil, &smithy.GenericAPIError{Code: clientss3.ObjectLockNotFoundErrCode}
+					},
+				}),
+			},
+			want: want{
+				err: nil,
+				cr:  s3Testing.Bucket(),
+			},
+		},
+		"SuccessfulLateInit": {
+			args: args{
+				b: s3Testing.Bucket(),
+				cl: NewObjectLockConfigurationClient(fake.MockBucketClient{
+					MockGetObjectLockConfiguration: getObjectLockConfig(generateAWSObjectLockConfig(types.ObjectLockRetentionModeGovernance, 7)),
+				}),
+			},
+			want: want{
+				err: nil,
+				cr:  s3Testing.Bucket(s3Testing.WithObjectLockConfig(generateObjectLockConfig(types.ObjectLockRetentionModeGovernance, 7))),
+			},
+		},
+		"NoOpLateInit": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithObjectLockConfig(generateObjectLockConfig(types.ObjectLockRetentionModeCompliance, 1))),
+				cl: NewObjectLockConfigurationClient(fake.MockBucketClient{
+					MockGetObjectLockConfiguration: getObjectLockConfig(generateAWSObjectLockConfig(types.ObjectLockRetentionModeGovernance, 7)),
+				}),
+			},
+			want: want{
+				err: nil,
+				cr:  s3Testing.Bucket(s3Testing.WithObjectLockConfig(generateObjectLockConfig(types.ObjectLockRetentionModeCompliance, 1))),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.args.cl.LateInitialize(context.Background(), tc.args.b)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.cr, tc.args.b, test.EquateConditions()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,406 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bucket
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/provider-aws/apis/s3/v1beta1"
+	awsclient "github.com/crossplane/provider-aws/pkg/clients"
+	clientss3 "github.com/crossplane/provider-aws/pkg/clients/s3"
+	"github.com/crossplane/provider-aws/pkg/clients/s3/fake"
+	s3Testing "github.com/crossplane/provider-aws/pkg/controller/s3/testing"
+)
+
+var _ SubresourceClient = &OwnershipControlsClient{}
+
+func generateOwnershipControls(o types.ObjectOwnership) *v1beta1.OwnershipControls {
+	return &v1beta1.OwnershipControls{Rules: []v1beta1.OwnershipControlsRule{{ObjectOwnership: string(o)}}}
+}
+
+func generateAWSOwnershipControls(o types.ObjectOwnership) *types.OwnershipControls {
+	return &types.OwnershipControls{Rules: []types.OwnershipControlsRule{{ObjectOwnership: o}}}
+}
+
+func TestOwnershipControlsObserve(t *testing.T) {
+	type args struct {
+		cl *OwnershipControlsClient
+		b  *v1beta1.Bucket
+	}
+
+	type want struct {
+		status ResourceStatus
+		err    error
+	}
+
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"Error": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithOwnershipControls(generateOwnershipControls(types.ObjectOwnershipObjectWriter))),
+				cl: NewOwnershipControlsClient(fake.MockBucketClient{
+					MockGetBucketOwnershipControls: func(ctx context.Context, input *s3.GetBucketOwnershipControlsInput, opts []func(*s3.Options)) (*s3.GetBucketOwnershipControlsOutput, error) {
+						return nil, errBoom
+					},
+				}),
+			},
+			want: want{
+				status: NeedsUpdate,
+				err:    awsclient.Wrap(errBoom, ownershipControlsGetFailed),
+			},
+		},
+		"UpdateNeededNotExists": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithOwnershipControls(generateOwnershipControls(types.ObjectOwnershipObjectWriter))),
+				cl: NewOwnershipControlsClient(fake.MockBucketClient{
+					MockGetBucketOwnershipControls: func(ctx context.Context, input *s3.GetBucketOwnershipControlsInput, opts []func(*s3.Options)) (*s3.GetBucketOwnershipControlsOutput, error) {
+						return nil, &smithy.GenericAPIError{Code: clientss3.OwnershipControlsNotFoundErrCode}
+					},
+				}),
+			},
+			want: want{
+				status: NeedsUpdate,
+				err:    nil,
+			},
+		},
+		"UpdateNeeded": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithOwnershipControls(generateOwnershipControls(clientss3.ObjectOwnershipBucketOwnerEnforced))),
+				cl: NewOwnershipControlsClient(fake.MockBucketClient{
+					MockGetBucketOwnershipControls: func(ctx context.Context, input *s3.GetBucketOwnershipControlsInput, opts []func(*s3.Options)) (*s3.GetBucketOwnershipControlsOutput, error) {
+						return &s3.GetBucketOwnershipControlsOutput{OwnershipControls: generateAWSOwnershipControls(types.ObjectOwnershipObjectWriter)}, nil
+					},
+				}),
+			},
+			want: want{
+				status: NeedsUpdate,
+				err:    nil,
+			},
+		},
+		"NeedsDelete": {
+			args: args{
+				b: s3Testing.Bucket(),
+				cl: NewOwnershipControlsClient(fake.MockBucketClient{
+					MockGetBucketOwnershipControls: func(ctx context.Context, input *s3.GetBucketOwnershipControlsInput, opts []func(*s3.Options)) (*s3.GetBucketOwnershipControlsOutput, error) {
+						return &s3.GetBucketOwnershipControlsOutput{OwnershipControls: generateAWSOwnershipControls(types.ObjectOwnershipObjectWriter)}, nil
+					},
+				}),
+			},
+			want: want{
+				status: NeedsDeletion,
+				err:    nil,
+			},
+		},
+		"NoUpdateNotExists": {
+			args: args{
+				b: s3Testing.Bucket(),
+				cl: NewOwnershipControlsClient(fake.MockBucketClient{
+					MockGetBucketOwnershipControls: func(ctx context.Context, input *s3.GetBucketOwnershipControlsInput, opts []func(*s3.Options)) (*s3.GetBucketOwnershipControlsOutput, error) {
+						return nil, &smithy.GenericAPIError{Code: clientss3.OwnershipControlsNotFoundErrCode}
+					},
+				}),
+			},
+			want: want{
+				status: Updated,
+				err:    nil,
+			},
+		},
+		"NoUpdateExists": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithOwnershipControls(generateOwnershipControls(clientss3.ObjectOwnershipBucketOwnerEnforced))),
+				cl: NewOwnershipControlsClient(fake.MockBucketClient{
+					MockGetBucketOwnershipControls: func(ctx context.Context, input *s3.GetBucketOwnershipControlsInput, opts []func(*s3.Options)) (*s3.GetBucketOwnershipControlsOutput, error) {
+						return &s3.GetBucketOwnershipControlsOutput{OwnershipControls: generateAWSOwnershipControls(clientss3.ObjectOwnershipBucketOwnerEnforced)}, nil
+					},
+				}),
+			},
+			want: want{
+				status: Updated,
+				err:    nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			status, err := tc.args.cl.Observe(context.Background(), tc.args.b)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.status, status); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestOwnershipControlsCreateOrUpdate(t *testing.T) {
+	type args struct {
+		cl *OwnershipControlsClient
+		b  *v1beta1.Bucket
+	}
+
+	type want struct {
+		err error
+	}
+
+	// aclReset records whether the ACL was reset before ACLs were disabled.
+	aclReset := false
+
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"Error": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithOwnershipControls(generateOwnershipControls(types.ObjectOwnershipObjectWriter))),
+				cl: NewOwnershipControlsClient(fake.MockBucketClient{
+					MockPutBucketOwnershipControls: func(ctx context.Context, input *s3.PutBucketOwnershipControlsInput, opts []func(*s3.Options)) (*s3.PutBucketOwnershipControlsOutput, error) {
+						return nil, errBoom
+					},
+				}),
+			},
+			want: want{
+				err: awsclient.Wrap(errBoom, ownershipControlsPutFailed),
+			},
+		},
+		"ACLResetError": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithOwnershipControls(generateOwnershipControls(clientss3.ObjectOwnershipBucketOwnerEnforced))),
+				cl: NewOwnershipControlsClient(fake.MockBucketClient{
+					MockPutBucketAcl: func(ctx context.Context, input *s3.PutBucketAclInput, opts []func(*s3.Options)) (*s3.PutBucketAclOutput, error) {
+						return nil, errBoom
+					},
+				}),
+			},
+			want: want{
+				err: awsclient.Wrap(errBoom, ownershipControlsACLFailed),
+			},
+		},
+		"NoRules": {
+			args: args{
+				b:  s3Testing.Bucket(s3Testing.WithOwnershipControls(&v1beta1.OwnershipControls{})),
+				cl: NewOwnershipControlsClient(fake.MockBucketClient{}),
+			},
+			want: want{
+				err: errors.Errorf(ownershipControlsRuleCount, 0),
+			},
+		},
+		"NoConfig": {
+			args: args{
+				b:  s3Testing.Bucket(),
+				cl: NewOwnershipControlsClient(fake.MockBucketClient{}),
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"SuccessfulCreate": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithOwnershipControls(generateOwnershipControls(clientss3.ObjectOwnershipBucketOwnerEnforced))),
+				cl: NewOwnershipControlsClient(fake.MockBucketClient{
+					MockPutBucketAcl: func(ctx context.Context, input *s3.PutBucketAclInput, opts []func(*s3.Options)) (*s3.PutBucketAclOutput, error) {
+						if input.ACL != types.BucketCannedACLPrivate {
+							return nil, errBoom
+						}
+						aclReset = true
+						return &s3.PutBucketAclOutput{}, nil
+					},
+					MockPutBucketOwnershipControls: func(ctx context.Context, input *s3.PutBucketOwnershipControlsInput, opts []func(*s3.Options)) (*s3.PutBucketOwnershipControlsOutput, error) {
+						if input.OwnershipControls.Rules[0].ObjectOwnership != clientss3.ObjectOwnershipBucketOwnerEnforced || !aclReset {
+							return nil, errBoom
+						}
+						return &s3.PutBucketOwnershipControlsOutput{}, nil
+					},
+				}),
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.args.cl.CreateOrUpdate(context.Background(), tc.args.b)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestOwnershipControlsDelete(t *testing.T) {
+	type args struct {
+		cl *OwnershipControlsClient
+		b  *v1beta1.Bucket
+	}
+
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"Error": {
+			args: args{
+				b: s3Testing.Bucket(),
+				cl: NewOwnershipControlsClient(fake.MockBucketClient{
+					MockDeleteBucketOwnershipControls: func(ctx context.Context, input *s3.DeleteBucketOwnershipControlsInput, opts []func(*s3.Options)) (*s3.DeleteBucketOwnershipControlsOutput, error) {
+						return nil, errBoom
+					},
+				}),
+			},
+			want: want{
+				err: awsclient.Wrap(errBoom, ownershipControlsDeleteFailed),
+			},
+		},
+		"NotFound": {
+			args: args{
+				b: s3Testing.Bucket(),
+				cl: NewOwnershipControlsClient(fake.MockBucketClient{
+					MockDeleteBucketOwnershipControls: func(ctx context.Context, input *s3.DeleteBucketOwnershipControlsInput, opts []func(*s3.Options)) (*s3.DeleteBucketOwnershipControlsOutput, error) {
+						return nil, &smithy.GenericAPIError{Code: clientss3.OwnershipControlsNotFoundErrCode}
+					},
+				}),
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"SuccessfulDelete": {
+			args: args{
+				b: s3Testing.Bucket(),
+				cl: NewOwnershipControlsClient(fake.MockBucketClient{
+					MockDeleteBucketOwnershipControls: func(ctx context.Context, input *s3.DeleteBucketOwnershipControlsInput, opts []func(*s3.Options)) (*s3.DeleteBucketOwnershipControlsOutput, error) {
+						return &s3.DeleteBucketOwnershipControlsOutput{}, nil
+					},
+				}),
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.args.cl.Delete(context.Background(), tc.args.b)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestOwnershipControlsLateInit(t *testing.T) {
+	type args struct {
+		cl SubresourceClient
+		b  *v1beta1.Bucket
+	}
+
+	type want struct {
+		err error
+		cr  *v1beta1.Bucket
+	}
+
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"Error": {
+			args: args{
+				b: s3Testing.Bucket(),
+				cl: NewOwnershipControlsClient(fake.MockBucketClient{
+					MockGetBucketOwnershipControls: func(ctx context.Context, input *s3.GetBucketOwnershipControlsInput, opts []func(*s3.Options)) (*s3.GetBucketOwnershipControlsOutput, error) {
+						return nil, errBoom
+					},
+				}),
+			},
+			want: want{
+				err: awsclient.Wrap(errBoom, ownershipControlsGetFailed),
+				cr:  s3Testing.Bucket(),
+			},
+		},
+		"NoLateInitNotFound": {
+			args: args{
+				b: s3Testing.Bucket(),
+				cl: NewOwnershipControlsClient(fake.MockBucketClient{
+					MockGetBucketOwnershipControls: func(ctx context.Context, input *s3.GetBucketOwnershipControlsInput, opts []func(*s3.Options)) (*s3.GetBucketOwnershipControlsOutput, error) {
+						return nil, &smithy.GenericAPIError{Code: clientss3.OwnershipControlsNotFoundErrCode}
+					},
+				}),
+			},
+			want: want{
+				err: nil,
+				cr:  s3Testing.Bucket(),
+			},
+		},
+		"SuccessfulLateInit": {
+			args: args{
+				b: s3Testing.Bucket(),
+				cl: NewOwnershipControlsClient(fake.MockBucketClient{
+					MockGetBucketOwnershipControls: func(ctx context.Context, input *s3.GetBucketOwnershipControlsInput, opts []func(*s3.Options)) (*s3.GetBucketOwnershipControlsOutput, error) {
+						return &s3.GetBucketOwnershipControlsOutput{OwnershipControls: generateAWSOwnershipControls(types.ObjectOwnershipBucketOwnerPreferred)}, nil
+					},
+				}),
+			},
+			want: want{
+				err: nil,
+				cr:  s3Testing.Bucket(s3Testing.WithOwnershipControls(generateOwnershipControls(types.ObjectOwnershipBucketOwnerPreferred))),
+			},
+		},
+		"NoOpLateInit": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithOwnershipControls(generateOwnershipControls(clientss3.ObjectOwnershipBucketOwnerEnforced))),
+				cl: NewOwnershipControlsClient(fake.MockBucketClient{
+					MockGetBucketOwnershipControls: func(ctx context.Context, input *s3.GetBucketOwnershipControlsInput, opts []func(*s3.Options)) (*s3.GetBucketOwnershipControlsOutput, error) {
+						return &s3.GetBucketOwnershipControlsOutput{OwnershipControls: generateAWSOwnershipControls(types.ObjectOwnershipBucketOwnerPreferred)}, nil
+					},
+				}),
+			},
+			want: want{
+				err: nil,
+				cr:  s3Testing.Bucket(s3Testing.WithOwnershipControls(generateOwnershipControls(clientss3.ObjectOwnershipBucketOwnerEnforced))),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.args.cl.LateInitialize(context.Background(), tc.args.b)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.args.b, tc.want.cr); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -35,6 +35,11 @@ const (
 	publicAccessBlockGetFailed    = "cannot get Bucket public access block"
 	publicAccessBlockPutFailed    = "cannot put Bucket public access block"
 	publicAccessBlockDeleteFailed = "cannot delete Bucket public access block"
+
+	// publicAccessBlockManaged marks a Bucket whose public access block was
+	// configured in its spec at some point, so that clearing the spec deletes
+	// the block rather than leaving it unmanaged.
+	publicAccessBlockManaged = "s3.aws.crossplane.io/public-access-block-managed"
 )
 
 // PublicAccessBlockClient is the client for API methods and reconciling the PublicAccessBlock
@@ -50,21 +55,41 @@ func NewPublicAccessBlockClient(client s3.BucketClient) *PublicAccessBlockClient
 // Observe checks if the resource exists and if it matches the local configuration
 func (in *PublicAccessBlockClient) Observe(ctx context.Context, cr *v1beta1.Bucket) (ResourceStatus, error) {
 	external, err := in.client.GetPublicAccessBlock(ctx, &awss3.GetPublicAccessBlockInput{Bucket: awsclient.String(meta.GetExternalName(cr))})
-	if s3.PublicAccessBlockConfigurationNotFound(err) && cr.Spec.ForProvider.PublicAccessBlockConfiguration == nil {
-		return Updated, nil
+	if s3.PublicAccessBlockConfigurationNotFound(err) {
+		if cr.Spec.ForProvider.PublicAccessBlockConfiguration == nil {
+			return Updated, nil
+		}
+		// The configuration was never applied or was deleted out of band.
+		return NeedsUpdate, nil
 	}
 	if err != nil {
-		return NeedsUpdate, awsclient.Wrap(resource.Ignore(s3.PublicAccessBlockConfigurationNotFound, err), publicAccessBlockGetFailed)
+		return NeedsUpdate, awsclient.Wrap(err, publicAccessBlockGetFailed)
 	}
-	if cr.Spec.ForProvider.PublicAccessBlockConfiguration != nil {
-		switch {
-		case awsclient.BoolValue(cr.Spec.ForProvider.PublicAccessBlockConfiguration.BlockPublicAcls) != external.PublicAccessBlockConfiguration.BlockPublicAcls:
-			return NeedsUpdate, nil
-		case awsclient.BoolValue(cr.Spec.ForProvider.PublicAccessBlockConfiguration.BlockPublicPolicy) != external.PublicAccessBlockConfiguration.BlockPublicPolicy:
-			return NeedsUpdate, nil
-		case awsclient.BoolValue(cr.Spec.ForProvider.PublicAccessBlockConfiguration.RestrictPublicBuckets) != external.PublicAccessBlockConfiguration.RestrictPublicBuckets:
-			return NeedsUpdate, nil
-		case awsclient.BoolValue(cr.Spec.ForProvider.PublicAccessBlockConfiguration.IgnorePublicAcls) != external.PublicAccessBlockConfiguration.IgnorePublicAcls:
+	config := cr.Spec.ForProvider.PublicAccessBlockConfiguration
+	exists := external != nil && external.PublicAccessBlockConfiguration != nil
+	switch {
+	// A public access block that was never configured in the spec, e.g. the
+	// one AWS adds to new buckets, is not managed and thus kept.
+	case config == nil && exists && cr.GetAnnotations()[publicAccessBlockManaged] == "true":
+		return NeedsDeletion, nil
+	case config == nil:
+		return Updated, nil
+	case !exists:
+		return NeedsUpdate, nil
+	}
+	// Only the settings that are set in the spec are compared, an unset one
+	// keeps whatever value it has in AWS.
+	e := external.PublicAccessBlockConfiguration
+	for _, s := range []struct {
+		local    *bool
+		external bool
+	}{
+		{local: config.BlockPublicAcls, external: e.BlockPublicAcls},
+		{local: config.BlockPublicPolicy, external: e.BlockPublicPolicy},
+		{local: config.RestrictPublicBuckets, external: e.RestrictPublicBuckets},
+		{local: config.IgnorePublicAcls, external: e.IgnorePublicAcls},
+	} {
+		if s.local != nil && *s.local != s.external {
 			return NeedsUpdate, nil
 		}
 	}
@@ -73,16 +98,29 @@ func (in *PublicAccessBlockClient) Observe(ctx context.Context, cr *v1beta1.Buck
 
 // CreateOrUpdate sends a request to have resource created on AWS
 func (in *PublicAccessBlockClient) CreateOrUpdate(ctx context.Context, cr *v1beta1.Bucket) error {
-	if cr.Spec.ForProvider.PublicAccessBlockConfiguration == nil {
+	config := cr.Spec.ForProvider.PublicAccessBlockConfiguration
+	if config == nil {
 		return nil
 	}
+	// The put replaces all settings, so those that are not set in the spec
+	// are sent with their current value.
+	current := awss3types.PublicAccessBlockConfiguration{}
+	if config.BlockPublicAcls == nil || config.BlockPublicPolicy == nil || config.RestrictPublicBuckets == nil || config.IgnorePublicAcls == nil {
+		external, err := in.client.GetPublicAccessBlock(ctx, &awss3.GetPublicAccessBlockInput{Bucket: awsclient.String(meta.GetExternalName(cr))})
+		if resource.Ignore(s3.PublicAccessBlockConfigurationNotFound, err) != nil {
+			return awsclient.Wrap(err, publicAccessBlockGetFailed)
+		}
+		if err == nil && external != nil && external.PublicAccessBlockConfiguration != nil {
+			current = *external.PublicAccessBlockConfiguration
+		}
+	}
 	input := &awss3.PutPublicAccessBlockInput{
 		Bucket: awsclient.String(meta.GetExternalName(cr)),
 		PublicAccessBlockConfiguration: &awss3types.PublicAccessBlockConfiguration{
-			BlockPublicAcls:       awsclient.BoolValue(cr.Spec.ForProvider.PublicAccessBlockConfiguration.BlockPublicAcls),
-			BlockPublicPolicy:     awsclient.BoolValue(cr.Spec.ForProvider.PublicAccessBlockConfiguration.BlockPublicPolicy),
-			RestrictPublicBuckets: awsclient.BoolValue(cr.Spec.ForProvider.PublicAccessBlockConfiguration.RestrictPublicBuckets),
-			IgnorePublicAcls:      awsclient.BoolValue(cr.Spec.ForProvider.PublicAccessBlockConfiguration.IgnorePublicAcls),
+			BlockPublicAcls:       awsclient.BoolValue(awsclient.LateInitializeBoolPtr(config.BlockPublicAcls, &current.BlockPublicAcls)),
+			BlockPublicPolicy:     awsclient.BoolValue(awsclient.LateInitializeBoolPtr(config.BlockPublicPolicy, &current.BlockPublicPolicy)),
+			RestrictPublicBuckets: awsclient.BoolValue(awsclient.LateInitializeBoolPtr(config.RestrictPublicBuckets, &current.RestrictPublicBuckets)),
+			IgnorePublicAcls:      awsclient.BoolValue(awsclient.LateInitializeBoolPtr(config.IgnorePublicAcls, &current.IgnorePublicAcls)),
 		},
 	}
 	_, err := in.client.PutPublicAccessBlock(ctx, input)
@@ -98,8 +136,13 @@ func (in *PublicAccessBlockClient) Delete(ctx context.Context, cr *v1beta1.Bucke
 	return errors.Wrap(resource.Ignore(s3.PublicAccessBlockConfigurationNotFound, err), publicAccessBlockDeleteFailed)
 }
 
-// LateInitialize is responsible for initializing the resource based on the external value
+// LateInitialize is responsible for initializing the resource based on the
+// external value. It also marks the public access block as managed once it is
+// configured in the spec.
 func (in *PublicAccessBlockClient) LateInitialize(ctx context.Context, cr *v1beta1.Bucket) error {
+	if cr.Spec.ForProvider.PublicAccessBlockConfiguration != nil {
+		meta.AddAnnotations(cr, map[string]string{publicAccessBlockManaged: "true"})
+	}
 	external, err := in.client.GetPublicAccessBlock(ctx, &awss3.GetPublicAccessBlockInput{Bucket: awsclient.String(meta.GetExternalName(cr))})
 	if err != nil {
 		return awsclient.Wrap(resource.Ignore(s3.PublicAccessBlockConfigurationNotFound, err), publicAccessBlockGetFailed)
@@ -110,6 +153,7 @@ func (in *PublicAccessBlockClient) LateInitialize(ctx context.Context, cr *v1bet
 
 	if cr.Spec.ForProvider.PublicAccessBlockConfiguration == nil {
 		cr.Spec.ForProvider.PublicAccessBlockConfiguration = &v1beta1.PublicAccessBlockConfiguration{}
+		meta.AddAnnotations(cr, map[string]string{publicAccessBlockManaged: "true"})
 	}
 	cr.Spec.ForProvider.PublicAccessBlockConfiguration.BlockPublicAcls = awsclient.LateInitializeBoolPtr(cr.Spec.ForProvider.PublicAccessBlockConfiguration.BlockPublicAcls, awsclient.Bool(external.PublicAccessBlockConfiguration.BlockPublicAcls))
 	cr.Spec.ForProvider.PublicAccessBlockConfiguration.BlockPublicPolicy = awsclient.LateInitializeBoolPtr(cr.Spec.ForProvider.PublicAccessBlockConfiguration.BlockPublicPolicy, awsclient.Bool(external.PublicAccessBlockConfiguration.BlockPublicPolicy))
@@ -118,6 +162,11 @@ func (in *PublicAccessBlockClient) LateInitialize(ctx context.Context, cr *v1bet
 	return nil
 }
 
+// CapturePrior returns a Bucket with the public access block currently in AWS.
+func (in *PublicAccessBlockClient) CapturePrior(ctx context.Context, cr *v1beta1.Bucket) (*v1beta1.Bucket, error) {
+	return lateInitializedPrior(ctx, in, cr)
+}
+
 // SubresourceExists checks if the subresource this controller manages currently exists
 func (in *PublicAccessBlockClient) SubresourceExists(cr *v1beta1.Bucket) bool {
 	return cr.Spec.ForProvider.PublicAccessBlockConfiguration != nil
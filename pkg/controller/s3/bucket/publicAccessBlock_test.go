@@ -7,7 +7,11 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/document"
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 
@@ -63,6 +67,48 @@ func TestPublicAccessBlockClient_Observe(t *testing.T) {
 				status: Updated,
 			},
 		},
+		"NotFoundRemovedExternally": {
+			args: args{
+				cr: &v1beta1.Bucket{
+					Spec: v1beta1.BucketSpec{
+						ForProvider: v1beta1.BucketParameters{
+							PublicAccessBlockConfiguration: &v1beta1.PublicAccessBlockConfiguration{
+								BlockPublicAcls: awsclient.Bool(true),
+							},
+						},
+					},
+				},
+				cl: NewPublicAccessBlockClient(fake.MockBucketClient{
+					MockGetPublicAccessBlock: func(ctx context.Context, input *s3.GetPublicAccessBlockInput, opts []func(*s3.Options)) (*s3.GetPublicAccessBlockOutput, error) {
+						return nil, &smithy.GenericAPIError{Code: clients3.PublicAccessBlockNotFoundErrCode}
+					},
+				}),
+			},
+			want: want{
+				status: NeedsUpdate,
+			},
+		},
+		"EmptyResponse": {
+			args: args{
+				cr: &v1beta1.Bucket{
+					Spec: v1beta1.BucketSpec{
+						ForProvider: v1beta1.BucketParameters{
+							PublicAccessBlockConfiguration: &v1beta1.PublicAccessBlockConfiguration{
+								BlockPublicAcls: awsclient.Bool(true),
+							},
+						},
+					},
+				},
+				cl: NewPublicAccessBlockClient(fake.MockBucketClient{
+					MockGetPublicAccessBlock: func(ctx context.Context, input *s3.GetPublicAccessBlockInput, opts []func(*s3.Options)) (*s3.GetPublicAccessBlockOutput, error) {
+						return &s3.GetPublicAccessBlockOutput{}, nil
+					},
+				}),
+			},
+			want: want{
+				status: NeedsUpdate,
+			},
+		},
 		"NeedsUpdate": {
 			args: args{
 				cr: &v1beta1.Bucket{
@@ -86,7 +132,7 @@ func TestPublicAccessBlockClient_Observe(t *testing.T) {
 				status: NeedsUpdate,
 			},
 		},
-		"NeedsUpdateMissingField": {
+		"UpdatedUnsetField": {
 			args: args{
 				cr: &v1beta1.Bucket{
 					Spec: v1beta1.BucketSpec{
@@ -107,7 +153,40 @@ func TestPublicAccessBlockClient_Observe(t *testing.T) {
 				}),
 			},
 			want: want{
-				status: NeedsUpdate,
+				status: Updated,
+			},
+		},
+		"NeedsDeletion": {
+			args: args{
+				cr: &v1beta1.Bucket{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{publicAccessBlockManaged: "true"}}},
+				cl: NewPublicAccessBlockClient(fake.MockBucketClient{
+					MockGetPublicAccessBlock: func(ctx context.Context, input *s3.GetPublicAccessBlockInput, opts []func(*s3.Options)) (*s3.GetPublicAccessBlockOutput, error) {
+						return &s3.GetPublicAccessBlockOutput{PublicAccessBlockConfiguration: &s3types.PublicAccessBlockConfiguration{
+							BlockPublicAcls: true,
+						}}, nil
+					},
+				}),
+			},
+			want: want{
+				status: NeedsDeletion,
+			},
+		},
+		"UnmanagedDefaultBlock": {
+			args: args{
+				cr: &v1beta1.Bucket{},
+				cl: NewPublicAccessBlockClient(fake.MockBucketClient{
+					MockGetPublicAccessBlock: func(ctx context.Context, input *s3.GetPublicAccessBlockInput, opts []func(*s3.Options)) (*s3.GetPublicAccessBlockOutput, error) {
+						return &s3.GetPublicAccessBlockOutput{PublicAccessBlockConfiguration: &s3types.PublicAccessBlockConfiguration{
+							BlockPublicAcls:       true,
+							BlockPublicPolicy:     true,
+							IgnorePublicAcls:      true,
+							RestrictPublicBuckets: true,
+						}}, nil
+					},
+				}),
+			},
+			want: want{
+				status: Updated,
 			},
 		},
 		"Updated": {
@@ -178,6 +257,9 @@ func TestPublicAccessBlockClient_CreateOrUpdate(t *testing.T) {
 					},
 				},
 				cl: NewPublicAccessBlockClient(fake.MockBucketClient{
+					MockGetPublicAccessBlock: func(ctx context.Context, input *s3.GetPublicAccessBlockInput, opts []func(*s3.Options)) (*s3.GetPublicAccessBlockOutput, error) {
+						return nil, &smithy.GenericAPIError{Code: clients3.PublicAccessBlockNotFoundErrCode}
+					},
 					MockPutPublicAccessBlock: func(ctx context.Context, input *s3.PutPublicAccessBlockInput, opts []func(*s3.Options)) (*s3.PutPublicAccessBlockOutput, error) {
 						return &s3.PutPublicAccessBlockOutput{}, errBoom
 					},
@@ -187,6 +269,58 @@ func TestPublicAccessBlockClient_CreateOrUpdate(t *testing.T) {
 				err: awsclient.Wrap(errBoom, publicAccessBlockPutFailed),
 			},
 		},
+		"GetError": {
+			args: args{
+				cr: &v1beta1.Bucket{
+					Spec: v1beta1.BucketSpec{
+						ForProvider: v1beta1.BucketParameters{
+							PublicAccessBlockConfiguration: &v1beta1.PublicAccessBlockConfiguration{},
+						},
+					},
+				},
+				cl: NewPublicAccessBlockClient(fake.MockBucketClient{
+					MockGetPublicAccessBlock: func(ctx context.Context, input *s3.GetPublicAccessBlockInput, opts []func(*s3.Options)) (*s3.GetPublicAccessBlockOutput, error) {
+						return nil, errBoom
+					},
+				}),
+			},
+			want: want{
+				err: awsclient.Wrap(errBoom, publicAccessBlockGetFailed),
+			},
+		},
+		"UnsetFieldsKeepCurrentValue": {
+			args: args{
+				cr: &v1beta1.Bucket{
+					Spec: v1beta1.BucketSpec{
+						ForProvider: v1beta1.BucketParameters{
+							PublicAccessBlockConfiguration: &v1beta1.PublicAccessBlockConfiguration{
+								BlockPublicAcls:  awsclient.Bool(true),
+								IgnorePublicAcls: awsclient.Bool(false, awsclient.FieldRequired),
+							},
+						},
+					},
+				},
+				cl: NewPublicAccessBlockClient(fake.MockBucketClient{
+					MockGetPublicAccessBlock: func(ctx context.Context, input *s3.GetPublicAccessBlockInput, opts []func(*s3.Options)) (*s3.GetPublicAccessBlockOutput, error) {
+						return &s3.GetPublicAccessBlockOutput{PublicAccessBlockConfiguration: &s3types.PublicAccessBlockConfiguration{
+							BlockPublicPolicy: true,
+							IgnorePublicAcls:  true,
+						}}, nil
+					},
+					MockPutPublicAccessBlock: func(ctx context.Context, input *s3.PutPublicAccessBlockInput, opts []func(*s3.Options)) (*s3.PutPublicAccessBlockOutput, error) {
+						want := &s3types.PublicAccessBlockConfiguration{
+							BlockPublicAcls:   true,
+							BlockPublicPolicy: true,
+						}
+						if diff := cmp.Diff(want, input.PublicAccessBlockConfiguration, cmpopts.IgnoreTypes(document.NoSerde{})); diff != "" {
+							return nil, errors.New(diff)
+						}
+						return &s3.PutPublicAccessBlockOutput{}, nil
+					},
+				}),
+			},
+			want: want{},
+		},
 	}
 
 	for name, tc := range cases {
@@ -255,7 +389,8 @@ func TestPublicAccessBlockClient_LateInitialize(t *testing.T) {
 	}
 
 	type want struct {
-		err error
+		err         error
+		annotations map[string]string
 	}
 
 	cases := map[string]struct {
@@ -296,6 +431,24 @@ func TestPublicAccessBlockClient_LateInitialize(t *testing.T) {
 					},
 				}),
 			},
+			want: want{
+				annotations: map[string]string{publicAccessBlockManaged: "true"},
+			},
+		},
+		"ConfiguredNotFound": {
+			args: args{
+				cr: &v1beta1.Bucket{Spec: v1beta1.BucketSpec{ForProvider: v1beta1.BucketParameters{
+					PublicAccessBlockConfiguration: &v1beta1.PublicAccessBlockConfiguration{BlockPublicAcls: awsclient.Bool(true)},
+				}}},
+				cl: NewPublicAccessBlockClient(fake.MockBucketClient{
+					MockGetPublicAccessBlock: func(ctx context.Context, input *s3.GetPublicAccessBlockInput, opts []func(*s3.Options)) (*s3.GetPublicAccessBlockOutput, error) {
+						return &s3.GetPublicAccessBlockOutput{}, &smithy.GenericAPIError{Code: clients3.PublicAccessBlockNotFoundErrCode}
+					},
+				}),
+			},
+			want: want{
+				annotations: map[string]string{publicAccessBlockManaged: "true"},
+			},
 		},
 	}
 
@@ -305,6 +458,9 @@ func TestPublicAccessBlockClient_LateInitialize(t *testing.T) {
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
 				t.Errorf("r: -want, +got:\n%s", diff)
 			}
+			if diff := cmp.Diff(tc.want.annotations, tc.args.cr.GetAnnotations()); diff != "" {
+				t.Errorf("annotations: -want, +got:\n%s", diff)
+			}
 		})
 	}
 }
@@ -18,15 +18,22 @@ package bucket
 
 import (
 	"context"
+	"sort"
+	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
 	awss3 "github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/aws/smithy-go/document"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
 	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
 
 	"github.com/crossplane/provider-aws/apis/s3/v1beta1"
 	awsclient "github.com/crossplane/provider-aws/pkg/clients"
@@ -37,22 +44,48 @@ const (
 	replicationGetFailed    = "cannot get replication configuration"
 	replicationPutFailed    = "cannot put Bucket replication"
 	replicationDeleteFailed = "cannot delete Bucket replication"
+
+	replicationVersioningGetFailed     = "cannot get versioning configuration of replication source bucket"
+	replicationDestinationClientFailed = "cannot get client for replication destination bucket"
+	replicationRoleSimulateFailed      = "cannot simulate the policies of the replication role"
+	replicationRoleNotAllowed          = "replication role %s is not allowed to perform %s on %s"
+	replicationDestinationSameRegion   = "replication destination bucket %s is in the same region %s as the source bucket"
+	replicationVersioningRequired      = "versioning must be enabled on the bucket and all replication destination buckets, set versioningConfiguration.status to Enabled"
+
+	// SameRegionDestinationsReject refuses to apply a replication
+	// configuration with a destination in the region of the source bucket.
+	SameRegionDestinationsReject = "Reject"
+
+	reasonSameRegionDestination event.Reason = "SameRegionReplicationDestination"
 )
 
+// A RoleSimulator simulates the policies of an IAM role.
+type RoleSimulator interface {
+	SimulatePrincipalPolicy(ctx context.Context, input *iam.SimulatePrincipalPolicyInput, opts ...func(*iam.Options)) (*iam.SimulatePrincipalPolicyOutput, error)
+}
+
 // ReplicationConfigurationClient is the client for API methods and reconciling the ReplicationConfiguration
 type ReplicationConfigurationClient struct {
-	client s3.BucketClient
+	client    s3.BucketClient
+	clientFn  ClientForProviderConfigFn
+	simulator RoleSimulator
+	recorder  event.Recorder
 }
 
-// NewReplicationConfigurationClient creates the client for Replication Configuration
-func NewReplicationConfigurationClient(client s3.BucketClient) *ReplicationConfigurationClient {
-	return &ReplicationConfigurationClient{client: client}
+// NewReplicationConfigurationClient creates the client for Replication Configuration.
+// The clientFn is used for calls against destination buckets that reference
+// their own ProviderConfig, the simulator to verify the permissions of the
+// replication role if requested and the recorder to warn about destinations in
+// the region of the bucket.
+func NewReplicationConfigurationClient(client s3.BucketClient, clientFn ClientForProviderConfigFn, simulator RoleSimulator, recorder event.Recorder) *ReplicationConfigurationClient {
+	return &ReplicationConfigurationClient{client: client, clientFn: clientFn, simulator: simulator, recorder: recorder}
 }
 
 // Observe checks if the resource exists and if it matches the local configuration
 func (in *ReplicationConfigurationClient) Observe(ctx context.Context, bucket *v1beta1.Bucket) (ResourceStatus, error) { // nolint:gocyclo
 	external, err := in.client.GetBucketReplication(ctx, &awss3.GetBucketReplicationInput{Bucket: awsclient.String(meta.GetExternalName(bucket))})
 	config := bucket.Spec.ForProvider.ReplicationConfiguration
+	bucket.Status.AtProvider.ReplicationRules = nil
 	if err != nil {
 		if s3.ReplicationConfigurationNotFound(err) && config == nil {
 			return Updated, nil
@@ -69,27 +102,188 @@ func (in *ReplicationConfigurationClient) Observe(ctx context.Context, bucket *v
 		return NeedsDeletion, nil
 	}
 
+	bucket.Status.AtProvider.ReplicationRules = GenerateReplicationObservation(external.ReplicationConfiguration)
+
 	source := GenerateReplicationConfiguration(config)
 
 	sortReplicationRules(external.ReplicationConfiguration.Rules)
+	translateV1ReplicationRules(external.ReplicationConfiguration.Rules)
+	normalizeReplicationMetrics(external.ReplicationConfiguration.Rules)
+	normalizeReplicationMetrics(source.Rules)
 
-	if cmp.Equal(external.ReplicationConfiguration, source, cmpopts.IgnoreTypes(document.NoSerde{})) {
+	// Rules may be in any order, they are matched by ID. Any change of a rule,
+	// including its destination, requires an update.
+	if cmp.Equal(external.ReplicationConfiguration, source, cmpopts.IgnoreTypes(document.NoSerde{}), cmpopts.SortSlices(replicationRuleLess)) {
 		return Updated, nil
 	}
 
 	return NeedsUpdate, nil
 }
 
+// replicationRuleLess orders replication rules by their ID, falling back to
+// their priority for rules without one.
+func replicationRuleLess(a, b types.ReplicationRule) bool {
+	if awsclient.StringValue(a.ID) != awsclient.StringValue(b.ID) {
+		return awsclient.StringValue(a.ID) < awsclient.StringValue(b.ID)
+	}
+	return a.Priority < b.Priority
+}
+
 // CreateOrUpdate sends a request to have resource created on awsclient.
 func (in *ReplicationConfigurationClient) CreateOrUpdate(ctx context.Context, bucket *v1beta1.Bucket) error {
 	if bucket.Spec.ForProvider.ReplicationConfiguration == nil {
 		return nil
 	}
+	if err := in.checkDestinations(ctx, bucket); err != nil {
+		return err
+	}
+	if awsclient.BoolValue(bucket.Spec.ForProvider.ReplicationConfiguration.VerifyRolePermissions) {
+		if err := in.checkRolePermissions(ctx, meta.GetExternalName(bucket), bucket.Spec.ForProvider.ReplicationConfiguration); err != nil {
+			return err
+		}
+	}
 	input := GeneratePutBucketReplicationInput(meta.GetExternalName(bucket), bucket.Spec.ForProvider.ReplicationConfiguration)
 	_, err := in.client.PutBucketReplication(ctx, input)
+	if s3.ReplicationVersioningNotEnabled(err) {
+		return errors.Wrap(err, replicationVersioningRequired)
+	}
 	return awsclient.Wrap(err, replicationPutFailed)
 }
 
+// checkDestinations warns about, or rejects if configured to, destinations in
+// the region of the bucket. The check is best effort: if the location of a
+// destination cannot be read, e.g. because it is owned by another account
+// without a providerConfigRef being set, the replication configuration is
+// applied as is. S3 itself rejects destinations without versioning.
+func (in *ReplicationConfigurationClient) checkDestinations(ctx context.Context, bucket *v1beta1.Bucket) error {
+	config := bucket.Spec.ForProvider.ReplicationConfiguration
+	for _, rule := range config.Rules {
+		d := rule.Destination
+		if d.Bucket == nil {
+			continue
+		}
+		cl, err := clientFor(ctx, in.client, in.clientFn, d.ProviderConfigReference)
+		if err != nil {
+			return errors.Wrap(err, replicationDestinationClientFailed)
+		}
+		if err := in.checkDestinationRegion(ctx, cl, bucket, bucketNameFromARN(aws.ToString(d.Bucket))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkDestinationRegion warns about, or returns an error for, a destination
+// bucket in the region of the source bucket. Same-region replication is
+// supported by S3, but is often a destination that was meant to be in another
+// region. The client may be configured for the region of the source bucket,
+// which is fine since S3 answers GetBucketLocation in any region.
+func (in *ReplicationConfigurationClient) checkDestinationRegion(ctx context.Context, cl s3.BucketClient, bucket *v1beta1.Bucket, name string) error {
+	location, err := cl.GetBucketLocation(ctx, &awss3.GetBucketLocationInput{Bucket: aws.String(name)})
+	if err != nil || location == nil {
+		return nil
+	}
+	region := s3.BucketRegion(location.LocationConstraint)
+	if region != s3.BucketRegion(types.BucketLocationConstraint(bucket.Spec.ForProvider.LocationConstraint)) {
+		return nil
+	}
+	err = errors.Errorf(replicationDestinationSameRegion, name, region)
+	if aws.ToString(bucket.Spec.ForProvider.ReplicationConfiguration.SameRegionDestinations) == SameRegionDestinationsReject {
+		return err
+	}
+	if in.recorder != nil {
+		in.recorder.Event(bucket, event.Warning(reasonSameRegionDestination, err))
+	}
+	return nil
+}
+
+// checkRolePermissions returns an error if the policies of the replication
+// role do not allow it to read the replication configuration of the source
+// bucket or to replicate objects to one of the destination buckets.
+func (in *ReplicationConfigurationClient) checkRolePermissions(ctx context.Context, source string, config *v1beta1.ReplicationConfiguration) error {
+	role := aws.ToString(config.Role)
+	partition := "aws"
+	if a, err := arn.Parse(role); err == nil {
+		partition = a.Partition
+	}
+	checks := map[string]string{
+		"arn:" + partition + ":s3:::" + source: "s3:GetReplicationConfiguration",
+	}
+	for _, rule := range config.Rules {
+		if d := aws.ToString(rule.Destination.Bucket); d != "" {
+			checks[d+"/*"] = "s3:ReplicateObject"
+		}
+	}
+	resources := make([]string, 0, len(checks))
+	for r := range checks {
+		resources = append(resources, r)
+	}
+	sort.Strings(resources)
+	for _, r := range resources {
+		out, err := in.simulator.SimulatePrincipalPolicy(ctx, &iam.SimulatePrincipalPolicyInput{
+			PolicySourceArn: aws.String(role),
+			ActionNames:     []string{checks[r]},
+			ResourceArns:    []string{r},
+		})
+		if err != nil {
+			return awsclient.Wrap(err, replicationRoleSimulateFailed)
+		}
+		for _, result := range out.EvaluationResults {
+			if result.EvalDecision != iamtypes.PolicyEvaluationDecisionTypeAllowed {
+				return errors.Errorf(replicationRoleNotAllowed, role, aws.ToString(result.EvalActionName), r)
+			}
+		}
+	}
+	return nil
+}
+
+// Prerequisites of the replication configuration. S3 rejects a replication
+// configuration unless versioning is enabled on the source bucket.
+func (in *ReplicationConfigurationClient) Prerequisites() []Prerequisite {
+	return []Prerequisite{{Description: "versioning is enabled on the bucket", Met: in.versioningEnabled}}
+}
+
+// versioningEnabled returns true if versioning is enabled on the bucket.
+func (in *ReplicationConfigurationClient) versioningEnabled(ctx context.Context, bucket *v1beta1.Bucket) (bool, error) {
+	external, err := in.client.GetBucketVersioning(ctx, &awss3.GetBucketVersioningInput{Bucket: awsclient.String(meta.GetExternalName(bucket))})
+	if err != nil {
+		return false, awsclient.Wrap(err, replicationVersioningGetFailed)
+	}
+	return external != nil && external.Status == types.BucketVersioningStatusEnabled, nil
+}
+
+// GenerateReplicationObservation returns the observed replication state of
+// each rule of the given replication configuration.
+func GenerateReplicationObservation(config *types.ReplicationConfiguration) []v1beta1.ReplicationRuleObservation {
+	if len(config.Rules) == 0 {
+		return nil
+	}
+	obs := make([]v1beta1.ReplicationRuleObservation, len(config.Rules))
+	for i, rule := range config.Rules {
+		obs[i] = v1beta1.ReplicationRuleObservation{
+			ID:     aws.ToString(rule.ID),
+			Status: string(rule.Status),
+		}
+		d := rule.Destination
+		if d == nil {
+			continue
+		}
+		obs[i].DestinationBucket = aws.ToString(d.Bucket)
+		if d.ReplicationTime != nil {
+			obs[i].ReplicationTime = string(d.ReplicationTime.Status)
+		}
+		if d.Metrics != nil {
+			obs[i].Metrics = string(d.Metrics.Status)
+		}
+	}
+	return obs
+}
+
+// bucketNameFromARN returns the name of the bucket with the given ARN.
+func bucketNameFromARN(arn string) string {
+	return arn[strings.LastIndex(arn, ":")+1:]
+}
+
 // Delete creates the request to delete the resource on AWS or set it to the default value.
 func (in *ReplicationConfigurationClient) Delete(ctx context.Context, bucket *v1beta1.Bucket) error {
 	_, err := in.client.DeleteBucketReplication(ctx,
@@ -119,11 +313,17 @@ func (in *ReplicationConfigurationClient) LateInitialize(ctx context.Context, bu
 	}
 	fp.ReplicationConfiguration.Role = awsclient.LateInitializeStringPtr(fp.ReplicationConfiguration.Role, external.ReplicationConfiguration.Role)
 	if fp.ReplicationConfiguration.Rules == nil {
+		translateV1ReplicationRules(external.ReplicationConfiguration.Rules)
 		createReplicationRulesFromExternal(external.ReplicationConfiguration, fp.ReplicationConfiguration)
 	}
 	return nil
 }
 
+// CapturePrior returns a Bucket with the replication configuration currently in AWS.
+func (in *ReplicationConfigurationClient) CapturePrior(ctx context.Context, bucket *v1beta1.Bucket) (*v1beta1.Bucket, error) {
+	return lateInitializedPrior(ctx, in, bucket)
+}
+
 // SubresourceExists checks if the subresource this controller manages currently exists
 func (in *ReplicationConfigurationClient) SubresourceExists(bucket *v1beta1.Bucket) bool {
 	return bucket.Spec.ForProvider.ReplicationConfiguration != nil
@@ -220,6 +420,37 @@ func sortReplicationRules(rules []types.ReplicationRule) {
 	}
 }
 
+// translateV1ReplicationRules translates rules of the earlier V1 schema, which
+// select objects with a prefix at the rule level instead of a filter, into
+// the V2 schema that is sent to AWS. V1 rules replicate delete markers, so
+// they are translated to rules with delete marker replication enabled. This
+// allows configurations that were created outside of Crossplane to be
+// adopted without being considered changed.
+func translateV1ReplicationRules(rules []types.ReplicationRule) {
+	for i := range rules {
+		if rules[i].Filter != nil {
+			continue
+		}
+		rules[i].Filter = &types.ReplicationRuleFilterMemberPrefix{Value: awsclient.StringValue(rules[i].Prefix)}
+		rules[i].Prefix = nil
+		if rules[i].DeleteMarkerReplication == nil {
+			rules[i].DeleteMarkerReplication = &types.DeleteMarkerReplication{Status: types.DeleteMarkerReplicationStatusEnabled}
+		}
+	}
+}
+
+// normalizeReplicationMetrics removes disabled destination metrics from the
+// rules. AWS may omit the metrics block of a destination whose metrics are
+// disabled, so a disabled block and an absent one are equivalent.
+func normalizeReplicationMetrics(rules []types.ReplicationRule) {
+	for i := range rules {
+		d := rules[i].Destination
+		if d != nil && d.Metrics != nil && d.Metrics.Status == types.MetricsStatusDisabled {
+			d.Metrics = nil
+		}
+	}
+}
+
 func copyDestination(input *v1beta1.ReplicationRule, newRule *types.ReplicationRule) {
 	newRule.Destination = &types.Destination{
 		AccessControlTranslation: nil,
@@ -259,12 +490,18 @@ func copyDestination(input *v1beta1.ReplicationRule, newRule *types.ReplicationR
 	}
 }
 
+// createRule creates a rule of the V2 schema, i.e. a rule that always has a
+// filter and a delete marker replication status. A rule without a filter
+// applies to all objects of the bucket and one without a delete marker
+// replication status does not replicate delete markers, as in the V2 schema.
 func createRule(input v1beta1.ReplicationRule) types.ReplicationRule {
 	Rule := input
 	newRule := types.ReplicationRule{
-		ID:       Rule.ID,
-		Priority: Rule.Priority,
-		Status:   types.ReplicationRuleStatus(Rule.Status),
+		ID:                      Rule.ID,
+		Priority:                Rule.Priority,
+		Status:                  types.ReplicationRuleStatus(Rule.Status),
+		Filter:                  &types.ReplicationRuleFilterMemberPrefix{Value: ""},
+		DeleteMarkerReplication: &types.DeleteMarkerReplication{Status: types.DeleteMarkerReplicationStatusDisabled},
 	}
 	if Rule.Filter != nil {
 		switch {
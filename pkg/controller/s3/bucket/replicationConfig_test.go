@@ -20,11 +20,20 @@ import (
 	"context"
 	"testing"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/document"
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
 
 	"github.com/crossplane/provider-aws/apis/s3/v1beta1"
 	awsclient "github.com/crossplane/provider-aws/pkg/clients"
@@ -34,13 +43,15 @@ import (
 )
 
 var (
-	role                              = "replication-role"
-	owner                             = "Destination"
-	accountID                         = "test-account-id"
-	kmsID                             = "encKmsID"
-	replicationTime                   = 15
-	priority        int32             = 1
-	_               SubresourceClient = &ReplicationConfigurationClient{}
+	role                               = "replication-role"
+	owner                              = "Destination"
+	accountID                          = "test-account-id"
+	kmsID                              = "encKmsID"
+	replicationTime                    = 15
+	priority        int32              = 1
+	disabled                           = "Disabled"
+	_               SubresourceClient  = &ReplicationConfigurationClient{}
+	_               PrerequisiteClient = &ReplicationConfigurationClient{}
 )
 
 func generateReplicationConfig() *v1beta1.ReplicationConfiguration {
@@ -113,6 +124,85 @@ func generateAWSReplication() *s3types.ReplicationConfiguration {
 	}
 }
 
+func generateReplicationConfigWithMetrics(m *v1beta1.Metrics) *v1beta1.ReplicationConfiguration {
+	c := generateReplicationConfig()
+	c.Rules[0].Destination.Metrics = m
+	return c
+}
+
+func generateAWSReplicationWithMetrics(m *s3types.Metrics) *s3types.ReplicationConfiguration {
+	c := generateAWSReplication()
+	c.Rules[0].Destination.Metrics = m
+	return c
+}
+
+func generateReplicationConfigWithFilter(f *v1beta1.ReplicationRuleFilter) *v1beta1.ReplicationConfiguration {
+	c := generateReplicationConfig()
+	c.Rules[0].Filter = f
+	return c
+}
+
+func generateAWSReplicationWithFilter(f s3types.ReplicationRuleFilter) *s3types.ReplicationConfiguration {
+	c := generateAWSReplication()
+	c.Rules[0].Filter = f
+	return c
+}
+
+// generateAWSV1Replication returns the replication configuration in the
+// earlier V1 schema, which selects objects with a prefix at the rule level.
+func generateAWSV1Replication() *s3types.ReplicationConfiguration {
+	c := generateAWSReplication()
+	c.Rules[0].Filter = nil
+	c.Rules[0].Prefix = &prefix
+	c.Rules[0].DeleteMarkerReplication = nil
+	return c
+}
+
+func generateReplicationConfigWithDestinationProviderConfig(name string) *v1beta1.ReplicationConfiguration {
+	config := generateReplicationConfig()
+	config.Rules[0].Destination.ProviderConfigReference = &xpv1.Reference{Name: name}
+	return config
+}
+
+func generateReplicationConfigWithShuffledTags() *v1beta1.ReplicationConfiguration {
+	config := generateReplicationConfig()
+	config.Rules[0].Filter.And.Tags = []v1beta1.Tag{tag2, tag, tag1}
+	return config
+}
+
+func generateAWSReplicationWithReversedTags() *s3types.ReplicationConfiguration {
+	config := generateAWSReplication()
+	and := config.Rules[0].Filter.(*s3types.ReplicationRuleFilterMemberAnd)
+	and.Value.Tags = []s3types.Tag{awsTag2, awsTag1, awsTag}
+	return config
+}
+
+// generateReplicationConfigWithSecondRule returns the replication
+// configuration with a second rule that replicates to the Glacier storage
+// class, in the given order.
+func generateReplicationConfigWithSecondRule(secondFirst bool) *v1beta1.ReplicationConfiguration {
+	c := generateReplicationConfig()
+	r := *c.Rules[0].DeepCopy()
+	r.ID = awsclient.String("glacier")
+	r.Priority = priority + 1
+	r.Destination.StorageClass = awsclient.String(string(s3types.StorageClassGlacier))
+	c.Rules = append(c.Rules, r)
+	if secondFirst {
+		c.Rules[0], c.Rules[1] = c.Rules[1], c.Rules[0]
+	}
+	return c
+}
+
+func generateAWSReplicationWithSecondRule() *s3types.ReplicationConfiguration {
+	c := generateAWSReplication()
+	r := generateAWSReplication().Rules[0]
+	r.ID = awsclient.String("glacier")
+	r.Priority = priority + 1
+	r.Destination.StorageClass = s3types.StorageClassGlacier
+	c.Rules = append(c.Rules, r)
+	return c
+}
+
 func TestReplicationObserve(t *testing.T) {
 	type args struct {
 		cl *ReplicationConfigurationClient
@@ -135,7 +225,7 @@ func TestReplicationObserve(t *testing.T) {
 					MockGetBucketReplication: func(ctx context.Context, input *s3.GetBucketReplicationInput, opts []func(*s3.Options)) (*s3.GetBucketReplicationOutput, error) {
 						return nil, errBoom
 					},
-				}),
+				}, nil, nil, nil),
 			},
 			want: want{
 				status: NeedsUpdate,
@@ -149,7 +239,7 @@ func TestReplicationObserve(t *testing.T) {
 					MockGetBucketReplication: func(ctx context.Context, input *s3.GetBucketReplicationInput, opts []func(*s3.Options)) (*s3.GetBucketReplicationOutput, error) {
 						return &s3.GetBucketReplicationOutput{ReplicationConfiguration: nil}, nil
 					},
-				}),
+				}, nil, nil, nil),
 			},
 			want: want{
 				status: NeedsUpdate,
@@ -163,7 +253,7 @@ func TestReplicationObserve(t *testing.T) {
 					MockGetBucketReplication: func(ctx context.Context, input *s3.GetBucketReplicationInput, opts []func(*s3.Options)) (*s3.GetBucketReplicationOutput, error) {
 						return &s3.GetBucketReplicationOutput{ReplicationConfiguration: generateAWSReplication()}, nil
 					},
-				}),
+				}, nil, nil, nil),
 			},
 			want: want{
 				status: NeedsDeletion,
@@ -177,7 +267,7 @@ func TestReplicationObserve(t *testing.T) {
 					MockGetBucketReplication: func(ctx context.Context, input *s3.GetBucketReplicationInput, opts []func(*s3.Options)) (*s3.GetBucketReplicationOutput, error) {
 						return nil, &smithy.GenericAPIError{Code: clientss3.ReplicationNotFoundErrCode}
 					},
-				}),
+				}, nil, nil, nil),
 			},
 			want: want{
 				status: Updated,
@@ -191,7 +281,7 @@ func TestReplicationObserve(t *testing.T) {
 					MockGetBucketReplication: func(ctx context.Context, input *s3.GetBucketReplicationInput, opts []func(*s3.Options)) (*s3.GetBucketReplicationOutput, error) {
 						return &s3.GetBucketReplicationOutput{ReplicationConfiguration: nil}, nil
 					},
-				}),
+				}, nil, nil, nil),
 			},
 			want: want{
 				status: Updated,
@@ -205,13 +295,159 @@ func TestReplicationObserve(t *testing.T) {
 					MockGetBucketReplication: func(ctx context.Context, input *s3.GetBucketReplicationInput, opts []func(*s3.Options)) (*s3.GetBucketReplicationOutput, error) {
 						return &s3.GetBucketReplicationOutput{ReplicationConfiguration: generateAWSReplication()}, nil
 					},
-				}),
+				}, nil, nil, nil),
+			},
+			want: want{
+				status: Updated,
+				err:    nil,
+			},
+		},
+		"NoUpdateRulesReordered": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithReplConfig(generateReplicationConfigWithSecondRule(true))),
+				cl: NewReplicationConfigurationClient(fake.MockBucketClient{
+					MockGetBucketReplication: func(ctx context.Context, input *s3.GetBucketReplicationInput, opts []func(*s3.Options)) (*s3.GetBucketReplicationOutput, error) {
+						return &s3.GetBucketReplicationOutput{ReplicationConfiguration: generateAWSReplicationWithSecondRule()}, nil
+					},
+				}, nil, nil, nil),
+			},
+			want: want{
+				status: Updated,
+				err:    nil,
+			},
+		},
+		"UpdateNeededDestinationChanged": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithReplConfig(generateReplicationConfigWithSecondRule(true))),
+				cl: NewReplicationConfigurationClient(fake.MockBucketClient{
+					MockGetBucketReplication: func(ctx context.Context, input *s3.GetBucketReplicationInput, opts []func(*s3.Options)) (*s3.GetBucketReplicationOutput, error) {
+						c := generateAWSReplicationWithSecondRule()
+						c.Rules[1].Destination.EncryptionConfiguration = nil
+						return &s3.GetBucketReplicationOutput{ReplicationConfiguration: c}, nil
+					},
+				}, nil, nil, nil),
+			},
+			want: want{
+				status: NeedsUpdate,
+				err:    nil,
+			},
+		},
+		"NoUpdateTagsReordered": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithReplConfig(generateReplicationConfigWithShuffledTags())),
+				cl: NewReplicationConfigurationClient(fake.MockBucketClient{
+					MockGetBucketReplication: func(ctx context.Context, input *s3.GetBucketReplicationInput, opts []func(*s3.Options)) (*s3.GetBucketReplicationOutput, error) {
+						return &s3.GetBucketReplicationOutput{ReplicationConfiguration: generateAWSReplicationWithReversedTags()}, nil
+					},
+				}, nil, nil, nil),
+			},
+			want: want{
+				status: Updated,
+				err:    nil,
+			},
+		},
+		"NoUpdateMetricsDisabledAbsent": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithReplConfig(generateReplicationConfigWithMetrics(&v1beta1.Metrics{Status: disabled}))),
+				cl: NewReplicationConfigurationClient(fake.MockBucketClient{
+					MockGetBucketReplication: func(ctx context.Context, input *s3.GetBucketReplicationInput, opts []func(*s3.Options)) (*s3.GetBucketReplicationOutput, error) {
+						return &s3.GetBucketReplicationOutput{ReplicationConfiguration: generateAWSReplicationWithMetrics(nil)}, nil
+					},
+				}, nil, nil, nil),
+			},
+			want: want{
+				status: Updated,
+				err:    nil,
+			},
+		},
+		"NoUpdateMetricsAbsentDisabled": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithReplConfig(generateReplicationConfigWithMetrics(nil))),
+				cl: NewReplicationConfigurationClient(fake.MockBucketClient{
+					MockGetBucketReplication: func(ctx context.Context, input *s3.GetBucketReplicationInput, opts []func(*s3.Options)) (*s3.GetBucketReplicationOutput, error) {
+						return &s3.GetBucketReplicationOutput{ReplicationConfiguration: generateAWSReplicationWithMetrics(&s3types.Metrics{Status: s3types.MetricsStatusDisabled})}, nil
+					},
+				}, nil, nil, nil),
 			},
 			want: want{
 				status: Updated,
 				err:    nil,
 			},
 		},
+		"UpdateNeededPrefixToTag": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithReplConfig(generateReplicationConfigWithFilter(&v1beta1.ReplicationRuleFilter{Tag: &tag}))),
+				cl: NewReplicationConfigurationClient(fake.MockBucketClient{
+					MockGetBucketReplication: func(ctx context.Context, input *s3.GetBucketReplicationInput, opts []func(*s3.Options)) (*s3.GetBucketReplicationOutput, error) {
+						return &s3.GetBucketReplicationOutput{ReplicationConfiguration: generateAWSReplicationWithFilter(&s3types.ReplicationRuleFilterMemberPrefix{Value: prefix})}, nil
+					},
+				}, nil, nil, nil),
+			},
+			want: want{
+				status: NeedsUpdate,
+				err:    nil,
+			},
+		},
+		"NoUpdateTagFilter": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithReplConfig(generateReplicationConfigWithFilter(&v1beta1.ReplicationRuleFilter{Tag: &tag}))),
+				cl: NewReplicationConfigurationClient(fake.MockBucketClient{
+					MockGetBucketReplication: func(ctx context.Context, input *s3.GetBucketReplicationInput, opts []func(*s3.Options)) (*s3.GetBucketReplicationOutput, error) {
+						return &s3.GetBucketReplicationOutput{ReplicationConfiguration: generateAWSReplicationWithFilter(&s3types.ReplicationRuleFilterMemberTag{Value: awsTag})}, nil
+					},
+				}, nil, nil, nil),
+			},
+			want: want{
+				status: Updated,
+				err:    nil,
+			},
+		},
+		"NoUpdateAdoptedV1": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithReplConfig(generateReplicationConfigWithFilter(&v1beta1.ReplicationRuleFilter{Prefix: &prefix}))),
+				cl: NewReplicationConfigurationClient(fake.MockBucketClient{
+					MockGetBucketReplication: func(ctx context.Context, input *s3.GetBucketReplicationInput, opts []func(*s3.Options)) (*s3.GetBucketReplicationOutput, error) {
+						return &s3.GetBucketReplicationOutput{ReplicationConfiguration: generateAWSV1Replication()}, nil
+					},
+				}, nil, nil, nil),
+			},
+			want: want{
+				status: Updated,
+				err:    nil,
+			},
+		},
+		"UpdateNeededV1DeleteMarkersNotReplicated": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithReplConfig(func() *v1beta1.ReplicationConfiguration {
+					c := generateReplicationConfigWithFilter(&v1beta1.ReplicationRuleFilter{Prefix: &prefix})
+					c.Rules[0].DeleteMarkerReplication = nil
+					return c
+				}())),
+				cl: NewReplicationConfigurationClient(fake.MockBucketClient{
+					MockGetBucketReplication: func(ctx context.Context, input *s3.GetBucketReplicationInput, opts []func(*s3.Options)) (*s3.GetBucketReplicationOutput, error) {
+						return &s3.GetBucketReplicationOutput{ReplicationConfiguration: generateAWSV1Replication()}, nil
+					},
+				}, nil, nil, nil),
+			},
+			want: want{
+				status: NeedsUpdate,
+				err:    nil,
+			},
+		},
+		"UpdateNeededMetricsEnabledAbsent": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithReplConfig(generateReplicationConfig())),
+				cl: NewReplicationConfigurationClient(fake.MockBucketClient{
+					MockGetBucketReplication: func(ctx context.Context, input *s3.GetBucketReplicationInput, opts []func(*s3.Options)) (*s3.GetBucketReplicationOutput, error) {
+						return &s3.GetBucketReplicationOutput{ReplicationConfiguration: generateAWSReplicationWithMetrics(nil)}, nil
+					},
+				}, nil, nil, nil),
+			},
+			want: want{
+				status: NeedsUpdate,
+				err:    nil,
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -227,6 +463,91 @@ func TestReplicationObserve(t *testing.T) {
 	}
 }
 
+func TestReplicationObserveStatus(t *testing.T) {
+	type args struct {
+		cl *ReplicationConfigurationClient
+		b  *v1beta1.Bucket
+	}
+
+	type want struct {
+		rules []v1beta1.ReplicationRuleObservation
+	}
+
+	stale := s3Testing.Bucket(s3Testing.WithReplConfig(generateReplicationConfig()))
+	stale.Status.AtProvider.ReplicationRules = []v1beta1.ReplicationRuleObservation{{ID: "stale", Status: enabled}}
+
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"ReplicationTimeAndMetricsEnabled": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithReplConfig(generateReplicationConfig())),
+				cl: NewReplicationConfigurationClient(fake.MockBucketClient{
+					MockGetBucketReplication: func(ctx context.Context, input *s3.GetBucketReplicationInput, opts []func(*s3.Options)) (*s3.GetBucketReplicationOutput, error) {
+						return &s3.GetBucketReplicationOutput{ReplicationConfiguration: generateAWSReplication()}, nil
+					},
+				}, nil, nil, nil),
+			},
+			want: want{
+				rules: []v1beta1.ReplicationRuleObservation{{
+					ID:                id,
+					Status:            enabled,
+					DestinationBucket: bucketName,
+					ReplicationTime:   enabled,
+					Metrics:           enabled,
+				}},
+			},
+		},
+		"MetricsAbsent": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithReplConfig(generateReplicationConfig())),
+				cl: NewReplicationConfigurationClient(fake.MockBucketClient{
+					MockGetBucketReplication: func(ctx context.Context, input *s3.GetBucketReplicationInput, opts []func(*s3.Options)) (*s3.GetBucketReplicationOutput, error) {
+						return &s3.GetBucketReplicationOutput{ReplicationConfiguration: generateAWSReplicationWithMetrics(nil)}, nil
+					},
+				}, nil, nil, nil),
+			},
+			want: want{
+				rules: []v1beta1.ReplicationRuleObservation{{
+					ID:                id,
+					Status:            enabled,
+					DestinationBucket: bucketName,
+					ReplicationTime:   enabled,
+				}},
+			},
+		},
+		"NotFoundClearsStaleStatus": {
+			args: args{
+				b: stale,
+				cl: NewReplicationConfigurationClient(fake.MockBucketClient{
+					MockGetBucketReplication: func(ctx context.Context, input *s3.GetBucketReplicationInput, opts []func(*s3.Options)) (*s3.GetBucketReplicationOutput, error) {
+						return nil, &smithy.GenericAPIError{Code: clientss3.ReplicationNotFoundErrCode}
+					},
+				}, nil, nil, nil),
+			},
+			want: want{
+				rules: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, _ = tc.args.cl.Observe(context.Background(), tc.args.b)
+			if diff := cmp.Diff(tc.want.rules, tc.args.b.Status.AtProvider.ReplicationRules); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func otherRegionLocation(ctx context.Context, input *s3.GetBucketLocationInput, opts []func(*s3.Options)) (*s3.GetBucketLocationOutput, error) {
+	return &s3.GetBucketLocationOutput{LocationConstraint: s3types.BucketLocationConstraintUsWest2}, nil
+}
+
+var versioningNotEnabled = &smithy.GenericAPIError{Code: clientss3.InvalidRequestErrCode, Message: "Versioning must be 'Enabled' on the bucket to apply a replication configuration"}
+
 func TestReplicationCreateOrUpdate(t *testing.T) {
 	type args struct {
 		cl *ReplicationConfigurationClient
@@ -245,23 +566,44 @@ func TestReplicationCreateOrUpdate(t *testing.T) {
 			args: args{
 				b: s3Testing.Bucket(s3Testing.WithReplConfig(generateReplicationConfig())),
 				cl: NewReplicationConfigurationClient(fake.MockBucketClient{
+					MockGetBucketLocation: otherRegionLocation,
 					MockPutBucketReplication: func(ctx context.Context, input *s3.PutBucketReplicationInput, opts []func(*s3.Options)) (*s3.PutBucketReplicationOutput, error) {
 						return nil, errBoom
 					},
-				}),
+				}, nil, nil, nil),
 			},
 			want: want{
 				err: awsclient.Wrap(errBoom, replicationPutFailed),
 			},
 		},
+		"VersioningNotEnabled": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithReplConfig(generateReplicationConfigWithDestinationProviderConfig("destination"))),
+				cl: NewReplicationConfigurationClient(fake.MockBucketClient{
+					MockPutBucketReplication: func(ctx context.Context, input *s3.PutBucketReplicationInput, opts []func(*s3.Options)) (*s3.PutBucketReplicationOutput, error) {
+						return nil, versioningNotEnabled
+					},
+				}, func(ctx context.Context, name string) (clientss3.BucketClient, error) {
+					return fake.MockBucketClient{
+						MockGetBucketLocation: func(ctx context.Context, input *s3.GetBucketLocationInput, opts []func(*s3.Options)) (*s3.GetBucketLocationOutput, error) {
+							return nil, errBoom
+						},
+					}, nil
+				}, nil, nil),
+			},
+			want: want{
+				err: errors.Wrap(versioningNotEnabled, replicationVersioningRequired),
+			},
+		},
 		"InvalidConfig": {
 			args: args{
 				b: s3Testing.Bucket(s3Testing.WithReplConfig(generateReplicationConfig())),
 				cl: NewReplicationConfigurationClient(fake.MockBucketClient{
+					MockGetBucketLocation: otherRegionLocation,
 					MockPutBucketReplication: func(ctx context.Context, input *s3.PutBucketReplicationInput, opts []func(*s3.Options)) (*s3.PutBucketReplicationOutput, error) {
 						return &s3.PutBucketReplicationOutput{}, nil
 					},
-				}),
+				}, nil, nil, nil),
 			},
 			want: want{
 				err: nil,
@@ -271,15 +613,49 @@ func TestReplicationCreateOrUpdate(t *testing.T) {
 			args: args{
 				b: s3Testing.Bucket(s3Testing.WithReplConfig(generateReplicationConfig())),
 				cl: NewReplicationConfigurationClient(fake.MockBucketClient{
+					MockGetBucketLocation: otherRegionLocation,
 					MockPutBucketReplication: func(ctx context.Context, input *s3.PutBucketReplicationInput, opts []func(*s3.Options)) (*s3.PutBucketReplicationOutput, error) {
 						return &s3.PutBucketReplicationOutput{}, nil
 					},
-				}),
+				}, nil, nil, nil),
 			},
 			want: want{
 				err: nil,
 			},
 		},
+		"CrossAccountDestinationSameRegion": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithReplConfig(func() *v1beta1.ReplicationConfiguration {
+					config := generateReplicationConfigWithDestinationProviderConfig("destination")
+					config.SameRegionDestinations = aws.String(SameRegionDestinationsReject)
+					return config
+				}())),
+				cl: NewReplicationConfigurationClient(fake.MockBucketClient{}, func(ctx context.Context, name string) (clientss3.BucketClient, error) {
+					if name != "destination" {
+						return nil, errBoom
+					}
+					return fake.MockBucketClient{
+						MockGetBucketLocation: func(ctx context.Context, input *s3.GetBucketLocationInput, opts []func(*s3.Options)) (*s3.GetBucketLocationOutput, error) {
+							return &s3.GetBucketLocationOutput{}, nil
+						},
+					}, nil
+				}, nil, nil),
+			},
+			want: want{
+				err: errors.Errorf(replicationDestinationSameRegion, bucketName, s3Testing.Region),
+			},
+		},
+		"CrossAccountDestinationClientError": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithReplConfig(generateReplicationConfigWithDestinationProviderConfig("destination"))),
+				cl: NewReplicationConfigurationClient(fake.MockBucketClient{}, func(ctx context.Context, name string) (clientss3.BucketClient, error) {
+					return nil, errBoom
+				}, nil, nil),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, replicationDestinationClientFailed),
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -292,6 +668,263 @@ func TestReplicationCreateOrUpdate(t *testing.T) {
 	}
 }
 
+func TestReplicationCreateOrUpdateDestinationRegion(t *testing.T) {
+	sameRegion := errors.Errorf(replicationDestinationSameRegion, bucketName, s3Testing.Region)
+
+	type want struct {
+		err    error
+		put    bool
+		events []event.Event
+	}
+
+	cases := map[string]struct {
+		location s3types.BucketLocationConstraint
+		policy   *string
+		want     want
+	}{
+		"CrossRegion": {
+			location: s3types.BucketLocationConstraintUsWest2,
+			want:     want{put: true},
+		},
+		"SameRegionWarns": {
+			// Buckets in us-east-1 have no location constraint.
+			location: "",
+			want: want{
+				put:    true,
+				events: []event.Event{event.Warning(reasonSameRegionDestination, sameRegion)},
+			},
+		},
+		"SameRegionRejected": {
+			location: "",
+			policy:   aws.String(SameRegionDestinationsReject),
+			want:     want{err: sameRegion},
+		},
+		"CrossRegionNotRejected": {
+			location: s3types.BucketLocationConstraintEu,
+			policy:   aws.String(SameRegionDestinationsReject),
+			want:     want{put: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			put := false
+			rec := &eventRecorder{}
+			cl := NewReplicationConfigurationClient(fake.MockBucketClient{
+				MockGetBucketLocation: func(ctx context.Context, input *s3.GetBucketLocationInput, opts []func(*s3.Options)) (*s3.GetBucketLocationOutput, error) {
+					return &s3.GetBucketLocationOutput{LocationConstraint: tc.location}, nil
+				},
+				MockPutBucketReplication: func(ctx context.Context, input *s3.PutBucketReplicationInput, opts []func(*s3.Options)) (*s3.PutBucketReplicationOutput, error) {
+					put = true
+					return &s3.PutBucketReplicationOutput{}, nil
+				},
+			}, nil, nil, rec)
+			config := generateReplicationConfig()
+			config.SameRegionDestinations = tc.policy
+			err := cl.CreateOrUpdate(context.Background(), s3Testing.Bucket(s3Testing.WithReplConfig(config)))
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.put, put); diff != "" {
+				t.Errorf("put: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.events, rec.events, test.EquateErrors()); diff != "" {
+				t.Errorf("events: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReplicationCreateOrUpdateFilter(t *testing.T) {
+	cases := map[string]struct {
+		filter *v1beta1.ReplicationRuleFilter
+		want   s3types.ReplicationRuleFilter
+	}{
+		"Prefix": {
+			filter: &v1beta1.ReplicationRuleFilter{Prefix: &prefix},
+			want:   &s3types.ReplicationRuleFilterMemberPrefix{Value: prefix},
+		},
+		"PrefixToTag": {
+			filter: &v1beta1.ReplicationRuleFilter{Tag: &tag},
+			want:   &s3types.ReplicationRuleFilterMemberTag{Value: awsTag},
+		},
+		"NoFilter": {
+			want: &s3types.ReplicationRuleFilterMemberPrefix{Value: ""},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got s3types.ReplicationRuleFilter
+			cl := NewReplicationConfigurationClient(fake.MockBucketClient{
+				MockGetBucketLocation: otherRegionLocation,
+				MockPutBucketReplication: func(ctx context.Context, input *s3.PutBucketReplicationInput, opts []func(*s3.Options)) (*s3.PutBucketReplicationOutput, error) {
+					got = input.ReplicationConfiguration.Rules[0].Filter
+					return &s3.PutBucketReplicationOutput{}, nil
+				},
+			}, nil, nil, nil)
+			if err := cl.CreateOrUpdate(context.Background(), s3Testing.Bucket(s3Testing.WithReplConfig(generateReplicationConfigWithFilter(tc.filter)))); err != nil {
+				t.Fatalf("CreateOrUpdate(...): %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got, cmpopts.IgnoreTypes(document.NoSerde{})); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+type mockRoleSimulator func(ctx context.Context, input *iam.SimulatePrincipalPolicyInput) (*iam.SimulatePrincipalPolicyOutput, error)
+
+func (m mockRoleSimulator) SimulatePrincipalPolicy(ctx context.Context, input *iam.SimulatePrincipalPolicyInput, _ ...func(*iam.Options)) (*iam.SimulatePrincipalPolicyOutput, error) {
+	return m(ctx, input)
+}
+
+func TestReplicationCreateOrUpdateRolePermissions(t *testing.T) {
+	roleARN := "arn:aws:iam::123456789012:role/replication"
+	destination := "arn:aws:s3:::destination"
+	denied := func(action, resource string) mockRoleSimulator {
+		return func(ctx context.Context, input *iam.SimulatePrincipalPolicyInput) (*iam.SimulatePrincipalPolicyOutput, error) {
+			decision := iamtypes.PolicyEvaluationDecisionTypeAllowed
+			if input.ActionNames[0] == action && input.ResourceArns[0] == resource {
+				decision = iamtypes.PolicyEvaluationDecisionTypeImplicitDeny
+			}
+			return &iam.SimulatePrincipalPolicyOutput{EvaluationResults: []iamtypes.EvaluationResult{{
+				EvalActionName:   aws.String(input.ActionNames[0]),
+				EvalResourceName: aws.String(input.ResourceArns[0]),
+				EvalDecision:     decision,
+			}}}, nil
+		}
+	}
+
+	type want struct {
+		err error
+		put bool
+	}
+
+	cases := map[string]struct {
+		verify    *bool
+		simulator RoleSimulator
+		want      want
+	}{
+		"NotVerified": {
+			want: want{put: true},
+		},
+		"Allowed": {
+			verify:    aws.Bool(true),
+			simulator: denied("", ""),
+			want:      want{put: true},
+		},
+		"ReplicateObjectDenied": {
+			verify:    aws.Bool(true),
+			simulator: denied("s3:ReplicateObject", destination+"/*"),
+			want:      want{err: errors.Errorf(replicationRoleNotAllowed, roleARN, "s3:ReplicateObject", destination+"/*")},
+		},
+		"GetReplicationConfigurationDenied": {
+			verify:    aws.Bool(true),
+			simulator: denied("s3:GetReplicationConfiguration", "arn:aws:s3:::"+bucketName),
+			want:      want{err: errors.Errorf(replicationRoleNotAllowed, roleARN, "s3:GetReplicationConfiguration", "arn:aws:s3:::"+bucketName)},
+		},
+		"SimulationError": {
+			verify: aws.Bool(true),
+			simulator: mockRoleSimulator(func(ctx context.Context, input *iam.SimulatePrincipalPolicyInput) (*iam.SimulatePrincipalPolicyOutput, error) {
+				return nil, errBoom
+			}),
+			want: want{err: awsclient.Wrap(errBoom, replicationRoleSimulateFailed)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			put := false
+			cl := NewReplicationConfigurationClient(fake.MockBucketClient{
+				MockGetBucketLocation: otherRegionLocation,
+				MockPutBucketReplication: func(ctx context.Context, input *s3.PutBucketReplicationInput, opts []func(*s3.Options)) (*s3.PutBucketReplicationOutput, error) {
+					put = true
+					return &s3.PutBucketReplicationOutput{}, nil
+				},
+			}, nil, tc.simulator, nil)
+			config := generateReplicationConfig()
+			config.Role = aws.String(roleARN)
+			config.Rules[0].Destination.Bucket = aws.String(destination)
+			config.VerifyRolePermissions = tc.verify
+
+			b := s3Testing.Bucket(s3Testing.WithReplConfig(config))
+			meta.SetExternalName(b, bucketName)
+
+			err := cl.CreateOrUpdate(context.Background(), b)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.put, put); diff != "" {
+				t.Errorf("r: -want put, +got put:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReplicationPrerequisites(t *testing.T) {
+	type want struct {
+		unmet []string
+		err   error
+	}
+
+	cases := map[string]struct {
+		cl *ReplicationConfigurationClient
+		want
+	}{
+		"VersioningEnabled": {
+			cl: NewReplicationConfigurationClient(fake.MockBucketClient{
+				MockGetBucketVersioning: func(ctx context.Context, input *s3.GetBucketVersioningInput, opts []func(*s3.Options)) (*s3.GetBucketVersioningOutput, error) {
+					return &s3.GetBucketVersioningOutput{Status: s3types.BucketVersioningStatusEnabled}, nil
+				},
+			}, nil, nil, nil),
+			want: want{},
+		},
+		"VersioningSuspended": {
+			cl: NewReplicationConfigurationClient(fake.MockBucketClient{
+				MockGetBucketVersioning: func(ctx context.Context, input *s3.GetBucketVersioningInput, opts []func(*s3.Options)) (*s3.GetBucketVersioningOutput, error) {
+					return &s3.GetBucketVersioningOutput{Status: s3types.BucketVersioningStatusSuspended}, nil
+				},
+			}, nil, nil, nil),
+			want: want{
+				unmet: []string{"versioning is enabled on the bucket"},
+			},
+		},
+		"VersioningNeverEnabled": {
+			cl: NewReplicationConfigurationClient(fake.MockBucketClient{
+				MockGetBucketVersioning: func(ctx context.Context, input *s3.GetBucketVersioningInput, opts []func(*s3.Options)) (*s3.GetBucketVersioningOutput, error) {
+					return &s3.GetBucketVersioningOutput{}, nil
+				},
+			}, nil, nil, nil),
+			want: want{
+				unmet: []string{"versioning is enabled on the bucket"},
+			},
+		},
+		"Error": {
+			cl: NewReplicationConfigurationClient(fake.MockBucketClient{
+				MockGetBucketVersioning: func(ctx context.Context, input *s3.GetBucketVersioningInput, opts []func(*s3.Options)) (*s3.GetBucketVersioningOutput, error) {
+					return nil, errBoom
+				},
+			}, nil, nil, nil),
+			want: want{
+				err: awsclient.Wrap(errBoom, replicationVersioningGetFailed),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			unmet, err := UnmetPrerequisites(context.Background(), tc.cl, s3Testing.Bucket(s3Testing.WithReplConfig(generateReplicationConfig())))
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.unmet, unmet); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestReplicationDelete(t *testing.T) {
 	type args struct {
 		cl *ReplicationConfigurationClient
@@ -313,7 +946,7 @@ func TestReplicationDelete(t *testing.T) {
 					MockDeleteBucketReplication: func(ctx context.Context, input *s3.DeleteBucketReplicationInput, opts []func(*s3.Options)) (*s3.DeleteBucketReplicationOutput, error) {
 						return nil, errBoom
 					},
-				}),
+				}, nil, nil, nil),
 			},
 			want: want{
 				err: awsclient.Wrap(errBoom, replicationDeleteFailed),
@@ -326,7 +959,7 @@ func TestReplicationDelete(t *testing.T) {
 					MockDeleteBucketReplication: func(ctx context.Context, input *s3.DeleteBucketReplicationInput, opts []func(*s3.Options)) (*s3.DeleteBucketReplicationOutput, error) {
 						return &s3.DeleteBucketReplicationOutput{}, nil
 					},
-				}),
+				}, nil, nil, nil),
 			},
 			want: want{
 				err: nil,
@@ -366,7 +999,7 @@ func TestReplicationLateInit(t *testing.T) {
 					MockGetBucketReplication: func(ctx context.Context, input *s3.GetBucketReplicationInput, opts []func(*s3.Options)) (*s3.GetBucketReplicationOutput, error) {
 						return &s3.GetBucketReplicationOutput{}, errBoom
 					},
-				}),
+				}, nil, nil, nil),
 			},
 			want: want{
 				err: awsclient.Wrap(errBoom, replicationGetFailed),
@@ -380,7 +1013,7 @@ func TestReplicationLateInit(t *testing.T) {
 					MockGetBucketReplication: func(ctx context.Context, input *s3.GetBucketReplicationInput, opts []func(*s3.Options)) (*s3.GetBucketReplicationOutput, error) {
 						return &s3.GetBucketReplicationOutput{}, &smithy.GenericAPIError{Code: clientss3.ReplicationNotFoundErrCode}
 					},
-				}),
+				}, nil, nil, nil),
 			},
 			want: want{
 				err: nil,
@@ -394,7 +1027,7 @@ func TestReplicationLateInit(t *testing.T) {
 					MockGetBucketReplication: func(ctx context.Context, input *s3.GetBucketReplicationInput, opts []func(*s3.Options)) (*s3.GetBucketReplicationOutput, error) {
 						return &s3.GetBucketReplicationOutput{}, nil
 					},
-				}),
+				}, nil, nil, nil),
 			},
 			want: want{
 				err: nil,
@@ -408,7 +1041,7 @@ func TestReplicationLateInit(t *testing.T) {
 					MockGetBucketReplication: func(ctx context.Context, input *s3.GetBucketReplicationInput, opts []func(*s3.Options)) (*s3.GetBucketReplicationOutput, error) {
 						return &s3.GetBucketReplicationOutput{ReplicationConfiguration: nil}, nil
 					},
-				}),
+				}, nil, nil, nil),
 			},
 			want: want{
 				err: nil,
@@ -422,13 +1055,27 @@ func TestReplicationLateInit(t *testing.T) {
 					MockGetBucketReplication: func(ctx context.Context, input *s3.GetBucketReplicationInput, opts []func(*s3.Options)) (*s3.GetBucketReplicationOutput, error) {
 						return &s3.GetBucketReplicationOutput{ReplicationConfiguration: generateAWSReplication()}, nil
 					},
-				}),
+				}, nil, nil, nil),
 			},
 			want: want{
 				err: nil,
 				cr:  s3Testing.Bucket(s3Testing.WithReplConfig(generateReplicationConfig())),
 			},
 		},
+		"SuccessfulLateInitV1": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithReplConfig(nil)),
+				cl: NewReplicationConfigurationClient(fake.MockBucketClient{
+					MockGetBucketReplication: func(ctx context.Context, input *s3.GetBucketReplicationInput, opts []func(*s3.Options)) (*s3.GetBucketReplicationOutput, error) {
+						return &s3.GetBucketReplicationOutput{ReplicationConfiguration: generateAWSV1Replication()}, nil
+					},
+				}, nil, nil, nil),
+			},
+			want: want{
+				err: nil,
+				cr:  s3Testing.Bucket(s3Testing.WithReplConfig(generateReplicationConfigWithFilter(&v1beta1.ReplicationRuleFilter{Prefix: &prefix}))),
+			},
+		},
 		"NoOpLateInit": {
 			args: args{
 				b: s3Testing.Bucket(s3Testing.WithReplConfig(generateReplicationConfig())),
@@ -438,7 +1085,7 @@ func TestReplicationLateInit(t *testing.T) {
 							ReplicationConfiguration: &s3types.ReplicationConfiguration{},
 						}, nil
 					},
-				}),
+				}, nil, nil, nil),
 			},
 			want: want{
 				err: nil,
@@ -29,8 +29,9 @@ import (
 )
 
 const (
-	paymentGetFailed = "cannot get request payment configuration"
-	paymentPutFailed = "cannot put Bucket payment"
+	paymentGetFailed   = "cannot get request payment configuration"
+	paymentPutFailed   = "cannot put Bucket payment"
+	paymentResetFailed = "cannot reset Bucket payer to bucket owner"
 )
 
 // RequestPaymentConfigurationClient is the client for API methods and reconciling the PaymentConfiguration
@@ -50,13 +51,18 @@ func (in *RequestPaymentConfigurationClient) Observe(ctx context.Context, bucket
 		return NeedsUpdate, awsclient.Wrap(err, paymentGetFailed)
 	}
 	config := bucket.Spec.ForProvider.PayerConfiguration
+	// The bucket owner pays for requests unless configured otherwise.
+	payer := types.PayerBucketOwner
+	if external != nil && len(external.Payer) != 0 {
+		payer = external.Payer
+	}
 
 	switch {
-	case config == nil && len(external.Payer) == 0:
+	case config == nil && payer == types.PayerBucketOwner:
 		return Updated, nil
-	case config == nil && len(external.Payer) != 0:
-		return NeedsUpdate, nil
-	case config.Payer != string(external.Payer):
+	case config == nil:
+		return NeedsDeletion, nil
+	case config.Payer != string(payer):
 		return NeedsUpdate, nil
 	default:
 		return Updated, nil
@@ -73,9 +79,12 @@ func (in *RequestPaymentConfigurationClient) CreateOrUpdate(ctx context.Context,
 	return awsclient.Wrap(err, paymentPutFailed)
 }
 
-// Delete does nothing since there is no corresponding deletion call in awsclient.
-func (*RequestPaymentConfigurationClient) Delete(_ context.Context, _ *v1beta1.Bucket) error {
-	return nil
+// Delete resets the payer to the bucket owner, which is the default, since
+// there is no corresponding deletion call.
+func (in *RequestPaymentConfigurationClient) Delete(ctx context.Context, bucket *v1beta1.Bucket) error {
+	input := GeneratePutBucketPaymentInput(meta.GetExternalName(bucket), &v1beta1.PaymentConfiguration{Payer: string(types.PayerBucketOwner)})
+	_, err := in.client.PutBucketRequestPayment(ctx, input)
+	return awsclient.Wrap(err, paymentResetFailed)
 }
 
 // LateInitialize is responsible for initializing the resource based on the external value
@@ -97,6 +106,11 @@ func (in *RequestPaymentConfigurationClient) LateInitialize(ctx context.Context,
 	return nil
 }
 
+// CapturePrior returns a Bucket with the request payment configuration currently in AWS.
+func (in *RequestPaymentConfigurationClient) CapturePrior(ctx context.Context, bucket *v1beta1.Bucket) (*v1beta1.Bucket, error) {
+	return lateInitializedPrior(ctx, in, bucket)
+}
+
 // SubresourceExists checks if the subresource this controller manages currently exists
 func (in *RequestPaymentConfigurationClient) SubresourceExists(bucket *v1beta1.Bucket) bool {
 	return bucket.Spec.ForProvider.PayerConfiguration != nil
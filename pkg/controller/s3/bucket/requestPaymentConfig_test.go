@@ -105,6 +105,48 @@ func TestRequestPaymentObserve(t *testing.T) {
 				err:    nil,
 			},
 		},
+		"NoUpdateMissingPayerIsBucketOwner": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithPayerConfig(&v1beta1.PaymentConfiguration{Payer: "BucketOwner"})),
+				cl: NewRequestPaymentConfigurationClient(fake.MockBucketClient{
+					MockGetBucketRequestPayment: func(ctx context.Context, input *s3.GetBucketRequestPaymentInput, opts []func(*s3.Options)) (*s3.GetBucketRequestPaymentOutput, error) {
+						return &s3.GetBucketRequestPaymentOutput{}, nil
+					},
+				}),
+			},
+			want: want{
+				status: Updated,
+				err:    nil,
+			},
+		},
+		"NoUpdateNotConfigured": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithPayerConfig(nil)),
+				cl: NewRequestPaymentConfigurationClient(fake.MockBucketClient{
+					MockGetBucketRequestPayment: func(ctx context.Context, input *s3.GetBucketRequestPaymentInput, opts []func(*s3.Options)) (*s3.GetBucketRequestPaymentOutput, error) {
+						return &s3.GetBucketRequestPaymentOutput{Payer: s3types.PayerBucketOwner}, nil
+					},
+				}),
+			},
+			want: want{
+				status: Updated,
+				err:    nil,
+			},
+		},
+		"NeedsDeletion": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithPayerConfig(nil)),
+				cl: NewRequestPaymentConfigurationClient(fake.MockBucketClient{
+					MockGetBucketRequestPayment: func(ctx context.Context, input *s3.GetBucketRequestPaymentInput, opts []func(*s3.Options)) (*s3.GetBucketRequestPaymentOutput, error) {
+						return &s3.GetBucketRequestPaymentOutput{Payer: s3types.PayerRequester}, nil
+					},
+				}),
+			},
+			want: want{
+				status: NeedsDeletion,
+				err:    nil,
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -120,6 +162,61 @@ func TestRequestPaymentObserve(t *testing.T) {
 	}
 }
 
+func TestRequestPaymentDelete(t *testing.T) {
+	type args struct {
+		cl *RequestPaymentConfigurationClient
+		b  *v1beta1.Bucket
+	}
+
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"Error": {
+			args: args{
+				b: s3Testing.Bucket(),
+				cl: NewRequestPaymentConfigurationClient(fake.MockBucketClient{
+					MockPutBucketRequestPayment: func(ctx context.Context, input *s3.PutBucketRequestPaymentInput, opts []func(*s3.Options)) (*s3.PutBucketRequestPaymentOutput, error) {
+						return nil, errBoom
+					},
+				}),
+			},
+			want: want{
+				err: awsclient.Wrap(errBoom, paymentResetFailed),
+			},
+		},
+		"SuccessfulReset": {
+			args: args{
+				b: s3Testing.Bucket(),
+				cl: NewRequestPaymentConfigurationClient(fake.MockBucketClient{
+					MockPutBucketRequestPayment: func(ctx context.Context, input *s3.PutBucketRequestPaymentInput, opts []func(*s3.Options)) (*s3.PutBucketRequestPaymentOutput, error) {
+						if input.RequestPaymentConfiguration.Payer != s3types.PayerBucketOwner {
+							return nil, errBoom
+						}
+						return &s3.PutBucketRequestPaymentOutput{}, nil
+					},
+				}),
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.args.cl.Delete(context.Background(), tc.args.b)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestRequestPaymentCreateOrUpdate(t *testing.T) {
 	type args struct {
 		cl *RequestPaymentConfigurationClient
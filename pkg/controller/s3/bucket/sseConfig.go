@@ -18,11 +18,25 @@ package bucket
 
 import (
 	"context"
+	"fmt"
+	"strconv"
+	"strings"
 
 	awss3 "github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	awsv1 "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/smithy-go/document"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
 	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/crossplane/provider-aws/apis/s3/v1beta1"
 	awsclient "github.com/crossplane/provider-aws/pkg/clients"
@@ -33,28 +47,81 @@ const (
 	sseGetFailed    = "cannot get encryption configuration"
 	ssePutFailed    = "cannot put encryption configuration"
 	sseDeleteFailed = "cannot delete encryption configuration"
+
+	sseKeySecretGetFailed = "cannot get secret containing the KMS key ID"
+	sseKeySecretKeyEmpty  = "key %s of secret %s/%s does not contain a KMS key ID"
+
+	sseKMSFallback     = "KMS key of the bucket encryption cannot be used, falling back to AES256: %s"
+	sseKMSAccessDenied = "access to KMS key %s of account %s was denied; if the key belongs to another account than the bucket, its key policy or a KMS grant must allow the account of the bucket to use it"
+
+	sseAlgorithmChanged = "server-side encryption algorithm changes from %s to %s; existing objects are not re-encrypted, only new objects use the new algorithm"
+
+	sseUnknownAlgorithm    = "unknown server-side encryption algorithm %q, must be one of AES256 (SSE-S3) or aws:kms (SSE-KMS)"
+	sseAlgorithmMissing    = "rule %d: sseAlgorithm is required unless a KMS key is set"
+	sseKMSKeyWithoutKMS    = "rule %d: a KMS key may only be set if the algorithm is aws:kms"
+	sseBucketKeyWithoutKMS = "rule %d: bucketKeyEnabled may only be set if the algorithm is aws:kms, S3 bucket keys reduce the cost of KMS requests and do not apply to AES256"
+
+	sseKeyRotationUnknown = "cannot get rotation status of KMS key %s: %s"
+
+	reasonKMSFallback         event.Reason = "FallbackToAES256"
+	reasonSSEAlgorithmChanged event.Reason = "SSEAlgorithmChanged"
+	reasonKeyRotationUnknown  event.Reason = "KMSKeyRotationUnknown"
 )
 
+// A KeyRotationGetter reads whether automatic rotation is enabled for a KMS
+// key.
+type KeyRotationGetter interface {
+	GetKeyRotationStatusWithContext(ctx awsv1.Context, input *kms.GetKeyRotationStatusInput, opts ...request.Option) (*kms.GetKeyRotationStatusOutput, error)
+}
+
 // SSEConfigurationClient is the client for API methods and reconciling the ServerSideEncryptionConfiguration
 type SSEConfigurationClient struct {
-	client s3.BucketClient
+	client          s3.BucketClient
+	defaultKMSKeyID *string
+	kube            client.Client
+	recorder        event.Recorder
+	keyRotation     KeyRotationGetter
 }
 
-// NewSSEConfigurationClient creates the client for Server Side Encryption Configuration
-func NewSSEConfigurationClient(client s3.BucketClient) *SSEConfigurationClient {
-	return &SSEConfigurationClient{client: client}
+// NewSSEConfigurationClient creates the client for Server Side Encryption Configuration.
+// The defaultKMSKeyID, if set, is used by aws:kms rules that do not specify a key,
+// kube is used to read KMS key IDs from secrets and the recorder is used to warn
+// about falling back to AES256. The rotation status of the KMS key is only
+// reported in the status of the Bucket if keyRotation is set.
+func NewSSEConfigurationClient(client s3.BucketClient, defaultKMSKeyID *string, kube client.Client, recorder event.Recorder, keyRotation KeyRotationGetter) *SSEConfigurationClient {
+	return &SSEConfigurationClient{client: client, defaultKMSKeyID: defaultKMSKeyID, kube: kube, recorder: recorder, keyRotation: keyRotation}
 }
 
 // Observe checks if the resource exists and if it matches the local configuration
 func (in *SSEConfigurationClient) Observe(ctx context.Context, bucket *v1beta1.Bucket) (ResourceStatus, error) { // nolint:gocyclo
-	config := bucket.Spec.ForProvider.ServerSideEncryptionConfiguration
+	config, err := in.effectiveConfig(ctx, bucket)
+	if err != nil {
+		return NeedsUpdate, err
+	}
+	config, err = in.resolveKMSKeys(ctx, config)
+	if err != nil {
+		return NeedsUpdate, err
+	}
+	in.observeKeyRotation(ctx, bucket, config)
+	// While the bucket falls back to AES256 for the same KMS keys, AES256 is
+	// what it is expected to use. Otherwise KMS is tried again.
+	if fallbackActive(bucket, config) {
+		config = withAES256(config)
+	} else if bucket.Status.GetCondition(TypeEncryptionFallback).Status == corev1.ConditionTrue {
+		bucket.Status.SetConditions(ConfiguredEncryption())
+	}
 	external, err := in.client.GetBucketEncryption(ctx, &awss3.GetBucketEncryptionInput{Bucket: awsclient.String(meta.GetExternalName(bucket))})
+	bucket.Status.AtProvider.ServerSideEncryptionRules = nil
 	if err != nil {
 		if s3.SSEConfigurationNotFound(err) && config == nil {
 			return Updated, nil
 		}
 		return NeedsUpdate, awsclient.Wrap(resource.Ignore(s3.SSEConfigurationNotFound, err), sseGetFailed)
 	}
+	if external.ServerSideEncryptionConfiguration != nil {
+		external.ServerSideEncryptionConfiguration.Rules = uniqueSSERules(external.ServerSideEncryptionConfiguration.Rules)
+		bucket.Status.AtProvider.ServerSideEncryptionRules = GenerateLocalBucketEncryption(external.ServerSideEncryptionConfiguration)
+	}
 
 	switch {
 	case external.ServerSideEncryptionConfiguration != nil && config == nil:
@@ -69,10 +136,21 @@ func (in *SSEConfigurationClient) Observe(ctx context.Context, bucket *v1beta1.B
 
 	for i, Rule := range config.Rules {
 		outputRule := external.ServerSideEncryptionConfiguration.Rules[i].ApplyServerSideEncryptionByDefault
-		if awsclient.StringValue(outputRule.KMSMasterKeyID) != awsclient.StringValue(Rule.ApplyServerSideEncryptionByDefault.KMSMasterKeyID) {
+		if outputRule == nil {
+			return NeedsUpdate, nil
+		}
+		if !s3.KMSKeyIDEqual(awsclient.StringValue(outputRule.KMSMasterKeyID), awsclient.StringValue(Rule.ApplyServerSideEncryptionByDefault.KMSMasterKeyID)) {
+			return NeedsUpdate, nil
+		}
+		if !s3.EnumEqual(string(outputRule.SSEAlgorithm), Rule.ApplyServerSideEncryptionByDefault.SSEAlgorithm) {
 			return NeedsUpdate, nil
 		}
-		if string(outputRule.SSEAlgorithm) != Rule.ApplyServerSideEncryptionByDefault.SSEAlgorithm {
+		// AWS reports a disabled bucket key as false, which is the default.
+		// Bucket keys only apply to aws:kms, so they are ignored otherwise.
+		if !s3.EnumEqual(string(outputRule.SSEAlgorithm), string(types.ServerSideEncryptionAwsKms)) {
+			continue
+		}
+		if external.ServerSideEncryptionConfiguration.Rules[i].BucketKeyEnabled != awsclient.BoolValue(Rule.BucketKeyEnabled) {
 			return NeedsUpdate, nil
 		}
 	}
@@ -80,16 +158,311 @@ func (in *SSEConfigurationClient) Observe(ctx context.Context, bucket *v1beta1.B
 	return Updated, nil
 }
 
+// uniqueSSERules returns the given rules without those that are identical to
+// an earlier rule. Some S3 backends return the same default encryption rule
+// more than once, which is not a difference to the configured rules.
+func uniqueSSERules(rules []types.ServerSideEncryptionRule) []types.ServerSideEncryptionRule {
+	unique := make([]types.ServerSideEncryptionRule, 0, len(rules))
+	for _, r := range rules {
+		duplicate := false
+		for _, u := range unique {
+			if cmp.Equal(r, u, cmpopts.IgnoreTypes(document.NoSerde{})) {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			unique = append(unique, r)
+		}
+	}
+	return unique
+}
+
+// observeKeyRotation reports whether automatic rotation is enabled for the
+// customer managed KMS key of the given configuration in the status of the
+// bucket. Nothing is reported for AES256, the AWS managed key, which AWS
+// always rotates, and aliases, which KMS does not accept for this call. If
+// the rotation status cannot be read, e.g. because the provider may not call
+// kms:GetKeyRotationStatus, a warning is emitted instead of failing the
+// reconcile.
+func (in *SSEConfigurationClient) observeKeyRotation(ctx context.Context, bucket *v1beta1.Bucket, config *v1beta1.ServerSideEncryptionConfiguration) {
+	bucket.Status.AtProvider.KMSKeyRotationEnabled = nil
+	if in.keyRotation == nil || config == nil {
+		return
+	}
+	for _, rule := range config.Rules {
+		id := awsclient.StringValue(rule.ApplyServerSideEncryptionByDefault.KMSMasterKeyID)
+		if id == "" || strings.HasPrefix(id, "alias/") || strings.Contains(id, ":alias/") ||
+			!s3.EnumEqual(rule.ApplyServerSideEncryptionByDefault.SSEAlgorithm, string(types.ServerSideEncryptionAwsKms)) {
+			continue
+		}
+		out, err := in.keyRotation.GetKeyRotationStatusWithContext(ctx, &kms.GetKeyRotationStatusInput{KeyId: awsv1.String(id)})
+		if err != nil {
+			in.recorder.Event(bucket, event.Warning(reasonKeyRotationUnknown, errors.Errorf(sseKeyRotationUnknown, id, err)))
+			return
+		}
+		bucket.Status.AtProvider.KMSKeyRotationEnabled = out.KeyRotationEnabled
+		return
+	}
+}
+
+// DryRunObserve returns the algorithm and KMS key of each rule that differs
+// between the local configuration and the configuration of the bucket.
+func (in *SSEConfigurationClient) DryRunObserve(ctx context.Context, bucket *v1beta1.Bucket) (Diff, error) {
+	config, err := in.effectiveConfig(ctx, bucket)
+	if err != nil {
+		return nil, err
+	}
+	config, err = in.resolveKMSKeys(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+	external, err := in.client.GetBucketEncryption(ctx, &awss3.GetBucketEncryptionInput{Bucket: awsclient.String(meta.GetExternalName(bucket))})
+	if resource.Ignore(s3.SSEConfigurationNotFound, err) != nil {
+		return nil, awsclient.Wrap(err, sseGetFailed)
+	}
+	var desired []v1beta1.ServerSideEncryptionRule
+	if config != nil {
+		desired = config.Rules
+	}
+	var observed []types.ServerSideEncryptionRule
+	if external != nil && external.ServerSideEncryptionConfiguration != nil {
+		observed = uniqueSSERules(external.ServerSideEncryptionConfiguration.Rules)
+	}
+	var diff Diff
+	for i := 0; i < len(desired) || i < len(observed); i++ {
+		var wantAlgorithm, wantKey, gotAlgorithm, gotKey string
+		wantBucketKey, gotBucketKey := false, false
+		if i < len(desired) {
+			wantAlgorithm = desired[i].ApplyServerSideEncryptionByDefault.SSEAlgorithm
+			wantKey = awsclient.StringValue(desired[i].ApplyServerSideEncryptionByDefault.KMSMasterKeyID)
+			wantBucketKey = awsclient.BoolValue(desired[i].BucketKeyEnabled)
+		}
+		if i < len(observed) {
+			gotBucketKey = observed[i].BucketKeyEnabled
+			if observed[i].ApplyServerSideEncryptionByDefault != nil {
+				gotAlgorithm = string(observed[i].ApplyServerSideEncryptionByDefault.SSEAlgorithm)
+				gotKey = awsclient.StringValue(observed[i].ApplyServerSideEncryptionByDefault.KMSMasterKeyID)
+			}
+		}
+		path := fmt.Sprintf("serverSideEncryptionConfiguration.rules[%d]", i)
+		diff = diff.add(path+".applyServerSideEncryptionByDefault.sseAlgorithm", wantAlgorithm, gotAlgorithm, s3.EnumEqual)
+		diff = diff.add(path+".applyServerSideEncryptionByDefault.kmsMasterKeyId", wantKey, gotKey, s3.KMSKeyIDEqual)
+		diff = diff.add(path+".bucketKeyEnabled", strconv.FormatBool(wantBucketKey), strconv.FormatBool(gotBucketKey), exactly)
+	}
+	return diff, nil
+}
+
 // CreateOrUpdate sends a request to have resource created on awsclient.
 func (in *SSEConfigurationClient) CreateOrUpdate(ctx context.Context, bucket *v1beta1.Bucket) error {
-	if bucket.Spec.ForProvider.ServerSideEncryptionConfiguration == nil {
-		return nil
+	config, err := in.effectiveConfig(ctx, bucket)
+	if err != nil || config == nil {
+		return err
+	}
+	config, err = in.resolveKMSKeys(ctx, config)
+	if err != nil {
+		return err
+	}
+	if err := canonicalizeAlgorithms(config); err != nil {
+		return err
+	}
+	if err := validateSSEConfiguration(config); err != nil {
+		return err
+	}
+	in.noteAlgorithmChange(bucket, config)
+	input := GeneratePutBucketEncryptionInput(meta.GetExternalName(bucket), config)
+	_, err = in.client.PutBucketEncryption(ctx, input)
+	if s3.KMSKeyInaccessible(err) && awsclient.BoolValue(config.FallbackToAES256OnKMSError) {
+		in.recorder.Event(bucket, event.Warning(reasonKMSFallback, errors.Errorf(sseKMSFallback, err)))
+		_, err = in.client.PutBucketEncryption(ctx, GeneratePutBucketEncryptionInput(meta.GetExternalName(bucket), withAES256(config)))
+		if err == nil {
+			bucket.Status.SetConditions(FallbackToAES256(kmsKeys(config)))
+		}
+	}
+	if s3.KMSAccessDenied(err) {
+		err = withKMSAccessHint(err, config)
 	}
-	input := GeneratePutBucketEncryptionInput(meta.GetExternalName(bucket), bucket.Spec.ForProvider.ServerSideEncryptionConfiguration)
-	_, err := in.client.PutBucketEncryption(ctx, input)
 	return awsclient.Wrap(err, ssePutFailed)
 }
 
+// noteAlgorithmChange emits an event if the given configuration changes the
+// SSE algorithm of the bucket, since S3 does not re-encrypt existing objects.
+// The rules of the bucket are those recorded in its status by Observe, so
+// nothing is emitted if the bucket had no encryption when it was observed.
+func (in *SSEConfigurationClient) noteAlgorithmChange(bucket *v1beta1.Bucket, config *v1beta1.ServerSideEncryptionConfiguration) {
+	for i, rule := range bucket.Status.AtProvider.ServerSideEncryptionRules {
+		if i >= len(config.Rules) {
+			return
+		}
+		from := rule.ApplyServerSideEncryptionByDefault.SSEAlgorithm
+		to := config.Rules[i].ApplyServerSideEncryptionByDefault.SSEAlgorithm
+		if !s3.EnumEqual(from, to) {
+			in.recorder.Event(bucket, event.Normal(reasonSSEAlgorithmChanged, fmt.Sprintf(sseAlgorithmChanged, from, to)))
+			return
+		}
+	}
+}
+
+// effectiveConfig returns the server side encryption configuration of the
+// bucket merged with the baseline it references, if any. Rules that set a KMS
+// key but no algorithm use aws:kms.
+func (in *SSEConfigurationClient) effectiveConfig(ctx context.Context, bucket *v1beta1.Bucket) (*v1beta1.ServerSideEncryptionConfiguration, error) {
+	base, err := getBaseline(ctx, in.kube, bucket)
+	if err != nil {
+		return nil, err
+	}
+	return withDefaultAlgorithms(mergeSSE(bucket.Spec.ForProvider.ServerSideEncryptionConfiguration, base.sse)), nil
+}
+
+// withDefaultAlgorithms returns a copy of the given configuration in which
+// each rule uses its default algorithm, see defaultAlgorithm.
+func withDefaultAlgorithms(config *v1beta1.ServerSideEncryptionConfiguration) *v1beta1.ServerSideEncryptionConfiguration {
+	if config == nil {
+		return nil
+	}
+	c := config.DeepCopy()
+	for i := range c.Rules {
+		d := &c.Rules[i].ApplyServerSideEncryptionByDefault
+		d.SSEAlgorithm = defaultAlgorithm(*d)
+	}
+	return c
+}
+
+// defaultAlgorithm returns the SSE algorithm of the given default encryption.
+// A KMS key, set directly, through a secret or by referencing a KMS Key, is
+// only allowed for aws:kms, so that is the algorithm if none is set.
+func defaultAlgorithm(d v1beta1.ServerSideEncryptionByDefault) string {
+	if d.SSEAlgorithm == "" && hasKMSKey(d) {
+		return string(types.ServerSideEncryptionAwsKms)
+	}
+	return d.SSEAlgorithm
+}
+
+// withKMSAccessHint wraps an error of KMS denying the use of a key with an
+// explanation of the grant that is missing if the configuration uses a KMS key
+// referenced by its ARN, which is the only way to use a key of another account.
+func withKMSAccessHint(err error, config *v1beta1.ServerSideEncryptionConfiguration) error {
+	for _, rule := range config.Rules {
+		id := awsclient.StringValue(rule.ApplyServerSideEncryptionByDefault.KMSMasterKeyID)
+		if account := s3.KMSKeyAccount(id); account != "" {
+			return errors.Wrapf(err, sseKMSAccessDenied, id, account)
+		}
+	}
+	return err
+}
+
+// canonicalizeAlgorithms replaces the SSE algorithm of each rule of the given
+// configuration, which may be an alias such as SSE-KMS, with the value AWS
+// uses. It returns an error if an algorithm is not known. The configuration
+// must be a copy that may be modified.
+func canonicalizeAlgorithms(config *v1beta1.ServerSideEncryptionConfiguration) error {
+	for i := range config.Rules {
+		d := &config.Rules[i].ApplyServerSideEncryptionByDefault
+		if d.SSEAlgorithm == "" {
+			return errors.Errorf(sseAlgorithmMissing, i)
+		}
+		a, err := canonicalAlgorithm(d.SSEAlgorithm)
+		if err != nil {
+			return err
+		}
+		d.SSEAlgorithm = string(a)
+	}
+	return nil
+}
+
+// validateSSEConfiguration returns an error if a rule of the supplied
+// configuration uses an unknown algorithm or an invalid KMS key ID, sets
+// neither an algorithm nor a KMS key, or sets a KMS key or enables the bucket
+// key without using aws:kms.
+func validateSSEConfiguration(config *v1beta1.ServerSideEncryptionConfiguration) error {
+	for i, rule := range config.Rules {
+		d := rule.ApplyServerSideEncryptionByDefault
+		algorithm := defaultAlgorithm(d)
+		if algorithm == "" {
+			return errors.Errorf(sseAlgorithmMissing, i)
+		}
+		a, err := canonicalAlgorithm(algorithm)
+		if err != nil {
+			return err
+		}
+		if awsclient.BoolValue(rule.BucketKeyEnabled) && a != types.ServerSideEncryptionAwsKms {
+			return errors.Errorf(sseBucketKeyWithoutKMS, i)
+		}
+		if !hasKMSKey(d) {
+			continue
+		}
+		if a != types.ServerSideEncryptionAwsKms {
+			return errors.Errorf(sseKMSKeyWithoutKMS, i)
+		}
+		if d.KMSMasterKeyID != nil {
+			if err := s3.ValidateKMSKeyID(*d.KMSMasterKeyID); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// hasKMSKey reports whether the given default encryption sets a KMS key in
+// any of the supported ways. A reference or selector is resolved into the
+// KMSMasterKeyID before the configuration is applied.
+func hasKMSKey(d v1beta1.ServerSideEncryptionByDefault) bool {
+	return d.KMSMasterKeyID != nil || d.KMSMasterKeyIDSecretRef != nil || d.KMSMasterKeyIDRef != nil || d.KMSMasterKeyIDSelector != nil
+}
+
+// canonicalAlgorithm returns the SSE algorithm the given value is equal to,
+// ignoring case and resolving aliases.
+func canonicalAlgorithm(v string) (types.ServerSideEncryption, error) {
+	for _, a := range types.ServerSideEncryption("").Values() {
+		if s3.EnumEqual(v, string(a)) {
+			return a, nil
+		}
+	}
+	return "", errors.Errorf(sseUnknownAlgorithm, v)
+}
+
+// withAES256 returns a copy of the given configuration in which all rules use
+// AES256 instead of their KMS key. The bucket key is disabled since it only
+// applies to aws:kms.
+func withAES256(config *v1beta1.ServerSideEncryptionConfiguration) *v1beta1.ServerSideEncryptionConfiguration {
+	c := config.DeepCopy()
+	for i := range c.Rules {
+		c.Rules[i].BucketKeyEnabled = nil
+		c.Rules[i].ApplyServerSideEncryptionByDefault = v1beta1.ServerSideEncryptionByDefault{
+			SSEAlgorithm: string(types.ServerSideEncryptionAes256),
+		}
+	}
+	return c
+}
+
+// fallbackActive reports whether the bucket fell back to AES256 for the KMS
+// keys of the given configuration and the configuration still allows it.
+func fallbackActive(bucket *v1beta1.Bucket, config *v1beta1.ServerSideEncryptionConfiguration) bool {
+	if config == nil || !awsclient.BoolValue(config.FallbackToAES256OnKMSError) {
+		return false
+	}
+	c := bucket.Status.GetCondition(TypeEncryptionFallback)
+	return c.Status == corev1.ConditionTrue && c.Message == FallbackToAES256(kmsKeys(config)).Message
+}
+
+// kmsKeys returns the KMS keys used by the rules of the given configuration.
+// Rules that use aws:kms without a key use the AWS managed key aws/s3.
+func kmsKeys(config *v1beta1.ServerSideEncryptionConfiguration) []string {
+	var keys []string
+	for _, rule := range config.Rules {
+		d := rule.ApplyServerSideEncryptionByDefault
+		if !s3.EnumEqual(d.SSEAlgorithm, string(types.ServerSideEncryptionAwsKms)) {
+			continue
+		}
+		id := awsclient.StringValue(d.KMSMasterKeyID)
+		if id == "" {
+			id = "aws/s3"
+		}
+		keys = append(keys, id)
+	}
+	return keys
+}
+
 // Delete creates the request to delete the resource on AWS or set it to the default value.
 func (in *SSEConfigurationClient) Delete(ctx context.Context, bucket *v1beta1.Bucket) error {
 	_, err := in.client.DeleteBucketEncryption(ctx,
@@ -103,6 +476,11 @@ func (in *SSEConfigurationClient) Delete(ctx context.Context, bucket *v1beta1.Bu
 // LateInitialize does nothing because the resource might have been deleted by
 // the user.
 func (in *SSEConfigurationClient) LateInitialize(ctx context.Context, bucket *v1beta1.Bucket) error {
+	// The observed encryption may come from the baseline, which must not be
+	// copied into the spec where it would take precedence over the baseline.
+	if bucket.Spec.ForProvider.BaselineConfigMapRef != nil {
+		return nil
+	}
 	external, err := in.client.GetBucketEncryption(ctx, &awss3.GetBucketEncryptionInput{Bucket: awsclient.String(meta.GetExternalName(bucket))})
 	if err != nil {
 		return awsclient.Wrap(resource.Ignore(s3.SSEConfigurationNotFound, err), sseGetFailed)
@@ -114,15 +492,83 @@ func (in *SSEConfigurationClient) LateInitialize(ctx context.Context, bucket *v1
 	}
 
 	fp := &bucket.Spec.ForProvider
+	if fp.ServerSideEncryptionConfiguration != nil && fp.ServerSideEncryptionConfiguration.Rules != nil {
+		return nil
+	}
+
+	// Rules without a default encryption cannot be represented in the spec,
+	// so there may be nothing to late initialize.
+	rules := GenerateLocalBucketEncryption(external.ServerSideEncryptionConfiguration)
+	if len(rules) == 0 {
+		return nil
+	}
+
 	if fp.ServerSideEncryptionConfiguration == nil {
 		fp.ServerSideEncryptionConfiguration = &v1beta1.ServerSideEncryptionConfiguration{}
 	}
+	fp.ServerSideEncryptionConfiguration.Rules = rules
+
+	return nil
+}
+
+// CapturePrior returns a Bucket with the default encryption currently in AWS.
+func (in *SSEConfigurationClient) CapturePrior(ctx context.Context, bucket *v1beta1.Bucket) (*v1beta1.Bucket, error) {
+	return lateInitializedPrior(ctx, in, bucket)
+}
+
+// resolveKMSKeys returns a copy of the given configuration in which the
+// KMSMasterKeyID of each rule is set to the value of its secret reference or,
+// for aws:kms rules, to the default KMS key of the client if it has neither.
+func (in *SSEConfigurationClient) resolveKMSKeys(ctx context.Context, config *v1beta1.ServerSideEncryptionConfiguration) (*v1beta1.ServerSideEncryptionConfiguration, error) {
+	config, err := in.withKMSKeysFromSecrets(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+	return in.withDefaultKMSKey(config), nil
+}
 
-	if fp.ServerSideEncryptionConfiguration.Rules == nil {
-		fp.ServerSideEncryptionConfiguration.Rules = GenerateLocalBucketEncryption(external.ServerSideEncryptionConfiguration)
+// withKMSKeysFromSecrets returns a copy of the given configuration in which
+// the rules that reference a secret instead of specifying a KMSMasterKeyID
+// use the KMS key ID stored in that secret.
+func (in *SSEConfigurationClient) withKMSKeysFromSecrets(ctx context.Context, config *v1beta1.ServerSideEncryptionConfiguration) (*v1beta1.ServerSideEncryptionConfiguration, error) {
+	if config == nil {
+		return nil, nil
+	}
+	c := config.DeepCopy()
+	for i := range c.Rules {
+		d := &c.Rules[i].ApplyServerSideEncryptionByDefault
+		ref := d.KMSMasterKeyIDSecretRef
+		if d.KMSMasterKeyID != nil || ref == nil {
+			continue
+		}
+		secret := &corev1.Secret{}
+		if err := in.kube.Get(ctx, k8stypes.NamespacedName{Name: ref.Name, Namespace: ref.Namespace}, secret); err != nil {
+			return nil, errors.Wrap(err, sseKeySecretGetFailed)
+		}
+		id := string(secret.Data[ref.Key])
+		if id == "" {
+			return nil, errors.Errorf(sseKeySecretKeyEmpty, ref.Key, ref.Namespace, ref.Name)
+		}
+		d.KMSMasterKeyID = awsclient.String(id)
 	}
+	return c, nil
+}
 
-	return nil
+// withDefaultKMSKey returns a copy of the given configuration in which the
+// aws:kms rules without a KMSMasterKeyID use the default KMS key of the
+// client. The configuration is returned as is if there is no default key.
+func (in *SSEConfigurationClient) withDefaultKMSKey(config *v1beta1.ServerSideEncryptionConfiguration) *v1beta1.ServerSideEncryptionConfiguration {
+	if config == nil || in.defaultKMSKeyID == nil {
+		return config
+	}
+	c := config.DeepCopy()
+	for i := range c.Rules {
+		d := &c.Rules[i].ApplyServerSideEncryptionByDefault
+		if s3.EnumEqual(d.SSEAlgorithm, string(types.ServerSideEncryptionAwsKms)) && d.KMSMasterKeyID == nil {
+			d.KMSMasterKeyID = awsclient.String(*in.defaultKMSKeyID)
+		}
+	}
+	return c
 }
 
 // SubresourceExists checks if the subresource this controller manages currently exists
@@ -144,6 +590,7 @@ func GeneratePutBucketEncryptionInput(name string, config *v1beta1.ServerSideEnc
 				KMSMasterKeyID: rule.ApplyServerSideEncryptionByDefault.KMSMasterKeyID,
 				SSEAlgorithm:   types.ServerSideEncryption(rule.ApplyServerSideEncryptionByDefault.SSEAlgorithm),
 			},
+			BucketKeyEnabled: awsclient.BoolValue(rule.BucketKeyEnabled),
 		}
 	}
 	return bei
@@ -151,14 +598,19 @@ func GeneratePutBucketEncryptionInput(name string, config *v1beta1.ServerSideEnc
 
 // GenerateLocalBucketEncryption creates the local ServerSideEncryptionConfiguration from the S3 Client request
 func GenerateLocalBucketEncryption(config *types.ServerSideEncryptionConfiguration) []v1beta1.ServerSideEncryptionRule {
-	rules := make([]v1beta1.ServerSideEncryptionRule, len(config.Rules))
-	for i, rule := range config.Rules {
-		rules[i] = v1beta1.ServerSideEncryptionRule{
-			ApplyServerSideEncryptionByDefault: v1beta1.ServerSideEncryptionByDefault{
-				KMSMasterKeyID: rule.ApplyServerSideEncryptionByDefault.KMSMasterKeyID,
-				SSEAlgorithm:   string(rule.ApplyServerSideEncryptionByDefault.SSEAlgorithm),
-			},
+	rules := make([]v1beta1.ServerSideEncryptionRule, 0, len(config.Rules))
+	for _, rule := range config.Rules {
+		if rule.ApplyServerSideEncryptionByDefault == nil {
+			continue
 		}
+		local := v1beta1.ServerSideEncryptionRule{}
+		def := &local.ApplyServerSideEncryptionByDefault
+		awsclient.LateInitializeFields(
+			awsclient.LateInitializeStringPtrField(&def.KMSMasterKeyID, rule.ApplyServerSideEncryptionByDefault.KMSMasterKeyID),
+			awsclient.LateInitializeStringField(&def.SSEAlgorithm, awsclient.String(string(rule.ApplyServerSideEncryptionByDefault.SSEAlgorithm))),
+			awsclient.LateInitializeBoolPtrField(&local.BucketKeyEnabled, awsclient.Bool(rule.BucketKeyEnabled)),
+		)
+		rules = append(rules, local)
 	}
 	return rules
 }
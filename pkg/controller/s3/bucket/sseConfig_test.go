@@ -18,13 +18,25 @@ package bucket
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	awsv1 "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/kms"
 	"github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/document"
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/crossplane/provider-aws/apis/s3/v1beta1"
 	awsclient "github.com/crossplane/provider-aws/pkg/clients"
@@ -34,12 +46,14 @@ import (
 )
 
 const (
-	keyID   = "test-key-id"
-	sseAlgo = "AES256"
+	keyID        = "1234abcd-12ab-34cd-56ef-1234567890ab"
+	defaultKeyID = "alias/test-default-key"
+	sseAlgo      = "AES256"
 )
 
 var (
 	_ SubresourceClient = &SSEConfigurationClient{}
+	_ DryRunClient      = &SSEConfigurationClient{}
 )
 
 func generateSSEConfig() *v1beta1.ServerSideEncryptionConfiguration {
@@ -55,6 +69,20 @@ func generateSSEConfig() *v1beta1.ServerSideEncryptionConfiguration {
 	}
 }
 
+// generateAES256SSEConfig returns a valid AES256 configuration. Unlike
+// generateSSEConfig it does not set a KMS key, which CreateOrUpdate rejects.
+func generateAES256SSEConfig() *v1beta1.ServerSideEncryptionConfiguration {
+	return &v1beta1.ServerSideEncryptionConfiguration{
+		Rules: []v1beta1.ServerSideEncryptionRule{
+			{
+				ApplyServerSideEncryptionByDefault: v1beta1.ServerSideEncryptionByDefault{
+					SSEAlgorithm: sseAlgo,
+				},
+			},
+		},
+	}
+}
+
 func generateAWSSSE() *s3types.ServerSideEncryptionConfiguration {
 	return &s3types.ServerSideEncryptionConfiguration{
 		Rules: []s3types.ServerSideEncryptionRule{
@@ -68,6 +96,68 @@ func generateAWSSSE() *s3types.ServerSideEncryptionConfiguration {
 	}
 }
 
+func generateKMSSSEConfig() *v1beta1.ServerSideEncryptionConfiguration {
+	return &v1beta1.ServerSideEncryptionConfiguration{
+		Rules: []v1beta1.ServerSideEncryptionRule{
+			{
+				ApplyServerSideEncryptionByDefault: v1beta1.ServerSideEncryptionByDefault{
+					SSEAlgorithm: string(s3types.ServerSideEncryptionAwsKms),
+				},
+			},
+		},
+	}
+}
+
+func generateAWSKMSSSE(key string) *s3types.ServerSideEncryptionConfiguration {
+	return &s3types.ServerSideEncryptionConfiguration{
+		Rules: []s3types.ServerSideEncryptionRule{
+			{
+				ApplyServerSideEncryptionByDefault: &s3types.ServerSideEncryptionByDefault{
+					KMSMasterKeyID: awsclient.String(key),
+					SSEAlgorithm:   s3types.ServerSideEncryptionAwsKms,
+				},
+			},
+		},
+	}
+}
+
+// multiRegionKeyARN returns the ARN of a multi-Region KMS key in the given
+// region.
+func multiRegionKeyARN(region string) string {
+	return "arn:aws:kms:" + region + ":111122223333:key/mrk-1234abcd12ab34cd56ef1234567890ab"
+}
+
+func generateMultiRegionKMSSSEConfig(region string) *v1beta1.ServerSideEncryptionConfiguration {
+	config := generateKMSSSEConfig()
+	config.Rules[0].ApplyServerSideEncryptionByDefault.KMSMasterKeyID = awsclient.String(multiRegionKeyARN(region))
+	return config
+}
+
+func generateKMSSSEConfigWithSecretRef() *v1beta1.ServerSideEncryptionConfiguration {
+	config := generateKMSSSEConfig()
+	config.Rules[0].ApplyServerSideEncryptionByDefault.KMSMasterKeyIDSecretRef = &xpv1.SecretKeySelector{
+		SecretReference: xpv1.SecretReference{Name: "kms", Namespace: "crossplane-system"},
+		Key:             "keyId",
+	}
+	return config
+}
+
+// kmsKeySecret returns a kube client whose Get returns a secret that stores
+// the given KMS key ID.
+func kmsKeySecret(id string) *test.MockClient {
+	return &test.MockClient{
+		MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+			obj.(*corev1.Secret).Data = map[string][]byte{"keyId": []byte(id)}
+			return nil
+		},
+	}
+}
+
+// sseNotFound is the GetBucketEncryption of a bucket without encryption.
+func sseNotFound(ctx context.Context, input *s3.GetBucketEncryptionInput, opts []func(*s3.Options)) (*s3.GetBucketEncryptionOutput, error) {
+	return nil, &smithy.GenericAPIError{Code: clients3.SSENotFoundErrCode}
+}
+
 func TestSSEObserve(t *testing.T) {
 	type args struct {
 		cl *SSEConfigurationClient
@@ -90,13 +180,31 @@ func TestSSEObserve(t *testing.T) {
 					MockGetBucketEncryption: func(ctx context.Context, input *s3.GetBucketEncryptionInput, opts []func(*s3.Options)) (*s3.GetBucketEncryptionOutput, error) {
 						return nil, errBoom
 					},
-				}),
+				}, nil, nil, nil, nil),
 			},
 			want: want{
 				status: NeedsUpdate,
 				err:    awsclient.Wrap(errBoom, sseGetFailed),
 			},
 		},
+		"UpdateNeededRuleWithoutDefault": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithSSEConfig(generateSSEConfig())),
+				cl: NewSSEConfigurationClient(fake.MockBucketClient{
+					MockGetBucketEncryption: func(ctx context.Context, input *s3.GetBucketEncryptionInput, opts []func(*s3.Options)) (*s3.GetBucketEncryptionOutput, error) {
+						return &s3.GetBucketEncryptionOutput{
+							ServerSideEncryptionConfiguration: &s3types.ServerSideEncryptionConfiguration{
+								Rules: []s3types.ServerSideEncryptionRule{{}},
+							},
+						}, nil
+					},
+				}, nil, nil, nil, nil),
+			},
+			want: want{
+				status: NeedsUpdate,
+				err:    nil,
+			},
+		},
 		"UpdateNeeded": {
 			args: args{
 				b: s3Testing.Bucket(s3Testing.WithSSEConfig(generateSSEConfig())),
@@ -104,13 +212,39 @@ func TestSSEObserve(t *testing.T) {
 					MockGetBucketEncryption: func(ctx context.Context, input *s3.GetBucketEncryptionInput, opts []func(*s3.Options)) (*s3.GetBucketEncryptionOutput, error) {
 						return &s3.GetBucketEncryptionOutput{ServerSideEncryptionConfiguration: nil}, nil
 					},
-				}),
+				}, nil, nil, nil, nil),
 			},
 			want: want{
 				status: NeedsUpdate,
 				err:    nil,
 			},
 		},
+		"NoUpdateMultiRegionKeyReplicaRegion": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithSSEConfig(generateMultiRegionKMSSSEConfig("us-east-1"))),
+				cl: NewSSEConfigurationClient(fake.MockBucketClient{
+					MockGetBucketEncryption: func(ctx context.Context, input *s3.GetBucketEncryptionInput, opts []func(*s3.Options)) (*s3.GetBucketEncryptionOutput, error) {
+						return &s3.GetBucketEncryptionOutput{ServerSideEncryptionConfiguration: generateAWSKMSSSE(multiRegionKeyARN("eu-west-1"))}, nil
+					},
+				}, nil, nil, nil, nil),
+			},
+			want: want{
+				status: Updated,
+			},
+		},
+		"UpdateNeededMultiRegionKeyChanged": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithSSEConfig(generateMultiRegionKMSSSEConfig("us-east-1"))),
+				cl: NewSSEConfigurationClient(fake.MockBucketClient{
+					MockGetBucketEncryption: func(ctx context.Context, input *s3.GetBucketEncryptionInput, opts []func(*s3.Options)) (*s3.GetBucketEncryptionOutput, error) {
+						return &s3.GetBucketEncryptionOutput{ServerSideEncryptionConfiguration: generateAWSKMSSSE("arn:aws:kms:eu-west-1:111122223333:key/mrk-0000abcd12ab34cd56ef1234567890ab")}, nil
+					},
+				}, nil, nil, nil, nil),
+			},
+			want: want{
+				status: NeedsUpdate,
+			},
+		},
 		"NeedsDelete": {
 			args: args{
 				b: s3Testing.Bucket(s3Testing.WithSSEConfig(nil)),
@@ -118,7 +252,7 @@ func TestSSEObserve(t *testing.T) {
 					MockGetBucketEncryption: func(ctx context.Context, input *s3.GetBucketEncryptionInput, opts []func(*s3.Options)) (*s3.GetBucketEncryptionOutput, error) {
 						return &s3.GetBucketEncryptionOutput{ServerSideEncryptionConfiguration: generateAWSSSE()}, nil
 					},
-				}),
+				}, nil, nil, nil, nil),
 			},
 			want: want{
 				status: NeedsDeletion,
@@ -132,7 +266,7 @@ func TestSSEObserve(t *testing.T) {
 					MockGetBucketEncryption: func(ctx context.Context, input *s3.GetBucketEncryptionInput, opts []func(*s3.Options)) (*s3.GetBucketEncryptionOutput, error) {
 						return nil, &smithy.GenericAPIError{Code: clients3.SSENotFoundErrCode}
 					},
-				}),
+				}, nil, nil, nil, nil),
 			},
 			want: want{
 				status: Updated,
@@ -146,7 +280,7 @@ func TestSSEObserve(t *testing.T) {
 					MockGetBucketEncryption: func(ctx context.Context, input *s3.GetBucketEncryptionInput, opts []func(*s3.Options)) (*s3.GetBucketEncryptionOutput, error) {
 						return &s3.GetBucketEncryptionOutput{ServerSideEncryptionConfiguration: nil}, nil
 					},
-				}),
+				}, nil, nil, nil, nil),
 			},
 			want: want{
 				status: Updated,
@@ -160,13 +294,157 @@ func TestSSEObserve(t *testing.T) {
 					MockGetBucketEncryption: func(ctx context.Context, input *s3.GetBucketEncryptionInput, opts []func(*s3.Options)) (*s3.GetBucketEncryptionOutput, error) {
 						return &s3.GetBucketEncryptionOutput{ServerSideEncryptionConfiguration: generateAWSSSE()}, nil
 					},
-				}),
+				}, nil, nil, nil, nil),
+			},
+			want: want{
+				status: Updated,
+				err:    nil,
+			},
+		},
+		"NoUpdateDuplicateRules": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithSSEConfig(generateSSEConfig())),
+				cl: NewSSEConfigurationClient(fake.MockBucketClient{
+					MockGetBucketEncryption: func(ctx context.Context, input *s3.GetBucketEncryptionInput, opts []func(*s3.Options)) (*s3.GetBucketEncryptionOutput, error) {
+						c := generateAWSSSE()
+						c.Rules = append(c.Rules, generateAWSSSE().Rules...)
+						return &s3.GetBucketEncryptionOutput{ServerSideEncryptionConfiguration: c}, nil
+					},
+				}, nil, nil, nil, nil),
+			},
+			want: want{
+				status: Updated,
+				err:    nil,
+			},
+		},
+		"UpdateNeededDifferentExtraRule": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithSSEConfig(generateSSEConfig())),
+				cl: NewSSEConfigurationClient(fake.MockBucketClient{
+					MockGetBucketEncryption: func(ctx context.Context, input *s3.GetBucketEncryptionInput, opts []func(*s3.Options)) (*s3.GetBucketEncryptionOutput, error) {
+						c := generateAWSSSE()
+						extra := generateAWSSSE().Rules[0]
+						extra.ApplyServerSideEncryptionByDefault.KMSMasterKeyID = nil
+						c.Rules = append(c.Rules, extra)
+						return &s3.GetBucketEncryptionOutput{ServerSideEncryptionConfiguration: c}, nil
+					},
+				}, nil, nil, nil, nil),
+			},
+			want: want{
+				status: NeedsUpdate,
+				err:    nil,
+			},
+		},
+		"NoUpdateAlgorithmSynonym": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithSSEConfig(func() *v1beta1.ServerSideEncryptionConfiguration {
+					c := generateSSEConfig()
+					c.Rules[0].ApplyServerSideEncryptionByDefault.SSEAlgorithm = "SSE-S3"
+					return c
+				}())),
+				cl: NewSSEConfigurationClient(fake.MockBucketClient{
+					MockGetBucketEncryption: func(ctx context.Context, input *s3.GetBucketEncryptionInput, opts []func(*s3.Options)) (*s3.GetBucketEncryptionOutput, error) {
+						return &s3.GetBucketEncryptionOutput{ServerSideEncryptionConfiguration: generateAWSSSE()}, nil
+					},
+				}, nil, nil, nil, nil),
+			},
+			want: want{
+				status: Updated,
+				err:    nil,
+			},
+		},
+		"NoUpdateAlgorithmAliasKMS": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithSSEConfig(func() *v1beta1.ServerSideEncryptionConfiguration {
+					c := generateKMSSSEConfig()
+					c.Rules[0].ApplyServerSideEncryptionByDefault.KMSMasterKeyID = awsclient.String(keyID)
+					c.Rules[0].ApplyServerSideEncryptionByDefault.SSEAlgorithm = "SSE-KMS"
+					return c
+				}())),
+				cl: NewSSEConfigurationClient(fake.MockBucketClient{
+					MockGetBucketEncryption: func(ctx context.Context, input *s3.GetBucketEncryptionInput, opts []func(*s3.Options)) (*s3.GetBucketEncryptionOutput, error) {
+						return &s3.GetBucketEncryptionOutput{ServerSideEncryptionConfiguration: generateAWSKMSSSE(keyID)}, nil
+					},
+				}, nil, nil, nil, nil),
+			},
+			want: want{
+				status: Updated,
+				err:    nil,
+			},
+		},
+		"NoUpdateAlgorithmCase": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithSSEConfig(func() *v1beta1.ServerSideEncryptionConfiguration {
+					c := generateKMSSSEConfig()
+					c.Rules[0].ApplyServerSideEncryptionByDefault.KMSMasterKeyID = awsclient.String(keyID)
+					c.Rules[0].ApplyServerSideEncryptionByDefault.SSEAlgorithm = "AWS:KMS"
+					return c
+				}())),
+				cl: NewSSEConfigurationClient(fake.MockBucketClient{
+					MockGetBucketEncryption: func(ctx context.Context, input *s3.GetBucketEncryptionInput, opts []func(*s3.Options)) (*s3.GetBucketEncryptionOutput, error) {
+						return &s3.GetBucketEncryptionOutput{ServerSideEncryptionConfiguration: generateAWSKMSSSE(keyID)}, nil
+					},
+				}, nil, nil, nil, nil),
+			},
+			want: want{
+				status: Updated,
+				err:    nil,
+			},
+		},
+		"NoUpdateDefaultKMSKey": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithSSEConfig(generateKMSSSEConfig())),
+				cl: NewSSEConfigurationClient(fake.MockBucketClient{
+					MockGetBucketEncryption: func(ctx context.Context, input *s3.GetBucketEncryptionInput, opts []func(*s3.Options)) (*s3.GetBucketEncryptionOutput, error) {
+						return &s3.GetBucketEncryptionOutput{ServerSideEncryptionConfiguration: generateAWSKMSSSE(defaultKeyID)}, nil
+					},
+				}, awsclient.String(defaultKeyID), nil, nil, nil),
+			},
+			want: want{
+				status: Updated,
+				err:    nil,
+			},
+		},
+		"NoUpdateKMSKeyFromSecret": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithSSEConfig(generateKMSSSEConfigWithSecretRef())),
+				cl: NewSSEConfigurationClient(fake.MockBucketClient{
+					MockGetBucketEncryption: func(ctx context.Context, input *s3.GetBucketEncryptionInput, opts []func(*s3.Options)) (*s3.GetBucketEncryptionOutput, error) {
+						return &s3.GetBucketEncryptionOutput{ServerSideEncryptionConfiguration: generateAWSKMSSSE(keyID)}, nil
+					},
+				}, awsclient.String(defaultKeyID), kmsKeySecret(keyID), nil, nil),
 			},
 			want: want{
 				status: Updated,
 				err:    nil,
 			},
 		},
+		"KMSKeySecretError": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithSSEConfig(generateKMSSSEConfigWithSecretRef())),
+				cl: NewSSEConfigurationClient(fake.MockBucketClient{}, nil, &test.MockClient{
+					MockGet: test.NewMockGetFn(errBoom),
+				}, nil, nil),
+			},
+			want: want{
+				status: NeedsUpdate,
+				err:    errors.Wrap(errBoom, sseKeySecretGetFailed),
+			},
+		},
+		"UpdateNeededDefaultKMSKey": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithSSEConfig(generateKMSSSEConfig())),
+				cl: NewSSEConfigurationClient(fake.MockBucketClient{
+					MockGetBucketEncryption: func(ctx context.Context, input *s3.GetBucketEncryptionInput, opts []func(*s3.Options)) (*s3.GetBucketEncryptionOutput, error) {
+						return &s3.GetBucketEncryptionOutput{ServerSideEncryptionConfiguration: generateAWSKMSSSE(keyID)}, nil
+					},
+				}, awsclient.String(defaultKeyID), nil, nil, nil),
+			},
+			want: want{
+				status: NeedsUpdate,
+				err:    nil,
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -190,6 +468,7 @@ func TestSSECreateOrUpdate(t *testing.T) {
 
 	type want struct {
 		err error
+		cr  *v1beta1.Bucket
 	}
 
 	cases := map[string]struct {
@@ -198,12 +477,13 @@ func TestSSECreateOrUpdate(t *testing.T) {
 	}{
 		"Error": {
 			args: args{
-				b: s3Testing.Bucket(s3Testing.WithSSEConfig(generateSSEConfig())),
+				b: s3Testing.Bucket(s3Testing.WithSSEConfig(generateAES256SSEConfig())),
 				cl: NewSSEConfigurationClient(fake.MockBucketClient{
+					MockGetBucketEncryption: sseNotFound,
 					MockPutBucketEncryption: func(ctx context.Context, input *s3.PutBucketEncryptionInput, opts []func(*s3.Options)) (*s3.PutBucketEncryptionOutput, error) {
 						return nil, errBoom
 					},
-				}),
+				}, nil, nil, nil, nil),
 			},
 			want: want{
 				err: awsclient.Wrap(errBoom, ssePutFailed),
@@ -211,12 +491,13 @@ func TestSSECreateOrUpdate(t *testing.T) {
 		},
 		"InvalidConfig": {
 			args: args{
-				b: s3Testing.Bucket(s3Testing.WithSSEConfig(generateSSEConfig())),
+				b: s3Testing.Bucket(s3Testing.WithSSEConfig(generateAES256SSEConfig())),
 				cl: NewSSEConfigurationClient(fake.MockBucketClient{
+					MockGetBucketEncryption: sseNotFound,
 					MockPutBucketEncryption: func(ctx context.Context, input *s3.PutBucketEncryptionInput, opts []func(*s3.Options)) (*s3.PutBucketEncryptionOutput, error) {
 						return &s3.PutBucketEncryptionOutput{}, nil
 					},
-				}),
+				}, nil, nil, nil, nil),
 			},
 			want: want{
 				err: nil,
@@ -224,15 +505,157 @@ func TestSSECreateOrUpdate(t *testing.T) {
 		},
 		"SuccessfulCreate": {
 			args: args{
-				b: s3Testing.Bucket(s3Testing.WithSSEConfig(generateSSEConfig())),
+				b: s3Testing.Bucket(s3Testing.WithSSEConfig(generateAES256SSEConfig())),
+				cl: NewSSEConfigurationClient(fake.MockBucketClient{
+					MockGetBucketEncryption: sseNotFound,
+					MockPutBucketEncryption: func(ctx context.Context, input *s3.PutBucketEncryptionInput, opts []func(*s3.Options)) (*s3.PutBucketEncryptionOutput, error) {
+						return &s3.PutBucketEncryptionOutput{}, nil
+					},
+				}, nil, nil, nil, nil),
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"InvalidKMSKeyID": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithSSEConfig(&v1beta1.ServerSideEncryptionConfiguration{
+					Rules: []v1beta1.ServerSideEncryptionRule{
+						{
+							ApplyServerSideEncryptionByDefault: v1beta1.ServerSideEncryptionByDefault{
+								KMSMasterKeyID: awsclient.String("not a key"),
+								SSEAlgorithm:   string(s3types.ServerSideEncryptionAwsKms),
+							},
+						},
+					},
+				})),
+				cl: NewSSEConfigurationClient(fake.MockBucketClient{}, nil, nil, nil, nil),
+			},
+			want: want{
+				err: clients3.ValidateKMSKeyID("not a key"),
+			},
+		},
+		"BucketKeyWithAES256": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithSSEConfig(&v1beta1.ServerSideEncryptionConfiguration{
+					Rules: []v1beta1.ServerSideEncryptionRule{
+						{
+							ApplyServerSideEncryptionByDefault: v1beta1.ServerSideEncryptionByDefault{
+								SSEAlgorithm: string(s3types.ServerSideEncryptionAes256),
+							},
+							BucketKeyEnabled: awsclient.Bool(true),
+						},
+					},
+				})),
+				cl: NewSSEConfigurationClient(fake.MockBucketClient{}, nil, nil, nil, nil),
+			},
+			want: want{
+				err: errors.Errorf(sseBucketKeyWithoutKMS, 0),
+			},
+		},
+		"BucketKeyWithKMS": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithSSEConfig(&v1beta1.ServerSideEncryptionConfiguration{
+					Rules: []v1beta1.ServerSideEncryptionRule{
+						{
+							ApplyServerSideEncryptionByDefault: v1beta1.ServerSideEncryptionByDefault{
+								KMSMasterKeyID: awsclient.String(keyID),
+								SSEAlgorithm:   string(s3types.ServerSideEncryptionAwsKms),
+							},
+							BucketKeyEnabled: awsclient.Bool(true),
+						},
+					},
+				})),
+				cl: NewSSEConfigurationClient(fake.MockBucketClient{
+					MockGetBucketEncryption: sseNotFound,
+					MockPutBucketEncryption: func(ctx context.Context, input *s3.PutBucketEncryptionInput, opts []func(*s3.Options)) (*s3.PutBucketEncryptionOutput, error) {
+						return &s3.PutBucketEncryptionOutput{}, nil
+					},
+				}, nil, nil, nil, nil),
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"SuccessfulCreateKMSKeyFromSecret": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithSSEConfig(generateKMSSSEConfigWithSecretRef())),
+				cl: NewSSEConfigurationClient(fake.MockBucketClient{
+					MockGetBucketEncryption: sseNotFound,
+					MockPutBucketEncryption: func(ctx context.Context, input *s3.PutBucketEncryptionInput, opts []func(*s3.Options)) (*s3.PutBucketEncryptionOutput, error) {
+						if diff := cmp.Diff(generateAWSKMSSSE(keyID), input.ServerSideEncryptionConfiguration, cmpopts.IgnoreTypes(document.NoSerde{})); diff != "" {
+							t.Errorf("r: -want, +got:\n%s", diff)
+						}
+						return &s3.PutBucketEncryptionOutput{}, nil
+					},
+				}, awsclient.String(defaultKeyID), kmsKeySecret(keyID), nil, nil),
+			},
+			want: want{
+				err: nil,
+				cr:  s3Testing.Bucket(s3Testing.WithSSEConfig(generateKMSSSEConfigWithSecretRef())),
+			},
+		},
+		"KMSKeyWithoutAlgorithm": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithSSEConfig(&v1beta1.ServerSideEncryptionConfiguration{
+					Rules: []v1beta1.ServerSideEncryptionRule{
+						{
+							ApplyServerSideEncryptionByDefault: v1beta1.ServerSideEncryptionByDefault{
+								KMSMasterKeyID: awsclient.String(keyID),
+							},
+						},
+					},
+				})),
+				cl: NewSSEConfigurationClient(fake.MockBucketClient{
+					MockGetBucketEncryption: sseNotFound,
+					MockPutBucketEncryption: func(ctx context.Context, input *s3.PutBucketEncryptionInput, opts []func(*s3.Options)) (*s3.PutBucketEncryptionOutput, error) {
+						if diff := cmp.Diff(generateAWSKMSSSE(keyID), input.ServerSideEncryptionConfiguration, cmpopts.IgnoreTypes(document.NoSerde{})); diff != "" {
+							t.Errorf("r: -want, +got:\n%s", diff)
+						}
+						return &s3.PutBucketEncryptionOutput{}, nil
+					},
+				}, nil, nil, nil, nil),
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"MissingAlgorithm": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithSSEConfig(&v1beta1.ServerSideEncryptionConfiguration{
+					Rules: []v1beta1.ServerSideEncryptionRule{{}},
+				})),
+				cl: NewSSEConfigurationClient(fake.MockBucketClient{}, nil, nil, nil, nil),
+			},
+			want: want{
+				err: errors.Errorf(sseAlgorithmMissing, 0),
+			},
+		},
+		"EmptyKMSKeySecret": {
+			args: args{
+				b:  s3Testing.Bucket(s3Testing.WithSSEConfig(generateKMSSSEConfigWithSecretRef())),
+				cl: NewSSEConfigurationClient(fake.MockBucketClient{}, nil, kmsKeySecret(""), nil, nil),
+			},
+			want: want{
+				err: errors.Errorf(sseKeySecretKeyEmpty, "keyId", "crossplane-system", "kms"),
+			},
+		},
+		"SuccessfulCreateDefaultKMSKey": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithSSEConfig(generateKMSSSEConfig())),
 				cl: NewSSEConfigurationClient(fake.MockBucketClient{
+					MockGetBucketEncryption: sseNotFound,
 					MockPutBucketEncryption: func(ctx context.Context, input *s3.PutBucketEncryptionInput, opts []func(*s3.Options)) (*s3.PutBucketEncryptionOutput, error) {
+						if diff := cmp.Diff(generateAWSKMSSSE(defaultKeyID), input.ServerSideEncryptionConfiguration, cmpopts.IgnoreTypes(document.NoSerde{})); diff != "" {
+							t.Errorf("r: -want, +got:\n%s", diff)
+						}
 						return &s3.PutBucketEncryptionOutput{}, nil
 					},
-				}),
+				}, awsclient.String(defaultKeyID), nil, nil, nil),
 			},
 			want: want{
 				err: nil,
+				cr:  s3Testing.Bucket(s3Testing.WithSSEConfig(generateKMSSSEConfig())),
 			},
 		},
 	}
@@ -243,6 +666,11 @@ func TestSSECreateOrUpdate(t *testing.T) {
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
 				t.Errorf("r: -want, +got:\n%s", diff)
 			}
+			if tc.want.cr != nil {
+				if diff := cmp.Diff(tc.want.cr, tc.args.b, test.EquateConditions()); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+			}
 		})
 	}
 }
@@ -268,7 +696,7 @@ func TestSSEDelete(t *testing.T) {
 					MockDeleteBucketEncryption: func(ctx context.Context, input *s3.DeleteBucketEncryptionInput, opts []func(*s3.Options)) (*s3.DeleteBucketEncryptionOutput, error) {
 						return nil, errBoom
 					},
-				}),
+				}, nil, nil, nil, nil),
 			},
 			want: want{
 				err: awsclient.Wrap(errBoom, sseDeleteFailed),
@@ -281,7 +709,7 @@ func TestSSEDelete(t *testing.T) {
 					MockDeleteBucketEncryption: func(ctx context.Context, input *s3.DeleteBucketEncryptionInput, opts []func(*s3.Options)) (*s3.DeleteBucketEncryptionOutput, error) {
 						return &s3.DeleteBucketEncryptionOutput{}, nil
 					},
-				}),
+				}, nil, nil, nil, nil),
 			},
 			want: want{
 				err: nil,
@@ -321,7 +749,7 @@ func TestSSELateInit(t *testing.T) {
 					MockGetBucketEncryption: func(ctx context.Context, input *s3.GetBucketEncryptionInput, opts []func(*s3.Options)) (*s3.GetBucketEncryptionOutput, error) {
 						return &s3.GetBucketEncryptionOutput{}, errBoom
 					},
-				}),
+				}, nil, nil, nil, nil),
 			},
 			want: want{
 				err: awsclient.Wrap(errBoom, sseGetFailed),
@@ -335,57 +763,133 @@ func TestSSELateInit(t *testing.T) {
 					MockGetBucketEncryption: func(ctx context.Context, input *s3.GetBucketEncryptionInput, opts []func(*s3.Options)) (*s3.GetBucketEncryptionOutput, error) {
 						return &s3.GetBucketEncryptionOutput{}, &smithy.GenericAPIError{Code: clients3.SSENotFoundErrCode}
 					},
-				}),
+				}, nil, nil, nil, nil),
 			},
 			want: want{
 				err: nil,
 				cr:  s3Testing.Bucket(),
 			},
 		},
-		"NoLateInitNil": {
+		"ErrorSSEConfigurationNotFoundNilOutput": {
 			args: args{
 				b: s3Testing.Bucket(),
 				cl: NewSSEConfigurationClient(fake.MockBucketClient{
 					MockGetBucketEncryption: func(ctx context.Context, input *s3.GetBucketEncryptionInput, opts []func(*s3.Options)) (*s3.GetBucketEncryptionOutput, error) {
-						return &s3.GetBucketEncryptionOutput{ServerSideEncryptionConfiguration: nil}, nil
+						return nil, &smithy.GenericAPIError{Code: clients3.SSENotFoundErrCode}
 					},
-				}),
+				}, nil, nil, nil, nil),
 			},
 			want: want{
 				err: nil,
 				cr:  s3Testing.Bucket(),
 			},
 		},
-		"NoLateInitEmpty": {
+		"NoLateInitNilOutput": {
 			args: args{
 				b: s3Testing.Bucket(),
 				cl: NewSSEConfigurationClient(fake.MockBucketClient{
 					MockGetBucketEncryption: func(ctx context.Context, input *s3.GetBucketEncryptionInput, opts []func(*s3.Options)) (*s3.GetBucketEncryptionOutput, error) {
-						return &s3.GetBucketEncryptionOutput{
-							ServerSideEncryptionConfiguration: &s3types.ServerSideEncryptionConfiguration{
-								Rules: nil,
-							},
-						}, nil
+						return nil, nil
 					},
-				}),
+				}, nil, nil, nil, nil),
 			},
 			want: want{
 				err: nil,
 				cr:  s3Testing.Bucket(),
 			},
 		},
-		"SuccessfulLateInit": {
+		"NoLateInitRuleWithoutDefault": {
 			args: args{
-				b: s3Testing.Bucket(s3Testing.WithSSEConfig(nil)),
+				b: s3Testing.Bucket(),
 				cl: NewSSEConfigurationClient(fake.MockBucketClient{
 					MockGetBucketEncryption: func(ctx context.Context, input *s3.GetBucketEncryptionInput, opts []func(*s3.Options)) (*s3.GetBucketEncryptionOutput, error) {
-						return &s3.GetBucketEncryptionOutput{ServerSideEncryptionConfiguration: generateAWSSSE()}, nil
+						return &s3.GetBucketEncryptionOutput{
+							ServerSideEncryptionConfiguration: &s3types.ServerSideEncryptionConfiguration{
+								Rules: []s3types.ServerSideEncryptionRule{{}},
+							},
+						}, nil
 					},
-				}),
+				}, nil, nil, nil, nil),
 			},
 			want: want{
 				err: nil,
-				cr:  s3Testing.Bucket(s3Testing.WithSSEConfig(generateSSEConfig())),
+				cr:  s3Testing.Bucket(),
+			},
+		},
+		"LateInitSkipsRuleWithoutDefault": {
+			args: args{
+				b: s3Testing.Bucket(),
+				cl: NewSSEConfigurationClient(fake.MockBucketClient{
+					MockGetBucketEncryption: func(ctx context.Context, input *s3.GetBucketEncryptionInput, opts []func(*s3.Options)) (*s3.GetBucketEncryptionOutput, error) {
+						c := generateAWSSSE()
+						c.Rules = append([]s3types.ServerSideEncryptionRule{{}}, c.Rules...)
+						return &s3.GetBucketEncryptionOutput{ServerSideEncryptionConfiguration: c}, nil
+					},
+				}, nil, nil, nil, nil),
+			},
+			want: want{
+				err: nil,
+				cr:  s3Testing.Bucket(s3Testing.WithSSEConfig(generateSSEConfig())),
+			},
+		},
+		"LateInitEmptyRules": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithSSEConfig(&v1beta1.ServerSideEncryptionConfiguration{})),
+				cl: NewSSEConfigurationClient(fake.MockBucketClient{
+					MockGetBucketEncryption: func(ctx context.Context, input *s3.GetBucketEncryptionInput, opts []func(*s3.Options)) (*s3.GetBucketEncryptionOutput, error) {
+						return &s3.GetBucketEncryptionOutput{ServerSideEncryptionConfiguration: generateAWSSSE()}, nil
+					},
+				}, nil, nil, nil, nil),
+			},
+			want: want{
+				err: nil,
+				cr:  s3Testing.Bucket(s3Testing.WithSSEConfig(generateSSEConfig())),
+			},
+		},
+		"NoLateInitNil": {
+			args: args{
+				b: s3Testing.Bucket(),
+				cl: NewSSEConfigurationClient(fake.MockBucketClient{
+					MockGetBucketEncryption: func(ctx context.Context, input *s3.GetBucketEncryptionInput, opts []func(*s3.Options)) (*s3.GetBucketEncryptionOutput, error) {
+						return &s3.GetBucketEncryptionOutput{ServerSideEncryptionConfiguration: nil}, nil
+					},
+				}, nil, nil, nil, nil),
+			},
+			want: want{
+				err: nil,
+				cr:  s3Testing.Bucket(),
+			},
+		},
+		"NoLateInitEmpty": {
+			args: args{
+				b: s3Testing.Bucket(),
+				cl: NewSSEConfigurationClient(fake.MockBucketClient{
+					MockGetBucketEncryption: func(ctx context.Context, input *s3.GetBucketEncryptionInput, opts []func(*s3.Options)) (*s3.GetBucketEncryptionOutput, error) {
+						return &s3.GetBucketEncryptionOutput{
+							ServerSideEncryptionConfiguration: &s3types.ServerSideEncryptionConfiguration{
+								Rules: nil,
+							},
+						}, nil
+					},
+				}, nil, nil, nil, nil),
+			},
+			want: want{
+				err: nil,
+				cr:  s3Testing.Bucket(),
+			},
+		},
+		"SuccessfulLateInit": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithSSEConfig(nil)),
+				cl: NewSSEConfigurationClient(fake.MockBucketClient{
+					MockGetBucketEncryption: func(ctx context.Context, input *s3.GetBucketEncryptionInput, opts []func(*s3.Options)) (*s3.GetBucketEncryptionOutput, error) {
+						return &s3.GetBucketEncryptionOutput{ServerSideEncryptionConfiguration: generateAWSSSE()}, nil
+					},
+				}, nil, nil, nil, nil),
+			},
+			want: want{
+				err: nil,
+				cr:  s3Testing.Bucket(s3Testing.WithSSEConfig(generateSSEConfig())),
 			},
 		},
 		"NoOpLateInit": {
@@ -401,7 +905,7 @@ func TestSSELateInit(t *testing.T) {
 							},
 						}, nil
 					},
-				}),
+				}, nil, nil, nil, nil),
 			},
 			want: want{
 				err: nil,
@@ -422,3 +926,700 @@ func TestSSELateInit(t *testing.T) {
 		})
 	}
 }
+
+type eventRecorder struct {
+	events []event.Event
+}
+
+func (r *eventRecorder) Event(_ runtime.Object, e event.Event) {
+	r.events = append(r.events, e)
+}
+
+func (r *eventRecorder) WithAnnotations(_ ...string) event.Recorder { return r }
+
+func TestSSECreateOrUpdateKMSFallback(t *testing.T) {
+	errKMS := &smithy.GenericAPIError{Code: "KMS.DisabledException", Message: "key is disabled"}
+
+	type want struct {
+		err       error
+		puts      []*s3types.ServerSideEncryptionConfiguration
+		events    []event.Event
+		condition xpv1.Condition
+	}
+
+	cases := map[string]struct {
+		fallback *bool
+		want
+	}{
+		"FallbackEnabled": {
+			fallback: awsclient.Bool(true),
+			want: want{
+				puts: []*s3types.ServerSideEncryptionConfiguration{generateAWSKMSSSE(keyID), {
+					Rules: []s3types.ServerSideEncryptionRule{{
+						ApplyServerSideEncryptionByDefault: &s3types.ServerSideEncryptionByDefault{SSEAlgorithm: s3types.ServerSideEncryptionAes256},
+					}},
+				}},
+				events:    []event.Event{event.Warning(reasonKMSFallback, errors.Errorf(sseKMSFallback, errKMS))},
+				condition: FallbackToAES256([]string{keyID}),
+			},
+		},
+		"FallbackDisabled": {
+			want: want{
+				err:       awsclient.Wrap(errKMS, ssePutFailed),
+				puts:      []*s3types.ServerSideEncryptionConfiguration{generateAWSKMSSSE(keyID)},
+				condition: xpv1.Condition{Type: TypeEncryptionFallback, Status: corev1.ConditionUnknown},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var puts []*s3types.ServerSideEncryptionConfiguration
+			rec := &eventRecorder{}
+			cl := NewSSEConfigurationClient(fake.MockBucketClient{
+				MockGetBucketEncryption: sseNotFound,
+				MockPutBucketEncryption: func(ctx context.Context, input *s3.PutBucketEncryptionInput, opts []func(*s3.Options)) (*s3.PutBucketEncryptionOutput, error) {
+					puts = append(puts, input.ServerSideEncryptionConfiguration)
+					if input.ServerSideEncryptionConfiguration.Rules[0].ApplyServerSideEncryptionByDefault.SSEAlgorithm == s3types.ServerSideEncryptionAwsKms {
+						return nil, errKMS
+					}
+					return &s3.PutBucketEncryptionOutput{}, nil
+				},
+			}, nil, nil, rec, nil)
+			config := generateKMSSSEConfig()
+			config.Rules[0].ApplyServerSideEncryptionByDefault.KMSMasterKeyID = awsclient.String(keyID)
+			config.FallbackToAES256OnKMSError = tc.fallback
+			b := s3Testing.Bucket(s3Testing.WithSSEConfig(config))
+
+			err := cl.CreateOrUpdate(context.Background(), b)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.condition, b.Status.GetCondition(TypeEncryptionFallback), test.EquateConditions()); diff != "" {
+				t.Errorf("condition: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.puts, puts, cmpopts.IgnoreTypes(document.NoSerde{})); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.events, rec.events, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSSEObserveKMSFallback(t *testing.T) {
+	aes256 := func(ctx context.Context, input *s3.GetBucketEncryptionInput, opts []func(*s3.Options)) (*s3.GetBucketEncryptionOutput, error) {
+		return &s3.GetBucketEncryptionOutput{ServerSideEncryptionConfiguration: &s3types.ServerSideEncryptionConfiguration{
+			Rules: []s3types.ServerSideEncryptionRule{{
+				ApplyServerSideEncryptionByDefault: &s3types.ServerSideEncryptionByDefault{SSEAlgorithm: s3types.ServerSideEncryptionAes256},
+			}},
+		}}, nil
+	}
+
+	type want struct {
+		status    ResourceStatus
+		condition xpv1.Condition
+	}
+
+	cases := map[string]struct {
+		key       string
+		fallback  *bool
+		condition *xpv1.Condition
+		want
+	}{
+		"FallbackActive": {
+			key:       keyID,
+			fallback:  awsclient.Bool(true),
+			condition: func() *xpv1.Condition { c := FallbackToAES256([]string{keyID}); return &c }(),
+			want: want{
+				status:    Updated,
+				condition: FallbackToAES256([]string{keyID}),
+			},
+		},
+		"KeyChanged": {
+			key:       "other-key",
+			fallback:  awsclient.Bool(true),
+			condition: func() *xpv1.Condition { c := FallbackToAES256([]string{keyID}); return &c }(),
+			want: want{
+				status:    NeedsUpdate,
+				condition: ConfiguredEncryption(),
+			},
+		},
+		"FallbackDisabled": {
+			key:       keyID,
+			condition: func() *xpv1.Condition { c := FallbackToAES256([]string{keyID}); return &c }(),
+			want: want{
+				status:    NeedsUpdate,
+				condition: ConfiguredEncryption(),
+			},
+		},
+		"NoFallback": {
+			key:      keyID,
+			fallback: awsclient.Bool(true),
+			want: want{
+				status:    NeedsUpdate,
+				condition: xpv1.Condition{Type: TypeEncryptionFallback, Status: corev1.ConditionUnknown},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			config := generateKMSSSEConfig()
+			config.Rules[0].ApplyServerSideEncryptionByDefault.KMSMasterKeyID = awsclient.String(tc.key)
+			config.FallbackToAES256OnKMSError = tc.fallback
+			b := s3Testing.Bucket(s3Testing.WithSSEConfig(config))
+			if tc.condition != nil {
+				b.Status.SetConditions(*tc.condition)
+			}
+			cl := NewSSEConfigurationClient(fake.MockBucketClient{MockGetBucketEncryption: aes256}, nil, nil, nil, nil)
+
+			status, err := cl.Observe(context.Background(), b)
+			if err != nil {
+				t.Fatalf("Observe(...): %s", err)
+			}
+			if diff := cmp.Diff(tc.want.status, status); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.condition, b.Status.GetCondition(TypeEncryptionFallback), test.EquateConditions()); diff != "" {
+				t.Errorf("condition: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSSECreateOrUpdateKMSAccessDenied(t *testing.T) {
+	keyARN := "arn:aws:kms:us-east-1:111122223333:key/" + keyID
+	errDenied := &smithy.GenericAPIError{Code: clients3.AccessDeniedErrCode, Message: "Access Denied"}
+	errKMSDenied := &smithy.GenericAPIError{Code: clients3.KMSAccessDeniedErrCode, Message: "not authorized to use key"}
+
+	type args struct {
+		keyID string
+		err   error
+	}
+
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"CrossAccountKeyDeniedByS3": {
+			args: args{
+				keyID: keyARN,
+				err:   errDenied,
+			},
+			want: want{
+				err: awsclient.Wrap(errDenied, ssePutFailed),
+			},
+		},
+		"CrossAccountKeyDeniedByKMS": {
+			args: args{
+				keyID: keyARN,
+				err:   errKMSDenied,
+			},
+			want: want{
+				err: awsclient.Wrap(errors.Wrapf(errKMSDenied, sseKMSAccessDenied, keyARN, "111122223333"), ssePutFailed),
+			},
+		},
+		"KeyOfSameAccount": {
+			args: args{
+				keyID: keyID,
+				err:   errDenied,
+			},
+			want: want{
+				err: awsclient.Wrap(errDenied, ssePutFailed),
+			},
+		},
+		"OtherError": {
+			args: args{
+				keyID: keyARN,
+				err:   errBoom,
+			},
+			want: want{
+				err: awsclient.Wrap(errBoom, ssePutFailed),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cl := NewSSEConfigurationClient(fake.MockBucketClient{
+				MockGetBucketEncryption: sseNotFound,
+				MockPutBucketEncryption: func(ctx context.Context, input *s3.PutBucketEncryptionInput, opts []func(*s3.Options)) (*s3.PutBucketEncryptionOutput, error) {
+					return nil, tc.args.err
+				},
+			}, nil, nil, nil, nil)
+			config := generateKMSSSEConfig()
+			config.Rules[0].ApplyServerSideEncryptionByDefault.KMSMasterKeyID = awsclient.String(tc.args.keyID)
+
+			err := cl.CreateOrUpdate(context.Background(), s3Testing.Bucket(s3Testing.WithSSEConfig(config)))
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSSECreateOrUpdateAlgorithmAliases(t *testing.T) {
+	type want struct {
+		err       error
+		algorithm s3types.ServerSideEncryption
+	}
+
+	cases := map[string]struct {
+		algorithm string
+		want
+	}{
+		"SSE-S3": {
+			algorithm: "SSE-S3",
+			want:      want{algorithm: s3types.ServerSideEncryptionAes256},
+		},
+		"SSE-KMS": {
+			algorithm: "SSE-KMS",
+			want:      want{algorithm: s3types.ServerSideEncryptionAwsKms},
+		},
+		"LowerCaseAES256": {
+			algorithm: "aes256",
+			want:      want{algorithm: s3types.ServerSideEncryptionAes256},
+		},
+		"UpperCaseKMS": {
+			algorithm: "AWS:KMS",
+			want:      want{algorithm: s3types.ServerSideEncryptionAwsKms},
+		},
+		"Canonical": {
+			algorithm: string(s3types.ServerSideEncryptionAwsKms),
+			want:      want{algorithm: s3types.ServerSideEncryptionAwsKms},
+		},
+		"Unknown": {
+			algorithm: "SSE-C",
+			want:      want{err: errors.Errorf(sseUnknownAlgorithm, "SSE-C")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got s3types.ServerSideEncryption
+			cl := NewSSEConfigurationClient(fake.MockBucketClient{
+				MockGetBucketEncryption: sseNotFound,
+				MockPutBucketEncryption: func(ctx context.Context, input *s3.PutBucketEncryptionInput, opts []func(*s3.Options)) (*s3.PutBucketEncryptionOutput, error) {
+					got = input.ServerSideEncryptionConfiguration.Rules[0].ApplyServerSideEncryptionByDefault.SSEAlgorithm
+					return &s3.PutBucketEncryptionOutput{}, nil
+				},
+			}, awsclient.String(defaultKeyID), nil, nil, nil)
+			config := generateKMSSSEConfig()
+			config.Rules[0].ApplyServerSideEncryptionByDefault.SSEAlgorithm = tc.algorithm
+			b := s3Testing.Bucket(s3Testing.WithSSEConfig(config))
+
+			err := cl.CreateOrUpdate(context.Background(), b)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.algorithm, got); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.algorithm, b.Spec.ForProvider.ServerSideEncryptionConfiguration.Rules[0].ApplyServerSideEncryptionByDefault.SSEAlgorithm); diff != "" {
+				t.Errorf("r: spec must not be modified: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSSEValidationConsistency(t *testing.T) {
+	withRule := func(m func(r *v1beta1.ServerSideEncryptionRule)) *v1beta1.ServerSideEncryptionConfiguration {
+		c := generateAES256SSEConfig()
+		m(&c.Rules[0])
+		return c
+	}
+
+	cases := map[string]struct {
+		config *v1beta1.ServerSideEncryptionConfiguration
+		want   error
+	}{
+		"KMSKeyWithAES256": {
+			config: generateSSEConfig(),
+			want:   errors.Errorf(sseKMSKeyWithoutKMS, 0),
+		},
+		"KMSKeyWithAES256Alias": {
+			config: withRule(func(r *v1beta1.ServerSideEncryptionRule) {
+				r.ApplyServerSideEncryptionByDefault.SSEAlgorithm = "SSE-S3"
+				r.ApplyServerSideEncryptionByDefault.KMSMasterKeyID = awsclient.String(keyID)
+			}),
+			want: errors.Errorf(sseKMSKeyWithoutKMS, 0),
+		},
+		"BucketKeyWithAES256": {
+			config: withRule(func(r *v1beta1.ServerSideEncryptionRule) {
+				r.BucketKeyEnabled = awsclient.Bool(true)
+			}),
+			want: errors.Errorf(sseBucketKeyWithoutKMS, 0),
+		},
+		"InvalidKMSKeyID": {
+			config: withRule(func(r *v1beta1.ServerSideEncryptionRule) {
+				r.ApplyServerSideEncryptionByDefault.SSEAlgorithm = string(s3types.ServerSideEncryptionAwsKms)
+				r.ApplyServerSideEncryptionByDefault.KMSMasterKeyID = awsclient.String("not a key")
+			}),
+			want: clients3.ValidateKMSKeyID("not a key"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			errs := ValidateBucketParameters("", &v1beta1.BucketParameters{ServerSideEncryptionConfiguration: tc.config})
+			if diff := cmp.Diff([]error{errors.Wrap(tc.want, "serverSideEncryptionConfiguration")}, errs, test.EquateErrors()); diff != "" {
+				t.Errorf("ValidateBucketParameters(...): -want, +got:\n%s", diff)
+			}
+
+			put := false
+			cl := NewSSEConfigurationClient(fake.MockBucketClient{
+				MockPutBucketEncryption: func(ctx context.Context, input *s3.PutBucketEncryptionInput, opts []func(*s3.Options)) (*s3.PutBucketEncryptionOutput, error) {
+					put = true
+					return &s3.PutBucketEncryptionOutput{}, nil
+				},
+			}, nil, nil, &eventRecorder{}, nil)
+			err := cl.CreateOrUpdate(context.Background(), s3Testing.Bucket(s3Testing.WithSSEConfig(tc.config)))
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("CreateOrUpdate(...): -want, +got:\n%s", diff)
+			}
+			if put {
+				t.Error("CreateOrUpdate(...): PutBucketEncryption must not be called for an invalid configuration")
+			}
+		})
+	}
+}
+
+func TestSSECreateOrUpdateAlgorithmChange(t *testing.T) {
+	cases := map[string]struct {
+		observed []v1beta1.ServerSideEncryptionRule
+		want     []event.Event
+	}{
+		"AES256ToKMS": {
+			observed: GenerateLocalBucketEncryption(generateAWSSSE()),
+			want:     []event.Event{event.Normal(reasonSSEAlgorithmChanged, fmt.Sprintf(sseAlgorithmChanged, s3types.ServerSideEncryptionAes256, s3types.ServerSideEncryptionAwsKms))},
+		},
+		"AlgorithmUnchanged": {
+			observed: GenerateLocalBucketEncryption(generateAWSKMSSSE("other-key")),
+		},
+		"NoEncryption": {},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			rec := &eventRecorder{}
+			// The algorithm is compared to the rules recorded by Observe, so
+			// GetBucketEncryption is not called again.
+			cl := NewSSEConfigurationClient(fake.MockBucketClient{
+				MockPutBucketEncryption: func(ctx context.Context, input *s3.PutBucketEncryptionInput, opts []func(*s3.Options)) (*s3.PutBucketEncryptionOutput, error) {
+					return &s3.PutBucketEncryptionOutput{}, nil
+				},
+			}, nil, nil, rec, nil)
+			config := generateKMSSSEConfig()
+			config.Rules[0].ApplyServerSideEncryptionByDefault.KMSMasterKeyID = awsclient.String(keyID)
+			b := s3Testing.Bucket(s3Testing.WithSSEConfig(config), s3Testing.WithObservedSSERules(tc.observed...))
+
+			if err := cl.CreateOrUpdate(context.Background(), b); err != nil {
+				t.Fatalf("CreateOrUpdate(...): %s", err)
+			}
+			if diff := cmp.Diff(tc.want, rec.events); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSSEDryRunObserve(t *testing.T) {
+	path := "serverSideEncryptionConfiguration.rules[0].applyServerSideEncryptionByDefault"
+	type want struct {
+		diff Diff
+		err  error
+	}
+
+	cases := map[string]struct {
+		config *v1beta1.ServerSideEncryptionConfiguration
+		get    func(ctx context.Context, input *s3.GetBucketEncryptionInput, opts []func(*s3.Options)) (*s3.GetBucketEncryptionOutput, error)
+		want
+	}{
+		"Error": {
+			config: generateSSEConfig(),
+			get: func(ctx context.Context, input *s3.GetBucketEncryptionInput, opts []func(*s3.Options)) (*s3.GetBucketEncryptionOutput, error) {
+				return nil, errBoom
+			},
+			want: want{
+				err: awsclient.Wrap(errBoom, sseGetFailed),
+			},
+		},
+		"NoChanges": {
+			config: generateSSEConfig(),
+			get: func(ctx context.Context, input *s3.GetBucketEncryptionInput, opts []func(*s3.Options)) (*s3.GetBucketEncryptionOutput, error) {
+				return &s3.GetBucketEncryptionOutput{ServerSideEncryptionConfiguration: generateAWSSSE()}, nil
+			},
+		},
+		"ChangedKey": {
+			config: func() *v1beta1.ServerSideEncryptionConfiguration {
+				c := generateKMSSSEConfig()
+				c.Rules[0].ApplyServerSideEncryptionByDefault.KMSMasterKeyID = awsclient.String(keyID)
+				return c
+			}(),
+			get: func(ctx context.Context, input *s3.GetBucketEncryptionInput, opts []func(*s3.Options)) (*s3.GetBucketEncryptionOutput, error) {
+				return &s3.GetBucketEncryptionOutput{ServerSideEncryptionConfiguration: generateAWSKMSSSE("other-key")}, nil
+			},
+			want: want{
+				diff: Diff{{Path: path + ".kmsMasterKeyId", Desired: keyID, Observed: "other-key"}},
+			},
+		},
+		"ChangedAlgorithm": {
+			config: generateKMSSSEConfig(),
+			get: func(ctx context.Context, input *s3.GetBucketEncryptionInput, opts []func(*s3.Options)) (*s3.GetBucketEncryptionOutput, error) {
+				return &s3.GetBucketEncryptionOutput{ServerSideEncryptionConfiguration: generateAWSSSE()}, nil
+			},
+			want: want{
+				diff: Diff{
+					{Path: path + ".sseAlgorithm", Desired: string(s3types.ServerSideEncryptionAwsKms), Observed: sseAlgo},
+					{Path: path + ".kmsMasterKeyId", Observed: keyID},
+				},
+			},
+		},
+		"NotFound": {
+			config: generateSSEConfig(),
+			get:    sseNotFound,
+			want: want{
+				diff: Diff{
+					{Path: path + ".sseAlgorithm", Desired: sseAlgo},
+					{Path: path + ".kmsMasterKeyId", Desired: keyID},
+				},
+			},
+		},
+		"NeedsDeletion": {
+			get: func(ctx context.Context, input *s3.GetBucketEncryptionInput, opts []func(*s3.Options)) (*s3.GetBucketEncryptionOutput, error) {
+				return &s3.GetBucketEncryptionOutput{ServerSideEncryptionConfiguration: generateAWSSSE()}, nil
+			},
+			want: want{
+				diff: Diff{
+					{Path: path + ".sseAlgorithm", Observed: sseAlgo},
+					{Path: path + ".kmsMasterKeyId", Observed: keyID},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cl := NewSSEConfigurationClient(fake.MockBucketClient{MockGetBucketEncryption: tc.get}, nil, nil, nil, nil)
+			diff, err := DryRunObserve(context.Background(), cl, s3Testing.Bucket(s3Testing.WithSSEConfig(tc.config)))
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.diff, diff); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSSEObserveBucketKeyEnabled(t *testing.T) {
+	cases := map[string]struct {
+		algorithm s3types.ServerSideEncryption
+		local     *bool
+		external  bool
+		want      ResourceStatus
+	}{
+		"NotSetAndDisabled": {
+			want: Updated,
+		},
+		"FalseAndDisabled": {
+			local: awsclient.Bool(false, awsclient.FieldRequired),
+			want:  Updated,
+		},
+		"EnabledAndEnabled": {
+			local:    awsclient.Bool(true),
+			external: true,
+			want:     Updated,
+		},
+		"NotSetAndEnabled": {
+			external: true,
+			want:     NeedsUpdate,
+		},
+		"EnabledAndDisabled": {
+			local: awsclient.Bool(true),
+			want:  NeedsUpdate,
+		},
+		"AES256IgnoresBucketKey": {
+			algorithm: s3types.ServerSideEncryptionAes256,
+			external:  true,
+			want:      Updated,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			algorithm := tc.algorithm
+			if algorithm == "" {
+				algorithm = s3types.ServerSideEncryptionAwsKms
+			}
+			config := generateSSEConfig()
+			config.Rules[0].ApplyServerSideEncryptionByDefault.SSEAlgorithm = string(algorithm)
+			config.Rules[0].BucketKeyEnabled = tc.local
+			cl := NewSSEConfigurationClient(fake.MockBucketClient{
+				MockGetBucketEncryption: func(ctx context.Context, input *s3.GetBucketEncryptionInput, opts []func(*s3.Options)) (*s3.GetBucketEncryptionOutput, error) {
+					external := generateAWSSSE()
+					external.Rules[0].ApplyServerSideEncryptionByDefault.SSEAlgorithm = algorithm
+					external.Rules[0].BucketKeyEnabled = tc.external
+					return &s3.GetBucketEncryptionOutput{ServerSideEncryptionConfiguration: external}, nil
+				},
+			}, nil, nil, nil, nil)
+			status, err := cl.Observe(context.Background(), s3Testing.Bucket(s3Testing.WithSSEConfig(config)))
+			if err != nil {
+				t.Fatalf("Observe(...): %s", err)
+			}
+			if diff := cmp.Diff(tc.want, status); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSSEBucketKeyEnabledRoundTrip(t *testing.T) {
+	config := generateSSEConfig()
+	config.Rules[0].BucketKeyEnabled = awsclient.Bool(true)
+
+	input := GeneratePutBucketEncryptionInput(bucketName, config)
+	if !input.ServerSideEncryptionConfiguration.Rules[0].BucketKeyEnabled {
+		t.Errorf("GeneratePutBucketEncryptionInput(...): BucketKeyEnabled is not set")
+	}
+	local := GenerateLocalBucketEncryption(input.ServerSideEncryptionConfiguration)
+	if diff := cmp.Diff(config.Rules, local); diff != "" {
+		t.Errorf("GenerateLocalBucketEncryption(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestSSEObserveStatus(t *testing.T) {
+	stale := s3Testing.Bucket(s3Testing.WithSSEConfig(nil), s3Testing.WithObservedSSERules(generateSSEConfig().Rules...))
+
+	cases := map[string]struct {
+		cl   *SSEConfigurationClient
+		b    *v1beta1.Bucket
+		want []v1beta1.ServerSideEncryptionRule
+	}{
+		"Observed": {
+			b: s3Testing.Bucket(s3Testing.WithSSEConfig(generateSSEConfig())),
+			cl: NewSSEConfigurationClient(fake.MockBucketClient{
+				MockGetBucketEncryption: func(ctx context.Context, input *s3.GetBucketEncryptionInput, opts []func(*s3.Options)) (*s3.GetBucketEncryptionOutput, error) {
+					return &s3.GetBucketEncryptionOutput{ServerSideEncryptionConfiguration: generateAWSSSE()}, nil
+				},
+			}, nil, nil, nil, nil),
+			want: generateSSEConfig().Rules,
+		},
+		"ObservedWithoutSpec": {
+			b: s3Testing.Bucket(s3Testing.WithSSEConfig(nil)),
+			cl: NewSSEConfigurationClient(fake.MockBucketClient{
+				MockGetBucketEncryption: func(ctx context.Context, input *s3.GetBucketEncryptionInput, opts []func(*s3.Options)) (*s3.GetBucketEncryptionOutput, error) {
+					return &s3.GetBucketEncryptionOutput{ServerSideEncryptionConfiguration: generateAWSKMSSSE(keyID)}, nil
+				},
+			}, nil, nil, nil, nil),
+			want: GenerateLocalBucketEncryption(generateAWSKMSSSE(keyID)),
+		},
+		"NotFoundClearsStaleStatus": {
+			b: stale,
+			cl: NewSSEConfigurationClient(fake.MockBucketClient{
+				MockGetBucketEncryption: sseNotFound,
+			}, nil, nil, nil, nil),
+			want: nil,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, _ = tc.cl.Observe(context.Background(), tc.b)
+			if diff := cmp.Diff(tc.want, tc.b.Status.AtProvider.ServerSideEncryptionRules); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+type mockKeyRotation func(ctx awsv1.Context, input *kms.GetKeyRotationStatusInput, opts ...request.Option) (*kms.GetKeyRotationStatusOutput, error)
+
+func (m mockKeyRotation) GetKeyRotationStatusWithContext(ctx awsv1.Context, input *kms.GetKeyRotationStatusInput, opts ...request.Option) (*kms.GetKeyRotationStatusOutput, error) {
+	return m(ctx, input, opts...)
+}
+
+func keyRotation(enabled bool) mockKeyRotation {
+	return func(ctx awsv1.Context, input *kms.GetKeyRotationStatusInput, opts ...request.Option) (*kms.GetKeyRotationStatusOutput, error) {
+		if awsv1.StringValue(input.KeyId) != keyID {
+			return nil, errors.Errorf("unexpected key %s", awsv1.StringValue(input.KeyId))
+		}
+		return &kms.GetKeyRotationStatusOutput{KeyRotationEnabled: awsv1.Bool(enabled)}, nil
+	}
+}
+
+func TestSSEObserveKeyRotation(t *testing.T) {
+	withKey := func() *v1beta1.ServerSideEncryptionConfiguration {
+		c := generateKMSSSEConfig()
+		c.Rules[0].ApplyServerSideEncryptionByDefault.KMSMasterKeyID = awsclient.String(keyID)
+		return c
+	}
+	getSSE := func(ctx context.Context, input *s3.GetBucketEncryptionInput, opts []func(*s3.Options)) (*s3.GetBucketEncryptionOutput, error) {
+		return &s3.GetBucketEncryptionOutput{ServerSideEncryptionConfiguration: generateAWSKMSSSE(keyID)}, nil
+	}
+
+	type want struct {
+		enabled *bool
+		events  []event.Event
+	}
+
+	cases := map[string]struct {
+		b        *v1beta1.Bucket
+		rotation KeyRotationGetter
+		want     want
+	}{
+		"RotationEnabled": {
+			b:        s3Testing.Bucket(s3Testing.WithSSEConfig(withKey())),
+			rotation: keyRotation(true),
+			want:     want{enabled: awsclient.Bool(true)},
+		},
+		"RotationDisabled": {
+			b:        s3Testing.Bucket(s3Testing.WithSSEConfig(withKey())),
+			rotation: keyRotation(false),
+			want:     want{enabled: awsclient.Bool(false, awsclient.FieldRequired)},
+		},
+		"NotEnabledOnProvider": {
+			b:    s3Testing.Bucket(s3Testing.WithSSEConfig(withKey())),
+			want: want{enabled: nil},
+		},
+		"AWSManagedKey": {
+			b: s3Testing.Bucket(s3Testing.WithSSEConfig(generateKMSSSEConfig())),
+			rotation: mockKeyRotation(func(ctx awsv1.Context, input *kms.GetKeyRotationStatusInput, opts ...request.Option) (*kms.GetKeyRotationStatusOutput, error) {
+				return nil, errBoom
+			}),
+			want: want{enabled: nil},
+		},
+		"Error": {
+			b: s3Testing.Bucket(s3Testing.WithSSEConfig(withKey())),
+			rotation: mockKeyRotation(func(ctx awsv1.Context, input *kms.GetKeyRotationStatusInput, opts ...request.Option) (*kms.GetKeyRotationStatusOutput, error) {
+				return nil, errBoom
+			}),
+			want: want{
+				enabled: nil,
+				events:  []event.Event{event.Warning(reasonKeyRotationUnknown, errors.Errorf(sseKeyRotationUnknown, keyID, errBoom))},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			rec := &eventRecorder{}
+			cl := NewSSEConfigurationClient(fake.MockBucketClient{MockGetBucketEncryption: getSSE}, nil, nil, rec, tc.rotation)
+			if _, err := cl.Observe(context.Background(), tc.b); err != nil {
+				t.Errorf("r: unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want.enabled, tc.b.Status.AtProvider.KMSKeyRotationEnabled); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.events, rec.events); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
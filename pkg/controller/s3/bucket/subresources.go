@@ -18,6 +18,16 @@ package bucket
 
 import (
 	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
 
 	"github.com/crossplane/provider-aws/apis/s3/v1beta1"
 	"github.com/crossplane/provider-aws/pkg/clients/s3"
@@ -30,28 +40,416 @@ type SubresourceClient interface {
 	Delete(ctx context.Context, bucket *v1beta1.Bucket) error
 	LateInitialize(ctx context.Context, bucket *v1beta1.Bucket) error
 	SubresourceExists(bucket *v1beta1.Bucket) bool
+	// CapturePrior returns a Bucket whose configuration of the subresource is
+	// the one currently in AWS, so that CreateOrUpdate or Delete can restore
+	// it. It returns nil if the configuration cannot be restored.
+	CapturePrior(ctx context.Context, bucket *v1beta1.Bucket) (*v1beta1.Bucket, error)
+}
+
+// newPriorBucket returns a Bucket with the metadata and location of the
+// supplied one but without any subresource configured.
+func newPriorBucket(bucket *v1beta1.Bucket) *v1beta1.Bucket {
+	prior := &v1beta1.Bucket{ObjectMeta: *bucket.ObjectMeta.DeepCopy()}
+	prior.Spec.ForProvider.LocationConstraint = bucket.Spec.ForProvider.LocationConstraint
+	return prior
+}
+
+// lateInitializedPrior returns a Bucket whose configuration of the
+// subresource of the supplied client is late initialized from AWS. It is used
+// by the clients whose LateInitialize copies the whole configuration.
+func lateInitializedPrior(ctx context.Context, c SubresourceClient, bucket *v1beta1.Bucket) (*v1beta1.Bucket, error) {
+	prior := newPriorBucket(bucket)
+	if err := c.LateInitialize(ctx, prior); err != nil {
+		return nil, err
+	}
+	return prior, nil
+}
+
+// A Prerequisite must be met before a subresource can be created or updated.
+type Prerequisite struct {
+	// Description of the prerequisite, e.g. "versioning is enabled".
+	Description string
+
+	// Met returns true if the prerequisite is met for the supplied Bucket.
+	Met func(ctx context.Context, bucket *v1beta1.Bucket) (bool, error)
+}
+
+// A PrerequisiteClient is a SubresourceClient that cannot be created or
+// updated until its prerequisites are met, e.g. replication which requires
+// versioning to be enabled on the bucket.
+type PrerequisiteClient interface {
+	SubresourceClient
+	Prerequisites() []Prerequisite
+}
+
+// UnmetPrerequisites returns the descriptions of the prerequisites of the
+// supplied client that are not met for the supplied Bucket. Clients that do
+// not implement PrerequisiteClient have no prerequisites.
+func UnmetPrerequisites(ctx context.Context, client SubresourceClient, bucket *v1beta1.Bucket) ([]string, error) {
+	pc, ok := client.(PrerequisiteClient)
+	if !ok {
+		return nil, nil
+	}
+	var unmet []string
+	for _, p := range pc.Prerequisites() {
+		met, err := p.Met(ctx, bucket)
+		if err != nil {
+			return nil, err
+		}
+		if !met {
+			unmet = append(unmet, p.Description)
+		}
+	}
+	return unmet, nil
+}
+
+// A Quota limits how much of a subresource a bucket or an account may have,
+// e.g. the number of inventory configurations per bucket.
+type Quota struct {
+	// Description of the quota, e.g. "inventory configurations per bucket".
+	Description string
+
+	// Limit of the quota.
+	Limit int
+
+	// Usage returns how much of the quota the supplied Bucket uses once its
+	// subresource is created or updated.
+	Usage func(ctx context.Context, bucket *v1beta1.Bucket) (int, error)
+}
+
+// A QuotaClient is a SubresourceClient whose configuration counts against
+// quotas that AWS enforces, e.g. a maximum number of configurations per
+// bucket.
+type QuotaClient interface {
+	SubresourceClient
+	Quotas() []Quota
+}
+
+// A QuotaUsage is the usage of a Quota by a Bucket.
+type QuotaUsage struct {
+	Quota
+	Used int
+}
+
+// Exceeded returns true if the usage is above the limit of the quota.
+func (u QuotaUsage) Exceeded() bool {
+	return u.Used > u.Limit
+}
+
+// NearLimit returns true if at least 90% of the quota is used.
+func (u QuotaUsage) NearLimit() bool {
+	return u.Used*10 >= u.Limit*9
+}
+
+// String describes the usage, e.g. "1000 of 1000 inventory configurations per
+// bucket".
+func (u QuotaUsage) String() string {
+	return fmt.Sprintf("%d of %d %s", u.Used, u.Limit, u.Description)
+}
+
+// QuotaUsages returns the usage of the quotas of the supplied client by the
+// supplied Bucket. Clients that do not implement QuotaClient have no quotas.
+func QuotaUsages(ctx context.Context, client SubresourceClient, bucket *v1beta1.Bucket) ([]QuotaUsage, error) {
+	qc, ok := client.(QuotaClient)
+	if !ok {
+		return nil, nil
+	}
+	usages := make([]QuotaUsage, 0, len(qc.Quotas()))
+	for _, q := range qc.Quotas() {
+		used, err := q.Usage(ctx, bucket)
+		if err != nil {
+			return nil, err
+		}
+		usages = append(usages, QuotaUsage{Quota: q, Used: used})
+	}
+	return usages, nil
+}
+
+// A CleanupClient is a SubresourceClient that changes resources outside of
+// the bucket, e.g. the key policy of a KMS key, which must be changed back
+// before the bucket is deleted.
+type CleanupClient interface {
+	SubresourceClient
+	Cleanup(ctx context.Context, bucket *v1beta1.Bucket) error
+}
+
+// Cleanup changes back the resources outside of the supplied Bucket that the
+// supplied client changed. Clients that do not implement CleanupClient have
+// nothing to clean up.
+func Cleanup(ctx context.Context, client SubresourceClient, bucket *v1beta1.Bucket) error {
+	cc, ok := client.(CleanupClient)
+	if !ok {
+		return nil
+	}
+	return cc.Cleanup(ctx, bucket)
+}
+
+// A FieldDiff is a field of a subresource whose desired value differs from
+// the value observed in AWS. Values that are not set are empty.
+type FieldDiff struct {
+	// Path of the field in the Bucket spec, e.g. "loggingConfiguration.targetPrefix".
+	Path     string
+	Desired  string
+	Observed string
+}
+
+// A Diff lists the field-level changes CreateOrUpdate would make to a
+// subresource.
+type Diff []FieldDiff
+
+// A DryRunClient is a SubresourceClient that can describe the changes it
+// would make to a subresource without making them.
+type DryRunClient interface {
+	SubresourceClient
+	DryRunObserve(ctx context.Context, bucket *v1beta1.Bucket) (Diff, error)
+}
+
+// DryRunObserve returns the changes the supplied client would make for the
+// supplied Bucket. It only calls read operations of the AWS API. Clients that
+// do not implement DryRunClient report no changes.
+func DryRunObserve(ctx context.Context, client SubresourceClient, bucket *v1beta1.Bucket) (Diff, error) {
+	dc, ok := client.(DryRunClient)
+	if !ok {
+		return nil, nil
+	}
+	return dc.DryRunObserve(ctx, bucket)
+}
+
+// add appends a FieldDiff to the diff if the desired and observed values
+// differ according to the supplied equality function.
+func (d Diff) add(path, desired, observed string, equal func(a, b string) bool) Diff {
+	if equal(desired, observed) {
+		return d
+	}
+	return append(d, FieldDiff{Path: path, Desired: desired, Observed: observed})
+}
+
+// exactly reports whether a and b are identical.
+func exactly(a, b string) bool { return a == b }
+
+// TypePrerequisites indicates whether the prerequisites of all subresources of
+// a Bucket are met.
+const TypePrerequisites xpv1.ConditionType = "Prerequisites"
+
+// Reasons a subresource is or is not waiting for its prerequisites.
+const (
+	ReasonWaitingForPrerequisites xpv1.ConditionReason = "WaitingForPrerequisites"
+	ReasonPrerequisitesMet        xpv1.ConditionReason = "PrerequisitesMet"
+)
+
+// WaitingForPrerequisites returns a condition that indicates that some
+// subresources of a Bucket are not created or updated until the supplied
+// prerequisites are met.
+func WaitingForPrerequisites(unmet []string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypePrerequisites,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonWaitingForPrerequisites,
+		Message:            "waiting for: " + strings.Join(unmet, ", "),
+	}
+}
+
+// PrerequisitesMet returns a condition that indicates that the prerequisites
+// of all subresources of a Bucket are met.
+func PrerequisitesMet() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypePrerequisites,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonPrerequisitesMet,
+	}
 }
 
-// NewSubresourceClients creates the array of all clients for a given BucketProvider
-func NewSubresourceClients(client s3.BucketClient) []SubresourceClient {
+// TypeDrift indicates whether the subresources of a Bucket match their
+// configuration in AWS. It is only reported by controllers in audit-only mode.
+const TypeDrift xpv1.ConditionType = "Drift"
+
+// Reasons the subresources of a Bucket do or do not drift.
+const (
+	ReasonDriftDetected xpv1.ConditionReason = "DriftDetected"
+	ReasonNoDrift       xpv1.ConditionReason = "NoDrift"
+)
+
+// DriftDetected returns a condition that indicates that the supplied
+// subresources of a Bucket do not match their configuration in AWS.
+func DriftDetected(subresources []string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeDrift,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonDriftDetected,
+		Message:            "drifted: " + strings.Join(subresources, ", "),
+	}
+}
+
+// NoDrift returns a condition that indicates that all subresources of a
+// Bucket match their configuration in AWS.
+func NoDrift() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeDrift,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonNoDrift,
+	}
+}
+
+// TypeUnsupportedSubresources indicates whether configured subresources of a
+// Bucket are skipped because the backend serving the S3 API does not
+// implement them.
+const TypeUnsupportedSubresources xpv1.ConditionType = "UnsupportedSubresources"
+
+// Reasons configured subresources of a Bucket are or are not skipped.
+const (
+	ReasonUnsupportedByBackend xpv1.ConditionReason = "UnsupportedByBackend"
+	ReasonAllSupported         xpv1.ConditionReason = "AllSupported"
+)
+
+// SubresourcesUnsupported returns a condition that indicates that the
+// supplied subresources of a Bucket are configured but skipped, since the
+// backend does not implement them.
+func SubresourcesUnsupported(subresources []string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeUnsupportedSubresources,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonUnsupportedByBackend,
+		Message:            "not implemented by the S3 backend and skipped: " + strings.Join(subresources, ", "),
+	}
+}
+
+// SubresourcesSupported returns a condition that indicates that all
+// configured subresources of a Bucket are implemented by the backend.
+func SubresourcesSupported() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeUnsupportedSubresources,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonAllSupported,
+	}
+}
+
+// TypeEncryptionFallback indicates whether the default encryption of a Bucket
+// fell back to AES256 because its KMS keys cannot be used.
+const TypeEncryptionFallback xpv1.ConditionType = "EncryptionFallback"
+
+// Reasons the default encryption of a Bucket does or does not fall back to
+// AES256.
+const (
+	ReasonFallbackToAES256     xpv1.ConditionReason = "FallbackToAES256"
+	ReasonConfiguredEncryption xpv1.ConditionReason = "ConfiguredEncryption"
+)
+
+// FallbackToAES256 returns a condition that indicates that the default
+// encryption of a Bucket uses AES256 because the supplied KMS keys cannot be
+// used. The fallback stays in place until the keys or the fallback setting of
+// the Bucket change.
+func FallbackToAES256(keys []string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeEncryptionFallback,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonFallbackToAES256,
+		Message:            "KMS keys cannot be used, encrypting with AES256 instead: " + strings.Join(keys, ", "),
+	}
+}
+
+// ConfiguredEncryption returns a condition that indicates that the default
+// encryption of a Bucket is applied as configured.
+func ConfiguredEncryption() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeEncryptionFallback,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonConfiguredEncryption,
+	}
+}
+
+// A ClientForProviderConfigFn returns a BucketClient that uses the credentials
+// of the ProviderConfig with the given name.
+type ClientForProviderConfigFn func(ctx context.Context, name string) (s3.BucketClient, error)
+
+// clientFor returns the client for calls authenticated by the referenced
+// ProviderConfig, or the given default client if there is no reference.
+func clientFor(ctx context.Context, def s3.BucketClient, fn ClientForProviderConfigFn, ref *xpv1.Reference) (s3.BucketClient, error) {
+	if ref == nil || fn == nil {
+		return def, nil
+	}
+	return fn(ctx, ref.Name)
+}
+
+// SubresourceClientOptions configure the clients created by
+// NewSubresourceClients. Fields that are not set disable the features that
+// need them.
+type SubresourceClientOptions struct {
+	// Logger is used to log details of the reconciliation. It defaults to a
+	// logger that discards everything.
+	Logger logging.Logger
+
+	// DefaultKMSKeyID is used for aws:kms encryption rules that do not
+	// specify a key.
+	DefaultKMSKeyID *string
+
+	// ClientForProviderConfig is used for calls against buckets owned by
+	// another account.
+	ClientForProviderConfig ClientForProviderConfigFn
+
+	// Kube is used to read values referenced by the Bucket, e.g. KMS key IDs
+	// stored in secrets.
+	Kube client.Client
+
+	// Recorder is used to emit events about the Bucket.
+	Recorder event.Recorder
+
+	// RoleSimulator is used to verify the permissions of replication roles.
+	RoleSimulator RoleSimulator
+
+	// KeyRotation is used to report the rotation status of the KMS key used
+	// for bucket encryption.
+	KeyRotation KeyRotationGetter
+
+	// KeyPolicy is used to allow S3 to deliver server access logs to targets
+	// encrypted with a KMS key.
+	KeyPolicy KeyPolicyClient
+}
+
+// NewSubresourceClients creates the array of all clients for a given BucketProvider.
+func NewSubresourceClients(client s3.BucketClient, o SubresourceClientOptions) []SubresourceClient {
+	if o.Logger == nil {
+		o.Logger = logging.NewNopLogger()
+	}
 	return []SubresourceClient{
 		// Note: Moved VersioningClient up, since ReplicationConfiguration may be blocked
 		// by an invalid VersioningConfig, see https://github.com/crossplane/provider-aws/issues/553
 		NewVersioningConfigurationClient(client),
+		NewObjectLockConfigurationClient(client),
+		NewOwnershipControlsClient(client),
+		// The ACL follows the ownership controls, since they decide whether
+		// the bucket accepts ACLs at all.
+		NewACLClient(client),
 		NewAccelerateConfigurationClient(client),
 		NewCORSConfigurationClient(client),
-		NewLifecycleConfigurationClient(client),
-		NewLoggingConfigurationClient(client),
+		NewLifecycleConfigurationClient(client, o.Logger),
+		NewLoggingConfigurationClient(client, o.ClientForProviderConfig, o.KeyPolicy),
 		NewNotificationConfigurationClient(client),
-		NewReplicationConfigurationClient(client),
+		NewReplicationConfigurationClient(client, o.ClientForProviderConfig, o.RoleSimulator, o.Recorder),
 		NewRequestPaymentConfigurationClient(client),
-		NewSSEConfigurationClient(client),
-		NewTaggingConfigurationClient(client),
+		NewSSEConfigurationClient(client, o.DefaultKMSKeyID, o.Kube, o.Recorder, o.KeyRotation),
+		NewTaggingConfigurationClient(client, o.Kube),
 		NewWebsiteConfigurationClient(client),
 		NewPublicAccessBlockClient(client),
+		NewMetricsConfigurationClient(client),
+		NewInventoryConfigurationClient(client),
+		NewIntelligentTieringConfigurationClient(client),
 	}
 }
 
+// A PreCreateOrUpdateHook is called before a subresource is created or
+// updated. It receives a copy of the Bucket that is passed on to the
+// subresource client, so it may modify the configuration that is sent to AWS
+// without modifying the spec, or return an error to prevent the call.
+type PreCreateOrUpdateHook func(ctx context.Context, bucket *v1beta1.Bucket) error
+
 // ResourceStatus represents the current status  if the resource resource is updated.
 type ResourceStatus int
 
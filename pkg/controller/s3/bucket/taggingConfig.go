@@ -17,7 +17,10 @@ limitations under the License.
 package bucket
 
 import (
+	"bytes"
 	"context"
+	"strings"
+	"text/template"
 
 	"github.com/aws/smithy-go/document"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -27,6 +30,8 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/crossplane/provider-aws/apis/s3/v1beta1"
 	awsclient "github.com/crossplane/provider-aws/pkg/clients"
@@ -34,54 +39,83 @@ import (
 )
 
 const (
-	taggingGetFailed    = "cannot get Bucket tagging set"
-	taggingPutFailed    = "cannot put Bucket tagging set"
-	taggingDeleteFailed = "cannot delete Bucket tagging set"
+	taggingGetFailed      = "cannot get Bucket tagging set"
+	taggingPutFailed      = "cannot put Bucket tagging set"
+	taggingDeleteFailed   = "cannot delete Bucket tagging set"
+	taggingTemplateFailed = "cannot resolve template in value of tag %q"
 )
 
 // TaggingConfigurationClient is the client for API methods and reconciling the CORSConfiguration
 type TaggingConfigurationClient struct {
 	client s3.BucketClient
+	kube   client.Client
 }
 
-// NewTaggingConfigurationClient creates the client for CORS Configuration
-func NewTaggingConfigurationClient(client s3.BucketClient) *TaggingConfigurationClient {
-	return &TaggingConfigurationClient{client: client}
+// NewTaggingConfigurationClient creates the client for CORS Configuration.
+// kube is used to read the baseline ConfigMap a Bucket may reference.
+func NewTaggingConfigurationClient(client s3.BucketClient, kube client.Client) *TaggingConfigurationClient {
+	return &TaggingConfigurationClient{client: client, kube: kube}
 }
 
 // Observe checks if the resource exists and if it matches the local configuration
 func (in *TaggingConfigurationClient) Observe(ctx context.Context, bucket *v1beta1.Bucket) (ResourceStatus, error) {
+	config, err := in.effectiveTagging(ctx, bucket)
+	if err != nil {
+		return NeedsUpdate, err
+	}
+	// S3 does not accept an empty tag set, so removing all tags deletes the
+	// tagging of the bucket.
+	untagged := config == nil || len(config.TagSet) == 0
 	external, err := in.client.GetBucketTagging(ctx, &awss3.GetBucketTaggingInput{Bucket: awsclient.String(meta.GetExternalName(bucket))})
-	config := bucket.Spec.ForProvider.BucketTagging
 	if err != nil {
-		if s3.TaggingNotFound(err) && config == nil {
+		if s3.TaggingNotFound(err) && untagged {
 			return Updated, nil
 		}
 		return NeedsUpdate, awsclient.Wrap(resource.Ignore(s3.TaggingNotFound, err), taggingGetFailed)
 	}
 
 	switch {
-	case config == nil && len(external.TagSet) == 0:
+	case untagged && len(external.TagSet) == 0:
 		return Updated, nil
-	case config == nil && len(external.TagSet) != 0:
+	case untagged && len(external.TagSet) != 0:
 		return NeedsDeletion, nil
-	case cmp.Equal(s3.SortS3TagSet(external.TagSet), s3.SortS3TagSet(GenerateTagging(config).TagSet), cmpopts.IgnoreTypes(document.NoSerde{})):
+	}
+
+	resolved, err := resolveTagging(bucket, config)
+	if err != nil {
+		return NeedsUpdate, err
+	}
+	if cmp.Equal(s3.SortS3TagSet(external.TagSet), s3.SortS3TagSet(GenerateTagging(resolved).TagSet), cmpopts.IgnoreTypes(document.NoSerde{})) {
 		return Updated, nil
-	default:
-		return NeedsUpdate, nil
 	}
+	return NeedsUpdate, nil
 }
 
 // CreateOrUpdate sends a request to have resource created on AWS
 func (in *TaggingConfigurationClient) CreateOrUpdate(ctx context.Context, bucket *v1beta1.Bucket) error {
-	if bucket.Spec.ForProvider.BucketTagging == nil {
-		return nil
+	config, err := in.effectiveTagging(ctx, bucket)
+	if err != nil || config == nil || len(config.TagSet) == 0 {
+		return err
 	}
-	input := GeneratePutBucketTagging(meta.GetExternalName(bucket), bucket.Spec.ForProvider.BucketTagging)
-	_, err := in.client.PutBucketTagging(ctx, input)
+	resolved, err := resolveTagging(bucket, config)
+	if err != nil {
+		return err
+	}
+	input := GeneratePutBucketTagging(meta.GetExternalName(bucket), resolved)
+	_, err = in.client.PutBucketTagging(ctx, input)
 	return awsclient.Wrap(err, taggingPutFailed)
 }
 
+// effectiveTagging returns the tagging of the bucket merged with the baseline
+// it references, if any.
+func (in *TaggingConfigurationClient) effectiveTagging(ctx context.Context, bucket *v1beta1.Bucket) (*v1beta1.Tagging, error) {
+	base, err := getBaseline(ctx, in.kube, bucket)
+	if err != nil {
+		return nil, err
+	}
+	return mergeTagging(bucket.Spec.ForProvider.BucketTagging, base.tagging), nil
+}
+
 // Delete creates the request to delete the resource on AWS or set it to the default value.
 func (in *TaggingConfigurationClient) Delete(ctx context.Context, bucket *v1beta1.Bucket) error {
 	_, err := in.client.DeleteBucketTagging(ctx,
@@ -95,6 +129,11 @@ func (in *TaggingConfigurationClient) Delete(ctx context.Context, bucket *v1beta
 // LateInitialize does nothing because the resource might have been deleted by
 // the user.
 func (in *TaggingConfigurationClient) LateInitialize(ctx context.Context, bucket *v1beta1.Bucket) error {
+	// The observed tags may come from the baseline, which must not be copied
+	// into the spec where they would take precedence over the baseline.
+	if bucket.Spec.ForProvider.BaselineConfigMapRef != nil {
+		return nil
+	}
 	external, err := in.client.GetBucketTagging(ctx, &awss3.GetBucketTaggingInput{Bucket: awsclient.String(meta.GetExternalName(bucket))})
 	if err != nil {
 		return awsclient.Wrap(resource.Ignore(s3.TaggingNotFound, err), taggingGetFailed)
@@ -117,11 +156,50 @@ func (in *TaggingConfigurationClient) LateInitialize(ctx context.Context, bucket
 	return nil
 }
 
+// CapturePrior returns a Bucket with the tags currently in AWS.
+func (in *TaggingConfigurationClient) CapturePrior(ctx context.Context, bucket *v1beta1.Bucket) (*v1beta1.Bucket, error) {
+	return lateInitializedPrior(ctx, in, bucket)
+}
+
 // SubresourceExists checks if the subresource this controller manages currently exists
 func (in *TaggingConfigurationClient) SubresourceExists(bucket *v1beta1.Bucket) bool {
 	return bucket.Spec.ForProvider.BucketTagging != nil
 }
 
+// resolveTagging returns a copy of the given tagging in which every tag value
+// containing a template, e.g. "{{ .metadata.name }}", is rendered against the
+// name, namespace and UID of the bucket. Values without a template are kept
+// as they are.
+func resolveTagging(bucket *v1beta1.Bucket, config *v1beta1.Tagging) (*v1beta1.Tagging, error) {
+	if config == nil || config.TagSet == nil {
+		return config, nil
+	}
+	data := map[string]interface{}{
+		"metadata": map[string]string{
+			"name":      bucket.GetName(),
+			"namespace": bucket.GetNamespace(),
+			"uid":       string(bucket.GetUID()),
+		},
+	}
+	resolved := &v1beta1.Tagging{TagSet: make([]v1beta1.Tag, len(config.TagSet))}
+	for i, t := range config.TagSet {
+		resolved.TagSet[i] = t
+		if !strings.Contains(t.Value, "{{") {
+			continue
+		}
+		tmpl, err := template.New(t.Key).Option("missingkey=error").Parse(t.Value)
+		if err != nil {
+			return nil, errors.Wrapf(err, taggingTemplateFailed, t.Key)
+		}
+		var b bytes.Buffer
+		if err := tmpl.Execute(&b, data); err != nil {
+			return nil, errors.Wrapf(err, taggingTemplateFailed, t.Key)
+		}
+		resolved.TagSet[i].Value = b.String()
+	}
+	return resolved, nil
+}
+
 // GenerateTagging creates the awss3.Tagging for the AWS SDK
 func GenerateTagging(config *v1beta1.Tagging) *types.Tagging {
 	if config == nil || config.TagSet == nil {
@@ -23,8 +23,12 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/document"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	k8stypes "k8s.io/apimachinery/pkg/types"
 
 	"github.com/crossplane/provider-aws/apis/s3/v1beta1"
 	aws "github.com/crossplane/provider-aws/pkg/clients"
@@ -62,6 +66,12 @@ var (
 	}
 	awsTags                   = []types.Tag{awsTag, awsTag1, awsTag2}
 	_       SubresourceClient = &TaggingConfigurationClient{}
+
+	templatedTagKey     = "crossplane-resource"
+	templatedTagValue   = "{{ .metadata.name }}/{{ .metadata.uid }}"
+	templatedBucketName = "my-bucket"
+	templatedBucketUID  = k8stypes.UID("2d0bcbd7-8b2f-4bb4-9a26-0d27e0fb7c8a")
+	resolvedTagValue    = templatedBucketName + "/" + string(templatedBucketUID)
 )
 
 func generateTaggingConfig() *v1beta1.Tagging {
@@ -76,6 +86,19 @@ func generateAWSTagging() *types.Tagging {
 	}
 }
 
+func templatedBucket(value string) *v1beta1.Bucket {
+	b := s3Testing.Bucket(s3Testing.WithTaggingConfig(&v1beta1.Tagging{
+		TagSet: []v1beta1.Tag{tag, {Key: templatedTagKey, Value: value}},
+	}))
+	b.SetName(templatedBucketName)
+	b.SetUID(templatedBucketUID)
+	return b
+}
+
+func generateAWSTemplatedTagging(value string) []types.Tag {
+	return []types.Tag{awsTag, {Key: aws.String(templatedTagKey), Value: aws.String(value)}}
+}
+
 func TestTaggingObserve(t *testing.T) {
 	type args struct {
 		cl *TaggingConfigurationClient
@@ -98,7 +121,7 @@ func TestTaggingObserve(t *testing.T) {
 					MockGetBucketTagging: func(ctx context.Context, input *s3.GetBucketTaggingInput, opts []func(*s3.Options)) (*s3.GetBucketTaggingOutput, error) {
 						return nil, errBoom
 					},
-				}),
+				}, nil),
 			},
 			want: want{
 				status: NeedsUpdate,
@@ -112,7 +135,7 @@ func TestTaggingObserve(t *testing.T) {
 					MockGetBucketTagging: func(ctx context.Context, input *s3.GetBucketTaggingInput, opts []func(*s3.Options)) (*s3.GetBucketTaggingOutput, error) {
 						return &s3.GetBucketTaggingOutput{TagSet: nil}, nil
 					},
-				}),
+				}, nil),
 			},
 			want: want{
 				status: NeedsUpdate,
@@ -126,13 +149,55 @@ func TestTaggingObserve(t *testing.T) {
 					MockGetBucketTagging: func(ctx context.Context, input *s3.GetBucketTaggingInput, opts []func(*s3.Options)) (*s3.GetBucketTaggingOutput, error) {
 						return &s3.GetBucketTaggingOutput{TagSet: generateAWSTagging().TagSet}, nil
 					},
-				}),
+				}, nil),
 			},
 			want: want{
 				status: NeedsDeletion,
 				err:    nil,
 			},
 		},
+		"NeedsDeleteEmptyTagSet": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithTaggingConfig(&v1beta1.Tagging{TagSet: []v1beta1.Tag{}})),
+				cl: NewTaggingConfigurationClient(fake.MockBucketClient{
+					MockGetBucketTagging: func(ctx context.Context, input *s3.GetBucketTaggingInput, opts []func(*s3.Options)) (*s3.GetBucketTaggingOutput, error) {
+						return &s3.GetBucketTaggingOutput{TagSet: generateAWSTagging().TagSet}, nil
+					},
+				}, nil),
+			},
+			want: want{
+				status: NeedsDeletion,
+				err:    nil,
+			},
+		},
+		"NoUpdateEmptyTagSetNotExists": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithTaggingConfig(&v1beta1.Tagging{TagSet: []v1beta1.Tag{}})),
+				cl: NewTaggingConfigurationClient(fake.MockBucketClient{
+					MockGetBucketTagging: func(ctx context.Context, input *s3.GetBucketTaggingInput, opts []func(*s3.Options)) (*s3.GetBucketTaggingOutput, error) {
+						return nil, &smithy.GenericAPIError{Code: clientss3.TaggingNotFoundErrCode}
+					},
+				}, nil),
+			},
+			want: want{
+				status: Updated,
+				err:    nil,
+			},
+		},
+		"UpdateNeededTagRemoved": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithTaggingConfig(&v1beta1.Tagging{TagSet: generateTaggingConfig().TagSet[:1]})),
+				cl: NewTaggingConfigurationClient(fake.MockBucketClient{
+					MockGetBucketTagging: func(ctx context.Context, input *s3.GetBucketTaggingInput, opts []func(*s3.Options)) (*s3.GetBucketTaggingOutput, error) {
+						return &s3.GetBucketTaggingOutput{TagSet: generateAWSTagging().TagSet}, nil
+					},
+				}, nil),
+			},
+			want: want{
+				status: NeedsUpdate,
+				err:    nil,
+			},
+		},
 		"NoUpdateNotExists": {
 			args: args{
 				b: s3Testing.Bucket(s3Testing.WithTaggingConfig(nil)),
@@ -140,13 +205,27 @@ func TestTaggingObserve(t *testing.T) {
 					MockGetBucketTagging: func(ctx context.Context, input *s3.GetBucketTaggingInput, opts []func(*s3.Options)) (*s3.GetBucketTaggingOutput, error) {
 						return nil, &smithy.GenericAPIError{Code: clientss3.TaggingNotFoundErrCode}
 					},
-				}),
+				}, nil),
 			},
 			want: want{
 				status: Updated,
 				err:    nil,
 			},
 		},
+		"UpdateNeededNotExists": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithTaggingConfig(generateTaggingConfig())),
+				cl: NewTaggingConfigurationClient(fake.MockBucketClient{
+					MockGetBucketTagging: func(ctx context.Context, input *s3.GetBucketTaggingInput, opts []func(*s3.Options)) (*s3.GetBucketTaggingOutput, error) {
+						return nil, &smithy.GenericAPIError{Code: clientss3.TaggingNotFoundErrCode}
+					},
+				}, nil),
+			},
+			want: want{
+				status: NeedsUpdate,
+				err:    nil,
+			},
+		},
 		"NoUpdateNotExistsNil": {
 			args: args{
 				b: s3Testing.Bucket(s3Testing.WithTaggingConfig(nil)),
@@ -154,7 +233,7 @@ func TestTaggingObserve(t *testing.T) {
 					MockGetBucketTagging: func(ctx context.Context, input *s3.GetBucketTaggingInput, opts []func(*s3.Options)) (*s3.GetBucketTaggingOutput, error) {
 						return &s3.GetBucketTaggingOutput{TagSet: nil}, nil
 					},
-				}),
+				}, nil),
 			},
 			want: want{
 				status: Updated,
@@ -168,7 +247,7 @@ func TestTaggingObserve(t *testing.T) {
 					MockGetBucketTagging: func(ctx context.Context, input *s3.GetBucketTaggingInput, opts []func(*s3.Options)) (*s3.GetBucketTaggingOutput, error) {
 						return &s3.GetBucketTaggingOutput{TagSet: generateAWSTagging().TagSet}, nil
 					},
-				}),
+				}, nil),
 			},
 			want: want{
 				status: Updated,
@@ -182,13 +261,56 @@ func TestTaggingObserve(t *testing.T) {
 					MockGetBucketTagging: func(ctx context.Context, input *s3.GetBucketTaggingInput, opts []func(*s3.Options)) (*s3.GetBucketTaggingOutput, error) {
 						return &s3.GetBucketTaggingOutput{TagSet: []types.Tag{awsTag2, awsTag, awsTag1}}, nil
 					},
-				}),
+				}, nil),
 			},
 			want: want{
 				status: Updated,
 				err:    nil,
 			},
 		},
+		"NoUpdateTemplatedValue": {
+			args: args{
+				b: templatedBucket(templatedTagValue),
+				cl: NewTaggingConfigurationClient(fake.MockBucketClient{
+					MockGetBucketTagging: func(ctx context.Context, input *s3.GetBucketTaggingInput, opts []func(*s3.Options)) (*s3.GetBucketTaggingOutput, error) {
+						return &s3.GetBucketTaggingOutput{TagSet: generateAWSTemplatedTagging(resolvedTagValue)}, nil
+					},
+				}, nil),
+			},
+			want: want{
+				status: Updated,
+				err:    nil,
+			},
+		},
+		"UpdateNeededTemplatedValueUnresolved": {
+			args: args{
+				b: templatedBucket(templatedTagValue),
+				cl: NewTaggingConfigurationClient(fake.MockBucketClient{
+					MockGetBucketTagging: func(ctx context.Context, input *s3.GetBucketTaggingInput, opts []func(*s3.Options)) (*s3.GetBucketTaggingOutput, error) {
+						return &s3.GetBucketTaggingOutput{TagSet: generateAWSTemplatedTagging(templatedTagValue)}, nil
+					},
+				}, nil),
+			},
+			want: want{
+				status: NeedsUpdate,
+				err:    nil,
+			},
+		},
+		"InvalidTemplate": {
+			args: args{
+				b: templatedBucket("{{ .metadata.labels }}"),
+				cl: NewTaggingConfigurationClient(fake.MockBucketClient{
+					MockGetBucketTagging: func(ctx context.Context, input *s3.GetBucketTaggingInput, opts []func(*s3.Options)) (*s3.GetBucketTaggingOutput, error) {
+						return &s3.GetBucketTaggingOutput{TagSet: generateAWSTagging().TagSet}, nil
+					},
+				}, nil),
+			},
+			want: want{
+				status: NeedsUpdate,
+				err: errors.Wrapf(errors.New(`template: crossplane-resource:1:12: executing "crossplane-resource" at <.metadata.labels>: map has no entry for key "labels"`),
+					taggingTemplateFailed, templatedTagKey),
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -225,7 +347,7 @@ func TestTaggingCreateOrUpdate(t *testing.T) {
 					MockPutBucketTagging: func(ctx context.Context, input *s3.PutBucketTaggingInput, opts []func(*s3.Options)) (*s3.PutBucketTaggingOutput, error) {
 						return nil, errBoom
 					},
-				}),
+				}, nil),
 			},
 			want: want{
 				err: awsclient.Wrap(errBoom, taggingPutFailed),
@@ -238,7 +360,16 @@ func TestTaggingCreateOrUpdate(t *testing.T) {
 					MockPutBucketTagging: func(ctx context.Context, input *s3.PutBucketTaggingInput, opts []func(*s3.Options)) (*s3.PutBucketTaggingOutput, error) {
 						return &s3.PutBucketTaggingOutput{}, nil
 					},
-				}),
+				}, nil),
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"NoPutEmptyTagSet": {
+			args: args{
+				b:  s3Testing.Bucket(s3Testing.WithTaggingConfig(&v1beta1.Tagging{TagSet: []v1beta1.Tag{}})),
+				cl: NewTaggingConfigurationClient(fake.MockBucketClient{}, nil),
 			},
 			want: want{
 				err: nil,
@@ -251,12 +382,58 @@ func TestTaggingCreateOrUpdate(t *testing.T) {
 					MockPutBucketTagging: func(ctx context.Context, input *s3.PutBucketTaggingInput, opts []func(*s3.Options)) (*s3.PutBucketTaggingOutput, error) {
 						return &s3.PutBucketTaggingOutput{}, nil
 					},
-				}),
+				}, nil),
 			},
 			want: want{
 				err: nil,
 			},
 		},
+		"TagRemoved": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithTaggingConfig(&v1beta1.Tagging{TagSet: generateTaggingConfig().TagSet[:1]})),
+				cl: NewTaggingConfigurationClient(fake.MockBucketClient{
+					MockPutBucketTagging: func(ctx context.Context, input *s3.PutBucketTaggingInput, opts []func(*s3.Options)) (*s3.PutBucketTaggingOutput, error) {
+						if diff := cmp.Diff(generateAWSTagging().TagSet[:1], input.Tagging.TagSet, cmpopts.IgnoreTypes(document.NoSerde{})); diff != "" {
+							return nil, errors.New(diff)
+						}
+						return &s3.PutBucketTaggingOutput{}, nil
+					},
+				}, nil),
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"TemplatedValue": {
+			args: args{
+				b: templatedBucket(templatedTagValue),
+				cl: NewTaggingConfigurationClient(fake.MockBucketClient{
+					MockPutBucketTagging: func(ctx context.Context, input *s3.PutBucketTaggingInput, opts []func(*s3.Options)) (*s3.PutBucketTaggingOutput, error) {
+						if diff := cmp.Diff(generateAWSTemplatedTagging(resolvedTagValue), input.Tagging.TagSet, cmpopts.IgnoreTypes(document.NoSerde{})); diff != "" {
+							return nil, errors.New(diff)
+						}
+						return &s3.PutBucketTaggingOutput{}, nil
+					},
+				}, nil),
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"InvalidTemplate": {
+			args: args{
+				b: templatedBucket("{{ upper .metadata.name }}"),
+				cl: NewTaggingConfigurationClient(fake.MockBucketClient{
+					MockPutBucketTagging: func(ctx context.Context, input *s3.PutBucketTaggingInput, opts []func(*s3.Options)) (*s3.PutBucketTaggingOutput, error) {
+						return &s3.PutBucketTaggingOutput{}, nil
+					},
+				}, nil),
+			},
+			want: want{
+				err: errors.Wrapf(errors.New(`template: crossplane-resource:1: function "upper" not defined`),
+					taggingTemplateFailed, templatedTagKey),
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -290,7 +467,7 @@ func TestTaggingDelete(t *testing.T) {
 					MockDeleteBucketTagging: func(ctx context.Context, input *s3.DeleteBucketTaggingInput, opts []func(*s3.Options)) (*s3.DeleteBucketTaggingOutput, error) {
 						return nil, errBoom
 					},
-				}),
+				}, nil),
 			},
 			want: want{
 				err: awsclient.Wrap(errBoom, taggingDeleteFailed),
@@ -303,7 +480,7 @@ func TestTaggingDelete(t *testing.T) {
 					MockDeleteBucketTagging: func(ctx context.Context, input *s3.DeleteBucketTaggingInput, opts []func(*s3.Options)) (*s3.DeleteBucketTaggingOutput, error) {
 						return &s3.DeleteBucketTaggingOutput{}, nil
 					},
-				}),
+				}, nil),
 			},
 			want: want{
 				err: nil,
@@ -343,7 +520,7 @@ func TestTaggingLateInit(t *testing.T) {
 					MockGetBucketTagging: func(ctx context.Context, input *s3.GetBucketTaggingInput, opts []func(*s3.Options)) (*s3.GetBucketTaggingOutput, error) {
 						return nil, errBoom
 					},
-				}),
+				}, nil),
 			},
 			want: want{
 				err: awsclient.Wrap(errBoom, taggingGetFailed),
@@ -357,7 +534,7 @@ func TestTaggingLateInit(t *testing.T) {
 					MockGetBucketTagging: func(ctx context.Context, input *s3.GetBucketTaggingInput, opts []func(*s3.Options)) (*s3.GetBucketTaggingOutput, error) {
 						return nil, &smithy.GenericAPIError{Code: clientss3.TaggingNotFoundErrCode}
 					},
-				}),
+				}, nil),
 			},
 			want: want{
 				err: nil,
@@ -371,7 +548,7 @@ func TestTaggingLateInit(t *testing.T) {
 					MockGetBucketTagging: func(ctx context.Context, input *s3.GetBucketTaggingInput, opts []func(*s3.Options)) (*s3.GetBucketTaggingOutput, error) {
 						return &s3.GetBucketTaggingOutput{TagSet: nil}, nil
 					},
-				}),
+				}, nil),
 			},
 			want: want{
 				err: nil,
@@ -385,7 +562,7 @@ func TestTaggingLateInit(t *testing.T) {
 					MockGetBucketTagging: func(ctx context.Context, input *s3.GetBucketTaggingInput, opts []func(*s3.Options)) (*s3.GetBucketTaggingOutput, error) {
 						return &s3.GetBucketTaggingOutput{TagSet: []types.Tag{}}, nil
 					},
-				}),
+				}, nil),
 			},
 			want: want{
 				err: nil,
@@ -399,7 +576,7 @@ func TestTaggingLateInit(t *testing.T) {
 					MockGetBucketTagging: func(ctx context.Context, input *s3.GetBucketTaggingInput, opts []func(*s3.Options)) (*s3.GetBucketTaggingOutput, error) {
 						return &s3.GetBucketTaggingOutput{TagSet: generateAWSTagging().TagSet}, nil
 					},
-				}),
+				}, nil),
 			},
 			want: want{
 				err: nil,
@@ -413,7 +590,7 @@ func TestTaggingLateInit(t *testing.T) {
 					MockGetBucketTagging: func(ctx context.Context, input *s3.GetBucketTaggingInput, opts []func(*s3.Options)) (*s3.GetBucketTaggingOutput, error) {
 						return &s3.GetBucketTaggingOutput{TagSet: []types.Tag{}}, nil
 					},
-				}),
+				}, nil),
 			},
 			want: want{
 				err: nil,
@@ -0,0 +1,101 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bucket
+
+import (
+	"net"
+	"regexp"
+
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/provider-aws/apis/s3/v1beta1"
+	"github.com/crossplane/provider-aws/pkg/clients/s3"
+)
+
+const (
+	bucketNameLength     = "bucket name must be between 3 and 63 characters long"
+	bucketNameCharacters = "bucket name must consist of lowercase letters, digits, dots and hyphens, and begin and end with a letter or digit"
+	bucketNameIPAddress  = "bucket name must not be formatted as an IP address"
+)
+
+var bucketNameRegexp = regexp.MustCompile(`^[a-z0-9][a-z0-9.-]*[a-z0-9]$`)
+
+// ValidateBucketParameters runs the validations the subresource clients
+// perform before calling AWS against the supplied bucket name and parameters
+// and returns all errors it finds. Each error is prefixed with the field it
+// refers to. It does not call AWS, so it can be used e.g. to check a Bucket in
+// CI or by an admission webhook. An empty name is not validated, since the
+// name of a Bucket may only be known once it is created. Values that are read
+// from secrets or a baseline ConfigMap are not validated.
+func ValidateBucketParameters(name string, params *v1beta1.BucketParameters) []error {
+	var errs []error
+	add := func(field string, err error) {
+		if err != nil {
+			errs = append(errs, errors.Wrap(err, field))
+		}
+	}
+	if name != "" {
+		add("name", validateBucketName(name))
+	}
+	if !s3.ACLsDisabled(*params) {
+		_, err := GenerateGrants(*params, nil)
+		add("acl", err)
+	}
+	if c := params.ServerSideEncryptionConfiguration; c != nil {
+		add("serverSideEncryptionConfiguration", validateSSEConfiguration(c))
+	}
+	if c := params.OwnershipControls; c != nil {
+		add("ownershipControls", validateOwnershipControls(c))
+	}
+	if c := params.ObjectLockConfiguration; c != nil {
+		add("objectLockConfiguration", validateObjectLockConfiguration(c))
+	}
+	if c := params.LifecycleConfiguration; c != nil {
+		add("lifecycleConfiguration", validateLifecycleRules(c.Rules))
+	}
+	if c := params.CORSConfiguration; c != nil {
+		add("corsConfiguration", validateCORSRules(c.CORSRules))
+	}
+	if c := params.LoggingConfiguration; c != nil && !loggingDisabled(c) {
+		add("loggingConfiguration", validateTargetGrants(c.TargetGrants))
+	}
+	if c := params.NotificationConfiguration; c != nil {
+		add("notificationConfiguration", validateNotificationFilters(c))
+	}
+	if c := params.WebsiteConfiguration; c != nil {
+		add("websiteConfiguration", validateWebsiteConfiguration(c))
+	}
+	add("metricsConfigurations", validateMetricsConfigurations(params.MetricsConfigurations))
+	add("inventoryConfigurations", validateInventoryConfigurations(params.InventoryConfigurations))
+	add("intelligentTieringConfigurations", validateIntelligentTieringConfigurations(params.IntelligentTieringConfigurations))
+	add("accelerateConfiguration", validateAccelerateRegion(params))
+	return errs
+}
+
+// validateBucketName checks the name against the naming rules of S3 for
+// buckets in all regions.
+func validateBucketName(name string) error {
+	switch {
+	case len(name) < 3 || len(name) > 63:
+		return errors.New(bucketNameLength)
+	case !bucketNameRegexp.MatchString(name):
+		return errors.New(bucketNameCharacters)
+	case net.ParseIP(name) != nil:
+		return errors.New(bucketNameIPAddress)
+	}
+	return nil
+}
@@ -0,0 +1,284 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bucket
+
+import (
+	"strings"
+	"testing"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/provider-aws/apis/s3/v1beta1"
+	awsclient "github.com/crossplane/provider-aws/pkg/clients"
+)
+
+func TestValidateBucketParameters(t *testing.T) {
+	cases := map[string]struct {
+		name   string
+		params *v1beta1.BucketParameters
+		want   []error
+	}{
+		"Empty": {
+			params: &v1beta1.BucketParameters{},
+		},
+		"Valid": {
+			params: &v1beta1.BucketParameters{
+				ServerSideEncryptionConfiguration: generateMultiRegionKMSSSEConfig("us-east-1"),
+				CORSConfiguration:                 &v1beta1.CORSConfiguration{CORSRules: []v1beta1.CORSRule{{AllowedMethods: []string{"GET", "PUT"}}}},
+				AccelerateConfiguration:           &v1beta1.AccelerateConfiguration{Status: "Enabled"},
+				LocationConstraint:                "us-east-1",
+			},
+		},
+		"ValidName": {
+			name:   "logs.example-bucket-1",
+			params: &v1beta1.BucketParameters{},
+		},
+		"NameTooShort": {
+			name:   "ab",
+			params: &v1beta1.BucketParameters{},
+			want: []error{
+				errors.Wrap(errors.New(bucketNameLength), "name"),
+			},
+		},
+		"NameTooLong": {
+			name:   strings.Repeat("a", 64),
+			params: &v1beta1.BucketParameters{},
+			want: []error{
+				errors.Wrap(errors.New(bucketNameLength), "name"),
+			},
+		},
+		"NameWithUppercaseLetters": {
+			name:   "My-Bucket",
+			params: &v1beta1.BucketParameters{},
+			want: []error{
+				errors.Wrap(errors.New(bucketNameCharacters), "name"),
+			},
+		},
+		"NameWithUnderscore": {
+			name:   "my_bucket",
+			params: &v1beta1.BucketParameters{},
+			want: []error{
+				errors.Wrap(errors.New(bucketNameCharacters), "name"),
+			},
+		},
+		"NameEndsWithHyphen": {
+			name:   "my-bucket-",
+			params: &v1beta1.BucketParameters{},
+			want: []error{
+				errors.Wrap(errors.New(bucketNameCharacters), "name"),
+			},
+		},
+		"NameFormattedAsIPAddress": {
+			name:   "192.168.5.4",
+			params: &v1beta1.BucketParameters{},
+			want: []error{
+				errors.Wrap(errors.New(bucketNameIPAddress), "name"),
+			},
+		},
+		"MultipleErrors": {
+			name: "-bucket",
+			params: &v1beta1.BucketParameters{
+				// A KMS key must not be set for AES256.
+				ServerSideEncryptionConfiguration: generateSSEConfig(),
+				LifecycleConfiguration: &v1beta1.BucketLifecycleConfiguration{Rules: []v1beta1.LifecycleRule{{
+					Status:      "Enabled",
+					Transitions: []v1beta1.Transition{{Date: &date, Days: days, StorageClass: "GLACIER"}},
+				}}},
+				CORSConfiguration: &v1beta1.CORSConfiguration{CORSRules: []v1beta1.CORSRule{{AllowedMethods: []string{"GET", "PATCH"}}}},
+				WebsiteConfiguration: &v1beta1.WebsiteConfiguration{
+					IndexDocument: &v1beta1.IndexDocument{Suffix: "docs/index.html"},
+				},
+				AccelerateConfiguration: &v1beta1.AccelerateConfiguration{Status: "Enabled"},
+				LocationConstraint:      "cn-north-1",
+			},
+			want: []error{
+				errors.Wrap(errors.New(bucketNameCharacters), "name"),
+				errors.Wrap(errors.Errorf(sseKMSKeyWithoutKMS, 0), "serverSideEncryptionConfiguration"),
+				errors.Wrap(errors.Errorf(lifecycleInvalidTransition, 0, 0), "lifecycleConfiguration"),
+				errors.Wrap(errors.Errorf(corsInvalidMethod, 0, "PATCH"), "corsConfiguration"),
+				errors.Wrap(errors.Errorf(websiteInvalidIndexSuffix, "docs/index.html"), "websiteConfiguration"),
+				errors.Wrap(errors.Errorf(accelUnsupportedRegion, "cn-north-1"), "accelerateConfiguration"),
+			},
+		},
+		"WebsiteErrorDocumentOnly": {
+			params: &v1beta1.BucketParameters{
+				WebsiteConfiguration: &v1beta1.WebsiteConfiguration{
+					ErrorDocument: &v1beta1.ErrorDocument{Key: "error.html"},
+				},
+			},
+			want: []error{
+				errors.Wrap(errors.New(websiteMissingIndex), "websiteConfiguration"),
+			},
+		},
+		"InvalidKMSKeyID": {
+			params: &v1beta1.BucketParameters{
+				ServerSideEncryptionConfiguration: &v1beta1.ServerSideEncryptionConfiguration{Rules: []v1beta1.ServerSideEncryptionRule{{
+					ApplyServerSideEncryptionByDefault: v1beta1.ServerSideEncryptionByDefault{
+						SSEAlgorithm:   "SSE-KMS",
+						KMSMasterKeyID: awsclient.String("not-a-key"),
+					},
+				}}},
+			},
+			want: []error{
+				errors.Wrap(errors.Errorf("invalid KMS key %q, must be a key ID, key ARN, alias name or alias ARN", "not-a-key"), "serverSideEncryptionConfiguration"),
+			},
+		},
+		"KMSKeySelectorWithAES256": {
+			params: &v1beta1.BucketParameters{
+				ServerSideEncryptionConfiguration: &v1beta1.ServerSideEncryptionConfiguration{Rules: []v1beta1.ServerSideEncryptionRule{{
+					ApplyServerSideEncryptionByDefault: v1beta1.ServerSideEncryptionByDefault{
+						SSEAlgorithm:           "AES256",
+						KMSMasterKeyIDSelector: &xpv1.Selector{MatchLabels: map[string]string{"app": "bucket"}},
+					},
+				}}},
+			},
+			want: []error{
+				errors.Wrap(errors.Errorf(sseKMSKeyWithoutKMS, 0), "serverSideEncryptionConfiguration"),
+			},
+		},
+		"KMSKeyWithoutAlgorithm": {
+			params: &v1beta1.BucketParameters{
+				ServerSideEncryptionConfiguration: &v1beta1.ServerSideEncryptionConfiguration{Rules: []v1beta1.ServerSideEncryptionRule{{
+					ApplyServerSideEncryptionByDefault: v1beta1.ServerSideEncryptionByDefault{KMSMasterKeyID: awsclient.String(keyID)},
+					BucketKeyEnabled:                   awsclient.Bool(true),
+				}}},
+			},
+		},
+		"KMSKeyRefWithoutAlgorithm": {
+			params: &v1beta1.BucketParameters{
+				ServerSideEncryptionConfiguration: &v1beta1.ServerSideEncryptionConfiguration{Rules: []v1beta1.ServerSideEncryptionRule{{
+					ApplyServerSideEncryptionByDefault: v1beta1.ServerSideEncryptionByDefault{KMSMasterKeyIDRef: &xpv1.Reference{Name: "key"}},
+				}}},
+			},
+		},
+		"MissingSSEAlgorithm": {
+			params: &v1beta1.BucketParameters{
+				ServerSideEncryptionConfiguration: &v1beta1.ServerSideEncryptionConfiguration{Rules: []v1beta1.ServerSideEncryptionRule{{}}},
+			},
+			want: []error{
+				errors.Wrap(errors.Errorf(sseAlgorithmMissing, 0), "serverSideEncryptionConfiguration"),
+			},
+		},
+		"BucketKeyWithAES256": {
+			params: &v1beta1.BucketParameters{
+				ServerSideEncryptionConfiguration: &v1beta1.ServerSideEncryptionConfiguration{Rules: []v1beta1.ServerSideEncryptionRule{{
+					ApplyServerSideEncryptionByDefault: v1beta1.ServerSideEncryptionByDefault{SSEAlgorithm: "SSE-S3"},
+					BucketKeyEnabled:                   awsclient.Bool(true),
+				}}},
+			},
+			want: []error{
+				errors.Wrap(errors.Errorf(sseBucketKeyWithoutKMS, 0), "serverSideEncryptionConfiguration"),
+			},
+		},
+		"BucketKeyWithKMS": {
+			params: &v1beta1.BucketParameters{
+				ServerSideEncryptionConfiguration: &v1beta1.ServerSideEncryptionConfiguration{Rules: []v1beta1.ServerSideEncryptionRule{{
+					ApplyServerSideEncryptionByDefault: v1beta1.ServerSideEncryptionByDefault{SSEAlgorithm: "aws:kms"},
+					BucketKeyEnabled:                   awsclient.Bool(true),
+				}}},
+			},
+		},
+		"TooManyOwnershipControlsRules": {
+			params: &v1beta1.BucketParameters{
+				OwnershipControls: &v1beta1.OwnershipControls{Rules: []v1beta1.OwnershipControlsRule{
+					{ObjectOwnership: "ObjectWriter"},
+					{ObjectOwnership: "BucketOwnerEnforced"},
+				}},
+			},
+			want: []error{
+				errors.Wrap(errors.Errorf(ownershipControlsRuleCount, 2), "ownershipControls"),
+			},
+		},
+		"EmailAddressGrantee": {
+			params: &v1beta1.BucketParameters{
+				GrantRead: awsclient.String(`emailAddress="user@example.com"`),
+			},
+			want: []error{
+				errors.Wrap(errors.Errorf(aclEmailGrantee, `emailAddress="user@example.com"`, "grantRead"), "acl"),
+			},
+		},
+		"EmailAddressGranteeACLsDisabled": {
+			params: &v1beta1.BucketParameters{
+				GrantRead:         awsclient.String(`emailAddress="user@example.com"`),
+				OwnershipControls: &v1beta1.OwnershipControls{Rules: []v1beta1.OwnershipControlsRule{{ObjectOwnership: "BucketOwnerEnforced"}}},
+			},
+		},
+		"DuplicateMetricsConfigurationID": {
+			params: &v1beta1.BucketParameters{
+				MetricsConfigurations: []v1beta1.MetricsConfiguration{{ID: "all"}, {ID: "all"}},
+			},
+			want: []error{
+				errors.Wrap(errors.Errorf(metricsDuplicateID, "all"), "metricsConfigurations"),
+			},
+		},
+		"DuplicateInventoryConfigurationID": {
+			params: &v1beta1.BucketParameters{
+				InventoryConfigurations: []v1beta1.InventoryConfiguration{{ID: "daily"}, {ID: "daily"}},
+			},
+			want: []error{
+				errors.Wrap(errors.Errorf(inventoryDuplicateID, "daily"), "inventoryConfigurations"),
+			},
+		},
+		"IntelligentTieringDaysOutOfRange": {
+			params: &v1beta1.BucketParameters{
+				IntelligentTieringConfigurations: []v1beta1.IntelligentTieringConfiguration{{
+					ID:       "archive",
+					Tierings: []v1beta1.Tiering{{AccessTier: "DEEP_ARCHIVE_ACCESS", Days: 179}},
+				}},
+			},
+			want: []error{
+				errors.Wrap(errors.Errorf(intelligentTieringDaysOutOfRange, "archive", "DEEP_ARCHIVE_ACCESS", 180, 730, 179), "intelligentTieringConfigurations"),
+			},
+		},
+		"ObjectLockDaysAndYears": {
+			params: &v1beta1.BucketParameters{
+				ObjectLockConfiguration: &v1beta1.ObjectLockConfiguration{
+					ObjectLockEnabled: "Enabled",
+					Rule: &v1beta1.ObjectLockRule{DefaultRetention: v1beta1.DefaultRetention{
+						Mode:  "GOVERNANCE",
+						Days:  awsclient.Int32(1),
+						Years: awsclient.Int32(1),
+					}},
+				},
+			},
+			want: []error{
+				errors.Wrap(errors.New(objectLockRetentionPeriod), "objectLockConfiguration"),
+			},
+		},
+		"DisabledLoggingIsNotValidated": {
+			params: &v1beta1.BucketParameters{
+				LoggingConfiguration: func() *v1beta1.LoggingConfiguration {
+					c := generateLoggingConfigWithEnabled(false)
+					c.TargetGrants[0].Grantee.Type = "Unknown"
+					return c
+				}(),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := ValidateBucketParameters(tc.name, tc.params)
+			if diff := cmp.Diff(tc.want, got, test.EquateErrors()); diff != "" {
+				t.Errorf("ValidateBucketParameters(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
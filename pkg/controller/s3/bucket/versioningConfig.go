@@ -29,8 +29,9 @@ import (
 )
 
 const (
-	versioningGetFailed = "cannot get Bucket versioning configuration"
-	versioningPutFailed = "cannot put Bucket versioning configuration"
+	versioningGetFailed    = "cannot get Bucket versioning configuration"
+	versioningPutFailed    = "cannot put Bucket versioning configuration"
+	versioningDeleteFailed = "cannot suspend Bucket versioning"
 )
 
 // VersioningConfigurationClient is the client for API methods and reconciling the VersioningConfiguration
@@ -50,6 +51,12 @@ func (in *VersioningConfigurationClient) Observe(ctx context.Context, bucket *v1
 		return NeedsUpdate, awsclient.Wrap(err, versioningGetFailed)
 	}
 	if bucket.Spec.ForProvider.VersioningConfiguration == nil {
+		// Versioning cannot be removed from a bucket once it was enabled, it
+		// can only be suspended. A bucket that was never versioned reports no
+		// status at all.
+		if external.Status == awss3types.BucketVersioningStatusEnabled {
+			return NeedsDeletion, nil
+		}
 		return Updated, nil
 	}
 	if string(external.Status) != awsclient.StringValue(bucket.Spec.ForProvider.VersioningConfiguration.Status) ||
@@ -69,9 +76,16 @@ func (in *VersioningConfigurationClient) CreateOrUpdate(ctx context.Context, buc
 	return awsclient.Wrap(err, versioningPutFailed)
 }
 
-// Delete does nothing because there is no corresponding deletion call in awsclient.
-func (*VersioningConfigurationClient) Delete(_ context.Context, _ *v1beta1.Bucket) error {
-	return nil
+// Delete suspends versioning on the bucket, since there is no corresponding
+// deletion call in awsclient.
+func (in *VersioningConfigurationClient) Delete(ctx context.Context, bucket *v1beta1.Bucket) error {
+	_, err := in.client.PutBucketVersioning(ctx, &awss3.PutBucketVersioningInput{
+		Bucket: awsclient.String(meta.GetExternalName(bucket)),
+		VersioningConfiguration: &awss3types.VersioningConfiguration{
+			Status: awss3types.BucketVersioningStatusSuspended,
+		},
+	})
+	return awsclient.Wrap(err, versioningDeleteFailed)
 }
 
 // LateInitialize is responsible for initializing the resource based on the external value
@@ -95,6 +109,11 @@ func (in *VersioningConfigurationClient) LateInitialize(ctx context.Context, buc
 	return nil
 }
 
+// CapturePrior returns a Bucket with the versioning configuration currently in AWS.
+func (in *VersioningConfigurationClient) CapturePrior(ctx context.Context, bucket *v1beta1.Bucket) (*v1beta1.Bucket, error) {
+	return lateInitializedPrior(ctx, in, bucket)
+}
+
 // SubresourceExists checks if the subresource this controller manages currently exists
 func (in *VersioningConfigurationClient) SubresourceExists(bucket *v1beta1.Bucket) bool {
 	return bucket.Spec.ForProvider.VersioningConfiguration != nil
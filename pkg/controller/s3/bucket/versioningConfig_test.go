@@ -107,6 +107,34 @@ func TestVersioningObserve(t *testing.T) {
 				err:    nil,
 			},
 		},
+		"NoUpdateSuspendedNotExists": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithVersioningConfig(nil)),
+				cl: NewVersioningConfigurationClient(fake.MockBucketClient{
+					MockGetBucketVersioning: func(ctx context.Context, input *s3.GetBucketVersioningInput, opts []func(*s3.Options)) (*s3.GetBucketVersioningOutput, error) {
+						return &s3.GetBucketVersioningOutput{Status: s3types.BucketVersioningStatusSuspended}, nil
+					},
+				}),
+			},
+			want: want{
+				status: Updated,
+				err:    nil,
+			},
+		},
+		"NeedsDeletion": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithVersioningConfig(nil)),
+				cl: NewVersioningConfigurationClient(fake.MockBucketClient{
+					MockGetBucketVersioning: func(ctx context.Context, input *s3.GetBucketVersioningInput, opts []func(*s3.Options)) (*s3.GetBucketVersioningOutput, error) {
+						return &s3.GetBucketVersioningOutput{Status: s3types.BucketVersioningStatusEnabled}, nil
+					},
+				}),
+			},
+			want: want{
+				status: NeedsDeletion,
+				err:    nil,
+			},
+		},
 		"NoUpdateExists": {
 			args: args{
 				b: s3Testing.Bucket(s3Testing.WithVersioningConfig(generateVersioningConfig())),
@@ -204,6 +232,61 @@ func TestVersioningCreateOrUpdate(t *testing.T) {
 	}
 }
 
+func TestVersioningDelete(t *testing.T) {
+	type args struct {
+		cl *VersioningConfigurationClient
+		b  *v1beta1.Bucket
+	}
+
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"Error": {
+			args: args{
+				b: s3Testing.Bucket(),
+				cl: NewVersioningConfigurationClient(fake.MockBucketClient{
+					MockPutBucketVersioning: func(ctx context.Context, input *s3.PutBucketVersioningInput, opts []func(*s3.Options)) (*s3.PutBucketVersioningOutput, error) {
+						return nil, errBoom
+					},
+				}),
+			},
+			want: want{
+				err: awsclient.Wrap(errBoom, versioningDeleteFailed),
+			},
+		},
+		"SuccessfulSuspend": {
+			args: args{
+				b: s3Testing.Bucket(),
+				cl: NewVersioningConfigurationClient(fake.MockBucketClient{
+					MockPutBucketVersioning: func(ctx context.Context, input *s3.PutBucketVersioningInput, opts []func(*s3.Options)) (*s3.PutBucketVersioningOutput, error) {
+						if input.VersioningConfiguration.Status != s3types.BucketVersioningStatusSuspended {
+							return nil, errBoom
+						}
+						return &s3.PutBucketVersioningOutput{}, nil
+					},
+				}),
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.args.cl.Delete(context.Background(), tc.args.b)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestVersioningLateInit(t *testing.T) {
 	type args struct {
 		cl SubresourceClient
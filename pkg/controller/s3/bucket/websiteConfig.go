@@ -18,6 +18,7 @@ package bucket
 
 import (
 	"context"
+	"strings"
 
 	"github.com/aws/smithy-go/document"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -27,6 +28,7 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
 
 	"github.com/crossplane/provider-aws/apis/s3/v1beta1"
 	awsclient "github.com/crossplane/provider-aws/pkg/clients"
@@ -37,6 +39,10 @@ const (
 	websiteGetFailed    = "cannot get Bucket website configuration"
 	websitePutFailed    = "cannot put Bucket website configuration"
 	websiteDeleteFailed = "cannot delete Bucket website configuration"
+
+	websiteInvalidIndexSuffix = "index document suffix %q of the website configuration must not be empty and must not contain a slash"
+	websiteRedirectAllNotOnly = "redirectAllRequestsTo of the website configuration cannot be combined with an index document, an error document or routing rules"
+	websiteMissingIndex       = "indexDocument of the website configuration is required unless redirectAllRequestsTo is set"
 )
 
 // WebsiteConfigurationClient is the client for API methods and reconciling the WebsiteConfiguration
@@ -75,7 +81,10 @@ func (in *WebsiteConfigurationClient) Observe(ctx context.Context, bucket *v1bet
 		RoutingRules:          external.RoutingRules,
 	}
 
-	if cmp.Equal(confBody, source, cmpopts.IgnoreTypes(document.NoSerde{})) {
+	// AWS omits empty routing rules, e.g. of a configuration that redirects
+	// all requests, and does not preserve the case of the redirect protocol.
+	if cmp.Equal(confBody, source, cmpopts.IgnoreTypes(document.NoSerde{}), cmpopts.EquateEmpty(),
+		cmp.Comparer(func(a, b types.Protocol) bool { return s3.EnumEqual(string(a), string(b)) })) {
 		return Updated, nil
 	}
 
@@ -87,11 +96,34 @@ func (in *WebsiteConfigurationClient) CreateOrUpdate(ctx context.Context, bucket
 	if bucket.Spec.ForProvider.WebsiteConfiguration == nil {
 		return nil
 	}
+	if err := validateWebsiteConfiguration(bucket.Spec.ForProvider.WebsiteConfiguration); err != nil {
+		return err
+	}
 	input := GeneratePutBucketWebsiteInput(meta.GetExternalName(bucket), bucket.Spec.ForProvider.WebsiteConfiguration)
 	_, err := in.client.PutBucketWebsite(ctx, input)
 	return awsclient.Wrap(err, websitePutFailed)
 }
 
+// validateWebsiteConfiguration returns an error for configurations that AWS
+// rejects with an unhelpful message.
+func validateWebsiteConfiguration(config *v1beta1.WebsiteConfiguration) error {
+	if config.RedirectAllRequestsTo != nil && (config.IndexDocument != nil || config.ErrorDocument != nil || len(config.RoutingRules) != 0) {
+		return errors.New(websiteRedirectAllNotOnly)
+	}
+	if config.RedirectAllRequestsTo != nil {
+		return nil
+	}
+	// An error document, or routing rules, cannot be served without an
+	// index document.
+	if config.IndexDocument == nil {
+		return errors.New(websiteMissingIndex)
+	}
+	if suffix := config.IndexDocument.Suffix; suffix == "" || strings.Contains(suffix, "/") {
+		return errors.Errorf(websiteInvalidIndexSuffix, suffix)
+	}
+	return nil
+}
+
 // Delete creates the request to delete the resource on AWS or set it to the default value.
 func (in *WebsiteConfigurationClient) Delete(ctx context.Context, bucket *v1beta1.Bucket) error {
 	_, err := in.client.DeleteBucketWebsite(ctx,
@@ -126,6 +158,11 @@ func (in *WebsiteConfigurationClient) LateInitialize(ctx context.Context, bucket
 	return nil
 }
 
+// CapturePrior returns a Bucket with the website configuration currently in AWS.
+func (in *WebsiteConfigurationClient) CapturePrior(ctx context.Context, bucket *v1beta1.Bucket) (*v1beta1.Bucket, error) {
+	return lateInitializedPrior(ctx, in, bucket)
+}
+
 // SubresourceExists checks if the subresource this controller manages currently exists
 func (in *WebsiteConfigurationClient) SubresourceExists(bucket *v1beta1.Bucket) bool {
 	return bucket.Spec.ForProvider.WebsiteConfiguration != nil
@@ -179,6 +216,17 @@ func GeneratePutBucketWebsiteInput(name string, config *v1beta1.WebsiteConfigura
 }
 
 func createWebsiteConfigFromExternal(external *awss3.GetBucketWebsiteOutput, config *v1beta1.WebsiteConfiguration) { // nolint:gocyclo
+	// A website either redirects all requests or serves an index document,
+	// which AWS does not allow to be combined. Fields of the other mode are
+	// not late initialized, since the bucket is about to switch modes.
+	redirectAll := config.RedirectAllRequestsTo != nil
+	hosting := config.IndexDocument != nil || config.ErrorDocument != nil || len(config.RoutingRules) != 0
+	if redirectAll {
+		external = &awss3.GetBucketWebsiteOutput{RedirectAllRequestsTo: external.RedirectAllRequestsTo}
+	}
+	if hosting {
+		external = &awss3.GetBucketWebsiteOutput{ErrorDocument: external.ErrorDocument, IndexDocument: external.IndexDocument, RoutingRules: external.RoutingRules}
+	}
 	if external.ErrorDocument != nil {
 		if config.ErrorDocument == nil {
 			config.ErrorDocument = &v1beta1.ErrorDocument{}
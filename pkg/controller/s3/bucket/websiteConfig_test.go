@@ -25,6 +25,7 @@ import (
 	"github.com/aws/smithy-go"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
 
 	"github.com/crossplane/provider-aws/apis/s3/v1beta1"
 	awsclient "github.com/crossplane/provider-aws/pkg/clients"
@@ -50,10 +51,6 @@ func generateWebsiteConfig() *v1beta1.WebsiteConfiguration {
 	return &v1beta1.WebsiteConfiguration{
 		ErrorDocument: &v1beta1.ErrorDocument{Key: errorObjectKey},
 		IndexDocument: &v1beta1.IndexDocument{Suffix: indexSuffix},
-		RedirectAllRequestsTo: &v1beta1.RedirectAllRequestsTo{
-			HostName: hostname,
-			Protocol: webProtocol,
-		},
 		RoutingRules: []v1beta1.RoutingRule{
 			{
 				Condition: &v1beta1.Condition{
@@ -72,14 +69,28 @@ func generateWebsiteConfig() *v1beta1.WebsiteConfiguration {
 	}
 }
 
-func generateAWSWebsite() *s3types.WebsiteConfiguration {
+func generateRedirectAllWebsiteConfig() *v1beta1.WebsiteConfiguration {
+	return &v1beta1.WebsiteConfiguration{
+		RedirectAllRequestsTo: &v1beta1.RedirectAllRequestsTo{
+			HostName: hostname,
+			Protocol: webProtocol,
+		},
+	}
+}
+
+func generateAWSRedirectAllWebsite() *s3types.WebsiteConfiguration {
 	return &s3types.WebsiteConfiguration{
-		ErrorDocument: &s3types.ErrorDocument{Key: &errorObjectKey},
-		IndexDocument: &s3types.IndexDocument{Suffix: &indexSuffix},
 		RedirectAllRequestsTo: &s3types.RedirectAllRequestsTo{
 			HostName: &hostname,
 			Protocol: s3types.ProtocolHttps,
 		},
+	}
+}
+
+func generateAWSWebsite() *s3types.WebsiteConfiguration {
+	return &s3types.WebsiteConfiguration{
+		ErrorDocument: &s3types.ErrorDocument{Key: &errorObjectKey},
+		IndexDocument: &s3types.IndexDocument{Suffix: &indexSuffix},
 		RoutingRules: []s3types.RoutingRule{
 			{
 				Condition: &s3types.Condition{
@@ -223,6 +234,66 @@ func TestWebsiteObserve(t *testing.T) {
 				err:    nil,
 			},
 		},
+		"NoUpdateRedirectAll": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithWebConfig(generateRedirectAllWebsiteConfig())),
+				cl: NewWebsiteConfigurationClient(fake.MockBucketClient{
+					MockGetBucketWebsite: func(ctx context.Context, input *s3.GetBucketWebsiteInput, opts []func(*s3.Options)) (*s3.GetBucketWebsiteOutput, error) {
+						return &s3.GetBucketWebsiteOutput{RedirectAllRequestsTo: generateAWSRedirectAllWebsite().RedirectAllRequestsTo}, nil
+					},
+				}),
+			},
+			want: want{
+				status: Updated,
+				err:    nil,
+			},
+		},
+		"UpdateNeededRedirectAllHost": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithWebConfig(generateRedirectAllWebsiteConfig())),
+				cl: NewWebsiteConfigurationClient(fake.MockBucketClient{
+					MockGetBucketWebsite: func(ctx context.Context, input *s3.GetBucketWebsiteInput, opts []func(*s3.Options)) (*s3.GetBucketWebsiteOutput, error) {
+						return &s3.GetBucketWebsiteOutput{RedirectAllRequestsTo: &s3types.RedirectAllRequestsTo{
+							HostName: awsclient.String("other-hostname"),
+							Protocol: s3types.ProtocolHttps,
+						}}, nil
+					},
+				}),
+			},
+			want: want{
+				status: NeedsUpdate,
+				err:    nil,
+			},
+		},
+		"UpdateNeededRedirectAllToIndex": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithWebConfig(generateWebsiteConfig())),
+				cl: NewWebsiteConfigurationClient(fake.MockBucketClient{
+					MockGetBucketWebsite: func(ctx context.Context, input *s3.GetBucketWebsiteInput, opts []func(*s3.Options)) (*s3.GetBucketWebsiteOutput, error) {
+						return &s3.GetBucketWebsiteOutput{RedirectAllRequestsTo: generateAWSRedirectAllWebsite().RedirectAllRequestsTo}, nil
+					},
+				}),
+			},
+			want: want{
+				status: NeedsUpdate,
+				err:    nil,
+			},
+		},
+		"UpdateNeededIndexToRedirectAll": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithWebConfig(generateRedirectAllWebsiteConfig())),
+				cl: NewWebsiteConfigurationClient(fake.MockBucketClient{
+					MockGetBucketWebsite: func(ctx context.Context, input *s3.GetBucketWebsiteInput, opts []func(*s3.Options)) (*s3.GetBucketWebsiteOutput, error) {
+						w := generateAWSWebsite()
+						return &s3.GetBucketWebsiteOutput{ErrorDocument: w.ErrorDocument, IndexDocument: w.IndexDocument, RoutingRules: w.RoutingRules}, nil
+					},
+				}),
+			},
+			want: want{
+				status: NeedsUpdate,
+				err:    nil,
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -238,6 +309,12 @@ func TestWebsiteObserve(t *testing.T) {
 	}
 }
 
+func generateWebsiteConfigWithIndexSuffix(suffix string) *v1beta1.WebsiteConfiguration {
+	config := generateWebsiteConfig()
+	config.IndexDocument.Suffix = suffix
+	return config
+}
+
 func TestWebsiteCreateOrUpdate(t *testing.T) {
 	type args struct {
 		cl *WebsiteConfigurationClient
@@ -265,6 +342,74 @@ func TestWebsiteCreateOrUpdate(t *testing.T) {
 				err: awsclient.Wrap(errBoom, websitePutFailed),
 			},
 		},
+		"EmptyIndexSuffix": {
+			args: args{
+				b:  s3Testing.Bucket(s3Testing.WithWebConfig(generateWebsiteConfigWithIndexSuffix(""))),
+				cl: NewWebsiteConfigurationClient(fake.MockBucketClient{}),
+			},
+			want: want{
+				err: errors.Errorf(websiteInvalidIndexSuffix, ""),
+			},
+		},
+		"IndexSuffixWithSlash": {
+			args: args{
+				b:  s3Testing.Bucket(s3Testing.WithWebConfig(generateWebsiteConfigWithIndexSuffix("docs/index.html"))),
+				cl: NewWebsiteConfigurationClient(fake.MockBucketClient{}),
+			},
+			want: want{
+				err: errors.Errorf(websiteInvalidIndexSuffix, "docs/index.html"),
+			},
+		},
+		"ErrorDocumentWithoutIndexDocument": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithWebConfig(&v1beta1.WebsiteConfiguration{
+					ErrorDocument: &v1beta1.ErrorDocument{Key: errorObjectKey},
+				})),
+				cl: NewWebsiteConfigurationClient(fake.MockBucketClient{}),
+			},
+			want: want{
+				err: errors.New(websiteMissingIndex),
+			},
+		},
+		"RedirectAllWithIndexDocument": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithWebConfig(func() *v1beta1.WebsiteConfiguration {
+					c := generateRedirectAllWebsiteConfig()
+					c.IndexDocument = &v1beta1.IndexDocument{Suffix: indexSuffix}
+					return c
+				}())),
+				cl: NewWebsiteConfigurationClient(fake.MockBucketClient{}),
+			},
+			want: want{
+				err: errors.New(websiteRedirectAllNotOnly),
+			},
+		},
+		"RedirectAllWithRoutingRules": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithWebConfig(func() *v1beta1.WebsiteConfiguration {
+					c := generateRedirectAllWebsiteConfig()
+					c.RoutingRules = generateWebsiteConfig().RoutingRules
+					return c
+				}())),
+				cl: NewWebsiteConfigurationClient(fake.MockBucketClient{}),
+			},
+			want: want{
+				err: errors.New(websiteRedirectAllNotOnly),
+			},
+		},
+		"SuccessfulCreateRedirectAll": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithWebConfig(generateRedirectAllWebsiteConfig())),
+				cl: NewWebsiteConfigurationClient(fake.MockBucketClient{
+					MockPutBucketWebsite: func(ctx context.Context, input *s3.PutBucketWebsiteInput, opts []func(*s3.Options)) (*s3.PutBucketWebsiteOutput, error) {
+						return &s3.PutBucketWebsiteOutput{}, nil
+					},
+				}),
+			},
+			want: want{
+				err: nil,
+			},
+		},
 		"InvalidConfig": {
 			args: args{
 				b: s3Testing.Bucket(s3Testing.WithWebConfig(generateWebsiteConfig())),
@@ -432,6 +577,40 @@ func TestWebsiteLateInit(t *testing.T) {
 				cr:  s3Testing.Bucket(s3Testing.WithWebConfig(generateWebsiteConfig())),
 			},
 		},
+		"NoLateInitIndexWhenRedirectingAll": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithWebConfig(generateRedirectAllWebsiteConfig())),
+				cl: NewWebsiteConfigurationClient(fake.MockBucketClient{
+					MockGetBucketWebsite: func(tx context.Context, input *s3.GetBucketWebsiteInput, opts []func(*s3.Options)) (*s3.GetBucketWebsiteOutput, error) {
+						return &s3.GetBucketWebsiteOutput{
+							ErrorDocument: generateAWSWebsite().ErrorDocument,
+							IndexDocument: generateAWSWebsite().IndexDocument,
+							RoutingRules:  generateAWSWebsite().RoutingRules,
+						}, nil
+					},
+				}),
+			},
+			want: want{
+				err: nil,
+				cr:  s3Testing.Bucket(s3Testing.WithWebConfig(generateRedirectAllWebsiteConfig())),
+			},
+		},
+		"NoLateInitRedirectAllWhenHosting": {
+			args: args{
+				b: s3Testing.Bucket(s3Testing.WithWebConfig(&v1beta1.WebsiteConfiguration{IndexDocument: &v1beta1.IndexDocument{Suffix: indexSuffix}})),
+				cl: NewWebsiteConfigurationClient(fake.MockBucketClient{
+					MockGetBucketWebsite: func(tx context.Context, input *s3.GetBucketWebsiteInput, opts []func(*s3.Options)) (*s3.GetBucketWebsiteOutput, error) {
+						return &s3.GetBucketWebsiteOutput{
+							RedirectAllRequestsTo: generateAWSRedirectAllWebsite().RedirectAllRequestsTo,
+						}, nil
+					},
+				}),
+			},
+			want: want{
+				err: nil,
+				cr:  s3Testing.Bucket(s3Testing.WithWebConfig(&v1beta1.WebsiteConfiguration{IndexDocument: &v1beta1.IndexDocument{Suffix: indexSuffix}})),
+			},
+		},
 		"NoOpLateInit": {
 			args: args{
 				b: s3Testing.Bucket(s3Testing.WithWebConfig(generateWebsiteConfig())),
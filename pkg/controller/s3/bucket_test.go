@@ -19,23 +19,33 @@ package s3
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	awss3 "github.com/aws/aws-sdk-go-v2/service/s3"
 	awss3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	awsv1 "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/kms"
 	"github.com/aws/smithy-go"
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
 	k8serrors "k8s.io/apimachinery/pkg/util/errors"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 
+	lambdav1alpha1 "github.com/crossplane/provider-aws/apis/lambda/v1alpha1"
 	"github.com/crossplane/provider-aws/apis/s3/v1beta1"
 	awsclient "github.com/crossplane/provider-aws/pkg/clients"
 	clients3 "github.com/crossplane/provider-aws/pkg/clients/s3"
@@ -124,9 +134,9 @@ func TestObserve(t *testing.T) {
 				},
 			},
 		},
-		"ValidInputNoLateInitializeUpdateACLFail": {
+		"ValidInputNoLateInitializeGetACLFail": {
 			args: args{
-				s3: s3Testing.Client(s3Testing.WithPutACL(func(ctx context.Context, input *awss3.PutBucketAclInput, opts []func(*awss3.Options)) (*awss3.PutBucketAclOutput, error) {
+				s3: s3Testing.Client(s3Testing.WithGetACL(func(ctx context.Context, input *awss3.GetBucketAclInput, opts []func(*awss3.Options)) (*awss3.GetBucketAclOutput, error) {
 					return nil, errBoom
 				})),
 				cr: s3Testing.Bucket(),
@@ -135,10 +145,80 @@ func TestObserve(t *testing.T) {
 				cr: s3Testing.Bucket(
 					s3Testing.WithArn(fmt.Sprintf("arn:aws:s3:::%s", s3Testing.BucketName)),
 				),
-				err:    errBoom,
+				err:    awsclient.Wrap(errBoom, "cannot get Bucket ACL"),
 				result: managed.ExternalObservation{},
 			},
 		},
+		"OwnershipControlsBeforeACL": {
+			args: args{
+				s3: s3Testing.Client(s3Testing.WithPutACL(func(ctx context.Context, input *awss3.PutBucketAclInput, opts []func(*awss3.Options)) (*awss3.PutBucketAclOutput, error) {
+					return nil, errBoom
+				})),
+				cr: s3Testing.Bucket(s3Testing.WithOwnershipControls(ownershipControls(awss3types.ObjectOwnershipObjectWriter))),
+			},
+			want: want{
+				cr: s3Testing.Bucket(
+					s3Testing.WithOwnershipControls(ownershipControls(awss3types.ObjectOwnershipObjectWriter)),
+					s3Testing.WithArn(fmt.Sprintf("arn:aws:s3:::%s", s3Testing.BucketName)),
+				),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+			},
+		},
+		"OwnershipControlsThenACL": {
+			args: args{
+				s3: s3Testing.Client(
+					s3Testing.WithGetOwnershipControls(getOwnershipControls(awss3types.ObjectOwnershipObjectWriter)),
+					s3Testing.WithGetACL(func(ctx context.Context, input *awss3.GetBucketAclInput, opts []func(*awss3.Options)) (*awss3.GetBucketAclOutput, error) {
+						return &awss3.GetBucketAclOutput{
+							Grants: []awss3types.Grant{{
+								Grantee:    &awss3types.Grantee{Type: awss3types.TypeGroup, URI: aws.String("http://acs.amazonaws.com/groups/global/AllUsers")},
+								Permission: awss3types.PermissionRead,
+							}},
+						}, nil
+					}),
+				),
+				cr: s3Testing.Bucket(s3Testing.WithOwnershipControls(ownershipControls(awss3types.ObjectOwnershipObjectWriter))),
+			},
+			want: want{
+				cr: s3Testing.Bucket(
+					s3Testing.WithOwnershipControls(ownershipControls(awss3types.ObjectOwnershipObjectWriter)),
+					s3Testing.WithArn(fmt.Sprintf("arn:aws:s3:::%s", s3Testing.BucketName)),
+				),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+			},
+		},
+		"OwnershipEnforcedSkipsACL": {
+			args: args{
+				s3: s3Testing.Client(
+					s3Testing.WithGetOwnershipControls(getOwnershipControls(clients3.ObjectOwnershipBucketOwnerEnforced)),
+					s3Testing.WithPutACL(func(ctx context.Context, input *awss3.PutBucketAclInput, opts []func(*awss3.Options)) (*awss3.PutBucketAclOutput, error) {
+						return nil, errBoom
+					}),
+				),
+				cr: s3Testing.Bucket(s3Testing.WithOwnershipControls(ownershipControls(clients3.ObjectOwnershipBucketOwnerEnforced))),
+			},
+			want: want{
+				cr: s3Testing.Bucket(
+					s3Testing.WithOwnershipControls(ownershipControls(clients3.ObjectOwnershipBucketOwnerEnforced)),
+					s3Testing.WithConditions(xpv1.Available()),
+					s3Testing.WithArn(fmt.Sprintf("arn:aws:s3:::%s", s3Testing.BucketName)),
+				),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+					ConnectionDetails: map[string][]byte{
+						xpv1.ResourceCredentialsSecretEndpointKey:  []byte(s3Testing.BucketName),
+						v1beta1.ResourceCredentialsSecretRegionKey: []byte(s3Testing.Region),
+					},
+				},
+			},
+		},
 		"LateInitialize": {
 			args: args{
 				s3: s3Testing.Client(
@@ -232,6 +312,12 @@ func TestObserve(t *testing.T) {
 							},
 						},
 					}),
+					s3Testing.WithObservedSSERules(v1beta1.ServerSideEncryptionRule{
+						ApplyServerSideEncryptionByDefault: v1beta1.ServerSideEncryptionByDefault{
+							KMSMasterKeyID: aws.String("1234567890"),
+							SSEAlgorithm:   "aws:kms",
+						},
+					}),
 				),
 				result: managed.ExternalObservation{
 					ResourceExists:   true,
@@ -243,7 +329,7 @@ func TestObserve(t *testing.T) {
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			e := &external{s3client: tc.s3, subresourceClients: bucket.NewSubresourceClients(tc.s3), kube: tc.kube}
+			e := &external{s3client: tc.s3, subresourceClients: bucket.NewSubresourceClients(tc.s3, bucket.SubresourceClientOptions{}), kube: tc.kube, recorder: event.NewNopRecorder()}
 			o, err := e.Observe(context.Background(), tc.args.cr)
 
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
@@ -259,6 +345,143 @@ func TestObserve(t *testing.T) {
 	}
 }
 
+type eventRecorder struct {
+	events []event.Event
+}
+
+func (r *eventRecorder) Event(_ runtime.Object, e event.Event) {
+	r.events = append(r.events, e)
+}
+
+func (r *eventRecorder) WithAnnotations(_ ...string) event.Recorder { return r }
+
+func TestACLDeprecations(t *testing.T) {
+	cr := s3Testing.Bucket(func(b *v1beta1.Bucket) {
+		b.Spec.ForProvider.ACL = aws.String("public-read")
+		b.Spec.ForProvider.GrantWrite = aws.String(`id="1234"`)
+	})
+	s3client := s3Testing.Client()
+	rec := &eventRecorder{}
+	e := &external{s3client: s3client, subresourceClients: []bucket.SubresourceClient{bucket.NewACLClient(s3client)}, logger: logging.NewNopLogger(), recorder: rec}
+
+	// Observing the bucket does not warn, only applying the ACL does.
+	if _, err := e.Observe(context.Background(), cr); err != nil {
+		t.Errorf("Observe(...): unexpected error: %s", err)
+	}
+	if diff := cmp.Diff([]event.Event(nil), rec.events); diff != "" {
+		t.Errorf("Observe(...): -want, +got:\n%s", diff)
+	}
+	if _, err := e.Update(context.Background(), cr); err != nil {
+		t.Errorf("Update(...): unexpected error: %s", err)
+	}
+	var got []event.Event
+	for _, ev := range rec.events {
+		if ev.Reason == reasonDeprecatedConfiguration {
+			got = append(got, ev)
+		}
+	}
+	want := []event.Event{
+		event.Warning(reasonDeprecatedConfiguration, errors.New(`canned ACL "public-read" is discouraged by AWS, use a bucket policy instead`)),
+		event.Warning(reasonDeprecatedConfiguration, errors.New("ACL grant grantWrite is discouraged by AWS, use a bucket policy instead")),
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Update(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestLoggingTargetWarning(t *testing.T) {
+	cr := s3Testing.Bucket(s3Testing.WithLoggingConfig(&v1beta1.LoggingConfiguration{TargetBucket: aws.String("cloudtrail-logs")}))
+	s3client := s3Testing.Client()
+	s3client.MockPutBucketLogging = func(ctx context.Context, input *awss3.PutBucketLoggingInput, opts []func(*awss3.Options)) (*awss3.PutBucketLoggingOutput, error) {
+		return &awss3.PutBucketLoggingOutput{}, nil
+	}
+	rec := &eventRecorder{}
+	e := &external{s3client: s3client, subresourceClients: []bucket.SubresourceClient{bucket.NewLoggingConfigurationClient(s3client, nil, nil)}, logger: logging.NewNopLogger(), recorder: rec}
+
+	// Observing the bucket does not warn, only applying the logging
+	// configuration does.
+	if _, err := e.Observe(context.Background(), cr); err != nil {
+		t.Errorf("Observe(...): unexpected error: %s", err)
+	}
+	if diff := cmp.Diff([]event.Event(nil), rec.events); diff != "" {
+		t.Errorf("Observe(...): -want, +got:\n%s", diff)
+	}
+	if _, err := e.Update(context.Background(), cr); err != nil {
+		t.Errorf("Update(...): unexpected error: %s", err)
+	}
+	var got []event.Event
+	for _, ev := range rec.events {
+		if ev.Reason == reasonLoggingTarget {
+			got = append(got, ev)
+		}
+	}
+	want := []event.Event{
+		event.Warning(reasonLoggingTarget, errors.New(bucket.LoggingTargetWarning(cr.Spec.ForProvider.LoggingConfiguration))),
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Update(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestDisableACLs(t *testing.T) {
+	ownership := awss3types.ObjectOwnershipObjectWriter
+	var calls []string
+	s3client := s3Testing.Client(
+		s3Testing.WithGetOwnershipControls(func(ctx context.Context, input *awss3.GetBucketOwnershipControlsInput, opts []func(*awss3.Options)) (*awss3.GetBucketOwnershipControlsOutput, error) {
+			return getOwnershipControls(ownership)(ctx, input, opts)
+		}),
+		s3Testing.WithPutACL(func(ctx context.Context, input *awss3.PutBucketAclInput, opts []func(*awss3.Options)) (*awss3.PutBucketAclOutput, error) {
+			calls = append(calls, "PutBucketAcl "+string(input.ACL))
+			return &awss3.PutBucketAclOutput{}, nil
+		}),
+	)
+	s3client.MockPutBucketOwnershipControls = func(ctx context.Context, input *awss3.PutBucketOwnershipControlsInput, opts []func(*awss3.Options)) (*awss3.PutBucketOwnershipControlsOutput, error) {
+		calls = append(calls, "PutBucketOwnershipControls "+string(input.OwnershipControls.Rules[0].ObjectOwnership))
+		ownership = input.OwnershipControls.Rules[0].ObjectOwnership
+		return &awss3.PutBucketOwnershipControlsOutput{}, nil
+	}
+	rec := &eventRecorder{}
+	e := &external{s3client: s3client, subresourceClients: bucket.NewSubresourceClients(s3client, bucket.SubresourceClientOptions{}), logger: logging.NewNopLogger(), recorder: rec}
+
+	// The bucket used a public ACL before ACLs are disabled.
+	cr := s3Testing.Bucket(s3Testing.WithOwnershipControls(ownershipControls(clients3.ObjectOwnershipBucketOwnerEnforced)), func(b *v1beta1.Bucket) {
+		b.Spec.ForProvider.ACL = aws.String("public-read")
+	})
+
+	obs, err := e.Observe(context.Background(), cr)
+	if err != nil {
+		t.Fatalf("Observe(...): unexpected error: %s", err)
+	}
+	if obs.ResourceUpToDate {
+		t.Errorf("Observe(...): bucket is up to date before ACLs are disabled")
+	}
+	if _, err := e.Update(context.Background(), cr); err != nil {
+		t.Fatalf("Update(...): unexpected error: %s", err)
+	}
+	obs, err = e.Observe(context.Background(), cr)
+	if err != nil {
+		t.Fatalf("Observe(...): unexpected error: %s", err)
+	}
+	if !obs.ResourceUpToDate {
+		t.Errorf("Observe(...): bucket is not up to date after ACLs are disabled")
+	}
+
+	// The ACL is reset before the ownership is enforced and is not applied
+	// once ACLs are disabled.
+	wantCalls := []string{"PutBucketAcl private", "PutBucketOwnershipControls BucketOwnerEnforced"}
+	if diff := cmp.Diff(wantCalls, calls); diff != "" {
+		t.Errorf("calls: -want, +got:\n%s", diff)
+	}
+	for _, ev := range rec.events {
+		if ev.Reason == reasonDeprecatedConfiguration {
+			t.Errorf("Observe(...): unexpected event for the unmanaged ACL: %s", ev.Message)
+		}
+	}
+	if diff := cmp.Diff(xpv1.Available(), cr.Status.GetCondition(xpv1.TypeReady), test.EquateConditions()); diff != "" {
+		t.Errorf("Observe(...): -want, +got:\n%s", diff)
+	}
+}
+
 func TestCreate(t *testing.T) {
 
 	type want struct {
@@ -283,6 +506,26 @@ func TestCreate(t *testing.T) {
 				cr: s3Testing.Bucket(),
 			},
 		},
+		"OwnershipEnforcedSkipsACL": {
+			args: args{
+				kube: &test.MockClient{
+					MockUpdate: test.NewMockUpdateFn(nil),
+				},
+				s3: s3Testing.Client(
+					s3Testing.WithGetOwnershipControls(getOwnershipControls(clients3.ObjectOwnershipBucketOwnerEnforced)),
+					s3Testing.WithCreateBucket(func(ctx context.Context, input *awss3.CreateBucketInput, opts []func(*awss3.Options)) (*awss3.CreateBucketOutput, error) {
+						if input.ACL != "" || input.GrantFullControl != nil || input.GrantRead != nil || input.GrantReadACP != nil || input.GrantWrite != nil || input.GrantWriteACP != nil {
+							return nil, errBoom
+						}
+						return &awss3.CreateBucketOutput{}, nil
+					}),
+				),
+				cr: s3Testing.Bucket(s3Testing.WithOwnershipControls(ownershipControls(clients3.ObjectOwnershipBucketOwnerEnforced))),
+			},
+			want: want{
+				cr: s3Testing.Bucket(s3Testing.WithOwnershipControls(ownershipControls(clients3.ObjectOwnershipBucketOwnerEnforced))),
+			},
+		},
 		"InValidInput": {
 			args: args{
 				kube: &test.MockClient{
@@ -440,7 +683,7 @@ func TestCreate(t *testing.T) {
 	for name, tc := range cases {
 		noop := logging.NewNopLogger()
 		t.Run(name, func(t *testing.T) {
-			e := &external{s3client: tc.s3, kube: tc.kube, logger: noop, subresourceClients: bucket.NewSubresourceClients(tc.s3)}
+			e := &external{s3client: tc.s3, kube: tc.kube, logger: noop, subresourceClients: bucket.NewSubresourceClients(tc.s3, bucket.SubresourceClientOptions{})}
 			o, err := e.Create(context.Background(), tc.args.cr)
 
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
@@ -578,6 +821,12 @@ func TestUpdate(t *testing.T) {
 				cr: s3Testing.Bucket(
 					s3Testing.WithConditions(),
 					s3Testing.WithSSEConfig(nil),
+					s3Testing.WithObservedSSERules(v1beta1.ServerSideEncryptionRule{
+						ApplyServerSideEncryptionByDefault: v1beta1.ServerSideEncryptionByDefault{
+							KMSMasterKeyID: aws.String("key-id"),
+							SSEAlgorithm:   "AES256",
+						},
+					}),
 				),
 				result: managed.ExternalUpdate{},
 			},
@@ -608,6 +857,12 @@ func TestUpdate(t *testing.T) {
 			want: want{
 				cr: s3Testing.Bucket(
 					s3Testing.WithSSEConfig(nil),
+					s3Testing.WithObservedSSERules(v1beta1.ServerSideEncryptionRule{
+						ApplyServerSideEncryptionByDefault: v1beta1.ServerSideEncryptionByDefault{
+							KMSMasterKeyID: aws.String("key-id"),
+							SSEAlgorithm:   "AES256",
+						},
+					}),
 				),
 				err:    awsclient.Wrap(awsclient.Wrap(errBoom, "cannot delete encryption configuration"), errDelete),
 				result: managed.ExternalUpdate{},
@@ -638,7 +893,7 @@ func TestUpdate(t *testing.T) {
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			e := &external{s3client: tc.s3, subresourceClients: bucket.NewSubresourceClients(tc.s3)}
+			e := &external{s3client: tc.s3, subresourceClients: bucket.NewSubresourceClients(tc.s3, bucket.SubresourceClientOptions{}), recorder: event.NewNopRecorder()}
 			o, err := e.Update(context.Background(), tc.args.cr)
 
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
@@ -654,6 +909,860 @@ func TestUpdate(t *testing.T) {
 	}
 }
 
+func TestUpdateSubresourceDeletionDisabled(t *testing.T) {
+	deleteCalled := false
+	s3client := s3Testing.Client(
+		s3Testing.WithDeleteSSE(func(ctx context.Context, input *awss3.DeleteBucketEncryptionInput, opts []func(*awss3.Options)) (*awss3.DeleteBucketEncryptionOutput, error) {
+			deleteCalled = true
+			return &awss3.DeleteBucketEncryptionOutput{}, nil
+		}),
+		s3Testing.WithGetSSE(func(ctx context.Context, input *awss3.GetBucketEncryptionInput, opts []func(*awss3.Options)) (*awss3.GetBucketEncryptionOutput, error) {
+			return &awss3.GetBucketEncryptionOutput{
+				ServerSideEncryptionConfiguration: &awss3types.ServerSideEncryptionConfiguration{
+					Rules: []awss3types.ServerSideEncryptionRule{
+						{
+							ApplyServerSideEncryptionByDefault: &awss3types.ServerSideEncryptionByDefault{
+								SSEAlgorithm: awss3types.ServerSideEncryptionAes256,
+							},
+						},
+					},
+				},
+			}, nil
+		}),
+	)
+	cr := s3Testing.Bucket(s3Testing.WithSSEConfig(nil))
+	c := &connector{logger: logging.NewNopLogger()}
+	WithSubresourceDeletionDisabled()(c)
+	e := &external{s3client: s3client, subresourceClients: bucket.NewSubresourceClients(s3client, bucket.SubresourceClientOptions{Logger: c.logger}), logger: c.logger, recorder: event.NewNopRecorder(), disableDelete: c.disableDelete}
+
+	status, err := bucket.NewSSEConfigurationClient(s3client, nil, nil, nil, nil).Observe(context.Background(), cr)
+	if diff := cmp.Diff(bucket.NeedsDeletion, status); diff != "" {
+		t.Errorf("r: -want, +got:\n%s", diff)
+	}
+	if diff := cmp.Diff(nil, err, test.EquateErrors()); diff != "" {
+		t.Errorf("r: -want, +got:\n%s", diff)
+	}
+	if _, err := e.Update(context.Background(), cr); err != nil {
+		t.Errorf("r: unexpected error: %s", err)
+	}
+	if deleteCalled {
+		t.Errorf("r: DeleteBucketEncryption was called although subresource deletion is disabled")
+	}
+}
+
+func TestUpdateMetricsDeletionDisabled(t *testing.T) {
+	var put, deleted []string
+	s3client := s3Testing.Client(func(c *fake.MockBucketClient) {
+		c.MockListBucketMetricsConfigurations = func(ctx context.Context, input *awss3.ListBucketMetricsConfigurationsInput, opts []func(*awss3.Options)) (*awss3.ListBucketMetricsConfigurationsOutput, error) {
+			configs := []awss3types.MetricsConfiguration{{Id: aws.String("stale")}}
+			for _, id := range put {
+				configs = append(configs, awss3types.MetricsConfiguration{Id: aws.String(id)})
+			}
+			return &awss3.ListBucketMetricsConfigurationsOutput{MetricsConfigurationList: configs}, nil
+		}
+		c.MockPutBucketMetricsConfiguration = func(ctx context.Context, input *awss3.PutBucketMetricsConfigurationInput, opts []func(*awss3.Options)) (*awss3.PutBucketMetricsConfigurationOutput, error) {
+			put = append(put, aws.ToString(input.Id))
+			return &awss3.PutBucketMetricsConfigurationOutput{}, nil
+		}
+		c.MockDeleteBucketMetricsConfiguration = func(ctx context.Context, input *awss3.DeleteBucketMetricsConfigurationInput, opts []func(*awss3.Options)) (*awss3.DeleteBucketMetricsConfigurationOutput, error) {
+			deleted = append(deleted, aws.ToString(input.Id))
+			return &awss3.DeleteBucketMetricsConfigurationOutput{}, nil
+		}
+	})
+	cr := s3Testing.Bucket(func(b *v1beta1.Bucket) {
+		b.Spec.ForProvider.MetricsConfigurations = []v1beta1.MetricsConfiguration{{ID: "EntireBucket"}}
+	})
+	c := &connector{logger: logging.NewNopLogger()}
+	WithSubresourceDeletionDisabled()(c)
+	e := &external{s3client: s3client, subresourceClients: []bucket.SubresourceClient{bucket.NewMetricsConfigurationClient(s3client)}, logger: c.logger, recorder: event.NewNopRecorder(), disableDelete: c.disableDelete}
+
+	// The first update puts the configured metrics configuration, the second
+	// one would delete the one that is not configured.
+	for i := 0; i < 2; i++ {
+		if _, err := e.Update(context.Background(), cr); err != nil {
+			t.Errorf("r: unexpected error: %s", err)
+		}
+	}
+	if diff := cmp.Diff([]string{"EntireBucket"}, put); diff != "" {
+		t.Errorf("put: -want, +got:\n%s", diff)
+	}
+	if len(deleted) != 0 {
+		t.Errorf("r: DeleteBucketMetricsConfiguration was called for %v although subresource deletion is disabled", deleted)
+	}
+}
+
+func TestUpdateInventoryDeletionDisabled(t *testing.T) {
+	var put []awss3types.InventoryConfiguration
+	var deleted []string
+	s3client := s3Testing.Client(func(c *fake.MockBucketClient) {
+		c.MockListBucketInventoryConfigurations = func(ctx context.Context, input *awss3.ListBucketInventoryConfigurationsInput, opts []func(*awss3.Options)) (*awss3.ListBucketInventoryConfigurationsOutput, error) {
+			configs := append([]awss3types.InventoryConfiguration{{Id: aws.String("stale")}}, put...)
+			return &awss3.ListBucketInventoryConfigurationsOutput{InventoryConfigurationList: configs}, nil
+		}
+		c.MockPutBucketInventoryConfiguration = func(ctx context.Context, input *awss3.PutBucketInventoryConfigurationInput, opts []func(*awss3.Options)) (*awss3.PutBucketInventoryConfigurationOutput, error) {
+			put = append(put, *input.InventoryConfiguration)
+			return &awss3.PutBucketInventoryConfigurationOutput{}, nil
+		}
+		c.MockDeleteBucketInventoryConfiguration = func(ctx context.Context, input *awss3.DeleteBucketInventoryConfigurationInput, opts []func(*awss3.Options)) (*awss3.DeleteBucketInventoryConfigurationOutput, error) {
+			deleted = append(deleted, aws.ToString(input.Id))
+			return &awss3.DeleteBucketInventoryConfigurationOutput{}, nil
+		}
+	})
+	cr := s3Testing.Bucket(func(b *v1beta1.Bucket) {
+		b.Spec.ForProvider.InventoryConfigurations = []v1beta1.InventoryConfiguration{{
+			ID:                     "daily",
+			IncludedObjectVersions: "Current",
+			Schedule:               v1beta1.InventorySchedule{Frequency: "Daily"},
+			Destination:            v1beta1.InventoryDestination{Bucket: aws.String("arn:aws:s3:::inventory"), Format: "CSV"},
+		}}
+	})
+	c := &connector{logger: logging.NewNopLogger()}
+	WithSubresourceDeletionDisabled()(c)
+	e := &external{s3client: s3client, subresourceClients: []bucket.SubresourceClient{bucket.NewInventoryConfigurationClient(s3client)}, logger: c.logger, recorder: event.NewNopRecorder(), disableDelete: c.disableDelete}
+
+	// The first update puts the configured inventory configuration, the
+	// second one would delete the one that is not configured.
+	for i := 0; i < 2; i++ {
+		if _, err := e.Update(context.Background(), cr); err != nil {
+			t.Errorf("r: unexpected error: %s", err)
+		}
+	}
+	if len(put) != 1 || aws.ToString(put[0].Id) != "daily" {
+		t.Errorf("r: want the inventory configuration daily put once, got %v", put)
+	}
+	if len(deleted) != 0 {
+		t.Errorf("r: DeleteBucketInventoryConfiguration was called for %v although subresource deletion is disabled", deleted)
+	}
+}
+
+// quotaClient is a subresource client that always needs an update and
+// counts against a quota of which it uses the given amount.
+type quotaClient struct {
+	bucket.SubresourceClient
+	used    int
+	updated bool
+}
+
+func (c *quotaClient) Observe(_ context.Context, _ *v1beta1.Bucket) (bucket.ResourceStatus, error) {
+	return bucket.NeedsUpdate, nil
+}
+
+func (c *quotaClient) CreateOrUpdate(_ context.Context, _ *v1beta1.Bucket) error {
+	c.updated = true
+	return nil
+}
+
+func (c *quotaClient) Quotas() []bucket.Quota {
+	return []bucket.Quota{{
+		Description: "configurations per bucket",
+		Limit:       1000,
+		Usage:       func(_ context.Context, _ *v1beta1.Bucket) (int, error) { return c.used, nil },
+	}}
+}
+
+func TestUpdateQuotaPreflight(t *testing.T) {
+	type want struct {
+		err     error
+		updated bool
+		reasons []event.Reason
+	}
+
+	cases := map[string]struct {
+		preflight bool
+		used      int
+		want
+	}{
+		"Exceeded": {
+			preflight: true,
+			used:      1001,
+			want: want{
+				err: errors.Errorf(errQuotaExceeded, "quota", "1001 of 1000 configurations per bucket"),
+			},
+		},
+		"NearLimit": {
+			preflight: true,
+			used:      950,
+			want: want{
+				updated: true,
+				reasons: []event.Reason{reasonQuotaNearLimit},
+			},
+		},
+		"WithinLimit": {
+			preflight: true,
+			used:      1,
+			want: want{
+				updated: true,
+			},
+		},
+		"PreflightDisabled": {
+			used: 1001,
+			want: want{
+				updated: true,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			qc := &quotaClient{used: tc.used}
+			rec := &eventRecorder{}
+			c := &connector{logger: logging.NewNopLogger()}
+			if tc.preflight {
+				WithQuotaPreflight()(c)
+			}
+			e := &external{
+				subresourceClients: []bucket.SubresourceClient{qc},
+				logger:             c.logger,
+				recorder:           rec,
+				quotaPreflight:     c.quotaPreflight,
+			}
+			_, err := e.Update(context.Background(), s3Testing.Bucket())
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.updated, qc.updated); diff != "" {
+				t.Errorf("updated: -want, +got:\n%s", diff)
+			}
+			var reasons []event.Reason
+			for _, ev := range rec.events {
+				if ev.Reason != reasonReconcileSummary {
+					reasons = append(reasons, ev.Reason)
+				}
+			}
+			if diff := cmp.Diff(tc.want.reasons, reasons); diff != "" {
+				t.Errorf("events: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUpdateTransactionalRollback(t *testing.T) {
+	var puts []awss3types.BucketAccelerateStatus
+	s3client := s3Testing.Client(
+		func(c *fake.MockBucketClient) {
+			c.MockGetBucketAccelerateConfiguration = func(ctx context.Context, input *awss3.GetBucketAccelerateConfigurationInput, opts []func(*awss3.Options)) (*awss3.GetBucketAccelerateConfigurationOutput, error) {
+				return &awss3.GetBucketAccelerateConfigurationOutput{Status: awss3types.BucketAccelerateStatusSuspended}, nil
+			}
+			c.MockPutBucketAccelerateConfiguration = func(ctx context.Context, input *awss3.PutBucketAccelerateConfigurationInput, opts []func(*awss3.Options)) (*awss3.PutBucketAccelerateConfigurationOutput, error) {
+				puts = append(puts, input.AccelerateConfiguration.Status)
+				return &awss3.PutBucketAccelerateConfigurationOutput{}, nil
+			}
+		},
+		s3Testing.WithGetRequestPayment(func(ctx context.Context, input *awss3.GetBucketRequestPaymentInput, opts []func(*awss3.Options)) (*awss3.GetBucketRequestPaymentOutput, error) {
+			return &awss3.GetBucketRequestPaymentOutput{Payer: awss3types.PayerBucketOwner}, nil
+		}),
+		s3Testing.WithPutRequestPayment(func(ctx context.Context, input *awss3.PutBucketRequestPaymentInput, opts []func(*awss3.Options)) (*awss3.PutBucketRequestPaymentOutput, error) {
+			return nil, errBoom
+		}),
+	)
+	cr := s3Testing.Bucket(
+		s3Testing.WithAccelerationConfig(&v1beta1.AccelerateConfiguration{Status: string(awss3types.BucketAccelerateStatusEnabled)}),
+		s3Testing.WithPayerConfig(&v1beta1.PaymentConfiguration{Payer: "Requester"}),
+	)
+	recorder := &eventRecorder{}
+	c := &connector{logger: logging.NewNopLogger()}
+	WithTransactionalUpdates()(c)
+	e := &external{
+		s3client: s3client,
+		subresourceClients: []bucket.SubresourceClient{
+			bucket.NewAccelerateConfigurationClient(s3client),
+			bucket.NewRequestPaymentConfigurationClient(s3client),
+		},
+		logger:        c.logger,
+		recorder:      recorder,
+		transactional: c.transactional,
+	}
+
+	_, err := e.Update(context.Background(), cr)
+	if diff := cmp.Diff(awsclient.Wrap(awsclient.Wrap(errBoom, "cannot put Bucket payment"), errCreateOrUpdate), err, test.EquateErrors()); diff != "" {
+		t.Errorf("r: -want, +got:\n%s", diff)
+	}
+	// The accelerate configuration is enabled and then reverted to the
+	// status observed before the update.
+	want := []awss3types.BucketAccelerateStatus{awss3types.BucketAccelerateStatusEnabled, awss3types.BucketAccelerateStatusSuspended}
+	if diff := cmp.Diff(want, puts); diff != "" {
+		t.Errorf("r: -want, +got:\n%s", diff)
+	}
+	if len(recorder.events) != 1 || recorder.events[0].Reason != reasonRolledBack {
+		t.Errorf("expected a single %s event, got %v", reasonRolledBack, recorder.events)
+	}
+}
+
+func TestUpdateTransactionalRollbackACL(t *testing.T) {
+	type acl struct {
+		canned           awss3types.BucketCannedACL
+		grantFullControl string
+		grantRead        string
+	}
+	var puts []acl
+	s3client := s3Testing.Client(
+		func(c *fake.MockBucketClient) {
+			c.MockGetBucketAcl = func(ctx context.Context, input *awss3.GetBucketAclInput, opts []func(*awss3.Options)) (*awss3.GetBucketAclOutput, error) {
+				return &awss3.GetBucketAclOutput{
+					Owner: &awss3types.Owner{ID: aws.String("owner")},
+					Grants: []awss3types.Grant{
+						{Grantee: &awss3types.Grantee{Type: awss3types.TypeCanonicalUser, ID: aws.String("owner")}, Permission: awss3types.PermissionFullControl},
+						{Grantee: &awss3types.Grantee{Type: awss3types.TypeGroup, URI: aws.String("http://acs.amazonaws.com/groups/global/AllUsers")}, Permission: awss3types.PermissionRead},
+					},
+				}, nil
+			}
+		},
+		s3Testing.WithPutACL(func(ctx context.Context, input *awss3.PutBucketAclInput, opts []func(*awss3.Options)) (*awss3.PutBucketAclOutput, error) {
+			puts = append(puts, acl{canned: input.ACL, grantFullControl: aws.ToString(input.GrantFullControl), grantRead: aws.ToString(input.GrantRead)})
+			return &awss3.PutBucketAclOutput{}, nil
+		}),
+		s3Testing.WithGetRequestPayment(func(ctx context.Context, input *awss3.GetBucketRequestPaymentInput, opts []func(*awss3.Options)) (*awss3.GetBucketRequestPaymentOutput, error) {
+			return &awss3.GetBucketRequestPaymentOutput{Payer: awss3types.PayerBucketOwner}, nil
+		}),
+		s3Testing.WithPutRequestPayment(func(ctx context.Context, input *awss3.PutBucketRequestPaymentInput, opts []func(*awss3.Options)) (*awss3.PutBucketRequestPaymentOutput, error) {
+			return nil, errBoom
+		}),
+	)
+	cr := s3Testing.Bucket(
+		func(b *v1beta1.Bucket) { b.Spec.ForProvider.ACL = aws.String("private") },
+		s3Testing.WithPayerConfig(&v1beta1.PaymentConfiguration{Payer: "Requester"}),
+	)
+	c := &connector{logger: logging.NewNopLogger()}
+	WithTransactionalUpdates()(c)
+	e := &external{
+		s3client: s3client,
+		subresourceClients: []bucket.SubresourceClient{
+			bucket.NewACLClient(s3client),
+			bucket.NewRequestPaymentConfigurationClient(s3client),
+		},
+		logger:        c.logger,
+		recorder:      &eventRecorder{},
+		transactional: c.transactional,
+	}
+
+	_, err := e.Update(context.Background(), cr)
+	if diff := cmp.Diff(awsclient.Wrap(awsclient.Wrap(errBoom, "cannot put Bucket payment"), errCreateOrUpdate), err, test.EquateErrors()); diff != "" {
+		t.Errorf("r: -want, +got:\n%s", diff)
+	}
+	// The ACL is made private and then reverted to the grants observed before
+	// the update rather than left private.
+	want := []acl{
+		{canned: awss3types.BucketCannedACLPrivate},
+		{grantFullControl: `id="owner"`, grantRead: `uri="http://acs.amazonaws.com/groups/global/AllUsers"`},
+	}
+	if diff := cmp.Diff(want, puts, cmp.AllowUnexported(acl{})); diff != "" {
+		t.Errorf("r: -want, +got:\n%s", diff)
+	}
+}
+
+func TestUpdatePreCreateOrUpdateHook(t *testing.T) {
+	putCalled := false
+	s3client := s3Testing.Client()
+	s3client.MockPutBucketEncryption = func(ctx context.Context, input *awss3.PutBucketEncryptionInput, opts []func(*awss3.Options)) (*awss3.PutBucketEncryptionOutput, error) {
+		putCalled = true
+		return &awss3.PutBucketEncryptionOutput{}, nil
+	}
+	cr := s3Testing.Bucket(s3Testing.WithSSEConfig(&v1beta1.ServerSideEncryptionConfiguration{
+		Rules: []v1beta1.ServerSideEncryptionRule{
+			{
+				ApplyServerSideEncryptionByDefault: v1beta1.ServerSideEncryptionByDefault{
+					SSEAlgorithm: string(awss3types.ServerSideEncryptionAes256),
+				},
+			},
+		},
+	}))
+	errAES256 := errors.New("AES256 is not allowed")
+	c := &connector{logger: logging.NewNopLogger()}
+	WithPreCreateOrUpdateHook(&bucket.SSEConfigurationClient{}, func(_ context.Context, b *v1beta1.Bucket) error {
+		for _, r := range b.Spec.ForProvider.ServerSideEncryptionConfiguration.Rules {
+			if r.ApplyServerSideEncryptionByDefault.SSEAlgorithm == string(awss3types.ServerSideEncryptionAes256) {
+				return errAES256
+			}
+		}
+		return nil
+	})(c)
+	e := &external{s3client: s3client, subresourceClients: bucket.NewSubresourceClients(s3client, bucket.SubresourceClientOptions{Logger: c.logger}), logger: c.logger, recorder: event.NewNopRecorder(), hooks: c.hooks}
+
+	_, err := e.Update(context.Background(), cr)
+	if diff := cmp.Diff(awsclient.Wrap(errAES256, errHook), err, test.EquateErrors()); diff != "" {
+		t.Errorf("r: -want, +got:\n%s", diff)
+	}
+	if putCalled {
+		t.Errorf("r: PutBucketEncryption was called although the hook rejected the configuration")
+	}
+}
+
+func TestUpdateReplicationWaitsForVersioning(t *testing.T) {
+	versioning := awss3types.BucketVersioningStatusSuspended
+	replicationPut := false
+	s3client := s3Testing.Client()
+	s3client.MockGetBucketVersioning = func(ctx context.Context, input *awss3.GetBucketVersioningInput, opts []func(*awss3.Options)) (*awss3.GetBucketVersioningOutput, error) {
+		return &awss3.GetBucketVersioningOutput{Status: versioning}, nil
+	}
+	s3client.MockPutBucketVersioning = func(ctx context.Context, input *awss3.PutBucketVersioningInput, opts []func(*awss3.Options)) (*awss3.PutBucketVersioningOutput, error) {
+		return &awss3.PutBucketVersioningOutput{}, nil
+	}
+	s3client.MockPutBucketReplication = func(ctx context.Context, input *awss3.PutBucketReplicationInput, opts []func(*awss3.Options)) (*awss3.PutBucketReplicationOutput, error) {
+		replicationPut = true
+		return &awss3.PutBucketReplicationOutput{}, nil
+	}
+	cr := s3Testing.Bucket(
+		s3Testing.WithVersioningConfig(&v1beta1.VersioningConfiguration{Status: aws.String(string(awss3types.BucketVersioningStatusEnabled))}),
+		s3Testing.WithReplConfig(&v1beta1.ReplicationConfiguration{
+			Role: aws.String("arn:aws:iam::123456789012:role/replication"),
+			Rules: []v1beta1.ReplicationRule{{
+				Destination: v1beta1.Destination{Bucket: aws.String("arn:aws:s3:::destination")},
+				Status:      "Enabled",
+			}},
+		}),
+	)
+	e := &external{s3client: s3client, subresourceClients: bucket.NewSubresourceClients(s3client, bucket.SubresourceClientOptions{}), logger: logging.NewNopLogger(), recorder: event.NewNopRecorder()}
+
+	// Versioning was requested but is not yet enabled, so replication must wait.
+	if _, err := e.Update(context.Background(), cr); err != nil {
+		t.Errorf("r: unexpected error: %s", err)
+	}
+	if replicationPut {
+		t.Errorf("r: PutBucketReplication was called before versioning was enabled")
+	}
+	want := bucket.WaitingForPrerequisites([]string{"versioning is enabled on the bucket"})
+	if diff := cmp.Diff(want, cr.Status.GetCondition(bucket.TypePrerequisites), test.EquateConditions()); diff != "" {
+		t.Errorf("r: -want, +got:\n%s", diff)
+	}
+
+	// Once versioning is enabled replication is applied.
+	versioning = awss3types.BucketVersioningStatusEnabled
+	if _, err := e.Update(context.Background(), cr); err != nil {
+		t.Errorf("r: unexpected error: %s", err)
+	}
+	if !replicationPut {
+		t.Errorf("r: PutBucketReplication was not called after versioning was enabled")
+	}
+	if diff := cmp.Diff(bucket.PrerequisitesMet(), cr.Status.GetCondition(bucket.TypePrerequisites), test.EquateConditions()); diff != "" {
+		t.Errorf("r: -want, +got:\n%s", diff)
+	}
+}
+
+func TestUpdateReconcileSummary(t *testing.T) {
+	s3client := s3Testing.Client(
+		s3Testing.WithGetRequestPayment(func(ctx context.Context, input *awss3.GetBucketRequestPaymentInput, opts []func(*awss3.Options)) (*awss3.GetBucketRequestPaymentOutput, error) {
+			return &awss3.GetBucketRequestPaymentOutput{Payer: awss3types.PayerBucketOwner}, nil
+		}),
+		s3Testing.WithPutRequestPayment(func(ctx context.Context, input *awss3.PutBucketRequestPaymentInput, opts []func(*awss3.Options)) (*awss3.PutBucketRequestPaymentOutput, error) {
+			return &awss3.PutBucketRequestPaymentOutput{}, nil
+		}),
+		s3Testing.WithGetSSE(func(ctx context.Context, input *awss3.GetBucketEncryptionInput, opts []func(*awss3.Options)) (*awss3.GetBucketEncryptionOutput, error) {
+			return &awss3.GetBucketEncryptionOutput{
+				ServerSideEncryptionConfiguration: &awss3types.ServerSideEncryptionConfiguration{
+					Rules: []awss3types.ServerSideEncryptionRule{
+						{
+							ApplyServerSideEncryptionByDefault: &awss3types.ServerSideEncryptionByDefault{
+								SSEAlgorithm: awss3types.ServerSideEncryptionAes256,
+							},
+						},
+					},
+				},
+			}, nil
+		}),
+		s3Testing.WithDeleteSSE(func(ctx context.Context, input *awss3.DeleteBucketEncryptionInput, opts []func(*awss3.Options)) (*awss3.DeleteBucketEncryptionOutput, error) {
+			return &awss3.DeleteBucketEncryptionOutput{}, nil
+		}),
+	)
+	clients := []bucket.SubresourceClient{
+		bucket.NewRequestPaymentConfigurationClient(s3client),
+		bucket.NewSSEConfigurationClient(s3client, nil, nil, nil, nil),
+		bucket.NewTaggingConfigurationClient(s3client, nil),
+	}
+
+	cases := map[string]struct {
+		disableDelete bool
+		want          event.Event
+	}{
+		"UpdatedDeletedUnchanged": {
+			want: event.Normal(reasonReconcileSummary, "updated: RequestPaymentConfiguration; deleted: SSEConfiguration; unchanged: TaggingConfiguration"),
+		},
+		"DeletionSkipped": {
+			disableDelete: true,
+			want:          event.Normal(reasonReconcileSummary, "updated: RequestPaymentConfiguration; deleted: none; unchanged: TaggingConfiguration; skipped: SSEConfiguration"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			rec := &eventRecorder{}
+			e := &external{s3client: s3client, subresourceClients: clients, logger: logging.NewNopLogger(), recorder: rec, disableDelete: tc.disableDelete}
+			cr := s3Testing.Bucket(s3Testing.WithPayerConfig(&v1beta1.PaymentConfiguration{Payer: "Requester"}), s3Testing.WithSSEConfig(nil))
+
+			if _, err := e.Update(context.Background(), cr); err != nil {
+				t.Errorf("r: unexpected error: %s", err)
+			}
+			if diff := cmp.Diff([]event.Event{tc.want}, rec.events); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+// countInvocations wraps every mock of the supplied client so that calling it
+// increments the returned counter.
+func ownershipControls(o awss3types.ObjectOwnership) *v1beta1.OwnershipControls {
+	return &v1beta1.OwnershipControls{Rules: []v1beta1.OwnershipControlsRule{{ObjectOwnership: string(o)}}}
+}
+
+func getOwnershipControls(o awss3types.ObjectOwnership) func(ctx context.Context, input *awss3.GetBucketOwnershipControlsInput, opts []func(*awss3.Options)) (*awss3.GetBucketOwnershipControlsOutput, error) {
+	return func(ctx context.Context, input *awss3.GetBucketOwnershipControlsInput, opts []func(*awss3.Options)) (*awss3.GetBucketOwnershipControlsOutput, error) {
+		return &awss3.GetBucketOwnershipControlsOutput{OwnershipControls: &awss3types.OwnershipControls{
+			Rules: []awss3types.OwnershipControlsRule{{ObjectOwnership: o}},
+		}}, nil
+	}
+}
+
+func countInvocations(cl *fake.MockBucketClient) *int64 {
+	n := new(int64)
+	v := reflect.ValueOf(cl).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Field(i)
+		if f.Kind() != reflect.Func || f.IsNil() {
+			continue
+		}
+		orig := f.Interface()
+		f.Set(reflect.MakeFunc(f.Type(), func(args []reflect.Value) []reflect.Value {
+			atomic.AddInt64(n, 1)
+			return reflect.ValueOf(orig).Call(args)
+		}))
+	}
+	return n
+}
+
+// countMutations wraps every mock of the supplied client that creates,
+// updates or deletes something in AWS so that calling it increments the
+// returned counter.
+func countMutations(cl *fake.MockBucketClient) *int64 {
+	n := new(int64)
+	v := reflect.ValueOf(cl).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Field(i)
+		name := v.Type().Field(i).Name
+		if f.Kind() != reflect.Func || f.IsNil() || !(strings.HasPrefix(name, "MockPut") || strings.HasPrefix(name, "MockDelete") || strings.HasPrefix(name, "MockCreate")) {
+			continue
+		}
+		orig := f.Interface()
+		f.Set(reflect.MakeFunc(f.Type(), func(args []reflect.Value) []reflect.Value {
+			atomic.AddInt64(n, 1)
+			return reflect.ValueOf(orig).Call(args)
+		}))
+	}
+	return n
+}
+
+func TestAuditOnly(t *testing.T) {
+	s3client := s3Testing.Client(
+		s3Testing.WithGetRequestPayment(func(ctx context.Context, input *awss3.GetBucketRequestPaymentInput, opts []func(*awss3.Options)) (*awss3.GetBucketRequestPaymentOutput, error) {
+			return &awss3.GetBucketRequestPaymentOutput{Payer: awss3types.PayerBucketOwner}, nil
+		}),
+		s3Testing.WithGetSSE(func(ctx context.Context, input *awss3.GetBucketEncryptionInput, opts []func(*awss3.Options)) (*awss3.GetBucketEncryptionOutput, error) {
+			return &awss3.GetBucketEncryptionOutput{
+				ServerSideEncryptionConfiguration: &awss3types.ServerSideEncryptionConfiguration{
+					Rules: []awss3types.ServerSideEncryptionRule{{
+						ApplyServerSideEncryptionByDefault: &awss3types.ServerSideEncryptionByDefault{
+							SSEAlgorithm: awss3types.ServerSideEncryptionAes256,
+						},
+					}},
+				},
+			}, nil
+		}),
+		s3Testing.WithPutRequestPayment(func(ctx context.Context, input *awss3.PutBucketRequestPaymentInput, opts []func(*awss3.Options)) (*awss3.PutBucketRequestPaymentOutput, error) {
+			return &awss3.PutBucketRequestPaymentOutput{}, nil
+		}),
+		s3Testing.WithDeleteSSE(func(ctx context.Context, input *awss3.DeleteBucketEncryptionInput, opts []func(*awss3.Options)) (*awss3.DeleteBucketEncryptionOutput, error) {
+			return &awss3.DeleteBucketEncryptionOutput{}, nil
+		}),
+		s3Testing.WithPutACL(func(ctx context.Context, input *awss3.PutBucketAclInput, opts []func(*awss3.Options)) (*awss3.PutBucketAclOutput, error) {
+			return &awss3.PutBucketAclOutput{}, nil
+		}),
+	)
+	s3client.MockDeleteBucket = func(ctx context.Context, input *awss3.DeleteBucketInput, opts []func(*awss3.Options)) (*awss3.DeleteBucketOutput, error) {
+		return &awss3.DeleteBucketOutput{}, nil
+	}
+	mutations := countMutations(s3client)
+	c := &connector{logger: logging.NewNopLogger()}
+	WithAuditOnly()(c)
+	rec := &eventRecorder{}
+	e := &external{
+		s3client: s3client,
+		subresourceClients: []bucket.SubresourceClient{
+			bucket.NewRequestPaymentConfigurationClient(s3client),
+			bucket.NewSSEConfigurationClient(s3client, nil, nil, nil, nil),
+			bucket.NewTaggingConfigurationClient(s3client, nil),
+		},
+		logger:    c.logger,
+		recorder:  rec,
+		auditOnly: c.auditOnly,
+	}
+	cr := s3Testing.Bucket(s3Testing.WithPayerConfig(&v1beta1.PaymentConfiguration{Payer: "Requester"}), s3Testing.WithSSEConfig(nil))
+
+	if _, err := e.Update(context.Background(), cr); err != nil {
+		t.Errorf("Update(...): unexpected error: %s", err)
+	}
+	want := bucket.DriftDetected([]string{"RequestPaymentConfiguration", "SSEConfiguration"})
+	if diff := cmp.Diff(want, cr.Status.GetCondition(bucket.TypeDrift), test.EquateConditions()); diff != "" {
+		t.Errorf("Update(...): -want, +got:\n%s", diff)
+	}
+	wantEvents := []event.Event{event.Warning(reasonDriftDetected, errors.New("subresources drift from their configuration in AWS: RequestPaymentConfiguration, SSEConfiguration"))}
+	if diff := cmp.Diff(wantEvents, rec.events); diff != "" {
+		t.Errorf("Update(...): -want, +got:\n%s", diff)
+	}
+
+	if _, err := e.Create(context.Background(), cr); err == nil {
+		t.Errorf("Create(...): expected an error in audit-only mode")
+	}
+	if err := e.Delete(context.Background(), cr); err == nil {
+		t.Errorf("Delete(...): expected an error in audit-only mode")
+	}
+	if *mutations != 0 {
+		t.Errorf("%d mutating S3 API calls were made in audit-only mode", *mutations)
+	}
+}
+
+// observeClient is a subresource client whose Observe returns a fixed status
+// and error, applies observe to the bucket if set and records how many Observe
+// calls run at the same time.
+type observeClient struct {
+	bucket.SubresourceClient
+	status  bucket.ResourceStatus
+	err     error
+	observe func(cr *v1beta1.Bucket)
+	running *int64
+	maxSeen *int64
+}
+
+func (c *observeClient) Observe(_ context.Context, cr *v1beta1.Bucket) (bucket.ResourceStatus, error) {
+	if c.observe != nil {
+		c.observe(cr)
+	}
+	if c.running != nil {
+		n := atomic.AddInt64(c.running, 1)
+		defer atomic.AddInt64(c.running, -1)
+		for {
+			m := atomic.LoadInt64(c.maxSeen)
+			if n <= m || atomic.CompareAndSwapInt64(c.maxSeen, m, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+	}
+	return c.status, c.err
+}
+
+func TestObserveSubresources(t *testing.T) {
+	errFirst := errors.New("first")
+	errSecond := errors.New("second")
+	notImplemented := &observeClient{err: awsclient.Wrap(&smithy.GenericAPIError{Code: clients3.NotImplementedErrCode}, "cannot get")}
+
+	type want struct {
+		status         bucket.ResourceStatus
+		notImplemented []bucket.SubresourceClient
+		err            error
+	}
+
+	cases := map[string]struct {
+		clients []bucket.SubresourceClient
+		want
+	}{
+		"AllUpdated": {
+			clients: []bucket.SubresourceClient{&observeClient{}, &observeClient{}},
+			want:    want{status: bucket.Updated},
+		},
+		"WorstStatus": {
+			clients: []bucket.SubresourceClient{&observeClient{}, &observeClient{status: bucket.NeedsDeletion}, &observeClient{status: bucket.NeedsUpdate}},
+			want:    want{status: bucket.NeedsDeletion},
+		},
+		"SingleError": {
+			clients: []bucket.SubresourceClient{&observeClient{status: bucket.NeedsUpdate}, &observeClient{err: errFirst}},
+			want:    want{status: bucket.NeedsUpdate, err: errFirst},
+		},
+		"ErrorsInClientOrder": {
+			clients: []bucket.SubresourceClient{&observeClient{err: errFirst}, &observeClient{}, &observeClient{err: errSecond}},
+			want:    want{status: bucket.Updated, err: k8serrors.NewAggregate([]error{errFirst, errSecond})},
+		},
+		"NotImplemented": {
+			clients: []bucket.SubresourceClient{&observeClient{}, notImplemented},
+			want:    want{status: bucket.Updated, notImplemented: []bucket.SubresourceClient{notImplemented}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{subresourceClients: tc.clients}
+			status, notImplemented, err := e.observeSubresources(context.Background(), s3Testing.Bucket())
+			if diff := cmp.Diff(tc.want.status, status); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.notImplemented, notImplemented, cmp.Comparer(func(a, b bucket.SubresourceClient) bool { return a == b })); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestObserveSubresourcesConcurrencyLimit(t *testing.T) {
+	running, maxSeen := new(int64), new(int64)
+	clients := make([]bucket.SubresourceClient, 3*maxConcurrentObserves)
+	for i := range clients {
+		clients[i] = &observeClient{running: running, maxSeen: maxSeen}
+	}
+	e := &external{subresourceClients: clients}
+	if _, _, err := e.observeSubresources(context.Background(), s3Testing.Bucket()); err != nil {
+		t.Fatalf("observeSubresources(...): %s", err)
+	}
+	if *maxSeen > maxConcurrentObserves {
+		t.Errorf("observeSubresources(...): %d subresources were observed concurrently, want at most %d", *maxSeen, maxConcurrentObserves)
+	}
+}
+
+func TestObserveSubresourcesMergesStatus(t *testing.T) {
+	rules := []v1beta1.ServerSideEncryptionRule{{ApplyServerSideEncryptionByDefault: v1beta1.ServerSideEncryptionByDefault{SSEAlgorithm: "AES256"}}}
+	fallback := bucket.FallbackToAES256([]string{"key"})
+	logging := &v1beta1.LoggingConfiguration{TargetBucket: awsclient.String("logs")}
+
+	// Each client writes its own fields of the status while all run at the
+	// same time, which go test -race reports unless every client observes
+	// its own copy of the bucket.
+	running, maxSeen := new(int64), new(int64)
+	clients := []bucket.SubresourceClient{
+		&observeClient{running: running, maxSeen: maxSeen, observe: func(cr *v1beta1.Bucket) {
+			cr.Status.SetConditions(fallback)
+			cr.Status.AtProvider.ServerSideEncryptionRules = rules
+		}},
+		&observeClient{running: running, maxSeen: maxSeen, observe: func(cr *v1beta1.Bucket) {
+			cr.Status.SetConditions(bucket.NoDrift())
+			cr.Status.AtProvider.KMSKeyRotationEnabled = awsclient.Bool(true)
+		}},
+		&observeClient{running: running, maxSeen: maxSeen, observe: func(cr *v1beta1.Bucket) {
+			cr.Status.AtProvider.LoggingConfiguration = nil
+		}},
+		&observeClient{running: running, maxSeen: maxSeen},
+	}
+	cr := s3Testing.Bucket(s3Testing.WithArn("arn"), s3Testing.WithObservedLoggingConfig(logging))
+	cr.Status.SetConditions(xpv1.Available())
+
+	e := &external{subresourceClients: clients}
+	if _, _, err := e.observeSubresources(context.Background(), cr); err != nil {
+		t.Fatalf("observeSubresources(...): %s", err)
+	}
+
+	want := s3Testing.Bucket(s3Testing.WithArn("arn"), s3Testing.WithObservedSSERules(rules...))
+	want.Status.AtProvider.KMSKeyRotationEnabled = awsclient.Bool(true)
+	want.Status.SetConditions(xpv1.Available(), fallback, bucket.NoDrift())
+	if diff := cmp.Diff(want, cr, test.EquateConditions()); diff != "" {
+		t.Errorf("observeSubresources(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestUnsupportedSubresources(t *testing.T) {
+	notImplemented := &smithy.GenericAPIError{Code: clients3.NotImplementedErrCode}
+	accelerate := &v1beta1.AccelerateConfiguration{Status: "Enabled"}
+
+	type want struct {
+		condition xpv1.Condition
+		upToDate  bool
+	}
+
+	cases := map[string]struct {
+		reason  string
+		backend bucket.Backend
+		s3      *fake.MockBucketClient
+		cr      *v1beta1.Bucket
+		want
+	}{
+		"KnownUnsupported": {
+			reason:  "Subresources a backend is known not to implement should never be called and reported as skipped",
+			backend: bucket.BackendMinIO,
+			s3: func() *fake.MockBucketClient {
+				c := s3Testing.Client()
+				c.MockGetBucketAccelerateConfiguration = nil
+				c.MockGetBucketWebsite = nil
+				c.MockGetBucketLogging = nil
+				c.MockGetBucketRequestPayment = nil
+				return c
+			}(),
+			cr: s3Testing.Bucket(s3Testing.WithAccelerationConfig(accelerate)),
+			want: want{
+				condition: bucket.SubresourcesUnsupported([]string{"AccelerateConfiguration"}),
+				upToDate:  true,
+			},
+		},
+		"NotImplemented": {
+			reason:  "Subresources the backend responds to with NotImplemented should be reported as skipped",
+			backend: bucket.BackendAWS,
+			s3: func() *fake.MockBucketClient {
+				c := s3Testing.Client()
+				c.MockGetBucketAccelerateConfiguration = func(ctx context.Context, input *awss3.GetBucketAccelerateConfigurationInput, opts []func(*awss3.Options)) (*awss3.GetBucketAccelerateConfigurationOutput, error) {
+					return nil, notImplemented
+				}
+				c.MockGetBucketWebsite = func(ctx context.Context, input *awss3.GetBucketWebsiteInput, opts []func(*awss3.Options)) (*awss3.GetBucketWebsiteOutput, error) {
+					return nil, notImplemented
+				}
+				return c
+			}(),
+			cr: s3Testing.Bucket(s3Testing.WithAccelerationConfig(accelerate)),
+			want: want{
+				condition: bucket.SubresourcesUnsupported([]string{"AccelerateConfiguration"}),
+				upToDate:  true,
+			},
+		},
+		"NoLongerUnsupported": {
+			reason:  "A previously reported condition should be cleared once all configured subresources are supported",
+			backend: bucket.BackendAWS,
+			s3:      s3Testing.Client(),
+			cr:      s3Testing.Bucket(s3Testing.WithConditions(bucket.SubresourcesUnsupported([]string{"AccelerateConfiguration"}))),
+			want: want{
+				condition: bucket.SubresourcesSupported(),
+				upToDate:  true,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			supported, unsupported := bucket.FilterSupported(tc.backend, bucket.NewSubresourceClients(tc.s3, bucket.SubresourceClientOptions{}))
+			e := &external{s3client: tc.s3, subresourceClients: supported, unsupported: unsupported, logger: logging.NewNopLogger(), recorder: event.NewNopRecorder()}
+			cr := tc.cr
+			obs, err := e.Observe(context.Background(), cr)
+			if err != nil {
+				t.Fatalf("\n%s\nObserve(...): unexpected error: %s", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want.upToDate, obs.ResourceUpToDate); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.condition, cr.Status.GetCondition(bucket.TypeUnsupportedSubresources), test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if _, err := e.Update(context.Background(), cr); err != nil {
+				t.Errorf("\n%s\nUpdate(...): unexpected error: %s", tc.reason, err)
+			}
+		})
+	}
+}
+
+func TestAPICallsInStatus(t *testing.T) {
+	mock := s3Testing.Client()
+	invocations := countInvocations(mock)
+	calls := clients3.NewCountingBucketClient(mock)
+	c := &connector{logger: logging.NewNopLogger()}
+	WithAPICallsInStatus()(c)
+	e := &external{s3client: calls, subresourceClients: bucket.NewSubresourceClients(calls, bucket.SubresourceClientOptions{Logger: c.logger}), logger: c.logger, recorder: event.NewNopRecorder(), calls: calls, apiCallsInStatus: c.apiCallsInStatus}
+	cr := s3Testing.Bucket()
+
+	if _, err := e.Observe(context.Background(), cr); err != nil {
+		t.Errorf("r: unexpected error: %s", err)
+	}
+	if *invocations == 0 {
+		t.Errorf("r: no mock was invoked")
+	}
+	if diff := cmp.Diff(invocations, cr.Status.AtProvider.APICalls); diff != "" {
+		t.Errorf("r: -want, +got:\n%s", diff)
+	}
+}
+
 func TestDelete(t *testing.T) {
 
 	type want struct {
@@ -730,3 +1839,193 @@ func TestDelete(t *testing.T) {
 		})
 	}
 }
+
+func TestResolveLambdaFunctionReferences(t *testing.T) {
+	functionARN := "arn:aws:lambda:us-east-1:123456789012:function:fn"
+	withLambda := func(arn string) *v1beta1.Bucket {
+		return s3Testing.Bucket(s3Testing.WithNotificationConfig(&v1beta1.NotificationConfiguration{
+			LambdaFunctionConfigurations: []v1beta1.LambdaFunctionConfiguration{{
+				Events:               []string{"s3:ObjectCreated:*"},
+				LambdaFunctionArn:    arn,
+				LambdaFunctionArnRef: &xpv1.Reference{Name: "fn"},
+			}},
+		}))
+	}
+	getFunction := func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+		fn, ok := obj.(*lambdav1alpha1.Function)
+		if !ok {
+			return errBoom
+		}
+		fn.Status.AtProvider.FunctionARN = aws.String(functionARN)
+		return nil
+	}
+
+	type want struct {
+		arn     string
+		err     error
+		updated bool
+	}
+
+	cases := map[string]struct {
+		cr   *v1beta1.Bucket
+		get  test.MockGetFn
+		want want
+	}{
+		"Resolved": {
+			cr:   withLambda(""),
+			get:  getFunction,
+			want: want{arn: functionARN, updated: true},
+		},
+		"ARNAlreadySet": {
+			cr:   withLambda("arn:aws:lambda:us-east-1:123456789012:function:other"),
+			get:  test.NewMockGetFn(errBoom),
+			want: want{arn: "arn:aws:lambda:us-east-1:123456789012:function:other"},
+		},
+		"GetError": {
+			cr:  withLambda(""),
+			get: test.NewMockGetFn(errBoom),
+			want: want{
+				err: errors.Wrap(errors.Wrap(errors.Wrap(errBoom, "cannot get referenced resource"),
+					"spec.forProvider.notificationConfiguration.lambdaFunctionConfigurations[0].lambdaFunctionArn"), errResolveRefs),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			updated := false
+			r := &referenceResolver{client: &test.MockClient{
+				MockGet: tc.get,
+				MockUpdate: func(_ context.Context, _ client.Object, _ ...client.UpdateOption) error {
+					updated = true
+					return nil
+				},
+			}}
+			err := r.ResolveReferences(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.arn, tc.cr.Spec.ForProvider.NotificationConfiguration.LambdaFunctionConfigurations[0].LambdaFunctionArn); diff != "" {
+				t.Errorf("arn: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.updated, updated); diff != "" {
+				t.Errorf("updated: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+type keyPolicyClient struct {
+	policy string
+	put    *string
+}
+
+func (c *keyPolicyClient) GetKeyPolicyWithContext(_ awsv1.Context, _ *kms.GetKeyPolicyInput, _ ...request.Option) (*kms.GetKeyPolicyOutput, error) {
+	return &kms.GetKeyPolicyOutput{Policy: awsv1.String(c.policy)}, nil
+}
+
+func (c *keyPolicyClient) PutKeyPolicyWithContext(_ awsv1.Context, input *kms.PutKeyPolicyInput, _ ...request.Option) (*kms.PutKeyPolicyOutput, error) {
+	c.put = input.Policy
+	return &kms.PutKeyPolicyOutput{}, nil
+}
+
+func TestDeleteRevokesLoggingKeyAccess(t *testing.T) {
+	const sid = "S3ServerAccessLogDelivery"
+	type want struct {
+		err     error
+		calls   []string
+		revoked bool
+	}
+
+	cases := map[string]struct {
+		getErr error
+		want
+	}{
+		"RevokeBeforeDelete": {
+			want: want{
+				calls:   []string{"GetBucketLogging", "PutKeyPolicy", "DeleteBucket"},
+				revoked: true,
+			},
+		},
+		"CleanupError": {
+			getErr: errBoom,
+			want: want{
+				err:   errors.Wrapf(awsclient.Wrap(errBoom, "cannot get Bucket logging configuration"), errCleanup, "LoggingConfiguration"),
+				calls: []string{"GetBucketLogging"},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var calls []string
+			s3client := &fake.MockBucketClient{
+				MockGetBucketLogging: func(ctx context.Context, input *awss3.GetBucketLoggingInput, opts []func(*awss3.Options)) (*awss3.GetBucketLoggingOutput, error) {
+					calls = append(calls, "GetBucketLogging")
+					if tc.getErr != nil {
+						return nil, tc.getErr
+					}
+					return &awss3.GetBucketLoggingOutput{LoggingEnabled: &awss3types.LoggingEnabled{TargetBucket: aws.String("logs")}}, nil
+				},
+				MockGetBucketEncryption: func(ctx context.Context, input *awss3.GetBucketEncryptionInput, opts []func(*awss3.Options)) (*awss3.GetBucketEncryptionOutput, error) {
+					return &awss3.GetBucketEncryptionOutput{ServerSideEncryptionConfiguration: &awss3types.ServerSideEncryptionConfiguration{
+						Rules: []awss3types.ServerSideEncryptionRule{{ApplyServerSideEncryptionByDefault: &awss3types.ServerSideEncryptionByDefault{
+							SSEAlgorithm:   awss3types.ServerSideEncryptionAwsKms,
+							KMSMasterKeyID: aws.String("key"),
+						}}},
+					}}, nil
+				},
+				MockDeleteBucket: func(ctx context.Context, input *awss3.DeleteBucketInput, opts []func(*awss3.Options)) (*awss3.DeleteBucketOutput, error) {
+					calls = append(calls, "DeleteBucket")
+					return &awss3.DeleteBucketOutput{}, nil
+				},
+			}
+			// Enabling logging adds the statement that grants log delivery to
+			// the key policy of the target.
+			kp := &keyPolicyClient{policy: `{"Statement":[]}`}
+			cr := s3Testing.Bucket(s3Testing.WithLoggingConfig(&v1beta1.LoggingConfiguration{TargetBucket: aws.String("logs")}))
+			enable := &fake.MockBucketClient{
+				MockGetBucketLogging: func(ctx context.Context, input *awss3.GetBucketLoggingInput, opts []func(*awss3.Options)) (*awss3.GetBucketLoggingOutput, error) {
+					return &awss3.GetBucketLoggingOutput{}, nil
+				},
+				MockGetBucketEncryption: s3client.MockGetBucketEncryption,
+				MockPutBucketLogging: func(ctx context.Context, input *awss3.PutBucketLoggingInput, opts []func(*awss3.Options)) (*awss3.PutBucketLoggingOutput, error) {
+					return &awss3.PutBucketLoggingOutput{}, nil
+				},
+			}
+			if err := bucket.NewLoggingConfigurationClient(enable, nil, kp).CreateOrUpdate(context.Background(), cr); err != nil {
+				t.Fatalf("CreateOrUpdate(...): unexpected error: %s", err)
+			}
+			if !strings.Contains(awsv1.StringValue(kp.put), sid) {
+				t.Fatalf("CreateOrUpdate(...): want log delivery granted, got policy %s", awsv1.StringValue(kp.put))
+			}
+			kp.policy, kp.put = awsv1.StringValue(kp.put), nil
+
+			e := &external{
+				s3client:           s3client,
+				subresourceClients: []bucket.SubresourceClient{bucket.NewLoggingConfigurationClient(s3client, nil, recordingKeyPolicy{kp, &calls})},
+			}
+			err := e.Delete(context.Background(), cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.calls, calls); diff != "" {
+				t.Errorf("calls: -want, +got:\n%s", diff)
+			}
+			if revoked := kp.put != nil && !strings.Contains(awsv1.StringValue(kp.put), sid); revoked != tc.want.revoked {
+				t.Errorf("revoked: want %t, got policy %s", tc.want.revoked, awsv1.StringValue(kp.put))
+			}
+		})
+	}
+}
+
+// recordingKeyPolicy records the key policy updates of a keyPolicyClient.
+type recordingKeyPolicy struct {
+	*keyPolicyClient
+	calls *[]string
+}
+
+func (c recordingKeyPolicy) PutKeyPolicyWithContext(ctx awsv1.Context, input *kms.PutKeyPolicyInput, opts ...request.Option) (*kms.PutKeyPolicyOutput, error) {
+	*c.calls = append(*c.calls, "PutKeyPolicy")
+	return c.keyPolicyClient.PutKeyPolicyWithContext(ctx, input, opts...)
+}
@@ -19,12 +19,14 @@ package bucketpolicy
 import (
 	"context"
 	"encoding/json"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	awss3 "github.com/aws/aws-sdk-go-v2/service/s3"
-	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -49,12 +51,55 @@ const (
 	errGet              = "failed to get BucketPolicy for bucket with name"
 	errUpdate           = "failed to update the policy for bucket"
 	errNotSpecified     = "failed to format bucketPolicy, no rawPolicy or policy specified"
+
+	sseMismatch        = "bucket policy requires server side encryption with %s but the bucket encrypts with %s by default"
+	sseContextMismatch = "bucket policy requires an encryption context on uploads but the default %s encryption of the bucket does not set one"
+
+	publicExposure = "bucket policy makes bucket %s public while its public access block does not block all public access"
+
+	reasonSSEMismatch        event.Reason = "EncryptionPolicyMismatch"
+	reasonSSEContextMismatch event.Reason = "EncryptionContextPolicyMismatch"
+	reasonPublicExposure     event.Reason = "PublicExposure"
 )
 
+// TypePublicExposure indicates whether the policy of a bucket makes it public
+// while the public access block of the bucket does not block public access.
+const TypePublicExposure xpv1.ConditionType = "PublicExposure"
+
+// Reasons a bucket is or is not exposed to the public by its policy.
+const (
+	ReasonPublicPolicy xpv1.ConditionReason = "PublicPolicy"
+	ReasonNotPublic    xpv1.ConditionReason = "NotPublic"
+)
+
+// PublicExposure returns a condition that indicates that the policy of a
+// bucket makes it public.
+func PublicExposure(msg string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypePublicExposure,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonPublicPolicy,
+		Message:            msg,
+	}
+}
+
+// NoPublicExposure returns a condition that indicates that the policy of a
+// bucket does not make it public, or that public access is blocked.
+func NoPublicExposure() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypePublicExposure,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonNotPublic,
+	}
+}
+
 // SetupBucketPolicy adds a controller that reconciles
 // BucketPolicies.
 func SetupBucketPolicy(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration) error {
 	name := managed.ControllerName(v1alpha3.BucketPolicyGroupKind)
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
@@ -65,16 +110,17 @@ func SetupBucketPolicy(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimi
 		Complete(managed.NewReconciler(mgr,
 			resource.ManagedKind(v1alpha3.BucketPolicyGroupVersionKind),
 			managed.WithExternalConnecter(&connector{kube: mgr.GetClient(),
-				newClientFn: s3.NewBucketPolicyClient}),
+				newClientFn: s3.NewBucketPolicyClient, recorder: recorder}),
 			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
 			managed.WithPollInterval(poll),
 			managed.WithLogger(l.WithValues("controller", name)),
-			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+			managed.WithRecorder(recorder)))
 }
 
 type connector struct {
 	kube        client.Client
 	newClientFn func(config aws.Config) s3.BucketPolicyClient
+	recorder    event.Recorder
 }
 
 func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
@@ -86,12 +132,13 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 	if err != nil {
 		return nil, err
 	}
-	return &external{client: c.newClientFn(*cfg), kube: c.kube}, nil
+	return &external{client: c.newClientFn(*cfg), kube: c.kube, recorder: c.recorder}, nil
 }
 
 type external struct {
-	client s3.BucketPolicyClient
-	kube   client.Client
+	client   s3.BucketPolicyClient
+	kube     client.Client
+	recorder event.Recorder
 }
 
 func (e *external) Observe(ctx context.Context, mgd resource.Managed) (managed.ExternalObservation, error) {
@@ -107,9 +154,19 @@ func (e *external) Observe(ctx context.Context, mgd resource.Managed) (managed.E
 		if s3.IsErrorBucketNotFound(err) {
 			return managed.ExternalObservation{}, nil
 		}
+		if s3.IsErrorPolicyNotFound(err) && !policySpecified(cr) {
+			// The policy was removed from the spec and is already deleted.
+			cr.SetConditions(xpv1.Available())
+			return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+		}
 		return managed.ExternalObservation{}, awsclient.Wrap(resource.Ignore(s3.IsErrorPolicyNotFound, err), errGet)
 	}
 
+	if !policySpecified(cr) {
+		// The policy was removed from the spec, so Update deletes it.
+		return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false}, nil
+	}
+
 	policyData, err := e.formatBucketPolicy(cr)
 
 	if err != nil {
@@ -118,13 +175,20 @@ func (e *external) Observe(ctx context.Context, mgd resource.Managed) (managed.E
 
 	cr.SetConditions(xpv1.Available())
 
-	// If our version and the external version are the same, we return ResourceUpToDate: true
+	// If our version and the external version are the same, we return ResourceUpToDate: true.
+	// The policies are compared in their canonical form since AWS may reorder
+	// condition keys and values and collapses lists of a single element.
 	return managed.ExternalObservation{
 		ResourceExists:   true,
-		ResourceUpToDate: cmp.Equal(*policyData, *resp.Policy),
+		ResourceUpToDate: s3.PolicyEqual(awsclient.StringValue(policyData), awsclient.StringValue(resp.Policy)),
 	}, nil
 }
 
+// policySpecified returns true if the BucketPolicy specifies a policy.
+func policySpecified(cr *v1alpha3.BucketPolicy) bool {
+	return cr.Spec.Parameters.RawPolicy != nil || cr.Spec.Parameters.Policy != nil
+}
+
 // formatBucketPolicy parses and formats the bucket.Spec.BucketPolicy struct
 func (e *external) formatBucketPolicy(original *v1alpha3.BucketPolicy) (*string, error) {
 	if original == nil {
@@ -149,6 +213,77 @@ func (e *external) formatBucketPolicy(original *v1alpha3.BucketPolicy) (*string,
 	return nil, errors.New(errNotSpecified)
 }
 
+// checkEncryption warns if the policy requires uploads to use a server side
+// encryption algorithm other than the default encryption of the bucket, or to
+// send an encryption context which the default encryption never does, since
+// uploads relying on the default would then be denied. The check is best
+// effort and skipped if the encryption of the bucket cannot be read.
+func (e *external) checkEncryption(ctx context.Context, cr *v1alpha3.BucketPolicy, policy string) {
+	required, err := s3.RequiredSSEAlgorithms(policy)
+	if err != nil {
+		return
+	}
+	needsContext, err := s3.RequiresSSEContext(policy)
+	if err != nil || (len(required) == 0 && !needsContext) {
+		return
+	}
+	resp, err := e.client.GetBucketEncryption(ctx, &awss3.GetBucketEncryptionInput{Bucket: cr.Spec.Parameters.BucketName})
+	if err != nil || resp.ServerSideEncryptionConfiguration == nil {
+		return
+	}
+	for _, rule := range resp.ServerSideEncryptionConfiguration.Rules {
+		if rule.ApplyServerSideEncryptionByDefault == nil {
+			continue
+		}
+		algorithm := string(rule.ApplyServerSideEncryptionByDefault.SSEAlgorithm)
+		if needsContext {
+			e.recorder.Event(cr, event.Warning(reasonSSEContextMismatch, errors.Errorf(sseContextMismatch, algorithm)))
+		}
+		if len(required) == 0 {
+			return
+		}
+		for _, r := range required {
+			if s3.EnumEqual(r, algorithm) {
+				return
+			}
+		}
+		e.recorder.Event(cr, event.Warning(reasonSSEMismatch, errors.Errorf(sseMismatch, strings.Join(required, ", "), algorithm)))
+		return
+	}
+}
+
+// checkPublicExposure sets a warning condition and emits a warning event if
+// the applied policy made the bucket public while its public access block is
+// not fully enabled. The public access block of the account is not taken into
+// account. The check is best effort and skipped if the policy status or the
+// public access block of the bucket cannot be read.
+func (e *external) checkPublicExposure(ctx context.Context, cr *v1alpha3.BucketPolicy) {
+	status, err := e.client.GetBucketPolicyStatus(ctx, &awss3.GetBucketPolicyStatusInput{Bucket: cr.Spec.Parameters.BucketName})
+	if err != nil || status == nil || status.PolicyStatus == nil {
+		return
+	}
+	exposed := status.PolicyStatus.IsPublic
+	if exposed {
+		pab, err := e.client.GetPublicAccessBlock(ctx, &awss3.GetPublicAccessBlockInput{Bucket: cr.Spec.Parameters.BucketName})
+		switch {
+		case s3.PublicAccessBlockConfigurationNotFound(err):
+		case err != nil:
+			return
+		case pab != nil:
+			exposed = !s3.PublicAccessFullyBlocked(pab.PublicAccessBlockConfiguration)
+		}
+	}
+	switch {
+	case exposed:
+		err := errors.Errorf(publicExposure, awsclient.StringValue(cr.Spec.Parameters.BucketName))
+		cr.SetConditions(PublicExposure(err.Error()))
+		e.recorder.Event(cr, event.Warning(reasonPublicExposure, err))
+	case cr.GetCondition(TypePublicExposure).Status == corev1.ConditionTrue:
+		// Only report that the bucket is not exposed once it was.
+		cr.SetConditions(NoPublicExposure())
+	}
+}
+
 func (e *external) Create(ctx context.Context, mgd resource.Managed) (managed.ExternalCreation, error) {
 	cr, ok := mgd.(*v1alpha3.BucketPolicy)
 	if !ok {
@@ -163,8 +298,13 @@ func (e *external) Create(ctx context.Context, mgd resource.Managed) (managed.Ex
 	}
 
 	policyString := *policyData
+	e.checkEncryption(ctx, cr, policyString)
 	_, err = e.client.PutBucketPolicy(ctx, &awss3.PutBucketPolicyInput{Bucket: cr.Spec.Parameters.BucketName, Policy: awsclient.String(policyString)})
-	return managed.ExternalCreation{}, awsclient.Wrap(err, errAttach)
+	if err != nil {
+		return managed.ExternalCreation{}, awsclient.Wrap(err, errAttach)
+	}
+	e.checkPublicExposure(ctx, cr)
+	return managed.ExternalCreation{}, nil
 }
 
 // Update patches the existing policy for the bucket with the policy in the request body
@@ -174,13 +314,23 @@ func (e *external) Update(ctx context.Context, mgd resource.Managed) (managed.Ex
 		return managed.ExternalUpdate{}, errors.New(errUnexpectedObject)
 	}
 
+	if !policySpecified(cr) {
+		_, err := e.client.DeleteBucketPolicy(ctx, &awss3.DeleteBucketPolicyInput{Bucket: cr.Spec.Parameters.BucketName})
+		return managed.ExternalUpdate{}, awsclient.Wrap(resource.Ignore(s3.IsErrorPolicyNotFound, err), errDelete)
+	}
+
 	policyData, err := e.formatBucketPolicy(cr)
 	if err != nil {
 		return managed.ExternalUpdate{}, awsclient.Wrap(err, errUpdate)
 	}
 
+	e.checkEncryption(ctx, cr, *policyData)
 	_, err = e.client.PutBucketPolicy(ctx, &awss3.PutBucketPolicyInput{Bucket: cr.Spec.Parameters.BucketName, Policy: awsclient.String(*policyData)})
-	return managed.ExternalUpdate{}, awsclient.Wrap(err, errUpdate)
+	if err != nil {
+		return managed.ExternalUpdate{}, awsclient.Wrap(err, errUpdate)
+	}
+	e.checkPublicExposure(ctx, cr)
+	return managed.ExternalUpdate{}, nil
 }
 
 // Delete removes the existing policy for a bucket
@@ -21,11 +21,14 @@ import (
 	"testing"
 
 	awss3 "github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/aws/smithy-go"
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
@@ -57,6 +60,46 @@ var (
 			},
 		},
 	}
+	conditionPolicy = `{"Statement":[{"Action":"s3:PutObject","Condition":{"StringEquals":{"aws:SourceAccount":"123456789012"},"ArnLike":{"aws:SourceArn":["arn:aws:s3:::b","arn:aws:s3:::a"]}},"Effect":"Allow","Principal":{"Service":"logging.s3.amazonaws.com"},"Resource":"arn:aws:s3:::test.s3.crossplane.com/*"}],"Version":"2012-10-17"}`
+
+	listPolicy      = `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:GetObject"],"Principal":{"AWS":["arn:aws:iam::123456789012:root"]},"Resource":["arn:aws:s3:::test.s3.crossplane.com/*"]}]}`
+	collapsedPolicy = `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"s3:GetObject","Principal":{"AWS":"arn:aws:iam::123456789012:root"},"Resource":"arn:aws:s3:::test.s3.crossplane.com/*"}]}`
+
+	conditionParams = v1alpha3.BucketPolicyParameters{
+		Policy: &v1alpha3.BucketPolicyBody{
+			Version: "2012-10-17",
+			Statements: []v1alpha3.BucketPolicyStatement{
+				{
+					Effect: "Allow",
+					Principal: &v1alpha3.BucketPrincipal{
+						Service: []string{"logging.s3.amazonaws.com"},
+					},
+					Action:   []string{"s3:PutObject"},
+					Resource: []string{"arn:aws:s3:::test.s3.crossplane.com/*"},
+					Condition: []v1alpha3.Condition{
+						{
+							OperatorKey: "ArnLike",
+							Conditions: []v1alpha3.ConditionPair{
+								{
+									ConditionKey:       "aws:SourceArn",
+									ConditionListValue: []string{"arn:aws:s3:::a", "arn:aws:s3:::b"},
+								},
+							},
+						},
+						{
+							OperatorKey: "StringEquals",
+							Conditions: []v1alpha3.ConditionPair{
+								{
+									ConditionKey:         "aws:SourceAccount",
+									ConditionStringValue: awsclient.String("123456789012"),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
 	errBoom = errors.New("boom")
 )
 
@@ -124,6 +167,83 @@ func TestObserve(t *testing.T) {
 				},
 			},
 		},
+		"ValidInputReorderedConditions": {
+			args: args{
+				s3: &fake.MockBucketPolicyClient{
+					MockGetBucketPolicy: func(ctx context.Context, input *awss3.GetBucketPolicyInput, opts []func(*awss3.Options)) (*awss3.GetBucketPolicyOutput, error) {
+						return &awss3.GetBucketPolicyOutput{
+							Policy: &conditionPolicy,
+						}, nil
+					},
+				},
+				cr: bucketPolicy(withPolicy(&conditionParams)),
+			},
+			want: want{
+				cr: bucketPolicy(withPolicy(&conditionParams),
+					withConditions(xpv1.Available())),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+			},
+		},
+		"ValidInputCollapsedLists": {
+			args: args{
+				s3: &fake.MockBucketPolicyClient{
+					MockGetBucketPolicy: func(ctx context.Context, input *awss3.GetBucketPolicyInput, opts []func(*awss3.Options)) (*awss3.GetBucketPolicyOutput, error) {
+						return &awss3.GetBucketPolicyOutput{
+							Policy: &collapsedPolicy,
+						}, nil
+					},
+				},
+				cr: bucketPolicy(withPolicy(&v1alpha3.BucketPolicyParameters{BucketName: &bucketName, RawPolicy: &listPolicy})),
+			},
+			want: want{
+				cr: bucketPolicy(withPolicy(&v1alpha3.BucketPolicyParameters{BucketName: &bucketName, RawPolicy: &listPolicy}),
+					withConditions(xpv1.Available())),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+			},
+		},
+		"PolicyRemoved": {
+			args: args{
+				s3: &fake.MockBucketPolicyClient{
+					MockGetBucketPolicy: func(ctx context.Context, input *awss3.GetBucketPolicyInput, opts []func(*awss3.Options)) (*awss3.GetBucketPolicyOutput, error) {
+						return &awss3.GetBucketPolicyOutput{
+							Policy: &policy,
+						}, nil
+					},
+				},
+				cr: bucketPolicy(withPolicy(&v1alpha3.BucketPolicyParameters{BucketName: &bucketName})),
+			},
+			want: want{
+				cr: bucketPolicy(withPolicy(&v1alpha3.BucketPolicyParameters{BucketName: &bucketName})),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+			},
+		},
+		"PolicyRemovedAndDeleted": {
+			args: args{
+				s3: &fake.MockBucketPolicyClient{
+					MockGetBucketPolicy: func(ctx context.Context, input *awss3.GetBucketPolicyInput, opts []func(*awss3.Options)) (*awss3.GetBucketPolicyOutput, error) {
+						return nil, &smithy.GenericAPIError{Code: "NoSuchBucketPolicy"}
+					},
+				},
+				cr: bucketPolicy(withPolicy(&v1alpha3.BucketPolicyParameters{BucketName: &bucketName})),
+			},
+			want: want{
+				cr: bucketPolicy(withPolicy(&v1alpha3.BucketPolicyParameters{BucketName: &bucketName}),
+					withConditions(xpv1.Available())),
+				result: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+			},
+		},
 		"InValidInput": {
 			args: args{
 				cr: unexpectedItem,
@@ -198,6 +318,7 @@ func TestCreate(t *testing.T) {
 					MockPutBucketPolicy: func(ctx context.Context, input *awss3.PutBucketPolicyInput, opts []func(*awss3.Options)) (*awss3.PutBucketPolicyOutput, error) {
 						return &awss3.PutBucketPolicyOutput{}, nil
 					},
+					MockGetBucketPolicyStatus: policyStatus(false),
 				},
 				cr: bucketPolicy(withPolicy(&params)),
 			},
@@ -270,6 +391,7 @@ func TestUpdate(t *testing.T) {
 					MockPutBucketPolicy: func(ctx context.Context, input *awss3.PutBucketPolicyInput, opts []func(*awss3.Options)) (*awss3.PutBucketPolicyOutput, error) {
 						return &awss3.PutBucketPolicyOutput{}, nil
 					},
+					MockGetBucketPolicyStatus: policyStatus(false),
 				},
 				cr: bucketPolicy(withPolicy(&params)),
 			},
@@ -277,6 +399,46 @@ func TestUpdate(t *testing.T) {
 				cr: bucketPolicy(withPolicy(&params)),
 			},
 		},
+		"PolicyRemoved": {
+			args: args{
+				s3: &fake.MockBucketPolicyClient{
+					MockDeleteBucketPolicy: func(ctx context.Context, input *awss3.DeleteBucketPolicyInput, opts []func(*awss3.Options)) (*awss3.DeleteBucketPolicyOutput, error) {
+						return &awss3.DeleteBucketPolicyOutput{}, nil
+					},
+				},
+				cr: bucketPolicy(withPolicy(&v1alpha3.BucketPolicyParameters{BucketName: &bucketName})),
+			},
+			want: want{
+				cr: bucketPolicy(withPolicy(&v1alpha3.BucketPolicyParameters{BucketName: &bucketName})),
+			},
+		},
+		"PolicyRemovedAlreadyDeleted": {
+			args: args{
+				s3: &fake.MockBucketPolicyClient{
+					MockDeleteBucketPolicy: func(ctx context.Context, input *awss3.DeleteBucketPolicyInput, opts []func(*awss3.Options)) (*awss3.DeleteBucketPolicyOutput, error) {
+						return nil, &smithy.GenericAPIError{Code: "NoSuchBucketPolicy"}
+					},
+				},
+				cr: bucketPolicy(withPolicy(&v1alpha3.BucketPolicyParameters{BucketName: &bucketName})),
+			},
+			want: want{
+				cr: bucketPolicy(withPolicy(&v1alpha3.BucketPolicyParameters{BucketName: &bucketName})),
+			},
+		},
+		"PolicyRemovedDeleteError": {
+			args: args{
+				s3: &fake.MockBucketPolicyClient{
+					MockDeleteBucketPolicy: func(ctx context.Context, input *awss3.DeleteBucketPolicyInput, opts []func(*awss3.Options)) (*awss3.DeleteBucketPolicyOutput, error) {
+						return nil, errBoom
+					},
+				},
+				cr: bucketPolicy(withPolicy(&v1alpha3.BucketPolicyParameters{BucketName: &bucketName})),
+			},
+			want: want{
+				cr:  bucketPolicy(withPolicy(&v1alpha3.BucketPolicyParameters{BucketName: &bucketName})),
+				err: awsclient.Wrap(errBoom, errDelete),
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -439,3 +601,186 @@ func TestFormat(t *testing.T) {
 		})
 	}
 }
+
+type eventRecorder struct {
+	events []event.Event
+}
+
+func (r *eventRecorder) Event(_ runtime.Object, e event.Event) {
+	r.events = append(r.events, e)
+}
+
+func (r *eventRecorder) WithAnnotations(_ ...string) event.Recorder { return r }
+
+func policyStatus(public bool) func(ctx context.Context, input *awss3.GetBucketPolicyStatusInput, opts []func(*awss3.Options)) (*awss3.GetBucketPolicyStatusOutput, error) {
+	return func(ctx context.Context, input *awss3.GetBucketPolicyStatusInput, opts []func(*awss3.Options)) (*awss3.GetBucketPolicyStatusOutput, error) {
+		return &awss3.GetBucketPolicyStatusOutput{PolicyStatus: &s3types.PolicyStatus{IsPublic: public}}, nil
+	}
+}
+
+func TestUpdatePublicExposure(t *testing.T) {
+	named := params
+	named.BucketName = &bucketName
+	exposed := errors.Errorf(publicExposure, bucketName)
+	fullyBlocked := &s3types.PublicAccessBlockConfiguration{BlockPublicAcls: true, IgnorePublicAcls: true, BlockPublicPolicy: true, RestrictPublicBuckets: true}
+
+	type want struct {
+		cr     *v1alpha3.BucketPolicy
+		events []event.Event
+	}
+
+	cases := map[string]struct {
+		cr     *v1alpha3.BucketPolicy
+		status func(ctx context.Context, input *awss3.GetBucketPolicyStatusInput, opts []func(*awss3.Options)) (*awss3.GetBucketPolicyStatusOutput, error)
+		pab    *s3types.PublicAccessBlockConfiguration
+		pabErr error
+		want   want
+	}{
+		"PublicPolicyWithoutPublicAccessBlock": {
+			cr:     bucketPolicy(withPolicy(&named)),
+			status: policyStatus(true),
+			pabErr: &smithy.GenericAPIError{Code: s3.PublicAccessBlockNotFoundErrCode},
+			want: want{
+				cr:     bucketPolicy(withPolicy(&named), withConditions(PublicExposure(exposed.Error()))),
+				events: []event.Event{event.Warning(reasonPublicExposure, exposed)},
+			},
+		},
+		"PublicPolicyWithPartialPublicAccessBlock": {
+			cr:     bucketPolicy(withPolicy(&named)),
+			status: policyStatus(true),
+			pab:    &s3types.PublicAccessBlockConfiguration{BlockPublicAcls: true, IgnorePublicAcls: true},
+			want: want{
+				cr:     bucketPolicy(withPolicy(&named), withConditions(PublicExposure(exposed.Error()))),
+				events: []event.Event{event.Warning(reasonPublicExposure, exposed)},
+			},
+		},
+		"PublicPolicyFullyBlocked": {
+			cr:     bucketPolicy(withPolicy(&named)),
+			status: policyStatus(true),
+			pab:    fullyBlocked,
+			want: want{
+				cr: bucketPolicy(withPolicy(&named)),
+			},
+		},
+		"PublicAccessBlockNotReadable": {
+			cr:     bucketPolicy(withPolicy(&named)),
+			status: policyStatus(true),
+			pabErr: errBoom,
+			want: want{
+				cr: bucketPolicy(withPolicy(&named)),
+			},
+		},
+		"NoLongerPublic": {
+			cr:     bucketPolicy(withPolicy(&named), withConditions(PublicExposure(exposed.Error()))),
+			status: policyStatus(false),
+			want: want{
+				cr: bucketPolicy(withPolicy(&named), withConditions(NoPublicExposure())),
+			},
+		},
+		"PolicyStatusNotReadable": {
+			cr: bucketPolicy(withPolicy(&named)),
+			status: func(ctx context.Context, input *awss3.GetBucketPolicyStatusInput, opts []func(*awss3.Options)) (*awss3.GetBucketPolicyStatusOutput, error) {
+				return nil, errBoom
+			},
+			want: want{
+				cr: bucketPolicy(withPolicy(&named)),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			rec := &eventRecorder{}
+			e := &external{recorder: rec, client: &fake.MockBucketPolicyClient{
+				MockPutBucketPolicy: func(ctx context.Context, input *awss3.PutBucketPolicyInput, opts []func(*awss3.Options)) (*awss3.PutBucketPolicyOutput, error) {
+					return &awss3.PutBucketPolicyOutput{}, nil
+				},
+				MockGetBucketPolicyStatus: tc.status,
+				MockGetPublicAccessBlock: func(ctx context.Context, input *awss3.GetPublicAccessBlockInput, opts []func(*awss3.Options)) (*awss3.GetPublicAccessBlockOutput, error) {
+					if tc.pabErr != nil {
+						return nil, tc.pabErr
+					}
+					return &awss3.GetPublicAccessBlockOutput{PublicAccessBlockConfiguration: tc.pab}, nil
+				},
+			}}
+			if _, err := e.Update(context.Background(), tc.cr); err != nil {
+				t.Fatalf("Update(...): %s", err)
+			}
+			if diff := cmp.Diff(tc.want.cr, tc.cr, test.EquateConditions()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.events, rec.events, test.EquateErrors()); diff != "" {
+				t.Errorf("events: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCreateEncryptionMismatch(t *testing.T) {
+	requireAES256 := `{"Version":"2012-10-17","Statement":[{"Effect":"Deny","Principal":"*","Action":"s3:PutObject","Resource":"arn:aws:s3:::test.s3.crossplane.com/*","Condition":{"StringNotEquals":{"s3:x-amz-server-side-encryption":"AES256"}}}]}`
+	requireContext := `{"Version":"2012-10-17","Statement":[{"Effect":"Deny","Principal":"*","Action":"s3:PutObject","Resource":"arn:aws:s3:::test.s3.crossplane.com/*","Condition":{"Null":{"s3:x-amz-server-side-encryption-context":"true"}}}]}`
+	requireKMSAndContext := `{"Version":"2012-10-17","Statement":[{"Effect":"Deny","Principal":"*","Action":"s3:PutObject","Resource":"arn:aws:s3:::test.s3.crossplane.com/*","Condition":{"StringNotEquals":{"s3:x-amz-server-side-encryption":"aws:kms"},"Null":{"s3:x-amz-server-side-encryption-context":"true"}}}]}`
+	encryption := func(a s3types.ServerSideEncryption) *awss3.GetBucketEncryptionOutput {
+		return &awss3.GetBucketEncryptionOutput{ServerSideEncryptionConfiguration: &s3types.ServerSideEncryptionConfiguration{
+			Rules: []s3types.ServerSideEncryptionRule{{
+				ApplyServerSideEncryptionByDefault: &s3types.ServerSideEncryptionByDefault{SSEAlgorithm: a},
+			}},
+		}}
+	}
+
+	cases := map[string]struct {
+		policy     string
+		encryption *awss3.GetBucketEncryptionOutput
+		err        error
+		want       []event.Event
+	}{
+		"PolicyRequiresAES256BucketUsesKMS": {
+			policy:     requireAES256,
+			encryption: encryption(s3types.ServerSideEncryptionAwsKms),
+			want:       []event.Event{event.Warning(reasonSSEMismatch, errors.Errorf(sseMismatch, "AES256", "aws:kms"))},
+		},
+		"PolicyMatchesBucket": {
+			policy:     requireAES256,
+			encryption: encryption(s3types.ServerSideEncryptionAes256),
+		},
+		"PolicyRequiresContextBucketUsesKMS": {
+			policy:     requireContext,
+			encryption: encryption(s3types.ServerSideEncryptionAwsKms),
+			want:       []event.Event{event.Warning(reasonSSEContextMismatch, errors.Errorf(sseContextMismatch, "aws:kms"))},
+		},
+		"PolicyRequiresKMSAndContextBucketUsesKMS": {
+			policy:     requireKMSAndContext,
+			encryption: encryption(s3types.ServerSideEncryptionAwsKms),
+			want:       []event.Event{event.Warning(reasonSSEContextMismatch, errors.Errorf(sseContextMismatch, "aws:kms"))},
+		},
+		"EncryptionNotReadable": {
+			policy: requireAES256,
+			err:    errBoom,
+		},
+		"PolicyDoesNotRequireEncryption": {
+			policy: policy,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			rec := &eventRecorder{}
+			e := &external{recorder: rec, client: &fake.MockBucketPolicyClient{
+				MockGetBucketEncryption: func(ctx context.Context, input *awss3.GetBucketEncryptionInput, opts []func(*awss3.Options)) (*awss3.GetBucketEncryptionOutput, error) {
+					return tc.encryption, tc.err
+				},
+				MockPutBucketPolicy: func(ctx context.Context, input *awss3.PutBucketPolicyInput, opts []func(*awss3.Options)) (*awss3.PutBucketPolicyOutput, error) {
+					return &awss3.PutBucketPolicyOutput{}, nil
+				},
+				MockGetBucketPolicyStatus: policyStatus(false),
+			}}
+			cr := bucketPolicy(withPolicy(&v1alpha3.BucketPolicyParameters{BucketName: &bucketName, RawPolicy: &tc.policy}))
+			if _, err := e.Create(context.Background(), cr); err != nil {
+				t.Fatalf("Create(...): %s", err)
+			}
+			if diff := cmp.Diff(tc.want, rec.events, test.EquateErrors()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
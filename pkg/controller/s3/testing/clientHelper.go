@@ -3,7 +3,9 @@ package testing
 import (
 	"context"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	awss3 "github.com/aws/aws-sdk-go-v2/service/s3"
+	awss3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/aws/smithy-go"
 
 	"github.com/crossplane/provider-aws/pkg/clients/s3"
@@ -21,6 +23,9 @@ func Client(m ...ClientModifier) *fake.MockBucketClient {
 		MockCreateBucket: func(ctx context.Context, input *awss3.CreateBucketInput, opts []func(*awss3.Options)) (*awss3.CreateBucketOutput, error) {
 			return &awss3.CreateBucketOutput{}, nil
 		},
+		MockGetBucketLocation: func(ctx context.Context, input *awss3.GetBucketLocationInput, opts []func(*awss3.Options)) (*awss3.GetBucketLocationOutput, error) {
+			return &awss3.GetBucketLocationOutput{}, nil
+		},
 		MockGetBucketAccelerateConfiguration: func(ctx context.Context, input *awss3.GetBucketAccelerateConfigurationInput, opts []func(*awss3.Options)) (*awss3.GetBucketAccelerateConfigurationOutput, error) {
 			return &awss3.GetBucketAccelerateConfigurationOutput{}, nil
 		},
@@ -54,6 +59,15 @@ func Client(m ...ClientModifier) *fake.MockBucketClient {
 		MockGetBucketWebsite: func(ctx context.Context, input *awss3.GetBucketWebsiteInput, opts []func(*awss3.Options)) (*awss3.GetBucketWebsiteOutput, error) {
 			return nil, &smithy.GenericAPIError{Code: clients3.WebsiteNotFoundErrCode}
 		},
+		MockGetBucketAcl: func(ctx context.Context, input *awss3.GetBucketAclInput, opts []func(*awss3.Options)) (*awss3.GetBucketAclOutput, error) {
+			return &awss3.GetBucketAclOutput{
+				Owner: &awss3types.Owner{ID: aws.String(OwnerID)},
+				Grants: []awss3types.Grant{{
+					Grantee:    &awss3types.Grantee{Type: awss3types.TypeCanonicalUser, ID: aws.String(OwnerID)},
+					Permission: awss3types.PermissionFullControl,
+				}},
+			}, nil
+		},
 		MockPutBucketAcl: func(ctx context.Context, input *awss3.PutBucketAclInput, opts []func(*awss3.Options)) (*awss3.PutBucketAclOutput, error) {
 			return &awss3.PutBucketAclOutput{}, nil
 		},
@@ -66,6 +80,27 @@ func Client(m ...ClientModifier) *fake.MockBucketClient {
 		MockDeletePublicAccessBlock: func(ctx context.Context, input *awss3.DeletePublicAccessBlockInput, opts []func(*awss3.Options)) (*awss3.DeletePublicAccessBlockOutput, error) {
 			return &awss3.DeletePublicAccessBlockOutput{}, nil
 		},
+		MockGetBucketOwnershipControls: func(ctx context.Context, input *awss3.GetBucketOwnershipControlsInput, opts []func(*awss3.Options)) (*awss3.GetBucketOwnershipControlsOutput, error) {
+			return nil, &smithy.GenericAPIError{Code: clients3.OwnershipControlsNotFoundErrCode}
+		},
+		MockPutBucketOwnershipControls: func(ctx context.Context, input *awss3.PutBucketOwnershipControlsInput, opts []func(*awss3.Options)) (*awss3.PutBucketOwnershipControlsOutput, error) {
+			return &awss3.PutBucketOwnershipControlsOutput{}, nil
+		},
+		MockDeleteBucketOwnershipControls: func(ctx context.Context, input *awss3.DeleteBucketOwnershipControlsInput, opts []func(*awss3.Options)) (*awss3.DeleteBucketOwnershipControlsOutput, error) {
+			return &awss3.DeleteBucketOwnershipControlsOutput{}, nil
+		},
+		MockGetObjectLockConfiguration: func(ctx context.Context, input *awss3.GetObjectLockConfigurationInput, opts []func(*awss3.Options)) (*awss3.GetObjectLockConfigurationOutput, error) {
+			return nil, &smithy.GenericAPIError{Code: clients3.ObjectLockNotFoundErrCode}
+		},
+		MockListBucketMetricsConfigurations: func(ctx context.Context, input *awss3.ListBucketMetricsConfigurationsInput, opts []func(*awss3.Options)) (*awss3.ListBucketMetricsConfigurationsOutput, error) {
+			return &awss3.ListBucketMetricsConfigurationsOutput{}, nil
+		},
+		MockListBucketInventoryConfigurations: func(ctx context.Context, input *awss3.ListBucketInventoryConfigurationsInput, opts []func(*awss3.Options)) (*awss3.ListBucketInventoryConfigurationsOutput, error) {
+			return &awss3.ListBucketInventoryConfigurationsOutput{}, nil
+		},
+		MockListBucketIntelligentTieringConfigurations: func(ctx context.Context, input *awss3.ListBucketIntelligentTieringConfigurationsInput, opts []func(*awss3.Options)) (*awss3.ListBucketIntelligentTieringConfigurationsOutput, error) {
+			return &awss3.ListBucketIntelligentTieringConfigurationsOutput{}, nil
+		},
 	}
 	for _, v := range m {
 		v(client)
@@ -111,9 +146,23 @@ func WithDeleteSSE(input func(ctx context.Context, input *awss3.DeleteBucketEncr
 	}
 }
 
+// WithGetACL sets the MockGetBucketAcl of the mock S3 Client
+func WithGetACL(input func(ctx context.Context, input *awss3.GetBucketAclInput, opts []func(*awss3.Options)) (*awss3.GetBucketAclOutput, error)) ClientModifier {
+	return func(client *fake.MockBucketClient) {
+		client.MockGetBucketAcl = input
+	}
+}
+
 // WithPutACL sets the MockPutBucketAclRequest of the mock S3 Client
 func WithPutACL(input func(ctx context.Context, input *awss3.PutBucketAclInput, opts []func(*awss3.Options)) (*awss3.PutBucketAclOutput, error)) ClientModifier {
 	return func(client *fake.MockBucketClient) {
 		client.MockPutBucketAcl = input
 	}
 }
+
+// WithGetOwnershipControls sets the MockGetBucketOwnershipControls of the mock S3 Client
+func WithGetOwnershipControls(input func(ctx context.Context, input *awss3.GetBucketOwnershipControlsInput, opts []func(*awss3.Options)) (*awss3.GetBucketOwnershipControlsOutput, error)) ClientModifier {
+	return func(client *fake.MockBucketClient) {
+		client.MockGetBucketOwnershipControls = input
+	}
+}
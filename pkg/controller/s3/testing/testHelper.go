@@ -27,15 +27,12 @@ var (
 	// an arbitrary managed resource
 	acl = "private"
 	// Region is the test region of the bucket
-	Region           = "us-east-1"
-	grantFullControl = "fullGrant"
-	grantRead        = "readGrant"
-	grantReadACP     = "readACPGrant"
-	grantWrite       = "writeGrant"
-	grantWriteACP    = "writeACPGrant"
-	objectLock       = true
+	Region     = "us-east-1"
+	objectLock = true
 	// BucketName is the name of the s3 bucket in testing
 	BucketName = "test.bucket.name"
+	// OwnerID is the canonical user ID of the owner of the s3 bucket in testing
+	OwnerID = "owner"
 )
 
 // BucketModifier is a function which modifies the Bucket for testing
@@ -48,11 +45,30 @@ func WithArn(arn string) BucketModifier {
 	}
 }
 
+// WithObservedSSERules sets the observed encryption rules for an S3 Bucket
+func WithObservedSSERules(r ...v1beta1.ServerSideEncryptionRule) BucketModifier {
+	return func(bucket *v1beta1.Bucket) {
+		bucket.Status.AtProvider.ServerSideEncryptionRules = r
+	}
+}
+
+// WithObservedLoggingConfig sets the observed logging configuration for an S3 Bucket
+func WithObservedLoggingConfig(c *v1beta1.LoggingConfiguration) BucketModifier {
+	return func(bucket *v1beta1.Bucket) {
+		bucket.Status.AtProvider.LoggingConfiguration = c
+	}
+}
+
 // WithConditions sets the Conditions for an S3 Bucket
 func WithConditions(c ...xpv1.Condition) BucketModifier { //nolint
 	return func(r *v1beta1.Bucket) { r.Status.ConditionedStatus.Conditions = c }
 }
 
+// WithRegion sets the LocationConstraint for an S3 Bucket
+func WithRegion(region string) BucketModifier { //nolint
+	return func(r *v1beta1.Bucket) { r.Spec.ForProvider.LocationConstraint = region }
+}
+
 // WithAccelerationConfig sets the AccelerateConfiguration for an S3 Bucket
 func WithAccelerationConfig(s *v1beta1.AccelerateConfiguration) BucketModifier { //nolint
 	return func(r *v1beta1.Bucket) { r.Spec.ForProvider.AccelerateConfiguration = s }
@@ -108,6 +124,21 @@ func WithNotificationConfig(s *v1beta1.NotificationConfiguration) BucketModifier
 	return func(r *v1beta1.Bucket) { r.Spec.ForProvider.NotificationConfiguration = s }
 }
 
+// WithOwnershipControls sets the OwnershipControls for an S3 Bucket
+func WithOwnershipControls(s *v1beta1.OwnershipControls) BucketModifier { //nolint
+	return func(r *v1beta1.Bucket) { r.Spec.ForProvider.OwnershipControls = s }
+}
+
+// WithObjectLockConfig sets the ObjectLockConfiguration for an S3 Bucket
+func WithObjectLockConfig(s *v1beta1.ObjectLockConfiguration) BucketModifier { //nolint
+	return func(r *v1beta1.Bucket) { r.Spec.ForProvider.ObjectLockConfiguration = s }
+}
+
+// WithBaselineConfigMapRef sets the BaselineConfigMapRef for an S3 Bucket
+func WithBaselineConfigMapRef(s *v1beta1.ConfigMapReference) BucketModifier { //nolint
+	return func(r *v1beta1.Bucket) { r.Spec.ForProvider.BaselineConfigMapRef = s }
+}
+
 // Bucket creates a v1beta1 Bucket for use in testing
 func Bucket(m ...BucketModifier) *v1beta1.Bucket {
 	cr := &v1beta1.Bucket{
@@ -115,11 +146,6 @@ func Bucket(m ...BucketModifier) *v1beta1.Bucket {
 			ForProvider: v1beta1.BucketParameters{
 				ACL:                        &acl,
 				LocationConstraint:         Region,
-				GrantFullControl:           &grantFullControl,
-				GrantRead:                  &grantRead,
-				GrantReadACP:               &grantReadACP,
-				GrantWrite:                 &grantWrite,
-				GrantWriteACP:              &grantWriteACP,
 				ObjectLockEnabledForBucket: &objectLock,
 			},
 		},